@@ -0,0 +1,63 @@
+// File: bind.go
+// Title: Typed Configuration Binding
+// Description: Provides generic helpers that allocate or populate a typed
+//              configuration struct, apply struct-tag defaults, and validate
+//              the result in one call.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial Bind and BindInto
+
+package config
+
+import (
+	"reflect"
+
+	"github.com/msto63/tbp/tbp-foundation/pkg/core"
+)
+
+// Bind allocates a T, populates it from the configuration, and validates
+// it. Typical use at service startup:
+//
+//	cfg, err := config.Bind[ServerConfig](c)
+//
+// See BindInto for the binding and validation rules.
+func Bind[T any](c *Config) (*T, error) {
+	dst := new(T)
+	if err := BindInto(c, dst); err != nil {
+		return nil, err
+	}
+	return dst, nil
+}
+
+// BindInto populates dst from the configuration like Unmarshal, including
+// `default` and `required` struct tags, and then validates it with
+// core.ValidateStruct, which checks `validate` tags and calls Validate if
+// T implements core.Validatable. T must be a struct type. All errors are
+// coded core.ErrCodeInvalidInput.
+func BindInto[T any](c *Config, dst *T) error {
+	typeName := reflect.TypeOf((*T)(nil)).Elem().String()
+
+	if c == nil {
+		return core.NewWithCodef(core.ErrCodeInvalidInput, "cannot bind %s: configuration is nil", typeName)
+	}
+	if dst == nil {
+		return core.NewWithCodef(core.ErrCodeInvalidInput, "cannot bind %s: target is nil", typeName)
+	}
+	if reflect.TypeOf(dst).Elem().Kind() != reflect.Struct {
+		return core.NewWithCodef(core.ErrCodeInvalidInput, "cannot bind %s: target must be a struct", typeName)
+	}
+
+	if err := c.Unmarshal(dst); err != nil {
+		return core.WrapWithCodef(err, core.ErrCodeInvalidInput, "failed to bind configuration into %s", typeName)
+	}
+
+	if err := core.ValidateStruct(dst); err != nil {
+		return core.WrapWithCodef(err, core.ErrCodeInvalidInput, "invalid %s configuration", typeName)
+	}
+
+	return nil
+}
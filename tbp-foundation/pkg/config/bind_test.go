@@ -0,0 +1,131 @@
+// File: bind_test.go
+// Title: Tests for Typed Configuration Binding
+// Description: Tests Bind and BindInto with nested structs, struct-tag
+//              defaults, and validation failures.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+
+package config
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/msto63/tbp/tbp-foundation/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type bindTLSConfig struct {
+	Enabled bool   `config:"enabled"`
+	Cert    string `config:"cert"`
+}
+
+type bindServerConfig struct {
+	Host    string        `config:"host" validate:"required"`
+	Port    int           `config:"port" default:"8080" validate:"min=1,max=65535"`
+	Timeout time.Duration `config:"timeout" default:"30s"`
+	TLS     bindTLSConfig `config:"tls"`
+}
+
+type bindAppConfig struct {
+	Name   string           `config:"app.name"`
+	Server bindServerConfig `config:"server"`
+}
+
+// Validate implements core.Validatable
+func (c bindAppConfig) Validate() error {
+	if c.Server.TLS.Enabled && c.Server.TLS.Cert == "" {
+		return errors.New("server.tls.cert is required when TLS is enabled")
+	}
+	return nil
+}
+
+func TestBind(t *testing.T) {
+	newConfig := func(t *testing.T, values map[string]interface{}) *Config {
+		t.Helper()
+		config, err := New(context.Background(), LoadOptions{
+			Environment: "test",
+			Sources:     []Source{&mockSource{name: "mock", priority: 10, values: values}},
+		})
+		require.NoError(t, err)
+		return config
+	}
+
+	t.Run("binds nested struct with defaults", func(t *testing.T) {
+		config := newConfig(t, map[string]interface{}{
+			"app.name":           "orders",
+			"server.host":        "localhost",
+			"server.tls.enabled": true,
+			"server.tls.cert":    "/etc/tls/cert.pem",
+		})
+
+		cfg, err := Bind[bindAppConfig](config)
+		require.NoError(t, err)
+		assert.Equal(t, "orders", cfg.Name)
+		assert.Equal(t, "localhost", cfg.Server.Host)
+		assert.Equal(t, 8080, cfg.Server.Port)
+		assert.Equal(t, 30*time.Second, cfg.Server.Timeout)
+		assert.True(t, cfg.Server.TLS.Enabled)
+		assert.Equal(t, "/etc/tls/cert.pem", cfg.Server.TLS.Cert)
+	})
+
+	t.Run("runs Validate of the bound type", func(t *testing.T) {
+		config := newConfig(t, map[string]interface{}{
+			"server.host":        "localhost",
+			"server.tls.enabled": true,
+		})
+
+		cfg, err := Bind[bindAppConfig](config)
+		require.Error(t, err)
+		assert.Nil(t, cfg)
+		assert.True(t, core.IsCode(err, core.ErrCodeInvalidInput))
+		assert.Contains(t, err.Error(), "server.tls.cert is required")
+	})
+
+	t.Run("checks validate tags", func(t *testing.T) {
+		config := newConfig(t, map[string]interface{}{"server.port": 70000})
+
+		_, err := Bind[bindServerConfig](config)
+		require.Error(t, err)
+		assert.True(t, core.IsCode(err, core.ErrCodeInvalidInput))
+	})
+
+	t.Run("reports conversion errors with code", func(t *testing.T) {
+		config := newConfig(t, map[string]interface{}{
+			"server.host": "localhost",
+			"server.port": "not-a-number",
+		})
+
+		_, err := Bind[bindAppConfig](config)
+		require.Error(t, err)
+		assert.True(t, core.IsCode(err, core.ErrCodeInvalidInput))
+		assert.Contains(t, err.Error(), "server.port")
+	})
+
+	t.Run("BindInto populates existing value", func(t *testing.T) {
+		config := newConfig(t, map[string]interface{}{"host": "example.com"})
+
+		cfg := bindServerConfig{Port: 9090}
+		require.NoError(t, BindInto(config, &cfg))
+		assert.Equal(t, "example.com", cfg.Host)
+		assert.Equal(t, 8080, cfg.Port)
+	})
+
+	t.Run("rejects invalid targets", func(t *testing.T) {
+		config := newConfig(t, map[string]interface{}{})
+
+		assert.True(t, core.IsCode(BindInto[bindServerConfig](config, nil), core.ErrCodeInvalidInput))
+		assert.True(t, core.IsCode(BindInto[bindServerConfig](nil, &bindServerConfig{}), core.ErrCodeInvalidInput))
+
+		_, err := Bind[string](config)
+		assert.True(t, core.IsCode(err, core.ErrCodeInvalidInput))
+	})
+}
@@ -0,0 +1,195 @@
+// File: bytesize_test.go
+// Title: Tests for Human-Readable Byte Sizes
+// Description: Tests parsing decimal and binary units, fractional values,
+//              invalid units and overflow, the GetBytes accessors, and the
+//              "bytes" type hint of the environment source.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+
+package config
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/msto63/tbp/tbp-foundation/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseByteSize(t *testing.T) {
+	t.Run("parses decimal and binary units", func(t *testing.T) {
+		testCases := []struct {
+			input    string
+			expected int64
+		}{
+			{"0", 0},
+			{"512", 512},
+			{"512B", 512},
+			{"10KB", 10_000},
+			{"10MB", 10_000_000},
+			{"2GB", 2_000_000_000},
+			{"1TB", 1_000_000_000_000},
+			{"1PB", 1_000_000_000_000_000},
+			{"10KiB", 10 * 1024},
+			{"10MiB", 10 * 1024 * 1024},
+			{"2GiB", 2 * 1024 * 1024 * 1024},
+			{"1TiB", 1 << 40},
+			{"1PiB", 1 << 50},
+			{"10mb", 10_000_000},
+			{"2gib", 2 << 30},
+			{"2GIB", 2 << 30},
+			{" 10 MB ", 10_000_000},
+			{"9223372036854775807", math.MaxInt64},
+		}
+
+		for _, tc := range testCases {
+			size, err := parseByteSize(tc.input)
+			require.NoError(t, err, "Failed for input: %s", tc.input)
+			assert.Equal(t, tc.expected, size, "Failed for input: %s", tc.input)
+		}
+	})
+
+	t.Run("parses fractional values", func(t *testing.T) {
+		testCases := []struct {
+			input    string
+			expected int64
+		}{
+			{"1.5GB", 1_500_000_000},
+			{"1.5GiB", 1536 * 1024 * 1024},
+			{"0.5KiB", 512},
+			{"0.29KB", 290},
+			{".5MB", 500_000},
+			{"1.5", 2},
+		}
+
+		for _, tc := range testCases {
+			size, err := parseByteSize(tc.input)
+			require.NoError(t, err, "Failed for input: %s", tc.input)
+			assert.Equal(t, tc.expected, size, "Failed for input: %s", tc.input)
+		}
+	})
+
+	t.Run("rejects unknown units", func(t *testing.T) {
+		for _, input := range []string{"10XB", "10 megabytes", "10M", "10KBs", "5GB extra"} {
+			_, err := parseByteSize(input)
+			require.Error(t, err, "Failed for input: %s", input)
+			assert.True(t, core.IsInvalidInput(err), "Failed for input: %s", input)
+			assert.Contains(t, err.Error(), "unknown byte size unit", "Failed for input: %s", input)
+		}
+	})
+
+	t.Run("rejects invalid numbers", func(t *testing.T) {
+		for _, input := range []string{"", "MB", "-1MB", "1.2.3MB", "1,000KB"} {
+			_, err := parseByteSize(input)
+			require.Error(t, err, "Failed for input: %q", input)
+			assert.True(t, core.IsInvalidInput(err), "Failed for input: %q", input)
+		}
+	})
+
+	t.Run("rejects overflow", func(t *testing.T) {
+		for _, input := range []string{"9223372036854775808", "10000PB", "8EiB", "8192PiB", "9300000000000000000.5", "99999999999999999999999GB"} {
+			_, err := parseByteSize(input)
+			require.Error(t, err, "Failed for input: %s", input)
+			assert.True(t, core.IsInvalidInput(err), "Failed for input: %s", input)
+		}
+
+		_, err := parseByteSize("10000PB")
+		assert.Contains(t, err.Error(), "exceeds the maximum")
+	})
+}
+
+func TestConfig_GetBytes(t *testing.T) {
+	source := &mockSource{
+		name:     "test",
+		priority: 100,
+		values: map[string]interface{}{
+			"upload.max_size":   "10MB",
+			"cache.size":        "2GiB",
+			"buffer.size":       4096,
+			"buffer.float_size": float64(8192),
+			"buffer.fraction":   1.5,
+			"buffer.negative":   -1,
+			"upload.invalid":    "10 lightyears",
+			"upload.enabled":    true,
+		},
+	}
+	config, err := New(context.Background(), LoadOptions{Environment: "test", Sources: []Source{source}})
+	require.NoError(t, err)
+	defer config.Close()
+
+	t.Run("parses human-readable sizes", func(t *testing.T) {
+		size, err := config.GetBytes("upload.max_size")
+		require.NoError(t, err)
+		assert.Equal(t, int64(10_000_000), size)
+
+		size, err = config.GetBytes("cache.size")
+		require.NoError(t, err)
+		assert.Equal(t, int64(2<<30), size)
+	})
+
+	t.Run("treats numbers as bytes", func(t *testing.T) {
+		size, err := config.GetBytes("buffer.size")
+		require.NoError(t, err)
+		assert.Equal(t, int64(4096), size)
+
+		size, err = config.GetBytes("buffer.float_size")
+		require.NoError(t, err)
+		assert.Equal(t, int64(8192), size)
+	})
+
+	t.Run("returns coded errors for invalid values", func(t *testing.T) {
+		_, err := config.GetBytes("upload.invalid")
+		require.Error(t, err)
+		assert.True(t, core.IsInvalidInput(err))
+		assert.Contains(t, err.Error(), "configuration key 'upload.invalid' cannot be parsed as byte size")
+		assert.Contains(t, err.Error(), "unknown byte size unit")
+
+		for _, key := range []string{"buffer.fraction", "buffer.negative", "upload.enabled"} {
+			_, err = config.GetBytes(key)
+			require.Error(t, err, key)
+			assert.True(t, core.IsInvalidInput(err), key)
+			assert.Contains(t, err.Error(), "cannot be converted to byte size", key)
+		}
+
+		_, err = config.GetBytes("missing")
+		assert.Error(t, err)
+	})
+
+	t.Run("falls back to default", func(t *testing.T) {
+		assert.Equal(t, int64(10_000_000), config.GetBytesWithDefault("upload.max_size", 1024))
+		assert.Equal(t, int64(1024), config.GetBytesWithDefault("upload.invalid", 1024))
+		assert.Equal(t, int64(1024), config.GetBytesWithDefault("missing", 1024))
+	})
+}
+
+func TestEnvSource_BytesTypeHint(t *testing.T) {
+	envSrc, err := NewEnvSource(EnvSourceOptions{Prefix: "TEST"})
+	require.NoError(t, err)
+
+	for _, hint := range []string{"bytes", "bytesize", "Bytes"} {
+		result, err := envSrc.convertByType("1.5GB", hint)
+		require.NoError(t, err, hint)
+		assert.Equal(t, int64(1_500_000_000), result, hint)
+	}
+
+	_, err = envSrc.convertByType("10XB", "bytes")
+	assert.True(t, core.IsInvalidInput(err))
+
+	t.Setenv("TEST_UPLOAD_MAX_SIZE", "512KiB")
+	envSrc, err = NewEnvSource(EnvSourceOptions{
+		Prefix:    "TEST",
+		TypeHints: map[string]string{"upload.max.size": "bytes"},
+	})
+	require.NoError(t, err)
+
+	values, err := envSrc.Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(512*1024), values["upload.max.size"])
+}
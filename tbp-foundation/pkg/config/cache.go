@@ -0,0 +1,125 @@
+// File: cache.go
+// Title: Conversion Cache for Configuration Accessors
+// Description: Provides an opt-in, bounded cache of converted values for
+//              typed accessors such as GetInt and GetBool, so that string
+//              values are parsed once instead of on every call in hot
+//              paths. Entries are tied to the raw value they were converted
+//              from and are dropped when a reload changes that value.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial conversion cache
+
+package config
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// ConversionCacheSize is the maximum number of entries of the conversion
+// cache enabled by LoadOptions.CacheConversions
+const ConversionCacheSize = 1024
+
+// conversionKey identifies a cached conversion of a key to a target type
+type conversionKey struct {
+	key        string
+	targetType string
+}
+
+// conversionEntry is a converted value and the raw value it was parsed from
+type conversionEntry struct {
+	raw   string
+	value interface{}
+}
+
+// conversionEntries is an immutable set of cached conversions
+type conversionEntries map[conversionKey]conversionEntry
+
+// conversionCache caches conversions of string values. Accessors on hot
+// paths read far more often than values change, so lookups read an
+// immutable map without locking and writers replace it with a copy. A nil
+// cache caches nothing, so accessors can use it unconditionally.
+type conversionCache struct {
+	mu         sync.Mutex // serializes writers
+	entries    atomic.Pointer[conversionEntries]
+	maxEntries int
+}
+
+// newConversionCache creates a cache holding up to maxEntries conversions
+func newConversionCache(maxEntries int) *conversionCache {
+	cc := &conversionCache{maxEntries: maxEntries}
+	cc.entries.Store(&conversionEntries{})
+	return cc
+}
+
+// lookup returns the cached conversion of raw for key and targetType.
+// Only string values are cached; entries of a different raw value miss.
+func (cc *conversionCache) lookup(key, targetType string, raw interface{}) (interface{}, bool) {
+	s, isString := raw.(string)
+	if cc == nil || !isString {
+		return nil, false
+	}
+
+	entry, exists := (*cc.entries.Load())[conversionKey{key: key, targetType: targetType}]
+	if !exists || entry.raw != s {
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// store caches the conversion of a string raw value. New entries are not
+// added while the cache is full; entries of changed values are replaced.
+func (cc *conversionCache) store(key, targetType string, raw, value interface{}) {
+	s, isString := raw.(string)
+	if cc == nil || !isString {
+		return
+	}
+
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	ck := conversionKey{key: key, targetType: targetType}
+	current := *cc.entries.Load()
+	if _, exists := current[ck]; !exists && len(current) >= cc.maxEntries {
+		return
+	}
+
+	updated := make(conversionEntries, len(current)+1)
+	for k, entry := range current {
+		updated[k] = entry
+	}
+	updated[ck] = conversionEntry{raw: s, value: value}
+	cc.entries.Store(&updated)
+}
+
+// invalidate drops entries whose key no longer has the value they were
+// converted from
+func (cc *conversionCache) invalidate(values map[string]interface{}) {
+	if cc == nil {
+		return
+	}
+
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	current := *cc.entries.Load()
+	updated := make(conversionEntries, len(current))
+	for k, entry := range current {
+		if value, ok := values[k.key].(string); ok && value == entry.raw {
+			updated[k] = entry
+		}
+	}
+	cc.entries.Store(&updated)
+}
+
+// len returns the number of cached conversions
+func (cc *conversionCache) len() int {
+	if cc == nil {
+		return 0
+	}
+	return len(*cc.entries.Load())
+}
@@ -0,0 +1,169 @@
+// File: cache_test.go
+// Title: Tests for the Configuration Conversion Cache
+// Description: Tests cached GetInt and GetBool results, invalidation on
+//              reload, the entry bound, and compares cached and uncached
+//              conversion in benchmarks.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCachingConfig creates a config over source with or without the conversion cache
+func newCachingConfig(tb testing.TB, source Source, cache bool) *Config {
+	config, err := New(context.Background(), LoadOptions{
+		Environment:      "test",
+		Sources:          []Source{source},
+		CacheConversions: cache,
+	})
+	require.NoError(tb, err)
+	tb.Cleanup(func() { config.Close() })
+	return config
+}
+
+func TestConfig_CacheConversions(t *testing.T) {
+	t.Run("caches string conversions", func(t *testing.T) {
+		config := newCachingConfig(t, &mockSource{name: "env", priority: 50, values: map[string]interface{}{
+			"port":    "8080",
+			"debug":   "yes",
+			"workers": 4,
+		}}, true)
+
+		for i := 0; i < 2; i++ {
+			port, err := config.GetInt("port")
+			require.NoError(t, err)
+			assert.Equal(t, 8080, port)
+
+			debug, err := config.GetBool("debug")
+			require.NoError(t, err)
+			assert.True(t, debug)
+
+			workers, err := config.GetInt("workers")
+			require.NoError(t, err)
+			assert.Equal(t, 4, workers)
+		}
+		assert.Equal(t, 2, config.conversions.len(), "only string values are cached")
+	})
+
+	t.Run("does not cache failed conversions", func(t *testing.T) {
+		config := newCachingConfig(t, &mockSource{name: "env", priority: 50, values: map[string]interface{}{
+			"port": "http",
+		}}, true)
+
+		_, err := config.GetInt("port")
+		assert.Error(t, err)
+		_, err = config.GetBool("port")
+		assert.Error(t, err)
+		assert.Zero(t, config.conversions.len())
+	})
+
+	t.Run("invalidates changed values on load", func(t *testing.T) {
+		source := &mockSource{name: "env", priority: 50, values: map[string]interface{}{
+			"port":  "8080",
+			"debug": "true",
+		}}
+		config := newCachingConfig(t, source, true)
+
+		_, err := config.GetInt("port")
+		require.NoError(t, err)
+		_, err = config.GetBool("debug")
+		require.NoError(t, err)
+		require.Equal(t, 2, config.conversions.len())
+
+		source.values = map[string]interface{}{"port": "9090", "debug": "true"}
+		require.NoError(t, config.Load(context.Background()))
+		assert.Equal(t, 1, config.conversions.len(), "unchanged value stays cached")
+
+		port, err := config.GetInt("port")
+		require.NoError(t, err)
+		assert.Equal(t, 9090, port)
+
+		source.values = map[string]interface{}{"port": "9090"}
+		require.NoError(t, config.Load(context.Background()))
+		_, err = config.GetBool("debug")
+		assert.Error(t, err, "removed key is not served from the cache")
+	})
+
+	t.Run("is bounded", func(t *testing.T) {
+		values := make(map[string]interface{})
+		for i := 0; i < ConversionCacheSize+10; i++ {
+			values[fmt.Sprintf("key%d", i)] = fmt.Sprint(i)
+		}
+		config := newCachingConfig(t, &mockSource{name: "env", priority: 50, values: values}, true)
+
+		for i := 0; i < ConversionCacheSize+10; i++ {
+			value, err := config.GetInt(fmt.Sprintf("key%d", i))
+			require.NoError(t, err)
+			assert.Equal(t, i, value)
+		}
+		assert.Equal(t, ConversionCacheSize, config.conversions.len())
+	})
+
+	t.Run("is safe for concurrent use", func(t *testing.T) {
+		config := newCachingConfig(t, &mockSource{name: "env", priority: 50, values: map[string]interface{}{
+			"port": "8080",
+		}}, true)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					port, err := config.GetInt("port")
+					assert.NoError(t, err)
+					assert.Equal(t, 8080, port)
+				}
+			}()
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				assert.NoError(t, config.Load(context.Background()))
+			}
+		}()
+		wg.Wait()
+	})
+
+	t.Run("is disabled by default", func(t *testing.T) {
+		config := newCachingConfig(t, &mockSource{name: "env", priority: 50, values: map[string]interface{}{
+			"port": "8080",
+		}}, false)
+
+		port, err := config.GetInt("port")
+		require.NoError(t, err)
+		assert.Equal(t, 8080, port)
+		assert.Nil(t, config.conversions)
+	})
+}
+
+func BenchmarkConfig_GetIntString(b *testing.B) {
+	for _, cache := range []bool{false, true} {
+		b.Run(fmt.Sprintf("cached=%t", cache), func(b *testing.B) {
+			config := newCachingConfig(b, &mockSource{name: "env", priority: 50, values: map[string]interface{}{
+				"test.number": "42",
+			}}, cache)
+
+			b.ResetTimer()
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _ = config.GetInt("test.number")
+			}
+		})
+	}
+}
@@ -5,23 +5,60 @@
 //              and remote configuration sources. Implements type-safe configuration
 //              structures with validation, hot-reloading, and sensitive data protection.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.1
+// Version: v0.1.32
 // Created: 2025-05-26
-// Modified: 2025-05-27
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-05-26 v0.1.0: Initial configuration management implementation
 // - 2025-05-27 v0.1.1: Improved interface segregation, error codes, validation enhancements
+// - 2026-10-16 v0.1.2: Added per-key and per-prefix change subscriptions
+// - 2026-10-16 v0.1.3: Added validated reload with rollback and reload statistics
+// - 2026-10-16 v0.1.4: Added debounced hot-reload via LoadOptions.ReloadDebounce
+// - 2026-10-16 v0.1.5: Added GetTime, GetTimeSlice, and GetTimeWithDefault accessors
+// - 2026-10-16 v0.1.6: Added MergeStrategy with deep merge and slice appending
+// - 2026-10-16 v0.1.7: Added post-merge interpolation of configuration key references
+// - 2026-10-16 v0.1.8: Added duration unit hints and GetDurationInUnit
+// - 2026-10-16 v0.1.9: Recover watcher panics via core.SafeGo
+// - 2026-10-16 v0.1.10: Extracted diffValues for snapshot diffs
+// - 2026-10-16 v0.1.11: Added ConfigMetrics hooks for load, reload, and validation
+// - 2026-10-16 v0.1.12: Inject Field.DefaultValue for missing keys during Load
+// - 2026-10-16 v0.1.13: Collect deprecation and source warnings as ConfigWarning data
+// - 2026-10-16 v0.1.14: Log watcher errors via core.ContextLogger instead of stdout
+// - 2026-10-16 v0.1.15: Added GetStringMap and GetStringMapString section accessors
+// - 2026-10-16 v0.1.16: Added LoadOptions.TimeFormats for custom time layouts
+// - 2026-10-16 v0.1.17: Added Freeze checks to mutating methods; AddValidator and field metadata methods return errors
+// - 2026-10-16 v0.1.18: Fixed Unmarshal of time.Duration fields from duration strings
+// - 2026-10-16 v0.1.19: Strict strconv-based numeric string coercion; added GetFloat
+// - 2026-10-16 v0.1.20: Added GetDurationSlice
+// - 2026-10-16 v0.1.21: Added SensitiveSource and redaction of sensitive keys in GetAll
+// - 2026-10-16 v0.1.22: Added CaseInsensitiveKeys and GetPath
+// - 2026-10-16 v0.1.23: Added GetInt64 and GetUint64, GetInt reports int overflow
+// - 2026-10-16 v0.1.24: Added Get...Or accessors that record runtime defaults
+// - 2026-10-16 v0.1.25: Coalesce watch notifications of all sources into a single reload goroutine; Close waits for it
+// - 2026-10-16 v0.1.26: Added Changes and StopChanges channel-based change streams
+// - 2026-10-16 v0.1.27: Added LoadOptions.StrictKeys and UnknownKeys
+// - 2026-10-16 v0.1.28: Added opt-in conversion cache for GetInt and GetBool
+// - 2026-10-16 v0.1.29: Added LoadOptions.WriteTarget for Config.Set
+// - 2026-10-16 v0.1.30: Added LoadOptions.StructuredValidation
+// - 2026-10-16 v0.1.31: Close also closes io.Closer sources
+// - 2026-10-16 v0.1.32: Added Field.MinLength/MaxLength and ranges for numeric strings
 
 package config
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"math"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/msto63/tbp/tbp-foundation/pkg/core"
 )
@@ -42,11 +79,109 @@ type Config struct {
 	// watchers contains registered configuration change watchers
 	watchers []Watcher
 
+	// subscriptions contains per-key and per-prefix change subscriptions
+	subscriptions []*subscription
+
+	// nextSubscriptionID is used to assign unique subscription identifiers
+	nextSubscriptionID uint64
+
+	// changeStreams contains the channels returned by Changes
+	changeStreams map[<-chan map[string]ConfigChange]*changeStream
+
 	// metadata contains configuration metadata and validation info
 	metadata *Metadata
 
 	// environment stores the current environment name
 	environment string
+
+	// validateOnReload enables validation of hot-reloaded configuration
+	validateOnReload bool
+
+	// lastReloadError stores the error of the most recent failed reload
+	lastReloadError error
+
+	// reloadSuccesses counts successful configuration loads
+	reloadSuccesses uint64
+
+	// reloadFailures counts failed or rejected configuration loads
+	reloadFailures uint64
+
+	// reloadDebounce is the window used to coalesce hot-reload triggers
+	reloadDebounce time.Duration
+
+	// mergeStrategy controls how values from different sources are combined
+	mergeStrategy MergeStrategy
+
+	// appendSlices appends slice values across sources in deep merge mode
+	appendSlices bool
+
+	// metrics receives load, reload, and validation measurements
+	metrics ConfigMetrics
+
+	// defaultedKeys contains keys whose value was injected from Field.DefaultValue
+	defaultedKeys map[string]bool
+
+	// runtimeDefaults contains defaults recorded by the Get...Or accessors,
+	// applied on every load to keys no source provides
+	runtimeDefaults map[string]interface{}
+
+	// sourceSensitiveKeys contains keys reported by SensitiveSource implementations
+	sourceSensitiveKeys map[string]bool
+
+	// warningsMu protects the collected warnings, which are also updated by
+	// Validate while only the read lock is held
+	warningsMu sync.Mutex
+
+	// setupWarnings contains warnings raised while creating the manager
+	setupWarnings []ConfigWarning
+
+	// validationWarnings contains warnings of the most recent validation run
+	validationWarnings []ConfigWarning
+
+	// onWarning receives every recorded warning
+	onWarning func(ConfigWarning)
+
+	// timeFormats contains custom time layouts tried before the defaults
+	timeFormats []string
+
+	// frozen marks the configuration read-only, see Freeze
+	frozen bool
+
+	// allowUnfreeze permits Unfreeze to lift a freeze
+	allowUnfreeze bool
+
+	// caseInsensitiveKeys lowercases keys at merge time and on lookup
+	caseInsensitiveKeys bool
+
+	// strictKeys makes validation fail for keys not declared in the metadata
+	strictKeys bool
+
+	// structuredValidation makes validation return a *core.ValidationError
+	structuredValidation bool
+
+	// conversions caches typed conversions of string values (nil = disabled)
+	conversions *conversionCache
+
+	// writeTarget is the name of the source Set persists values to
+	writeTarget string
+
+	// overrides holds values changed with Set (nil until first used)
+	overrides *overrideSource
+
+	// setMu serializes Set calls, which write back outside the main lock
+	setMu sync.Mutex
+
+	// done is closed when the configuration manager is closed
+	done chan struct{}
+
+	// closeOnce ensures the done channel is closed only once
+	closeOnce sync.Once
+
+	// watchCancel stops the source watches started by StartWatching
+	watchCancel context.CancelFunc
+
+	// watchWG tracks the goroutines started by StartWatching
+	watchWG sync.WaitGroup
 }
 
 // Source represents a configuration source (env vars, files, etc.)
@@ -89,12 +224,50 @@ type WritableSource interface {
 	WriteConfig(values map[string]interface{}) error
 }
 
+// SensitiveSource extends Source with the keys whose values are secret.
+// Sources of secrets such as Vault implement this so that their values are
+// redacted by Config.GetAll and listed in Config.Summary.
+type SensitiveSource interface {
+	Source
+
+	// SensitiveKeys returns the keys of the most recently loaded values
+	// that must not be exposed
+	SensitiveKeys() []string
+}
+
 // Watcher receives notifications when configuration changes
 type Watcher interface {
 	// OnConfigChange is called when configuration values change
 	OnConfigChange(ctx context.Context, changes map[string]ConfigChange)
 }
 
+// subscription represents a change subscription for a single key or a key prefix
+type subscription struct {
+	id       uint64
+	key      string
+	prefix   bool
+	onKey    func(ConfigChange)
+	onPrefix func(map[string]ConfigChange)
+}
+
+// matches returns the subset of changes relevant to the subscription
+func (s *subscription) matches(changes map[string]ConfigChange) map[string]ConfigChange {
+	matched := make(map[string]ConfigChange)
+	if !s.prefix {
+		if change, exists := changes[s.key]; exists {
+			matched[s.key] = change
+		}
+		return matched
+	}
+
+	for key, change := range changes {
+		if strings.HasPrefix(key, s.key) {
+			matched[key] = change
+		}
+	}
+	return matched
+}
+
 // ConfigChange represents a configuration value change
 type ConfigChange struct {
 	Key      string      `json:"key"`
@@ -123,6 +296,35 @@ func (ca ChangeAction) String() string {
 	return string(ca)
 }
 
+// MergeStrategy determines how values for the same key from different
+// sources are combined during Load.
+//
+// Sources are always applied from lowest to highest priority. Distinct
+// dotted keys (e.g. db.options.sslmode and db.options.timeout) are kept
+// from every source regardless of the strategy. The strategy only matters
+// when several sources provide a value for the same key:
+//
+//   - MergeOverwrite: the value of the highest priority source wins.
+//   - MergeDeep: map values are merged recursively, with the higher
+//     priority source winning for conflicting nested keys. Slice values
+//     are replaced unless LoadOptions.AppendSlices is set, in which case
+//     the higher priority slice is appended to the lower priority one.
+//     All other values are replaced by the higher priority source.
+type MergeStrategy string
+
+const (
+	// MergeOverwrite replaces values from lower priority sources (default)
+	MergeOverwrite MergeStrategy = "overwrite"
+
+	// MergeDeep recursively merges map values and optionally appends slices
+	MergeDeep MergeStrategy = "deep"
+)
+
+// String returns the string representation of the merge strategy
+func (ms MergeStrategy) String() string {
+	return string(ms)
+}
+
 // Metadata contains configuration schema and validation information
 type Metadata struct {
 	Name        string            `json:"name"`
@@ -145,6 +347,8 @@ type Field struct {
 	Validators   []string    `json:"validators,omitempty"`
 	MinValue     interface{} `json:"min_value,omitempty"`
 	MaxValue     interface{} `json:"max_value,omitempty"`
+	MinLength    int         `json:"min_length,omitempty"` // Minimum length of string values in characters (0 = no limit)
+	MaxLength    int         `json:"max_length,omitempty"` // Maximum length of string values in characters (0 = no limit)
 	Pattern      string      `json:"pattern,omitempty"`
 	Enum         []string    `json:"enum,omitempty"`
 }
@@ -154,15 +358,53 @@ type ValidatorFunc func(key string, value interface{}) error
 
 // LoadOptions configures how configuration is loaded
 type LoadOptions struct {
-	Sources      []Source               `json:"-"`
-	Environment  string                 `json:"environment"`
-	ConfigPaths  []string               `json:"config_paths"`
-	EnvPrefix    string                 `json:"env_prefix"`
-	Defaults     map[string]interface{} `json:"defaults"`
-	Validation   bool                   `json:"validation"`
-	HotReload    bool                   `json:"hot_reload"`
-	Metadata     *Metadata              `json:"metadata,omitempty"`
-	FailOnMissing bool                  `json:"fail_on_missing"` // Fail if required sources are missing
+	Sources        []Source               `json:"-"`
+	Environment    string                 `json:"environment"`
+	ConfigPaths    []string               `json:"config_paths"`
+	EnvPrefix      string                 `json:"env_prefix"`
+	Defaults       map[string]interface{} `json:"defaults"`
+	Validation     bool                   `json:"validation"`
+	HotReload      bool                   `json:"hot_reload"`
+	ReloadDebounce time.Duration          `json:"reload_debounce"` // Coalesce hot-reload triggers (0 = reload immediately)
+	Metadata       *Metadata              `json:"metadata,omitempty"`
+	FailOnMissing  bool                   `json:"fail_on_missing"` // Fail if required sources are missing
+	MergeStrategy  MergeStrategy          `json:"merge_strategy"`  // How values for the same key are combined (default: overwrite)
+	AppendSlices   bool                   `json:"append_slices"`   // Append slices across sources (deep merge only)
+	Metrics        ConfigMetrics          `json:"-"`               // Receives load and validation measurements (default: no-op)
+	OnWarning      func(ConfigWarning)    `json:"-"`               // Receives warnings as they are recorded, see Config.Warnings
+	TimeFormats    []string               `json:"time_formats"`    // Custom time layouts tried before the defaults (see TimeFormatEpochMillis)
+	AllowUnfreeze  bool                   `json:"allow_unfreeze"`  // Permit Unfreeze after Freeze (intended for tests)
+
+	// CaseInsensitiveKeys lowercases all keys when sources are merged and
+	// when keys are looked up, so "Server.Port" and "server.port" address
+	// the same value. Keys from EnvSource are already lowercase and are not
+	// affected. If differently-cased keys collide, the value from the
+	// higher-priority source wins; within one source the lowercase spelling
+	// wins.
+	CaseInsensitiveKeys bool `json:"case_insensitive_keys"`
+
+	// StrictKeys makes Validate fail for keys that are not declared in
+	// Metadata.Fields, to catch typos like "serer.port". It only applies
+	// if fields are declared. Keys from DefaultSource sources and runtime
+	// defaults are always accepted. See Config.UnknownKeys.
+	StrictKeys bool `json:"strict_keys"`
+
+	// CacheConversions caches the results of GetInt and GetBool for string
+	// values, so that hot paths parse each value once. The cache holds up
+	// to ConversionCacheSize entries and drops entries whose value changed
+	// on reload.
+	CacheConversions bool `json:"cache_conversions"`
+
+	// WriteTarget names the WritableSource, e.g. a file source, to which
+	// Config.Set persists values. Without a write target, Set only changes
+	// the values in memory.
+	WriteTarget string `json:"write_target"`
+
+	// StructuredValidation makes Validate, and Load with Validation,
+	// return a *core.ValidationError with one core.FieldError per failed
+	// field, sorted by field name, instead of a flat error, so callers can
+	// report field errors to users. See core.IsValidationError.
+	StructuredValidation bool `json:"structured_validation"`
 }
 
 // New creates a new configuration manager with the specified options
@@ -175,12 +417,39 @@ func New(ctx context.Context, opts LoadOptions) (*Config, error) {
 		opts.Environment = "development"
 	}
 
+	if opts.MergeStrategy == "" {
+		opts.MergeStrategy = MergeOverwrite
+	}
+	if opts.MergeStrategy != MergeOverwrite && opts.MergeStrategy != MergeDeep {
+		return nil, core.Newf("unsupported merge strategy: %s", opts.MergeStrategy)
+	}
+
+	if opts.Metrics == nil {
+		opts.Metrics = noopMetrics{}
+	}
+
 	config := &Config{
-		sources:     make([]Source, 0),
-		values:      make(map[string]interface{}),
-		watchers:    make([]Watcher, 0),
-		metadata:    opts.Metadata,
-		environment: opts.Environment,
+		sources:              make([]Source, 0),
+		values:               make(map[string]interface{}),
+		watchers:             make([]Watcher, 0),
+		metadata:             opts.Metadata,
+		environment:          opts.Environment,
+		validateOnReload:     opts.Validation,
+		reloadDebounce:       opts.ReloadDebounce,
+		mergeStrategy:        opts.MergeStrategy,
+		appendSlices:         opts.AppendSlices,
+		metrics:              opts.Metrics,
+		onWarning:            opts.OnWarning,
+		timeFormats:          opts.TimeFormats,
+		allowUnfreeze:        opts.AllowUnfreeze,
+		caseInsensitiveKeys:  opts.CaseInsensitiveKeys,
+		strictKeys:           opts.StrictKeys,
+		writeTarget:          opts.WriteTarget,
+		structuredValidation: opts.StructuredValidation,
+		done:                 make(chan struct{}),
+	}
+	if opts.CacheConversions {
+		config.conversions = newConversionCache(ConversionCacheSize)
 	}
 
 	// Set default metadata if not provided
@@ -217,8 +486,11 @@ func New(ctx context.Context, opts LoadOptions) (*Config, error) {
 				if opts.FailOnMissing {
 					return nil, core.Wrapf(err, "failed to create file source for %s", path)
 				}
-				// Log warning but continue if not failing on missing
-				fmt.Printf("Warning: failed to create file source for %s: %v\n", path, err)
+				// Record warning but continue if not failing on missing
+				config.addSetupWarning(ConfigWarning{
+					Message:  fmt.Sprintf("failed to create file source for %s: %v", path, err),
+					Severity: WarningSeverityWarning,
+				})
 				continue
 			}
 			opts.Sources = append(opts.Sources, fileSource)
@@ -262,7 +534,8 @@ func New(ctx context.Context, opts LoadOptions) (*Config, error) {
 	return config, nil
 }
 
-// AddSource adds a configuration source to the configuration manager
+// AddSource adds a configuration source to the configuration manager.
+// It fails with ErrCodeForbidden if the configuration is frozen.
 func (c *Config) AddSource(source Source) error {
 	if source == nil {
 		return core.New("source cannot be nil")
@@ -278,6 +551,10 @@ func (c *Config) AddSource(source Source) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	if c.frozen {
+		return frozenError("add source " + source.Name())
+	}
+
 	c.sources = append(c.sources, source)
 	
 	// Sort sources by priority (highest priority first)
@@ -294,9 +571,123 @@ func (c *Config) AddSource(source Source) error {
 
 // Load loads configuration from all sources and merges them
 func (c *Config) Load(ctx context.Context) error {
+	return c.load(ctx, false)
+}
+
+// ReloadValidated loads configuration from all sources into a temporary map
+// and validates it before applying. If validation fails, the previous values
+// are kept, watchers are not notified, and the validation error is returned.
+func (c *Config) ReloadValidated(ctx context.Context) error {
+	return c.load(ctx, true)
+}
+
+// load merges all sources and swaps the result into the active values,
+// optionally validating the merged values first
+func (c *Config) load(ctx context.Context, validate bool) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	start := time.Now()
+	newValues, defaulted, err := c.mergeSources(ctx)
+	c.metricsHook().ObserveLoad(time.Since(start), len(c.sources))
+	if err != nil {
+		c.recordReload(err)
+		return err
+	}
+
+	if validate {
+		warnings, err := c.validateValues(newValues)
+		if err != nil {
+			err = core.Wrap(err, "reloaded configuration rejected, keeping previous values")
+			c.recordReload(err)
+			return err
+		}
+		c.setValidationWarnings(warnings)
+	}
+
+	// Store old values for change detection
+	oldValues, oldDefaulted := c.values, c.defaultedKeys
+	c.values = newValues
+	c.defaultedKeys = defaulted
+	c.conversions.invalidate(newValues)
+	c.sourceSensitiveKeys = c.collectSensitiveKeys()
+	c.recordReload(nil)
+
+	// Notify watchers and subscriptions of changes
+	if len(c.watchers) > 0 || len(c.subscriptions) > 0 {
+		changes := c.detectChanges(oldValues, newValues)
+		markDefaultedChanges(changes, oldDefaulted, defaulted)
+		if len(changes) > 0 {
+			go c.notifyWatchers(ctx, changes)
+		}
+	}
+
+	return nil
+}
+
+// collectSensitiveKeys gathers the keys reported by sensitive sources.
+// The caller must hold the lock.
+func (c *Config) collectSensitiveKeys() map[string]bool {
+	keys := make(map[string]bool)
+	for _, source := range c.sources {
+		if sensitive, ok := source.(SensitiveSource); ok {
+			for _, key := range sensitive.SensitiveKeys() {
+				keys[c.normalizeKey(key)] = true
+			}
+		}
+	}
+	return keys
+}
+
+// isSensitiveKey reports whether the key is marked Sensitive in the
+// metadata or by a SensitiveSource. The caller must hold the lock.
+func (c *Config) isSensitiveKey(key string) bool {
+	if c.sourceSensitiveKeys[key] {
+		return true
+	}
+	for fieldName, field := range c.metadata.Fields {
+		if field.Sensitive && c.normalizeKey(fieldName) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeKey returns the key under which a value is stored: the key
+// itself, or its lowercase form if CaseInsensitiveKeys is enabled
+func (c *Config) normalizeKey(key string) string {
+	if c.caseInsensitiveKeys {
+		return strings.ToLower(key)
+	}
+	return key
+}
+
+// normalizeSourceKeys lowercases the keys of one source's values if
+// CaseInsensitiveKeys is enabled. Keys are applied in sorted order so that
+// collisions resolve deterministically: uppercase letters sort first, so
+// the lowercase spelling is applied last and wins.
+func (c *Config) normalizeSourceKeys(values map[string]interface{}) map[string]interface{} {
+	if !c.caseInsensitiveKeys {
+		return values
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	normalized := make(map[string]interface{}, len(values))
+	for _, key := range keys {
+		normalized[strings.ToLower(key)] = values[key]
+	}
+	return normalized
+}
+
+// mergeSources loads all sources, merges their values by priority, and
+// injects field defaults. Returns the merged values and the set of keys
+// whose value was injected from Field.DefaultValue.
+func (c *Config) mergeSources(ctx context.Context) (map[string]interface{}, map[string]bool, error) {
 	newValues := make(map[string]interface{})
 
 	// Load from sources in reverse priority order (lowest first)
@@ -306,119 +697,426 @@ func (c *Config) Load(ctx context.Context) error {
 		
 		values, err := source.Load(ctx)
 		if err != nil {
-			return core.Wrapf(err, "failed to load from source %s", source.Name())
+			return nil, nil, core.Wrapf(err, "failed to load from source %s", source.Name())
 		}
+		values = c.normalizeSourceKeys(values)
 
 		// Merge values (higher priority overwrites lower priority)
 		for key, value := range values {
+			if c.mergeStrategy == MergeDeep {
+				c.deepMergeValue(newValues, key, value)
+				continue
+			}
 			newValues[key] = value
 		}
 	}
 
-	// Store old values for change detection
-	oldValues := c.values
-	c.values = newValues
+	// Keep indexed keys (list.0, list.1, ...) consistent with appended slices
+	if c.mergeStrategy == MergeDeep && c.appendSlices {
+		reindexSlices(newValues)
+	}
 
-	// Notify watchers of changes
-	if len(c.watchers) > 0 {
-		changes := c.detectChanges(oldValues, newValues)
-		if len(changes) > 0 {
-			go c.notifyWatchers(ctx, changes)
+	// Fill keys no source provided from field metadata defaults
+	defaulted := c.applyFieldDefaults(newValues)
+
+	// Keep defaults recorded at runtime for keys still missing
+	for key, value := range c.runtimeDefaults {
+		if _, exists := newValues[key]; !exists {
+			newValues[key] = value
 		}
 	}
 
-	return nil
+	// Resolve ${key.path} references across all merged sources
+	if err := interpolateValues(newValues); err != nil {
+		return nil, nil, err
+	}
+
+	return newValues, defaulted, nil
+}
+
+// MetadataDefaultSourceName is the source name reported for values
+// injected from Field.DefaultValue
+const MetadataDefaultSourceName = "metadata-default"
+
+// applyFieldDefaults sets Field.DefaultValue for every field that declares
+// a default and has no value from any source. Returns the injected keys.
+func (c *Config) applyFieldDefaults(values map[string]interface{}) map[string]bool {
+	defaulted := make(map[string]bool)
+	if c.metadata == nil {
+		return defaulted
+	}
+
+	for fieldName, field := range c.metadata.Fields {
+		if field.DefaultValue == nil {
+			continue
+		}
+		key := c.normalizeKey(fieldName)
+		if _, exists := values[key]; exists {
+			continue
+		}
+		values[key] = field.DefaultValue
+		defaulted[key] = true
+	}
+	return defaulted
+}
+
+// markDefaultedChanges reports MetadataDefaultSourceName as the source of
+// changes to values injected from field defaults
+func markDefaultedChanges(changes map[string]ConfigChange, oldDefaulted, newDefaulted map[string]bool) {
+	for key, change := range changes {
+		defaulted := newDefaulted[key]
+		if change.Action == ChangeActionDelete {
+			defaulted = oldDefaulted[key]
+		}
+		if defaulted {
+			change.Source = MetadataDefaultSourceName
+			changes[key] = change
+		}
+	}
+}
+
+// reindexSlices sets indexed keys for all scalar slice elements so they
+// match the merged slice values
+func reindexSlices(values map[string]interface{}) {
+	sliceValues := make(map[string][]interface{})
+	for key, value := range values {
+		if slice, ok := value.([]interface{}); ok {
+			sliceValues[key] = slice
+		}
+	}
+
+	for key, slice := range sliceValues {
+		for i, item := range slice {
+			if _, isMap := item.(map[string]interface{}); !isMap {
+				values[fmt.Sprintf("%s.%d", key, i)] = item
+			}
+		}
+	}
+}
+
+// deepMergeValue merges a value into the target map, combining nested maps
+// and optionally appending slices instead of replacing them
+func (c *Config) deepMergeValue(target map[string]interface{}, key string, value interface{}) {
+	existing, exists := target[key]
+	if !exists {
+		target[key] = value
+		return
+	}
+
+	switch newValue := value.(type) {
+	case map[string]interface{}:
+		if existingMap, ok := existing.(map[string]interface{}); ok {
+			merged := make(map[string]interface{}, len(existingMap)+len(newValue))
+			for k, v := range existingMap {
+				merged[k] = v
+			}
+			for k, v := range newValue {
+				c.deepMergeValue(merged, k, v)
+			}
+			target[key] = merged
+			return
+		}
+
+	case []interface{}:
+		if existingSlice, ok := existing.([]interface{}); ok && c.appendSlices {
+			merged := make([]interface{}, 0, len(existingSlice)+len(newValue))
+			merged = append(merged, existingSlice...)
+			merged = append(merged, newValue...)
+			target[key] = merged
+			return
+		}
+	}
+
+	target[key] = value
 }
 
-// Validate validates the current configuration against defined rules
+// recordReload updates the reload counters and the last reload error
+func (c *Config) recordReload(err error) {
+	c.metricsHook().ObserveReload(err == nil)
+	if err != nil {
+		c.reloadFailures++
+		c.lastReloadError = err
+		return
+	}
+	c.reloadSuccesses++
+}
+
+// metricsHook returns the configured metrics hook or a no-op implementation
+func (c *Config) metricsHook() ConfigMetrics {
+	if c.metrics == nil {
+		return noopMetrics{}
+	}
+	return c.metrics
+}
+
+// LastReloadError returns the error of the most recent failed reload.
+// Returns nil if no reload has failed yet.
+func (c *Config) LastReloadError() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.lastReloadError
+}
+
+// ReloadStats returns counters of successful and failed configuration loads
+func (c *Config) ReloadStats() ReloadStats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return ReloadStats{
+		Successful: c.reloadSuccesses,
+		Failed:     c.reloadFailures,
+	}
+}
+
+// ReloadStats provides counters of configuration load outcomes
+type ReloadStats struct {
+	Successful uint64 `json:"successful"`
+	Failed     uint64 `json:"failed"`
+}
+
+// Validate validates the current configuration against defined rules.
+// Non-fatal findings such as deprecated fields do not fail validation;
+// they are available via Warnings afterwards. With
+// LoadOptions.StructuredValidation the error is a *core.ValidationError.
 func (c *Config) Validate(ctx context.Context) error {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
+	warnings, err := c.validateValues(c.values)
+	c.setValidationWarnings(warnings)
+	return err
+}
+
+// validateValues validates the given values against the configuration metadata
+// and returns the warnings found. The caller must hold the configuration lock.
+func (c *Config) validateValues(values map[string]interface{}) ([]ConfigWarning, error) {
 	var validationErrors []string
+	var fieldErrors []core.FieldError
+
+	start := time.Now()
+	defer func() {
+		c.metricsHook().ObserveValidation(time.Since(start), len(validationErrors))
+	}()
 
 	// Validate required fields
 	for fieldName, field := range c.metadata.Fields {
+		key := c.normalizeKey(fieldName)
 		if field.Required {
-			if _, exists := c.values[fieldName]; !exists {
-				validationErrors = append(validationErrors, 
-					fmt.Sprintf("required configuration field '%s' is missing", fieldName))
+			if _, exists := values[key]; !exists {
+				message := fmt.Sprintf("required configuration field '%s' is missing", fieldName)
+				validationErrors = append(validationErrors, message)
+				fieldErrors = append(fieldErrors, core.FieldError{Field: fieldName, Message: message, Rule: "required"})
 			}
 		}
 		
 		// Validate field constraints if value exists
-		if value, exists := c.values[fieldName]; exists {
-			if err := c.validateField(fieldName, field, value); err != nil {
+		if value, exists := values[key]; exists {
+			if rule, err := c.validateField(fieldName, field, value); err != nil {
 				validationErrors = append(validationErrors, err.Error())
+				fieldErrors = append(fieldErrors, core.FieldError{Field: fieldName, Message: err.Error(), Rule: rule})
 			}
 		}
 	}
 
 	// Run custom validators
 	for _, validator := range c.metadata.Validators {
-		for key, value := range c.values {
+		for key, value := range values {
 			if err := validator(key, value); err != nil {
-				validationErrors = append(validationErrors, 
-					fmt.Sprintf("validation failed for field '%s': %v", key, err))
+				message := fmt.Sprintf("validation failed for field '%s': %v", key, err)
+				validationErrors = append(validationErrors, message)
+				fieldErrors = append(fieldErrors, core.FieldError{Field: key, Message: message, Rule: "custom"})
+			}
+		}
+	}
+
+	// Reject keys not declared in the metadata
+	var unknownKeys []string
+	if c.strictKeys {
+		unknownKeys = c.unknownKeys(values)
+		if len(unknownKeys) > 0 {
+			validationErrors = append(validationErrors,
+				fmt.Sprintf("unknown configuration keys: %s", strings.Join(unknownKeys, ", ")))
+			for _, key := range unknownKeys {
+				fieldErrors = append(fieldErrors, core.FieldError{
+					Field:   key,
+					Message: fmt.Sprintf("unknown configuration key '%s'", key),
+					Rule:    "unknown",
+				})
 			}
 		}
 	}
 
 	// Check for deprecated fields
-	for key := range c.values {
-		if field, exists := c.metadata.Fields[key]; exists && field.Deprecated {
-			fmt.Printf("Warning: configuration field '%s' is deprecated: %s\n", 
-				key, field.Description)
+	var warnings []ConfigWarning
+	for fieldName, field := range c.metadata.Fields {
+		key := c.normalizeKey(fieldName)
+		if _, exists := values[key]; exists && field.Deprecated {
+			message := "configuration field is deprecated"
+			if field.Description != "" {
+				message += ": " + field.Description
+			}
+			warnings = append(warnings, ConfigWarning{
+				Key:      key,
+				Message:  message,
+				Severity: WarningSeverityWarning,
+			})
 		}
 	}
+	sort.Slice(warnings, func(i, j int) bool { return warnings[i].Key < warnings[j].Key })
 
+	if len(validationErrors) > 0 && c.structuredValidation {
+		// Fields and values are maps, so sort for a deterministic order
+		sort.SliceStable(fieldErrors, func(i, j int) bool { return fieldErrors[i].Field < fieldErrors[j].Field })
+
+		err := core.NewValidationError("configuration validation failed")
+		for _, fieldErr := range fieldErrors {
+			err.AddField(fieldErr.Field, fieldErr.Rule, fieldErr.Message)
+		}
+		if len(unknownKeys) > 0 {
+			err = err.WithContext("unknown_keys", unknownKeys)
+		}
+		return warnings, err
+	}
 	if len(validationErrors) > 0 {
-		return core.Newf("configuration validation failed:\n  - %s", 
+		err := core.Newf("configuration validation failed:\n  - %s",
 			strings.Join(validationErrors, "\n  - "))
+		if len(unknownKeys) > 0 {
+			err = err.WithCode(core.ErrCodeInvalidInput).WithContext("unknown_keys", unknownKeys)
+		}
+		return warnings, err
 	}
 
-	return nil
+	return warnings, nil
+}
+
+// UnknownKeys returns the sorted keys that have a value but are not
+// declared in the metadata fields. A key is declared if it or one of its
+// parents is a field, e.g. "labels.team" for a field "labels". Keys from
+// DefaultSource sources and runtime defaults are not reported. Returns nil
+// if no fields are declared.
+func (c *Config) UnknownKeys() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.unknownKeys(c.values)
+}
+
+// unknownKeys returns the sorted keys of values not declared in the
+// metadata. The caller must hold the configuration lock.
+func (c *Config) unknownKeys(values map[string]interface{}) []string {
+	if c.metadata == nil || len(c.metadata.Fields) == 0 {
+		return nil
+	}
+
+	declared := make(map[string]bool, len(c.metadata.Fields))
+	for fieldName := range c.metadata.Fields {
+		declared[c.normalizeKey(fieldName)] = true
+	}
+	for _, source := range c.sources {
+		if defaults, ok := source.(*DefaultSource); ok {
+			for key := range defaults.GetDefaults() {
+				declared[c.normalizeKey(key)] = true
+			}
+		}
+	}
+	for key := range c.runtimeDefaults {
+		declared[key] = true
+	}
+
+	var unknown []string
+	for key := range values {
+		if !isDeclaredKey(key, declared) {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// isDeclaredKey reports whether key or one of its parents is declared
+func isDeclaredKey(key string, declared map[string]bool) bool {
+	for {
+		if declared[key] {
+			return true
+		}
+		i := strings.LastIndex(key, ".")
+		if i < 0 {
+			return false
+		}
+		key = key[:i]
+	}
 }
 
-// validateField validates a single field against its constraints
-func (c *Config) validateField(fieldName string, field Field, value interface{}) error {
+// validateField validates a single field against its constraints and
+// returns the name of the failed rule with the error
+func (c *Config) validateField(fieldName string, field Field, value interface{}) (string, error) {
 	// Type validation
 	if field.Type != "" {
 		if err := c.validateFieldType(fieldName, field.Type, value); err != nil {
-			return err
+			return "type", err
 		}
 	}
 
 	// Range validation for numeric types
 	if field.MinValue != nil || field.MaxValue != nil {
 		if err := c.validateFieldRange(fieldName, field, value); err != nil {
-			return err
+			return "range", err
+		}
+	}
+
+	// Length validation for strings
+	if field.MinLength > 0 || field.MaxLength > 0 {
+		if err := c.validateFieldLength(fieldName, field, value); err != nil {
+			return "length", err
 		}
 	}
 
 	// Enum validation
 	if len(field.Enum) > 0 {
 		if err := c.validateFieldEnum(fieldName, field.Enum, value); err != nil {
-			return err
+			return "enum", err
 		}
 	}
 
 	// Pattern validation for strings
 	if field.Pattern != "" {
 		if err := c.validateFieldPattern(fieldName, field.Pattern, value); err != nil {
-			return err
+			return "pattern", err
 		}
 	}
 
-	return nil
+	return "", nil
 }
 
 // validateFieldType validates the type of a field value
 func (c *Config) validateFieldType(fieldName, expectedType string, value interface{}) error {
+	// Duration unit hints accept bare numbers and duration strings
+	if _, isDurationHint := durationUnitHints[expectedType]; isDurationHint {
+		if _, ok := numericDuration(value, time.Second); ok {
+			return nil
+		}
+		if str, ok := value.(string); ok {
+			if _, err := time.ParseDuration(str); err == nil {
+				return nil
+			}
+		}
+		if _, ok := value.(time.Duration); ok {
+			return nil
+		}
+		return core.Newf("field '%s' has type %T but expected %s",
+			fieldName, value, expectedType)
+	}
+
 	actualType := reflect.TypeOf(value).String()
 	
 	// Normalize type names
 	normalizedExpected := c.normalizeTypeName(expectedType)
+
+	// Numeric fields accept numeric strings, e.g. from environment variables
+	if str, ok := value.(string); ok && isNumericString(normalizedExpected, str) {
+		return nil
+	}
 	normalizedActual := c.normalizeTypeName(actualType)
 	
 	if normalizedExpected != normalizedActual {
@@ -429,38 +1127,81 @@ func (c *Config) validateFieldType(fieldName, expectedType string, value interfa
 	return nil
 }
 
-// validateFieldRange validates numeric range constraints
+// validateFieldRange validates numeric range constraints. Values and
+// bounds of any numeric type are compared; string values are parsed if the
+// field type is numeric and skipped otherwise.
 func (c *Config) validateFieldRange(fieldName string, field Field, value interface{}) error {
-	// This is a simplified version - a full implementation would handle
-	// all numeric types and proper type conversion
-	switch v := value.(type) {
-	case int:
-		if field.MinValue != nil {
-			if min, ok := field.MinValue.(int); ok && v < min {
-				return core.Newf("field '%s' value %d is below minimum %d", fieldName, v, min)
-			}
-		}
-		if field.MaxValue != nil {
-			if max, ok := field.MaxValue.(int); ok && v > max {
-				return core.Newf("field '%s' value %d exceeds maximum %d", fieldName, v, max)
-			}
-		}
-	case float64:
-		if field.MinValue != nil {
-			if min, ok := field.MinValue.(float64); ok && v < min {
-				return core.Newf("field '%s' value %f is below minimum %f", fieldName, v, min)
-			}
-		}
-		if field.MaxValue != nil {
-			if max, ok := field.MaxValue.(float64); ok && v > max {
-				return core.Newf("field '%s' value %f exceeds maximum %f", fieldName, v, max)
-			}
+	number, ok := numericValue(value)
+	if str, isString := value.(string); isString {
+		normalizedType := c.normalizeTypeName(field.Type)
+		if !isNumericString(normalizedType, str) {
+			return nil
 		}
+		number, _ = parseFloatString(str)
+		ok = true
+	}
+	if !ok {
+		return nil
+	}
+
+	if min, ok := numericValue(field.MinValue); ok && number < min {
+		return core.Newf("field '%s' value %v is below minimum %v", fieldName, value, field.MinValue)
+	}
+	if max, ok := numericValue(field.MaxValue); ok && number > max {
+		return core.Newf("field '%s' value %v exceeds maximum %v", fieldName, value, field.MaxValue)
 	}
-	
 	return nil
 }
 
+// validateFieldLength validates the length of string values in characters.
+// Values of other types are skipped.
+func (c *Config) validateFieldLength(fieldName string, field Field, value interface{}) error {
+	str, ok := value.(string)
+	if !ok {
+		return nil
+	}
+
+	length := utf8.RuneCountInString(str)
+	if field.MinLength > 0 && length < field.MinLength {
+		return core.Newf("field '%s' length %d is below minimum length %d", fieldName, length, field.MinLength)
+	}
+	if field.MaxLength > 0 && length > field.MaxLength {
+		return core.Newf("field '%s' length %d exceeds maximum length %d", fieldName, length, field.MaxLength)
+	}
+	return nil
+}
+
+// numericValue converts a value of any numeric kind to float64
+func numericValue(value interface{}) (float64, bool) {
+	if !isNumber(value) {
+		return 0, false
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	default:
+		return rv.Float(), true
+	}
+}
+
+// isNumericString reports whether str holds a number of the normalized
+// numeric type ("integer", "float", or "number")
+func isNumericString(normalizedType, str string) bool {
+	switch normalizedType {
+	case "integer":
+		_, err := parseIntString(str, 64)
+		return err == nil
+	case "float", "number":
+		_, err := parseFloatString(str)
+		return err == nil
+	default:
+		return false
+	}
+}
+
 // validateFieldEnum validates enum constraints
 func (c *Config) validateFieldEnum(fieldName string, enum []string, value interface{}) error {
 	strValue := fmt.Sprintf("%v", value)
@@ -502,15 +1243,36 @@ func (c *Config) normalizeTypeName(typeName string) string {
 	}
 }
 
-// Get retrieves a configuration value by key
+// Get retrieves a configuration value by key. The key is matched
+// regardless of case if CaseInsensitiveKeys is enabled.
 func (c *Config) Get(key string) (interface{}, bool) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	value, exists := c.values[key]
+	value, exists := c.values[c.normalizeKey(key)]
 	return value, exists
 }
 
+// getConverted retrieves a configuration value together with its cached
+// conversion to targetType, or nil if the conversion is not cached
+func (c *Config) getConverted(key, targetType string) (value, cached interface{}, exists bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	normalized := c.normalizeKey(key)
+	value, exists = c.values[normalized]
+	if exists {
+		cached, _ = c.conversions.lookup(normalized, targetType, value)
+	}
+	return value, cached, exists
+}
+
+// GetPath retrieves a configuration value by its key segments, so
+// GetPath("server", "port") is equivalent to Get("server.port")
+func (c *Config) GetPath(path ...string) (interface{}, bool) {
+	return c.Get(strings.Join(path, "."))
+}
+
 // GetString retrieves a string configuration value
 func (c *Config) GetString(key string) (string, error) {
 	value, exists := c.Get(key)
@@ -525,41 +1287,199 @@ func (c *Config) GetString(key string) (string, error) {
 	return fmt.Sprintf("%v", value), nil
 }
 
-// GetInt retrieves an integer configuration value
+// GetInt retrieves an integer configuration value. Floats are truncated.
+// Returns an error if the value is outside the range of int on the
+// current platform instead of wrapping around.
 func (c *Config) GetInt(key string) (int, error) {
+	value, cached, exists := c.getConverted(key, "int")
+	if !exists {
+		return 0, core.Newf("configuration key '%s' not found", key)
+	}
+	if cached != nil {
+		return cached.(int), nil
+	}
+
+	result, err := toInt64(value)
+	if errors.Is(err, strconv.ErrRange) || (err == nil && (result < math.MinInt || result > math.MaxInt)) {
+		return 0, core.Newf("configuration key '%s' with value '%v' overflows int", key, value)
+	}
+	if err != nil {
+		return 0, core.Newf("configuration key '%s' with value '%v' cannot be converted to int", key, value)
+	}
+
+	c.conversions.store(c.normalizeKey(key), "int", value, int(result))
+	return int(result), nil
+}
+
+// GetInt64 retrieves a 64-bit integer configuration value without
+// truncation, for byte sizes, timestamps, and other large numbers
+func (c *Config) GetInt64(key string) (int64, error) {
 	value, exists := c.Get(key)
 	if !exists {
 		return 0, core.Newf("configuration key '%s' not found", key)
 	}
 
+	result, err := toInt64(value)
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, core.Newf("configuration key '%s' with value '%v' overflows int64", key, value)
+	}
+	if err != nil {
+		return 0, core.Newf("configuration key '%s' with value '%v' cannot be converted to int64", key, value)
+	}
+
+	return result, nil
+}
+
+// GetUint64 retrieves an unsigned 64-bit integer configuration value.
+// Negative values are rejected.
+func (c *Config) GetUint64(key string) (uint64, error) {
+	value, exists := c.Get(key)
+	if !exists {
+		return 0, core.Newf("configuration key '%s' not found", key)
+	}
+
+	result, err := toUint64(value)
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, core.Newf("configuration key '%s' with value '%v' is out of range for uint64", key, value)
+	}
+	if err != nil {
+		return 0, core.Newf("configuration key '%s' with value '%v' cannot be converted to uint64", key, value)
+	}
+
+	return result, nil
+}
+
+// toInt64 converts a numeric value or numeric string to int64. Returns an
+// error wrapping strconv.ErrRange if the value does not fit, or
+// strconv.ErrSyntax if it is not numeric.
+func toInt64(value interface{}) (int64, error) {
 	switch v := value.(type) {
 	case int:
-		return v, nil
+		return int64(v), nil
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
 	case int32:
-		return int(v), nil
+		return int64(v), nil
 	case int64:
-		return int(v), nil
+		return v, nil
+	case uint8:
+		return int64(v), nil
+	case uint16:
+		return int64(v), nil
+	case uint32:
+		return int64(v), nil
+	case uint:
+		return toInt64(uint64(v))
+	case uint64:
+		if v > math.MaxInt64 {
+			return 0, strconv.ErrRange
+		}
+		return int64(v), nil
+	case float32:
+		return floatToInt64(float64(v))
 	case float64:
-		return int(v), nil
+		return floatToInt64(v)
+	case string:
+		return parseIntString(v, 64)
+	}
+	return 0, strconv.ErrSyntax
+}
+
+// toUint64 converts a non-negative numeric value or numeric string to
+// uint64, with the same errors as toInt64
+func toUint64(value interface{}) (uint64, error) {
+	switch v := value.(type) {
+	case uint:
+		return uint64(v), nil
+	case uint64:
+		return v, nil
 	case float32:
-		return int(v), nil
+		return floatToUint64(float64(v))
+	case float64:
+		return floatToUint64(v)
 	case string:
-		if i, err := fmt.Sscanf(v, "%d", new(int)); err == nil && i == 1 {
-			var result int
-			fmt.Sscanf(v, "%d", &result)
+		trimmed := strings.TrimSpace(v)
+		if strings.HasPrefix(trimmed, "-") {
+			if n, err := parseIntString(trimmed, 64); err == nil && n < 0 {
+				return 0, strconv.ErrRange
+			}
+		}
+		return strconv.ParseUint(trimmed, 10, 64)
+	}
+
+	result, err := toInt64(value)
+	if err != nil {
+		return 0, err
+	}
+	if result < 0 {
+		return 0, strconv.ErrRange
+	}
+	return uint64(result), nil
+}
+
+// floatToInt64 truncates a float to int64, rejecting values outside its range
+func floatToInt64(value float64) (int64, error) {
+	if math.IsNaN(value) {
+		return 0, strconv.ErrSyntax
+	}
+	// float64(math.MaxInt64) rounds up to 2^63, which is already out of range
+	if value < math.MinInt64 || value >= math.MaxInt64 {
+		return 0, strconv.ErrRange
+	}
+	return int64(value), nil
+}
+
+// floatToUint64 truncates a float to uint64, rejecting values outside its range
+func floatToUint64(value float64) (uint64, error) {
+	if math.IsNaN(value) {
+		return 0, strconv.ErrSyntax
+	}
+	if value <= -1 || value >= math.MaxUint64 {
+		return 0, strconv.ErrRange
+	}
+	return uint64(value), nil
+}
+
+// GetFloat retrieves a float64 value from configuration.
+// Strings are parsed like GetInt, see parseFloatString.
+func (c *Config) GetFloat(key string) (float64, error) {
+	value, exists := c.Get(key)
+	if !exists {
+		return 0, core.Newf("configuration key '%s' not found", key)
+	}
+
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case int:
+		return float64(v), nil
+	case int32:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case string:
+		result, err := parseFloatString(v)
+		if err == nil {
 			return result, nil
 		}
 	}
 
-	return 0, core.Newf("configuration key '%s' with value '%v' cannot be converted to int", key, value)
+	return 0, core.Newf("configuration key '%s' with value '%v' cannot be converted to float", key, value)
 }
 
 // GetBool retrieves a boolean configuration value
 func (c *Config) GetBool(key string) (bool, error) {
-	value, exists := c.Get(key)
+	value, cached, exists := c.getConverted(key, "bool")
 	if !exists {
 		return false, core.Newf("configuration key '%s' not found", key)
 	}
+	if cached != nil {
+		return cached.(bool), nil
+	}
 
 	switch v := value.(type) {
 	case bool:
@@ -568,8 +1488,10 @@ func (c *Config) GetBool(key string) (bool, error) {
 		lower := strings.ToLower(strings.TrimSpace(v))
 		switch lower {
 		case "true", "yes", "1", "on", "enable", "enabled", "y", "t":
+			c.conversions.store(c.normalizeKey(key), "bool", value, true)
 			return true, nil
 		case "false", "no", "0", "off", "disable", "disabled", "n", "f", "":
+			c.conversions.store(c.normalizeKey(key), "bool", value, false)
 			return false, nil
 		}
 	case int:
@@ -578,50 +1500,221 @@ func (c *Config) GetBool(key string) (bool, error) {
 		return v != 0, nil
 	}
 
-	return false, core.Newf("configuration key '%s' with value '%v' cannot be converted to bool", key, value)
+	return false, core.Newf("configuration key '%s' with value '%v' cannot be converted to bool", key, value)
+}
+
+// GetDuration retrieves a duration configuration value.
+// Strings are parsed with time.ParseDuration (e.g. "30s", "1m30s"). Bare
+// numbers, including floats, are interpreted in the unit declared by the
+// field's Type hint (e.g. "duration_ms", see durationUnitHints) and as
+// seconds if no hint is registered. Use GetDurationInUnit to override both.
+func (c *Config) GetDuration(key string) (time.Duration, error) {
+	return c.GetDurationInUnit(key, c.durationUnit(key))
+}
+
+// GetDurationInUnit retrieves a duration configuration value, interpreting
+// bare numeric values in the given unit, so 30000 with time.Millisecond
+// yields 30s and 1.5 with time.Second yields 1.5s. The explicit unit takes
+// precedence over any Field.Type hint. Strings are always parsed with
+// time.ParseDuration and must carry their own unit suffix.
+func (c *Config) GetDurationInUnit(key string, unit time.Duration) (time.Duration, error) {
+	if unit <= 0 {
+		return 0, core.Newf("invalid duration unit %v for configuration key '%s'", unit, key)
+	}
+
+	value, exists := c.Get(key)
+	if !exists {
+		return 0, core.Newf("configuration key '%s' not found", key)
+	}
+
+	switch v := value.(type) {
+	case time.Duration:
+		return v, nil
+	case string:
+		duration, err := time.ParseDuration(v)
+		if err != nil {
+			return 0, core.Wrapf(err, "configuration key '%s' cannot be parsed as duration", key)
+		}
+		return duration, nil
+	}
+
+	if duration, ok := numericDuration(value, unit); ok {
+		return duration, nil
+	}
+
+	return 0, core.Newf("configuration key '%s' with value '%v' cannot be converted to duration", key, value)
+}
+
+// GetDurationSlice retrieves a list of durations. Accepts slices of
+// durations, strings, or numbers as well as a comma-separated string such as
+// "30s,1m,5m". Bare numbers use the unit of the field's Type hint like
+// GetDuration.
+func (c *Config) GetDurationSlice(key string) ([]time.Duration, error) {
+	value, exists := c.Get(key)
+	if !exists {
+		return nil, core.Newf("configuration key '%s' not found", key)
+	}
+
+	var items []interface{}
+	switch v := value.(type) {
+	case []time.Duration:
+		result := make([]time.Duration, len(v))
+		copy(result, v)
+		return result, nil
+	case []interface{}:
+		items = v
+	case []string:
+		for _, item := range v {
+			items = append(items, item)
+		}
+	case string:
+		for _, part := range strings.Split(v, ",") {
+			if trimmed := strings.TrimSpace(part); trimmed != "" {
+				items = append(items, trimmed)
+			}
+		}
+	default:
+		items = []interface{}{v}
+	}
+
+	unit := c.durationUnit(key)
+	result := make([]time.Duration, 0, len(items))
+	for i, item := range items {
+		var duration time.Duration
+		var ok bool
+		switch v := item.(type) {
+		case time.Duration:
+			duration, ok = v, true
+		case string:
+			parsed, err := time.ParseDuration(strings.TrimSpace(v))
+			duration, ok = parsed, err == nil
+		default:
+			duration, ok = numericDuration(v, unit)
+		}
+		if !ok {
+			return nil, core.Newf("configuration key '%s' element %d with value '%v' cannot be converted to duration", key, i, item)
+		}
+		result = append(result, duration)
+	}
+	return result, nil
+}
+
+// durationUnitHints maps Field.Type hints to the unit used for bare numeric
+// duration values
+var durationUnitHints = map[string]time.Duration{
+	"duration_ns": time.Nanosecond,
+	"duration_us": time.Microsecond,
+	"duration_ms": time.Millisecond,
+	"duration_s":  time.Second,
+	"duration_m":  time.Minute,
+	"duration_h":  time.Hour,
+}
+
+// durationUnit returns the unit for bare numeric values of a duration key,
+// taken from the field's Type hint and defaulting to seconds
+func (c *Config) durationUnit(key string) time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if field, exists := c.metadata.Fields[key]; exists {
+		if unit, ok := durationUnitHints[field.Type]; ok {
+			return unit
+		}
+	}
+	return time.Second
+}
+
+// numericDuration converts a numeric value to a duration in the given unit
+func numericDuration(value interface{}, unit time.Duration) (time.Duration, bool) {
+	switch v := value.(type) {
+	case int:
+		return time.Duration(v) * unit, true
+	case int32:
+		return time.Duration(v) * unit, true
+	case int64:
+		return time.Duration(v) * unit, true
+	case float32:
+		return time.Duration(float64(v) * float64(unit)), true
+	case float64:
+		return time.Duration(v * float64(unit)), true
+	}
+	return 0, false
+}
+
+// GetTime retrieves a time configuration value.
+// Accepts time.Time values, Unix timestamps in seconds, and strings in the
+// formats supported by Unmarshal (RFC3339, "2006-01-02 15:04:05", "2006-01-02").
+func (c *Config) GetTime(key string) (time.Time, error) {
+	value, exists := c.Get(key)
+	if !exists {
+		return time.Time{}, core.Newf("configuration key '%s' not found", key)
+	}
+
+	t, err := c.toTime(value)
+	if err != nil {
+		return time.Time{}, core.Newf("configuration key '%s' with value '%v' cannot be converted to time", key, value)
+	}
+	return t, nil
 }
 
-// GetDuration retrieves a duration configuration value
-func (c *Config) GetDuration(key string) (time.Duration, error) {
+// GetTimeSlice retrieves a slice of time configuration values.
+// Accepts arrays of supported time values and comma-separated strings.
+func (c *Config) GetTimeSlice(key string) ([]time.Time, error) {
 	value, exists := c.Get(key)
 	if !exists {
-		return 0, core.Newf("configuration key '%s' not found", key)
+		return nil, core.Newf("configuration key '%s' not found", key)
 	}
 
+	var items []interface{}
 	switch v := value.(type) {
-	case time.Duration:
-		return v, nil
-	case string:
-		duration, err := time.ParseDuration(v)
-		if err != nil {
-			return 0, core.Wrapf(err, "configuration key '%s' cannot be parsed as duration", key)
+	case []time.Time:
+		result := make([]time.Time, len(v))
+		copy(result, v)
+		return result, nil
+	case []interface{}:
+		items = v
+	case []string:
+		for _, item := range v {
+			items = append(items, item)
 		}
-		return duration, nil
-	case int, int32, int64:
-		// Assume seconds if numeric value provided
-		var seconds int64
-		switch num := v.(type) {
-		case int:
-			seconds = int64(num)
-		case int32:
-			seconds = int64(num)
-		case int64:
-			seconds = num
+	case string:
+		for _, part := range strings.Split(v, ",") {
+			if trimmed := strings.TrimSpace(part); trimmed != "" {
+				items = append(items, trimmed)
+			}
 		}
-		return time.Duration(seconds) * time.Second, nil
-	case float32, float64:
-		// Assume seconds if numeric value provided
-		var seconds float64
-		switch num := v.(type) {
-		case float32:
-			seconds = float64(num)
-		case float64:
-			seconds = num
+	default:
+		items = []interface{}{v}
+	}
+
+	result := make([]time.Time, 0, len(items))
+	for i, item := range items {
+		t, err := c.toTime(item)
+		if err != nil {
+			return nil, core.Newf("configuration key '%s' element %d with value '%v' cannot be converted to time", key, i, item)
 		}
-		return time.Duration(seconds * float64(time.Second)), nil
+		result = append(result, t)
 	}
+	return result, nil
+}
 
-	return 0, core.Newf("configuration key '%s' with value '%v' cannot be converted to duration", key, value)
+// toTime converts a configuration value to time, accepting Unix timestamps
+// of any integer type in addition to the values supported by parseTime
+func (c *Config) toTime(value interface{}) (time.Time, error) {
+	switch v := value.(type) {
+	case int:
+		return unixTime(int64(v), c.timeFormats), nil
+	case int32:
+		return unixTime(int64(v), c.timeFormats), nil
+	case uint32:
+		return unixTime(int64(v), c.timeFormats), nil
+	case float64:
+		return unixTime(int64(v), c.timeFormats), nil
+	case string:
+		return c.parseTime(strings.TrimSpace(v))
+	default:
+		return c.parseTime(value)
+	}
 }
 
 // GetStringWithDefault retrieves a string value with a default fallback
@@ -640,6 +1733,14 @@ func (c *Config) GetIntWithDefault(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// GetFloatWithDefault retrieves a float64 value with a default fallback
+func (c *Config) GetFloatWithDefault(key string, defaultValue float64) float64 {
+	if value, err := c.GetFloat(key); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
 // GetBoolWithDefault retrieves a bool value with a default fallback
 func (c *Config) GetBoolWithDefault(key string, defaultValue bool) bool {
 	if value, err := c.GetBool(key); err == nil {
@@ -656,6 +1757,136 @@ func (c *Config) GetDurationWithDefault(key string, defaultValue time.Duration)
 	return defaultValue
 }
 
+// GetTimeWithDefault retrieves a time value with a default fallback
+func (c *Config) GetTimeWithDefault(key string, defaultValue time.Time) time.Time {
+	if value, err := c.GetTime(key); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+// RuntimeDefaultSourceName is the source name reported for defaults
+// recorded by the Get...Or accessors
+const RuntimeDefaultSourceName = "defaults-runtime"
+
+// GetStringOr retrieves a string value like GetStringWithDefault. If the
+// key is missing, the default is also stored in the configuration, so it
+// appears in GetAll and snapshots and is kept across reloads until a
+// source provides the key.
+func (c *Config) GetStringOr(key, defaultValue string) string {
+	c.setRuntimeDefault(key, defaultValue)
+	return c.GetStringWithDefault(key, defaultValue)
+}
+
+// GetIntOr retrieves an integer value and records a missing key's default like GetStringOr
+func (c *Config) GetIntOr(key string, defaultValue int) int {
+	c.setRuntimeDefault(key, defaultValue)
+	return c.GetIntWithDefault(key, defaultValue)
+}
+
+// GetBoolOr retrieves a boolean value and records a missing key's default like GetStringOr
+func (c *Config) GetBoolOr(key string, defaultValue bool) bool {
+	c.setRuntimeDefault(key, defaultValue)
+	return c.GetBoolWithDefault(key, defaultValue)
+}
+
+// GetDurationOr retrieves a duration value and records a missing key's default like GetStringOr
+func (c *Config) GetDurationOr(key string, defaultValue time.Duration) time.Duration {
+	c.setRuntimeDefault(key, defaultValue)
+	return c.GetDurationWithDefault(key, defaultValue)
+}
+
+// setRuntimeDefault stores value under key if no value is present. Values
+// loaded from sources are never overwritten, and nothing is recorded while
+// the configuration is frozen.
+func (c *Config) setRuntimeDefault(key string, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key = c.normalizeKey(key)
+	if _, exists := c.values[key]; exists || c.frozen {
+		return
+	}
+
+	if c.values == nil {
+		c.values = make(map[string]interface{})
+	}
+	if c.runtimeDefaults == nil {
+		c.runtimeDefaults = make(map[string]interface{})
+	}
+	c.values[key] = value
+	c.runtimeDefaults[key] = value
+}
+
+// GetStringMap returns all values below prefix as a map keyed by the
+// remainder of the key after "prefix.", e.g. GetStringMap("database")
+// returns {"host": ..., "options.sslmode": ...} for database.host and
+// database.options.sslmode. Arrays are returned as slices without their
+// indexed element keys. Returns an empty map if no key matches.
+func (c *Config) GetStringMap(prefix string) map[string]interface{} {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	prefix = strings.TrimSuffix(c.normalizeKey(prefix), ".")
+	if prefix != "" {
+		prefix += "."
+	}
+
+	result := make(map[string]interface{})
+	for key, value := range c.values {
+		if strings.HasPrefix(key, prefix) && len(key) > len(prefix) {
+			result[key[len(prefix):]] = value
+		}
+	}
+
+	for key := range result {
+		if isSliceElementKey(result, key) {
+			delete(result, key)
+		}
+	}
+
+	return result
+}
+
+// GetStringMapString returns all values below prefix like GetStringMap,
+// formatting each value as a string
+func (c *Config) GetStringMapString(prefix string) map[string]string {
+	values := c.GetStringMap(prefix)
+
+	result := make(map[string]string, len(values))
+	for key, value := range values {
+		if str, ok := value.(string); ok {
+			result[key] = str
+			continue
+		}
+		result[key] = fmt.Sprintf("%v", value)
+	}
+	return result
+}
+
+// isSliceElementKey reports whether key addresses an element of a slice
+// value that is also present in values, such as "servers.0" or
+// "servers.0.host" next to "servers"
+func isSliceElementKey(values map[string]interface{}, key string) bool {
+	for i := 0; i < len(key); i++ {
+		if key[i] != '.' {
+			continue
+		}
+
+		segment := key[i+1:]
+		if end := strings.IndexByte(segment, '.'); end >= 0 {
+			segment = segment[:end]
+		}
+		if _, err := strconv.Atoi(segment); err != nil {
+			continue
+		}
+		if parent, exists := values[key[:i]]; exists && reflect.ValueOf(parent).Kind() == reflect.Slice {
+			return true
+		}
+	}
+	return false
+}
+
 // Unmarshal unmarshals configuration into a struct
 func (c *Config) Unmarshal(v interface{}) error {
 	c.mu.RLock()
@@ -704,7 +1935,7 @@ func (c *Config) unmarshalValue(rv reflect.Value, prefix string) error {
 		}
 
 		// Get value from configuration
-		value, exists := c.values[fullKey]
+		value, exists := c.values[c.normalizeKey(fullKey)]
 		if !exists {
 			// Check for default value in struct tag
 			if defaultValue := field.Tag.Get("default"); defaultValue != "" {
@@ -732,6 +1963,16 @@ func (c *Config) unmarshalValue(rv reflect.Value, prefix string) error {
 
 // setFieldValue sets a reflect.Value from a configuration value
 func (c *Config) setFieldValue(rv reflect.Value, value interface{}) error {
+	// time.Duration has kind Int64 and must be handled before the kind switch
+	if rv.Type() == reflect.TypeOf(time.Duration(0)) {
+		duration, err := c.parseDuration(value)
+		if err != nil {
+			return core.Wrapf(err, "cannot convert '%v' to duration", value)
+		}
+		rv.Set(reflect.ValueOf(duration))
+		return nil
+	}
+
 	switch rv.Kind() {
 	case reflect.String:
 		if str, ok := value.(string); ok {
@@ -768,9 +2009,11 @@ func (c *Config) setFieldValue(rv reflect.Value, value interface{}) error {
 		case float64:
 			intVal = int64(v)
 		case string:
-			if i, err := fmt.Sscanf(v, "%d", &intVal); err != nil || i != 1 {
+			parsed, err := parseIntString(v, 64)
+			if err != nil {
 				return core.Newf("cannot convert '%v' to int", value)
 			}
+			intVal = parsed
 		default:
 			return core.Newf("cannot convert '%v' to int", value)
 		}
@@ -810,9 +2053,11 @@ func (c *Config) setFieldValue(rv reflect.Value, value interface{}) error {
 			}
 			uintVal = uint64(v)
 		case string:
-			if i, err := fmt.Sscanf(v, "%d", &uintVal); err != nil || i != 1 {
+			parsed, err := strconv.ParseUint(strings.TrimSpace(v), 10, 64)
+			if err != nil {
 				return core.Newf("cannot convert '%v' to uint", value)
 			}
+			uintVal = parsed
 		default:
 			return core.Newf("cannot convert '%v' to uint", value)
 		}
@@ -862,9 +2107,11 @@ func (c *Config) setFieldValue(rv reflect.Value, value interface{}) error {
 		case int64:
 			floatVal = float64(v)
 		case string:
-			if f, err := fmt.Sscanf(v, "%f", &floatVal); err != nil || f != 1 {
+			parsed, err := parseFloatString(v)
+			if err != nil {
 				return core.Newf("cannot convert '%v' to float", value)
 			}
+			floatVal = parsed
 		default:
 			return core.Newf("cannot convert '%v' to float", value)
 		}
@@ -876,14 +2123,8 @@ func (c *Config) setFieldValue(rv reflect.Value, value interface{}) error {
 		rv.SetFloat(floatVal)
 
 	default:
-		// Handle special types like time.Duration, time.Time, etc.
-		if rv.Type() == reflect.TypeOf(time.Duration(0)) {
-			duration, err := c.parseDuration(value)
-			if err != nil {
-				return core.Wrapf(err, "cannot convert '%v' to duration", value)
-			}
-			rv.Set(reflect.ValueOf(duration))
-		} else if rv.Type() == reflect.TypeOf(time.Time{}) {
+		// Handle special types like time.Time
+		if rv.Type() == reflect.TypeOf(time.Time{}) {
 			timeVal, err := c.parseTime(value)
 			if err != nil {
 				return core.Wrapf(err, "cannot convert '%v' to time", value)
@@ -897,6 +2138,23 @@ func (c *Config) setFieldValue(rv reflect.Value, value interface{}) error {
 	return nil
 }
 
+// parseIntString parses a decimal integer with optional sign and
+// surrounding whitespace, like the environment source does. Trailing
+// characters, digit separators, and base prefixes such as 0x are rejected.
+func parseIntString(value string, bitSize int) (int64, error) {
+	return strconv.ParseInt(strings.TrimSpace(value), 10, bitSize)
+}
+
+// parseFloatString parses a floating point number with optional sign and
+// surrounding whitespace. Digit separators are rejected as for integers.
+func parseFloatString(value string) (float64, error) {
+	trimmed := strings.TrimSpace(value)
+	if strings.Contains(trimmed, "_") {
+		return 0, core.Newf("invalid float syntax: %s", value)
+	}
+	return strconv.ParseFloat(trimmed, 64)
+}
+
 // parseDuration parses a duration from various value types
 func (c *Config) parseDuration(value interface{}) (time.Duration, error) {
 	switch v := value.(type) {
@@ -926,28 +2184,102 @@ func (c *Config) parseTime(value interface{}) (time.Time, error) {
 	case time.Time:
 		return v, nil
 	case string:
-		// Try multiple time formats
-		timeFormats := []string{
-			time.RFC3339,
-			time.RFC3339Nano,
-			"2006-01-02T15:04:05Z",
-			"2006-01-02 15:04:05",
-			"2006-01-02",
-		}
-		
-		for _, format := range timeFormats {
-			if t, err := time.Parse(format, v); err == nil {
-				return t, nil
-			}
+		t, err := parseTimeString(v, c.timeFormats)
+		if err != nil {
+			return time.Time{}, core.Newf("cannot parse time string '%s'", v)
 		}
-		return time.Time{}, core.Newf("cannot parse time string '%s'", v)
+		return t, nil
 	case int64:
-		return time.Unix(v, 0), nil
+		return unixTime(v, c.timeFormats), nil
 	default:
 		return time.Time{}, core.Newf("cannot convert %T to time", value)
 	}
 }
 
+// ChangesBufferSize is the capacity of the channels returned by Changes
+const ChangesBufferSize = 16
+
+// changeStream is the Watcher behind a channel returned by Changes
+type changeStream struct {
+	mu     sync.Mutex
+	ch     chan map[string]ConfigChange
+	closed bool
+}
+
+// OnConfigChange implements the Watcher interface. If the channel is full,
+// the oldest pending change set is dropped, so a slow consumer never blocks
+// reloads and always receives the most recent changes.
+func (s *changeStream) OnConfigChange(ctx context.Context, changes map[string]ConfigChange) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+	for {
+		select {
+		case s.ch <- changes:
+			return
+		default:
+		}
+		select {
+		case <-s.ch:
+		default:
+		}
+	}
+}
+
+// close closes the channel; further changes are ignored
+func (s *changeStream) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.closed {
+		s.closed = true
+		close(s.ch)
+	}
+}
+
+// Changes returns a channel receiving the change set of every reload that
+// changed values, as passed to watchers. The channel buffers up to
+// ChangesBufferSize change sets; when it is full, the oldest change set is
+// dropped. Change sets are delivered asynchronously and may arrive out of
+// order when reloads happen in quick succession. The channel is closed by
+// StopChanges or Close; after Close a closed channel is returned.
+func (c *Config) Changes() <-chan map[string]ConfigChange {
+	stream := &changeStream{ch: make(chan map[string]ConfigChange, ChangesBufferSize)}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.isClosed() {
+		stream.close()
+		return stream.ch
+	}
+	if c.changeStreams == nil {
+		c.changeStreams = make(map[<-chan map[string]ConfigChange]*changeStream)
+	}
+	c.changeStreams[stream.ch] = stream
+	c.watchers = append(c.watchers, stream)
+
+	return stream.ch
+}
+
+// StopChanges stops delivery to a channel returned by Changes and closes
+// it. Change sets still buffered can be drained. Unknown channels are
+// ignored.
+func (c *Config) StopChanges(ch <-chan map[string]ConfigChange) {
+	c.mu.Lock()
+	stream, exists := c.changeStreams[ch]
+	delete(c.changeStreams, ch)
+	c.mu.Unlock()
+
+	if exists {
+		c.RemoveWatcher(stream)
+		stream.close()
+	}
+}
+
 // AddWatcher adds a configuration change watcher
 func (c *Config) AddWatcher(watcher Watcher) {
 	if watcher == nil {
@@ -979,21 +2311,105 @@ func (c *Config) RemoveWatcher(watcher Watcher) {
 	}
 }
 
-// StartWatching starts watching all sources for configuration changes
+// WatchKey registers a callback that is invoked when the given key changes.
+// Returns a function that cancels the subscription when called.
+func (c *Config) WatchKey(key string, callback func(ConfigChange)) func() {
+	return c.subscribe(&subscription{
+		key:   c.normalizeKey(key),
+		onKey: callback,
+	})
+}
+
+// WatchPrefix registers a callback that is invoked with all changed keys
+// starting with the given prefix. Returns a function that cancels the
+// subscription when called.
+func (c *Config) WatchPrefix(prefix string, callback func(map[string]ConfigChange)) func() {
+	return c.subscribe(&subscription{
+		key:      c.normalizeKey(prefix),
+		prefix:   true,
+		onPrefix: callback,
+	})
+}
+
+// subscribe registers a subscription and returns its cancel function
+func (c *Config) subscribe(sub *subscription) func() {
+	if sub.onKey == nil && sub.onPrefix == nil {
+		return func() {}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.nextSubscriptionID++
+	sub.id = c.nextSubscriptionID
+	c.subscriptions = append(c.subscriptions, sub)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			c.unsubscribe(sub.id)
+		})
+	}
+}
+
+// unsubscribe removes the subscription with the given identifier
+func (c *Config) unsubscribe(id uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, sub := range c.subscriptions {
+		if sub.id == id {
+			c.subscriptions = append(c.subscriptions[:i], c.subscriptions[i+1:]...)
+			break
+		}
+	}
+}
+
+// StartWatching starts watching all sources for configuration changes.
+// Notifications of all sources are funneled into a single reload goroutine,
+// so changes arriving while a reload is pending or running are coalesced
+// into one further reload instead of one reload per source. If a reload
+// debounce window is configured, notifications are additionally coalesced
+// until the window elapses. Calling StartWatching again while watching is
+// a no-op. Close stops watching and waits for the reload goroutine.
 func (c *Config) StartWatching(ctx context.Context) error {
-	for _, source := range c.sources {
+	c.mu.Lock()
+	if c.watchCancel != nil {
+		c.mu.Unlock()
+		return nil
+	}
+	watchCtx, cancel := context.WithCancel(ctx)
+	c.watchCancel = cancel
+	sources := append([]Source(nil), c.sources...)
+	c.mu.Unlock()
+
+	// A pending notification is kept in the buffer; further notifications
+	// arriving before the reload goroutine picks it up are dropped
+	changes := make(chan string, 1)
+	notify := func(sourceName string) {
+		select {
+		case changes <- sourceName:
+		default:
+		}
+	}
+
+	c.watchWG.Add(1)
+	go func() {
+		defer c.watchWG.Done()
+		c.runReloadLoop(watchCtx, changes)
+	}()
+
+	for _, source := range sources {
 		if watchable, ok := source.(WatchableSource); ok {
+			c.watchWG.Add(1)
 			go func(ws WatchableSource) {
-				err := ws.Watch(ctx, func(values map[string]interface{}) {
-					// Reload configuration when source changes
-					if err := c.Load(ctx); err != nil {
-						// Log error but continue watching
-						// In a real implementation, this would use the logging package
-						fmt.Printf("Error reloading configuration from %s: %v\n", ws.Name(), err)
-					}
+				defer c.watchWG.Done()
+				err := ws.Watch(watchCtx, func(values map[string]interface{}) {
+					notify(ws.Name())
 				})
 				if err != nil {
-					fmt.Printf("Error watching source %s: %v\n", ws.Name(), err)
+					core.ContextLogger(ctx).Error("failed to watch configuration source",
+						"source", ws.Name(), "error", err)
 				}
 			}(watchable)
 		}
@@ -1002,8 +2418,122 @@ func (c *Config) StartWatching(ctx context.Context) error {
 	return nil
 }
 
+// runReloadLoop reloads the configuration for each change notification
+// until ctx is cancelled or the configuration is closed
+func (c *Config) runReloadLoop(ctx context.Context, changes <-chan string) {
+	var debounced chan struct{}
+	var debouncer *reloadDebouncer
+	if c.reloadDebounce > 0 {
+		debounced = make(chan struct{}, 1)
+		debouncer = newReloadDebouncer(c.reloadDebounce, func() {
+			select {
+			case debounced <- struct{}{}:
+			default:
+			}
+		})
+		defer debouncer.stop()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.done:
+			return
+		case sourceName := <-changes:
+			if debouncer != nil {
+				debouncer.trigger()
+				continue
+			}
+			c.reloadFromWatch(ctx, sourceName)
+		case <-debounced:
+			c.reloadFromWatch(ctx, "debounced sources")
+		}
+	}
+}
+
+// reloadFromWatch reloads configuration after a source change notification,
+// rejecting invalid configuration if validation is enabled
+func (c *Config) reloadFromWatch(ctx context.Context, sourceName string) {
+	if ctx.Err() != nil || c.isClosed() {
+		return
+	}
+
+	reload := c.Load
+	if c.validateOnReload {
+		reload = c.ReloadValidated
+	}
+	if err := reload(ctx); err != nil {
+		// Log error but continue watching
+		core.ContextLogger(ctx).Error("failed to reload configuration",
+			"source", sourceName, "error", err)
+	}
+}
+
+// isClosed reports whether the configuration manager has been closed
+func (c *Config) isClosed() bool {
+	if c.done == nil {
+		return false
+	}
+	select {
+	case <-c.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// reloadDebouncer coalesces rapid reload triggers into a single reload
+type reloadDebouncer struct {
+	mu      sync.Mutex
+	window  time.Duration
+	timer   *time.Timer
+	stopped bool
+	reload  func()
+}
+
+// newReloadDebouncer creates a debouncer that calls reload once the window
+// elapses without further triggers
+func newReloadDebouncer(window time.Duration, reload func()) *reloadDebouncer {
+	return &reloadDebouncer{
+		window: window,
+		reload: reload,
+	}
+}
+
+// trigger (re)starts the debounce window
+func (d *reloadDebouncer) trigger() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.stopped {
+		return
+	}
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+	d.timer = time.AfterFunc(d.window, d.reload)
+}
+
+// stop cancels any pending reload and ignores further triggers
+func (d *reloadDebouncer) stop() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.stopped = true
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+}
+
 // detectChanges compares old and new configuration values to detect changes
 func (c *Config) detectChanges(oldValues, newValues map[string]interface{}) map[string]ConfigChange {
+	return diffValues(oldValues, newValues)
+}
+
+// diffValues compares two sets of configuration values and returns the
+// added, updated, and deleted keys
+func diffValues(oldValues, newValues map[string]interface{}) map[string]ConfigChange {
 	changes := make(map[string]ConfigChange)
 
 	// Check for modified and new values
@@ -1049,21 +2579,44 @@ func (c *Config) notifyWatchers(ctx context.Context, changes map[string]ConfigCh
 	c.mu.RLock()
 	watchers := make([]Watcher, len(c.watchers))
 	copy(watchers, c.watchers)
+	subscriptions := make([]*subscription, len(c.subscriptions))
+	copy(subscriptions, c.subscriptions)
 	c.mu.RUnlock()
 
 	for _, watcher := range watchers {
-		go func(w Watcher) {
-			defer func() {
-				if r := recover(); r != nil {
-					fmt.Printf("Panic in configuration watcher: %v\n", r)
-				}
-			}()
+		w := watcher
+		core.SafeGo(ctx, func(ctx context.Context) error {
 			w.OnConfigChange(ctx, changes)
-		}(watcher)
+			return nil
+		}, func(err error) {
+			core.ContextLogger(ctx).Error("configuration watcher failed", "error", err)
+		})
+	}
+
+	for _, sub := range subscriptions {
+		matched := sub.matches(changes)
+		if len(matched) == 0 {
+			continue
+		}
+
+		s := sub
+		core.SafeGo(ctx, func(context.Context) error {
+			if s.prefix {
+				s.onPrefix(matched)
+			} else {
+				s.onKey(matched[s.key])
+			}
+			return nil
+		}, func(err error) {
+			core.ContextLogger(ctx).Error("configuration subscription failed",
+				"key", s.key, "error", err)
+		})
 	}
 }
 
-// GetAll returns all configuration values
+// GetAll returns all configuration values. Values of keys marked Sensitive
+// in the metadata or reported by a SensitiveSource are replaced with
+// RedactedValue; use Get to read them.
 func (c *Config) GetAll() map[string]interface{} {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -1071,6 +2624,9 @@ func (c *Config) GetAll() map[string]interface{} {
 	// Return a copy to prevent external modification
 	result := make(map[string]interface{})
 	for key, value := range c.values {
+		if c.isSensitiveKey(key) {
+			value = RedactedValue
+		}
 		result[key] = value
 	}
 	return result
@@ -1093,7 +2649,7 @@ func (c *Config) HasKey(key string) bool {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	_, exists := c.values[key]
+	_, exists := c.values[c.normalizeKey(key)]
 	return exists
 }
 
@@ -1125,6 +2681,14 @@ func (c *Config) GetSources() []SourceInfo {
 			Validatable: isValidatableSource(source),
 		}
 	}
+
+	// Report injected field defaults as the lowest priority source
+	if len(c.defaultedKeys) > 0 {
+		sources = append(sources, SourceInfo{Name: MetadataDefaultSourceName})
+	}
+	if len(c.runtimeDefaults) > 0 {
+		sources = append(sources, SourceInfo{Name: RuntimeDefaultSourceName})
+	}
 	return sources
 }
 
@@ -1155,11 +2719,16 @@ func isValidatableSource(source Source) bool {
 	return ok
 }
 
-// WriteToSource writes configuration values to a specific source (if writable)
+// WriteToSource writes configuration values to a specific source (if writable).
+// It fails with ErrCodeForbidden if the configuration is frozen.
 func (c *Config) WriteToSource(sourceName string, values map[string]interface{}) error {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
+	if c.frozen {
+		return frozenError("write to source " + sourceName)
+	}
+
 	for _, source := range c.sources {
 		if source.Name() == sourceName {
 			if writable, ok := source.(WritableSource); ok {
@@ -1177,24 +2746,55 @@ func (c *Config) Reload(ctx context.Context) error {
 	return c.Load(ctx)
 }
 
-// Close cleanly shuts down the configuration manager
+// Close cleanly shuts down the configuration manager. It stops watching,
+// waits for the reload goroutine, and stops every source that has a Stop
+// method, or else closes every source implementing io.Closer, so that no
+// watcher goroutines are left behind. Returns the errors of closed sources.
 func (c *Config) Close() error {
+	c.mu.Lock()
+	// Signal background goroutines to stop
+	if c.done != nil {
+		c.closeOnce.Do(func() { close(c.done) })
+	}
+	cancelWatch := c.watchCancel
+	c.watchCancel = nil
+	c.mu.Unlock()
+
+	// Wait for the reload goroutine without holding the lock, since a
+	// reload in progress needs it to finish
+	if cancelWatch != nil {
+		cancelWatch()
+	}
+	c.watchWG.Wait()
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
 	// Stop all watchers
+	var closeErrors []error
 	for _, source := range c.sources {
 		if stoppable, ok := source.(interface{ Stop() }); ok {
 			stoppable.Stop()
+		} else if closer, ok := source.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				closeErrors = append(closeErrors, core.Wrapf(err, "failed to close source %s", source.Name()))
+			}
 		}
 	}
 
+	// Close all change channels
+	for _, stream := range c.changeStreams {
+		stream.close()
+	}
+
 	// Clear all data
 	c.sources = nil
 	c.values = nil
 	c.watchers = nil
+	c.subscriptions = nil
+	c.changeStreams = nil
 
-	return nil
+	return core.JoinErrors(closeErrors...)
 }
 
 // DefaultSource implements the Source interface for default configuration values
@@ -1260,31 +2860,49 @@ func (ds *DefaultSource) SetPriority(priority int) {
 	ds.priority = priority
 }
 
-// AddValidator adds a custom validator function to the configuration
-func (c *Config) AddValidator(validator ValidatorFunc) {
+// AddValidator adds a custom validator function to the configuration.
+// It fails with ErrCodeForbidden if the configuration is frozen.
+func (c *Config) AddValidator(validator ValidatorFunc) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	if c.frozen {
+		return frozenError("add validator")
+	}
+
 	c.metadata.Validators = append(c.metadata.Validators, validator)
+	return nil
 }
 
-// AddFieldMetadata adds metadata for a configuration field
-func (c *Config) AddFieldMetadata(fieldName string, field Field) {
+// AddFieldMetadata adds metadata for a configuration field.
+// It fails with ErrCodeForbidden if the configuration is frozen.
+func (c *Config) AddFieldMetadata(fieldName string, field Field) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	if c.frozen {
+		return frozenError("add metadata for field " + fieldName)
+	}
+
 	if c.metadata.Fields == nil {
 		c.metadata.Fields = make(map[string]Field)
 	}
 	c.metadata.Fields[fieldName] = field
+	return nil
 }
 
-// RemoveFieldMetadata removes metadata for a configuration field
-func (c *Config) RemoveFieldMetadata(fieldName string) {
+// RemoveFieldMetadata removes metadata for a configuration field.
+// It fails with ErrCodeForbidden if the configuration is frozen.
+func (c *Config) RemoveFieldMetadata(fieldName string) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	if c.frozen {
+		return frozenError("remove metadata for field " + fieldName)
+	}
+
 	delete(c.metadata.Fields, fieldName)
+	return nil
 }
 
 // GetFieldMetadata returns metadata for a specific field
@@ -1320,6 +2938,12 @@ func (c *Config) Summary() ConfigSummary {
 			summary.SensitiveFields = append(summary.SensitiveFields, fieldName)
 		}
 	}
+	for key := range c.sourceSensitiveKeys {
+		if field, exists := c.metadata.Fields[key]; !exists || !field.Sensitive {
+			summary.SensitiveFields = append(summary.SensitiveFields, key)
+		}
+	}
+	sort.Strings(summary.SensitiveFields)
 
 	return summary
 }
@@ -5,23 +5,44 @@
 //              hot-reloading, and struct unmarshaling. Tests cover edge cases,
 //              concurrency, and performance characteristics.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.2
+// Version: v0.1.18
 // Created: 2025-05-26
-// Modified: 2025-05-27
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-05-26 v0.1.0: Initial test implementation with comprehensive coverage
 // - 2025-05-27 v0.1.1: Updated for interface segregation and enhanced validation
 // - 2025-05-27 v0.1.2: Fixed compilation errors - missing imports and type issues
+// - 2026-10-16 v0.1.3: Added tests for per-key and per-prefix subscriptions
+// - 2026-10-16 v0.1.4: Added tests for validated reload and reload statistics
+// - 2026-10-16 v0.1.5: Added tests for debounced hot-reload
+// - 2026-10-16 v0.1.6: Added tests for time accessors
+// - 2026-10-16 v0.1.7: Added merge strategy tests
+// - 2026-10-16 v0.1.8: Added GetDurationInUnit and duration unit hint tests
+// - 2026-10-16 v0.1.9: Added field default injection tests
+// - 2026-10-16 v0.1.10: Added GetStringMap tests
+// - 2026-10-16 v0.1.11: Added numeric string coercion and GetFloat tests
+// - 2026-10-16 v0.1.12: Added GetDurationSlice tests
+// - 2026-10-16 v0.1.13: Added watch coalescing tests
+// - 2026-10-16 v0.1.14: Added change stream tests
+// - 2026-10-16 v0.1.15: Added strict key tests
+// - 2026-10-16 v0.1.16: Added structured validation tests
+// - 2026-10-16 v0.1.17: Added string length and numeric string range tests
+// - 2026-10-16 v0.1.18: Guarded mockSource values with a mutex
 
 package config
 
 import (
 	"context"
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/msto63/tbp/tbp-foundation/pkg/core"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -196,6 +217,369 @@ func TestConfig_GetInt(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "not found")
 	})
+
+	t.Run("coerces numeric strings strictly", func(t *testing.T) {
+		config := &Config{values: map[string]interface{}{
+			"padded":     " 42 ",
+			"signed":     "+42",
+			"negative":   "-7",
+			"trailing":   "42abc",
+			"underscore": "1_000",
+			"hex":        "0x1F",
+			"decimal":    "4.2",
+			"empty":      "",
+			"blank":      "   ",
+		}}
+
+		for key, expected := range map[string]int{"padded": 42, "signed": 42, "negative": -7} {
+			value, err := config.GetInt(key)
+			assert.NoError(t, err, key)
+			assert.Equal(t, expected, value, key)
+		}
+
+		for _, key := range []string{"trailing", "underscore", "hex", "decimal", "empty", "blank"} {
+			_, err := config.GetInt(key)
+			assert.ErrorContains(t, err, "cannot be converted to int", key)
+		}
+	})
+
+	t.Run("detects overflow", func(t *testing.T) {
+		config := &Config{values: map[string]interface{}{
+			"max_int64":   int64(math.MaxInt64),
+			"max_uint64":  uint64(math.MaxUint64),
+			"huge_string": "9223372036854775808",
+			"huge_float":  1e19,
+		}}
+
+		// The range of int depends on the platform
+		value, err := config.GetInt("max_int64")
+		if strconv.IntSize == 64 {
+			assert.NoError(t, err)
+			assert.Equal(t, math.MaxInt, value)
+		} else {
+			assert.ErrorContains(t, err, "overflows int")
+		}
+
+		for _, key := range []string{"max_uint64", "huge_string", "huge_float"} {
+			_, err := config.GetInt(key)
+			assert.ErrorContains(t, err, "overflows int", key)
+		}
+	})
+}
+
+func TestConfig_GetInt64(t *testing.T) {
+	config := &Config{values: map[string]interface{}{
+		"max":         int64(math.MaxInt64),
+		"min":         int64(math.MinInt64),
+		"int":         42,
+		"uint32":      uint32(math.MaxUint32),
+		"float":       1.5e12,
+		"string":      " 9223372036854775807 ",
+		"max_uint64":  uint64(math.MaxUint64),
+		"huge_string": "9223372036854775808",
+		"huge_float":  1e19,
+		"invalid":     "abc",
+		"bool":        true,
+	}}
+
+	t.Run("does not truncate large values", func(t *testing.T) {
+		expected := map[string]int64{
+			"max":    math.MaxInt64,
+			"min":    math.MinInt64,
+			"int":    42,
+			"uint32": math.MaxUint32,
+			"float":  1500000000000,
+			"string": math.MaxInt64,
+		}
+		for key, want := range expected {
+			value, err := config.GetInt64(key)
+			assert.NoError(t, err, key)
+			assert.Equal(t, want, value, key)
+		}
+	})
+
+	t.Run("detects overflow", func(t *testing.T) {
+		for _, key := range []string{"max_uint64", "huge_string", "huge_float"} {
+			_, err := config.GetInt64(key)
+			assert.ErrorContains(t, err, "overflows int64", key)
+		}
+	})
+
+	t.Run("rejects non-numeric values", func(t *testing.T) {
+		for _, key := range []string{"invalid", "bool"} {
+			_, err := config.GetInt64(key)
+			assert.ErrorContains(t, err, "cannot be converted to int64", key)
+		}
+
+		_, err := config.GetInt64("missing.key")
+		assert.ErrorContains(t, err, "not found")
+	})
+}
+
+func TestConfig_GetUint64(t *testing.T) {
+	config := &Config{values: map[string]interface{}{
+		"max":      uint64(math.MaxUint64),
+		"max_int":  int64(math.MaxInt64),
+		"string":   "18446744073709551615",
+		"float":    4096.0,
+		"negative": -1,
+		"neg_str":  "-5",
+		"huge":     "18446744073709551616",
+		"invalid":  "abc",
+	}}
+
+	t.Run("converts values", func(t *testing.T) {
+		expected := map[string]uint64{
+			"max":     math.MaxUint64,
+			"max_int": math.MaxInt64,
+			"string":  math.MaxUint64,
+			"float":   4096,
+		}
+		for key, want := range expected {
+			value, err := config.GetUint64(key)
+			assert.NoError(t, err, key)
+			assert.Equal(t, want, value, key)
+		}
+	})
+
+	t.Run("rejects negative and out-of-range values", func(t *testing.T) {
+		for _, key := range []string{"negative", "neg_str", "huge"} {
+			_, err := config.GetUint64(key)
+			assert.ErrorContains(t, err, "out of range for uint64", key)
+		}
+
+		_, err := config.GetUint64("invalid")
+		assert.ErrorContains(t, err, "cannot be converted to uint64")
+	})
+}
+
+func TestConfig_GetFloat(t *testing.T) {
+	config := &Config{values: map[string]interface{}{
+		"float":      1.5,
+		"int":        3,
+		"padded":     " 2.5 ",
+		"signed":     "+42",
+		"trailing":   "42abc",
+		"underscore": "1_000",
+		"hex":        "0x1F",
+		"empty":      "",
+	}}
+
+	t.Run("converts numeric values and strings", func(t *testing.T) {
+		for key, expected := range map[string]float64{"float": 1.5, "int": 3, "padded": 2.5, "signed": 42} {
+			value, err := config.GetFloat(key)
+			assert.NoError(t, err, key)
+			assert.Equal(t, expected, value, key)
+		}
+	})
+
+	t.Run("rejects malformed strings", func(t *testing.T) {
+		for _, key := range []string{"trailing", "underscore", "hex", "empty"} {
+			_, err := config.GetFloat(key)
+			assert.ErrorContains(t, err, "cannot be converted to float", key)
+		}
+	})
+
+	t.Run("returns error for missing key", func(t *testing.T) {
+		_, err := config.GetFloat("missing.key")
+		assert.ErrorContains(t, err, "not found")
+		assert.Equal(t, 0.5, config.GetFloatWithDefault("missing.key", 0.5))
+	})
+}
+
+func TestConfig_CaseInsensitiveKeys(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("matches keys regardless of case", func(t *testing.T) {
+		config, err := New(ctx, LoadOptions{
+			CaseInsensitiveKeys: true,
+			Sources: []Source{
+				&mockSource{name: "file", priority: 10, values: map[string]interface{}{"Server.Port": 8080}},
+			},
+		})
+		require.NoError(t, err)
+
+		for _, key := range []string{"server.port", "Server.Port", "SERVER.PORT"} {
+			assert.True(t, config.HasKey(key), key)
+			value, err := config.GetInt(key)
+			require.NoError(t, err, key)
+			assert.Equal(t, 8080, value, key)
+
+			str, err := config.GetString(key)
+			require.NoError(t, err, key)
+			assert.Equal(t, "8080", str, key)
+		}
+		assert.Equal(t, []string{"server.port"}, config.GetKeys())
+
+		value, exists := config.GetPath("SERVER", "port")
+		assert.True(t, exists)
+		assert.Equal(t, 8080, value)
+	})
+
+	t.Run("higher priority source wins collisions", func(t *testing.T) {
+		config, err := New(ctx, LoadOptions{
+			CaseInsensitiveKeys: true,
+			Sources: []Source{
+				&mockSource{name: "low", priority: 10, values: map[string]interface{}{"server.port": 8080}},
+				&mockSource{name: "high", priority: 20, values: map[string]interface{}{"Server.Port": 9090}},
+			},
+		})
+		require.NoError(t, err)
+
+		value, err := config.GetInt("server.port")
+		require.NoError(t, err)
+		assert.Equal(t, 9090, value)
+		assert.Len(t, config.GetKeys(), 1)
+	})
+
+	t.Run("lowercase spelling wins within one source", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			config, err := New(ctx, LoadOptions{
+				CaseInsensitiveKeys: true,
+				Sources: []Source{
+					&mockSource{name: "file", priority: 10, values: map[string]interface{}{
+						"Server.Port": 8080,
+						"server.port": 9090,
+						"SERVER.PORT": 7070,
+					}},
+				},
+			})
+			require.NoError(t, err)
+
+			value, err := config.GetInt("Server.Port")
+			require.NoError(t, err)
+			assert.Equal(t, 9090, value)
+		}
+	})
+
+	t.Run("applies to metadata and prefixes", func(t *testing.T) {
+		config, err := New(ctx, LoadOptions{
+			CaseInsensitiveKeys: true,
+			Validation:          true,
+			Metadata: &Metadata{Fields: map[string]Field{
+				"Database.Host": {Type: "string", Required: true},
+				"Database.Port": {Type: "int", DefaultValue: 5432},
+			}},
+			Sources: []Source{
+				&mockSource{name: "file", priority: 10, values: map[string]interface{}{"database.HOST": "db"}},
+			},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]interface{}{"host": "db", "port": 5432}, config.GetStringMap("DATABASE"))
+	})
+
+	t.Run("keys are case-sensitive by default", func(t *testing.T) {
+		config, err := New(ctx, LoadOptions{
+			Sources: []Source{
+				&mockSource{name: "file", priority: 10, values: map[string]interface{}{"Server.Port": 8080}},
+			},
+		})
+		require.NoError(t, err)
+
+		assert.True(t, config.HasKey("Server.Port"))
+		assert.False(t, config.HasKey("server.port"))
+	})
+}
+
+func TestConfig_GetPath(t *testing.T) {
+	config := &Config{values: map[string]interface{}{"server.port": 8080, "name": "app"}}
+
+	value, exists := config.GetPath("server", "port")
+	assert.True(t, exists)
+	assert.Equal(t, 8080, value)
+
+	value, exists = config.GetPath("name")
+	assert.True(t, exists)
+	assert.Equal(t, "app", value)
+
+	_, exists = config.GetPath("server", "host")
+	assert.False(t, exists)
+}
+
+func TestConfig_RuntimeDefaults(t *testing.T) {
+	ctx := context.Background()
+
+	newConfig := func(t *testing.T) (*Config, *mockSource) {
+		source := &mockSource{name: "file", priority: 10, values: map[string]interface{}{"app.name": "orders"}}
+		config, err := New(ctx, LoadOptions{Sources: []Source{source}})
+		require.NoError(t, err)
+		return config, source
+	}
+
+	t.Run("records defaults for missing keys", func(t *testing.T) {
+		config, _ := newConfig(t)
+
+		assert.Equal(t, "localhost", config.GetStringOr("server.host", "localhost"))
+		assert.Equal(t, 8080, config.GetIntOr("server.port", 8080))
+		assert.True(t, config.GetBoolOr("server.tls", true))
+		assert.Equal(t, 5*time.Second, config.GetDurationOr("server.timeout", 5*time.Second))
+
+		all := config.GetAll()
+		assert.Equal(t, "localhost", all["server.host"])
+		assert.Equal(t, 8080, all["server.port"])
+		assert.Equal(t, true, all["server.tls"])
+		assert.Equal(t, 5*time.Second, all["server.timeout"])
+
+		var names []string
+		for _, source := range config.GetSources() {
+			names = append(names, source.Name)
+		}
+		assert.Contains(t, names, RuntimeDefaultSourceName)
+
+		// The first recorded default sticks
+		assert.Equal(t, "localhost", config.GetStringOr("server.host", "other"))
+	})
+
+	t.Run("does not override existing values", func(t *testing.T) {
+		config, _ := newConfig(t)
+
+		assert.Equal(t, "orders", config.GetStringOr("app.name", "fallback"))
+		assert.Equal(t, "orders", config.GetAll()["app.name"])
+	})
+
+	t.Run("survives reloads until a source provides the key", func(t *testing.T) {
+		config, source := newConfig(t)
+		config.GetIntOr("server.port", 8080)
+
+		require.NoError(t, config.Reload(ctx))
+		assert.Equal(t, 8080, config.GetAll()["server.port"])
+
+		source.values["server.port"] = 9090
+		require.NoError(t, config.Reload(ctx))
+		assert.Equal(t, 9090, config.GetIntOr("server.port", 8080))
+
+		delete(source.values, "server.port")
+		require.NoError(t, config.Reload(ctx))
+		assert.Equal(t, 8080, config.GetIntOr("server.port", 1))
+	})
+
+	t.Run("does not record while frozen", func(t *testing.T) {
+		config, _ := newConfig(t)
+		config.Freeze()
+
+		assert.Equal(t, "localhost", config.GetStringOr("server.host", "localhost"))
+		assert.False(t, config.HasKey("server.host"))
+	})
+
+	t.Run("is safe for concurrent use", func(t *testing.T) {
+		config, _ := newConfig(t)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				config.GetIntOr(fmt.Sprintf("worker.%d", i%5), i)
+				_ = config.Reload(ctx)
+			}(i)
+		}
+		wg.Wait()
+
+		for i := 0; i < 5; i++ {
+			assert.True(t, config.HasKey(fmt.Sprintf("worker.%d", i)))
+		}
+	})
 }
 
 func TestConfig_GetBool(t *testing.T) {
@@ -274,6 +658,290 @@ func TestConfig_GetDuration(t *testing.T) {
 	})
 }
 
+func TestConfig_GetDurationSlice(t *testing.T) {
+	config := &Config{
+		values: map[string]interface{}{
+			"parsed":    []time.Duration{time.Second, time.Minute},
+			"list":      "30s, 1m,5m",
+			"strings":   []string{"1s", "2s"},
+			"mixed":     []interface{}{"1s", 2, time.Minute},
+			"millis":    []interface{}{250, 500},
+			"single":    "10s",
+			"invalid":   "30s,soon",
+			"wrongtype": true,
+		},
+		metadata: &Metadata{Fields: map[string]Field{
+			"millis": {Name: "millis", Type: "duration_ms"},
+		}},
+	}
+
+	t.Run("accepts slices and comma-separated strings", func(t *testing.T) {
+		testCases := map[string][]time.Duration{
+			"parsed":  {time.Second, time.Minute},
+			"list":    {30 * time.Second, time.Minute, 5 * time.Minute},
+			"strings": {time.Second, 2 * time.Second},
+			"mixed":   {time.Second, 2 * time.Second, time.Minute},
+			"millis":  {250 * time.Millisecond, 500 * time.Millisecond},
+			"single":  {10 * time.Second},
+		}
+
+		for key, expected := range testCases {
+			value, err := config.GetDurationSlice(key)
+			require.NoError(t, err, key)
+			assert.Equal(t, expected, value, key)
+		}
+	})
+
+	t.Run("returns errors for invalid elements", func(t *testing.T) {
+		_, err := config.GetDurationSlice("invalid")
+		assert.ErrorContains(t, err, "element 1")
+
+		_, err = config.GetDurationSlice("wrongtype")
+		assert.ErrorContains(t, err, "cannot be converted to duration")
+
+		_, err = config.GetDurationSlice("missing")
+		assert.ErrorContains(t, err, "not found")
+	})
+}
+
+func TestConfig_GetDurationInUnit(t *testing.T) {
+	config, err := New(context.Background(), LoadOptions{
+		Environment: "test",
+		Sources: []Source{
+			&mockSource{
+				name:     "test",
+				priority: 100,
+				values: map[string]interface{}{
+					"http.timeout":    30000,
+					"http.idle":       1500.5,
+					"http.keepalive":  "45s",
+					"http.retry":      2,
+					"http.deadline":   int64(90),
+					"http.unhinted":   30,
+					"http.bad_string": "not-a-duration",
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	t.Run("interprets integers in the given unit", func(t *testing.T) {
+		value, err := config.GetDurationInUnit("http.timeout", time.Millisecond)
+		require.NoError(t, err)
+		assert.Equal(t, 30*time.Second, value)
+
+		value, err = config.GetDurationInUnit("http.deadline", time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, 90*time.Minute, value)
+	})
+
+	t.Run("interprets floats in the given unit", func(t *testing.T) {
+		value, err := config.GetDurationInUnit("http.idle", time.Millisecond)
+		require.NoError(t, err)
+		assert.Equal(t, 1500*time.Millisecond+500*time.Microsecond, value)
+	})
+
+	t.Run("parses strings with their own unit", func(t *testing.T) {
+		value, err := config.GetDurationInUnit("http.keepalive", time.Millisecond)
+		require.NoError(t, err)
+		assert.Equal(t, 45*time.Second, value)
+
+		_, err = config.GetDurationInUnit("http.bad_string", time.Millisecond)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot be parsed as duration")
+	})
+
+	t.Run("returns error for invalid unit", func(t *testing.T) {
+		_, err := config.GetDurationInUnit("http.timeout", 0)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid duration unit")
+	})
+
+	t.Run("honors field type hints in GetDuration", func(t *testing.T) {
+		config.AddFieldMetadata("http.timeout", Field{Name: "http.timeout", Type: "duration_ms"})
+		config.AddFieldMetadata("http.idle", Field{Name: "http.idle", Type: "duration_ms"})
+		config.AddFieldMetadata("http.keepalive", Field{Name: "http.keepalive", Type: "duration_ms"})
+
+		value, err := config.GetDuration("http.timeout")
+		require.NoError(t, err)
+		assert.Equal(t, 30*time.Second, value)
+
+		value, err = config.GetDuration("http.idle")
+		require.NoError(t, err)
+		assert.Equal(t, 1500*time.Millisecond+500*time.Microsecond, value)
+
+		value, err = config.GetDuration("http.keepalive")
+		require.NoError(t, err)
+		assert.Equal(t, 45*time.Second, value)
+
+		// Hint-typed fields pass validation
+		assert.NoError(t, config.Validate(context.Background()))
+	})
+
+	t.Run("explicit unit overrides field type hint", func(t *testing.T) {
+		config.AddFieldMetadata("http.retry", Field{Name: "http.retry", Type: "duration_ms"})
+
+		value, err := config.GetDurationInUnit("http.retry", time.Second)
+		require.NoError(t, err)
+		assert.Equal(t, 2*time.Second, value)
+	})
+
+	t.Run("defaults to seconds without hint", func(t *testing.T) {
+		value, err := config.GetDuration("http.unhinted")
+		require.NoError(t, err)
+		assert.Equal(t, 30*time.Second, value)
+	})
+
+	t.Run("rejects non-duration values for hinted fields", func(t *testing.T) {
+		config.AddFieldMetadata("http.bad_string", Field{Name: "http.bad_string", Type: "duration_ms"})
+		err := config.Validate(context.Background())
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "expected duration_ms")
+	})
+}
+
+func TestConfig_GetTime(t *testing.T) {
+	expected := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	config, err := New(context.Background(), LoadOptions{
+		Environment: "test",
+		Sources: []Source{&mockSource{
+			name:     "mock",
+			priority: 50,
+			values: map[string]interface{}{
+				"time.value":     expected,
+				"time.rfc3339":   "2024-01-15T10:30:00Z",
+				"time.datetime":  "2024-01-15 10:30:00",
+				"time.date":      "2024-01-15",
+				"time.unix":      int64(expected.Unix()),
+				"time.unix_int":  int(expected.Unix()),
+				"time.invalid":   "not a time",
+				"time.list":      "2024-01-15, 2024-02-01T00:00:00Z",
+				"time.array":     []interface{}{"2024-01-15", int64(expected.Unix())},
+				"time.bad_array": []interface{}{"2024-01-15", "invalid"},
+			},
+		}},
+	})
+	require.NoError(t, err)
+
+	t.Run("gets time value", func(t *testing.T) {
+		value, err := config.GetTime("time.value")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value)
+	})
+
+	t.Run("parses supported string formats", func(t *testing.T) {
+		value, err := config.GetTime("time.rfc3339")
+		assert.NoError(t, err)
+		assert.True(t, expected.Equal(value))
+
+		value, err = config.GetTime("time.datetime")
+		assert.NoError(t, err)
+		assert.True(t, expected.Equal(value))
+
+		value, err = config.GetTime("time.date")
+		assert.NoError(t, err)
+		assert.Equal(t, "2024-01-15", value.Format("2006-01-02"))
+	})
+
+	t.Run("converts unix timestamps", func(t *testing.T) {
+		value, err := config.GetTime("time.unix")
+		assert.NoError(t, err)
+		assert.True(t, expected.Equal(value))
+
+		value, err = config.GetTime("time.unix_int")
+		assert.NoError(t, err)
+		assert.True(t, expected.Equal(value))
+	})
+
+	t.Run("returns error for invalid time", func(t *testing.T) {
+		_, err := config.GetTime("time.invalid")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot be converted to time")
+	})
+
+	t.Run("returns error for missing key", func(t *testing.T) {
+		_, err := config.GetTime("nonexistent")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+
+	t.Run("returns default for missing or invalid key", func(t *testing.T) {
+		fallback := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+		assert.Equal(t, fallback, config.GetTimeWithDefault("nonexistent", fallback))
+		assert.Equal(t, fallback, config.GetTimeWithDefault("time.invalid", fallback))
+		assert.Equal(t, expected, config.GetTimeWithDefault("time.value", fallback))
+	})
+
+	t.Run("gets time slice from comma-separated string", func(t *testing.T) {
+		values, err := config.GetTimeSlice("time.list")
+		require.NoError(t, err)
+		require.Len(t, values, 2)
+		assert.Equal(t, "2024-01-15", values[0].Format("2006-01-02"))
+		assert.Equal(t, "2024-02-01", values[1].Format("2006-01-02"))
+	})
+
+	t.Run("gets time slice from array", func(t *testing.T) {
+		values, err := config.GetTimeSlice("time.array")
+		require.NoError(t, err)
+		require.Len(t, values, 2)
+		assert.True(t, expected.Equal(values[1]))
+	})
+
+	t.Run("returns error for invalid slice element", func(t *testing.T) {
+		_, err := config.GetTimeSlice("time.bad_array")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "element 1")
+		assert.Contains(t, err.Error(), "cannot be converted to time")
+	})
+}
+
+func TestConfig_GetStringMap(t *testing.T) {
+	path := createTempFile(t, "config.json", `{
+		"database": {
+			"host": "localhost",
+			"port": 5432,
+			"options": {"sslmode": "require"},
+			"replicas": ["db-1", "db-2"]
+		},
+		"databases": {"legacy": true},
+		"labels": {"team": "core", "tier": 1}
+	}`)
+	fileSource, err := NewFileSource(FileSourceOptions{Path: path})
+	require.NoError(t, err)
+
+	config, err := New(context.Background(), LoadOptions{
+		Environment: "test",
+		Sources:     []Source{fileSource},
+	})
+	require.NoError(t, err)
+
+	t.Run("returns section without prefix", func(t *testing.T) {
+		section := config.GetStringMap("database")
+		assert.Equal(t, map[string]interface{}{
+			"host":            "localhost",
+			"port":            float64(5432),
+			"options.sslmode": "require",
+			"replicas":        []interface{}{"db-1", "db-2"},
+		}, section)
+	})
+
+	t.Run("accepts trailing dot", func(t *testing.T) {
+		assert.Equal(t, config.GetStringMap("database"), config.GetStringMap("database."))
+	})
+
+	t.Run("returns empty map when nothing matches", func(t *testing.T) {
+		section := config.GetStringMap("missing")
+		assert.NotNil(t, section)
+		assert.Empty(t, section)
+	})
+
+	t.Run("formats values as strings", func(t *testing.T) {
+		assert.Equal(t, map[string]string{"team": "core", "tier": "1"}, config.GetStringMapString("labels"))
+		assert.Empty(t, config.GetStringMapString("missing"))
+	})
+}
+
 func TestConfig_WithDefault(t *testing.T) {
 	config := createTestConfig(t)
 
@@ -465,173 +1133,944 @@ func TestConfig_AddSource(t *testing.T) {
 			valid:      false,
 		}
 
-		err := config.AddSource(invalidSource)
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "validation failed")
+		err := config.AddSource(invalidSource)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "validation failed")
+	})
+
+	t.Run("rejects nil source", func(t *testing.T) {
+		err := config.AddSource(nil)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "source cannot be nil")
+	})
+}
+
+func TestConfig_MergeStrategy(t *testing.T) {
+	newSources := func() []Source {
+		return []Source{
+			&mockSource{
+				name:     "base",
+				priority: 10,
+				values: map[string]interface{}{
+					"db.options.sslmode": "require",
+					"db.options":         map[string]interface{}{"pool": 5, "ssl": map[string]interface{}{"mode": "require", "ca": "/etc/ca.pem"}},
+					"db.hosts":           []interface{}{"db1"},
+					"db.hosts.0":         "db1",
+				},
+			},
+			&mockSource{
+				name:     "file",
+				priority: 50,
+				values: map[string]interface{}{
+					"db.options.timeout": "5s",
+					"db.options":         map[string]interface{}{"ssl": map[string]interface{}{"mode": "verify-full"}},
+					"db.hosts":           []interface{}{"db2"},
+					"db.hosts.0":         "db2",
+				},
+			},
+			&mockSource{
+				name:     "env",
+				priority: 100,
+				values: map[string]interface{}{
+					"db.options.retries": 3,
+					"db.options":         map[string]interface{}{"pool": 20},
+					"db.hosts":           []interface{}{"db3"},
+					"db.hosts.0":         "db3",
+				},
+			},
+		}
+	}
+
+	t.Run("overwrites by default", func(t *testing.T) {
+		config, err := New(context.Background(), LoadOptions{
+			Environment: "test",
+			Sources:     newSources(),
+		})
+		require.NoError(t, err)
+
+		// Distinct dotted keys from all sources are combined
+		assert.Equal(t, "require", config.GetStringWithDefault("db.options.sslmode", ""))
+		assert.Equal(t, "5s", config.GetStringWithDefault("db.options.timeout", ""))
+		assert.Equal(t, 3, config.GetIntWithDefault("db.options.retries", 0))
+
+		// Values for the same key are replaced by the highest priority source
+		options, _ := config.Get("db.options")
+		assert.Equal(t, map[string]interface{}{"pool": 20}, options)
+		hosts, _ := config.Get("db.hosts")
+		assert.Equal(t, []interface{}{"db3"}, hosts)
+	})
+
+	t.Run("deep merges maps", func(t *testing.T) {
+		config, err := New(context.Background(), LoadOptions{
+			Environment:   "test",
+			Sources:       newSources(),
+			MergeStrategy: MergeDeep,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "require", config.GetStringWithDefault("db.options.sslmode", ""))
+		assert.Equal(t, "5s", config.GetStringWithDefault("db.options.timeout", ""))
+		assert.Equal(t, 3, config.GetIntWithDefault("db.options.retries", 0))
+
+		options, _ := config.Get("db.options")
+		assert.Equal(t, map[string]interface{}{
+			"pool": 20,
+			"ssl":  map[string]interface{}{"mode": "verify-full", "ca": "/etc/ca.pem"},
+		}, options)
+
+		// Slices are replaced unless appending is enabled
+		hosts, _ := config.Get("db.hosts")
+		assert.Equal(t, []interface{}{"db3"}, hosts)
+	})
+
+	t.Run("appends slices when enabled", func(t *testing.T) {
+		config, err := New(context.Background(), LoadOptions{
+			Environment:   "test",
+			Sources:       newSources(),
+			MergeStrategy: MergeDeep,
+			AppendSlices:  true,
+		})
+		require.NoError(t, err)
+
+		hosts, _ := config.Get("db.hosts")
+		assert.Equal(t, []interface{}{"db1", "db2", "db3"}, hosts)
+		assert.Equal(t, "db1", config.GetStringWithDefault("db.hosts.0", ""))
+		assert.Equal(t, "db2", config.GetStringWithDefault("db.hosts.1", ""))
+		assert.Equal(t, "db3", config.GetStringWithDefault("db.hosts.2", ""))
+	})
+
+	t.Run("rejects unknown strategy", func(t *testing.T) {
+		_, err := New(context.Background(), LoadOptions{
+			Environment:   "test",
+			Sources:       newSources(),
+			MergeStrategy: "unknown",
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported merge strategy")
+	})
+}
+
+func TestConfig_Validation(t *testing.T) {
+	t.Run("validates successfully with all required fields", func(t *testing.T) {
+		config := createTestConfigWithMetadata(t)
+		err := config.Validate(context.Background())
+		assert.NoError(t, err)
+	})
+
+	t.Run("fails validation with missing required field", func(t *testing.T) {
+		config := createTestConfigWithMissingRequired(t)
+		err := config.Validate(context.Background())
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "required configuration field")
+	})
+
+	t.Run("validates field types", func(t *testing.T) {
+		metadata := &Metadata{
+			Name:        "test-config",
+			Environment: "test",
+			Fields: map[string]Field{
+				"test.number": {
+					Name: "test.number",
+					Type: "string", // Wrong type - should be int
+				},
+			},
+		}
+
+		config := createTestConfigWithCustomMetadata(t, metadata)
+		err := config.Validate(context.Background())
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "has type")
+	})
+
+	t.Run("validates field ranges", func(t *testing.T) {
+		metadata := &Metadata{
+			Name:        "test-config",
+			Environment: "test",
+			Fields: map[string]Field{
+				"test.number": {
+					Name:     "test.number",
+					Type:     "integer",
+					MinValue: 50, // test.number is 42, should fail
+					MaxValue: 100,
+				},
+			},
+		}
+
+		config := createTestConfigWithCustomMetadata(t, metadata)
+		err := config.Validate(context.Background())
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "below minimum")
+	})
+
+	t.Run("validates enum values", func(t *testing.T) {
+		metadata := &Metadata{
+			Name:        "test-config",
+			Environment: "test",
+			Fields: map[string]Field{
+				"test.key": {
+					Name: "test.key",
+					Type: "string",
+					Enum: []string{"production", "staging"}, // test.key is "test_value"
+				},
+			},
+		}
+
+		config := createTestConfigWithCustomMetadata(t, metadata)
+		err := config.Validate(context.Background())
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not in allowed enum values")
+	})
+
+	t.Run("validates string lengths", func(t *testing.T) {
+		apiKeyField := map[string]Field{
+			"api_key": {Name: "api_key", Type: "string", MinLength: 32, MaxLength: 64},
+		}
+		validate := func(apiKey string) error {
+			config, err := New(context.Background(), LoadOptions{
+				Environment: "test",
+				Sources:     []Source{&mockSource{name: "file", priority: 50, values: map[string]interface{}{"api_key": apiKey}}},
+				Metadata:    &Metadata{Fields: apiKeyField},
+			})
+			require.NoError(t, err)
+			defer config.Close()
+			return config.Validate(context.Background())
+		}
+
+		err := validate(strings.Repeat("k", 31))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "field 'api_key' length 31 is below minimum length 32")
+
+		err = validate(strings.Repeat("k", 65))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "field 'api_key' length 65 exceeds maximum length 64")
+
+		assert.NoError(t, validate(strings.Repeat("k", 32)))
+		assert.NoError(t, validate(strings.Repeat("k", 64)))
+		assert.NoError(t, validate(strings.Repeat("ä", 32)), "length is counted in characters")
+	})
+
+	t.Run("validates ranges of numeric strings", func(t *testing.T) {
+		validate := func(fieldType string, value interface{}) error {
+			config, err := New(context.Background(), LoadOptions{
+				Environment: "test",
+				Sources:     []Source{&mockSource{name: "env", priority: 100, values: map[string]interface{}{"server.port": value}}},
+				Metadata: &Metadata{Fields: map[string]Field{
+					"server.port": {Name: "server.port", Type: fieldType, MinValue: 1, MaxValue: 65535},
+				}},
+			})
+			require.NoError(t, err)
+			defer config.Close()
+			return config.Validate(context.Background())
+		}
+
+		err := validate("int", "70000")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "field 'server.port' value 70000 exceeds maximum 65535")
+		assert.Contains(t, validate("int", " 0 ").Error(), "below minimum 1")
+
+		assert.NoError(t, validate("int", "8080"), "numeric strings pass the type check")
+		assert.NoError(t, validate("int", "65535"))
+		assert.NoError(t, validate("float64", "1.5"))
+		assert.NoError(t, validate("string", "70000"), "strings of non-numeric fields are not ranged")
+		assert.Contains(t, validate("int", "8080abc").Error(), "has type string but expected int")
+		assert.Contains(t, validate("int", int64(0)).Error(), "below minimum 1")
+	})
+
+	t.Run("runs custom validators", func(t *testing.T) {
+		config := createTestConfig(t)
+
+		// Add a custom validator that fails - Fixed signature
+		config.AddValidator(func(key string, value interface{}) error {
+			if key == "test.key" {
+				if strValue, ok := value.(string); ok && strValue == "test_value" {
+					return fmt.Errorf("custom validation failed")
+				}
+			}
+			return nil
+		})
+
+		err := config.Validate(context.Background())
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "custom validation failed")
+	})
+}
+
+func TestConfig_StrictKeys(t *testing.T) {
+	metadata := func() *Metadata {
+		return &Metadata{Fields: map[string]Field{
+			"server.port": {Name: "server.port", Type: "int"},
+			"labels":      {Name: "labels", Type: "map"},
+		}}
+	}
+	newStrictConfig := func(t *testing.T, strict bool, values map[string]interface{}, defaults map[string]interface{}) *Config {
+		config, err := New(context.Background(), LoadOptions{
+			Environment: "test",
+			Sources:     []Source{&mockSource{name: "file", priority: 50, values: values}},
+			Defaults:    defaults,
+			Metadata:    metadata(),
+			StrictKeys:  strict,
+		})
+		require.NoError(t, err)
+		t.Cleanup(func() { config.Close() })
+		return config
+	}
+
+	t.Run("rejects undeclared keys", func(t *testing.T) {
+		config := newStrictConfig(t, true, map[string]interface{}{
+			"server.port": 8080,
+			"serer.port":  8081,
+			"debug":       true,
+		}, nil)
+
+		err := config.Validate(context.Background())
+		require.Error(t, err)
+		assert.True(t, core.IsInvalidInput(err))
+		assert.Contains(t, err.Error(), "unknown configuration keys: debug, serer.port")
+
+		var tbpErr *core.Error
+		require.ErrorAs(t, err, &tbpErr)
+		assert.Equal(t, []string{"debug", "serer.port"}, tbpErr.Context["unknown_keys"])
+	})
+
+	t.Run("accepts declared keys and their children", func(t *testing.T) {
+		config := newStrictConfig(t, true, map[string]interface{}{
+			"server.port": 8080,
+			"labels.team": "core",
+		}, nil)
+
+		assert.NoError(t, config.Validate(context.Background()))
+		assert.Empty(t, config.UnknownKeys())
+	})
+
+	t.Run("accepts keys of the defaults source", func(t *testing.T) {
+		config := newStrictConfig(t, true,
+			map[string]interface{}{"server.port": 8080, "cache.ttl": "5m"},
+			map[string]interface{}{"cache.ttl": "1m", "cache.size": 100})
+
+		assert.NoError(t, config.Validate(context.Background()), "defaults declare their keys")
+		assert.Empty(t, config.UnknownKeys())
+	})
+
+	t.Run("is disabled by default", func(t *testing.T) {
+		config := newStrictConfig(t, false, map[string]interface{}{
+			"server.port": 8080,
+			"serer.port":  8081,
+		}, nil)
+
+		assert.NoError(t, config.Validate(context.Background()))
+		assert.Equal(t, []string{"serer.port"}, config.UnknownKeys())
+	})
+
+	t.Run("requires declared fields", func(t *testing.T) {
+		config, err := New(context.Background(), LoadOptions{
+			Environment: "test",
+			Sources:     []Source{&mockSource{name: "file", priority: 50, values: map[string]interface{}{"any.key": 1}}},
+			StrictKeys:  true,
+		})
+		require.NoError(t, err)
+		defer config.Close()
+
+		assert.NoError(t, config.Validate(context.Background()))
+		assert.Nil(t, config.UnknownKeys())
+	})
+}
+
+func TestConfig_StructuredValidation(t *testing.T) {
+	newConfig := func(t *testing.T, structured bool) *Config {
+		config, err := New(context.Background(), LoadOptions{
+			Environment: "test",
+			Sources: []Source{&mockSource{name: "file", priority: 50, values: map[string]interface{}{
+				"server.port": 70000,
+				"log.level":   "verbose",
+				"serer.port":  8081,
+			}}},
+			Metadata: &Metadata{Fields: map[string]Field{
+				"server.port": {Name: "server.port", Type: "int", MinValue: 1, MaxValue: 65535},
+				"server.host": {Name: "server.host", Required: true},
+				"log.level":   {Name: "log.level", Type: "string", Enum: []string{"debug", "info"}},
+			}},
+			StrictKeys:           true,
+			StructuredValidation: structured,
+		})
+		require.NoError(t, err)
+		t.Cleanup(func() { config.Close() })
+		return config
+	}
+
+	t.Run("returns field errors", func(t *testing.T) {
+		err := newConfig(t, true).Validate(context.Background())
+		require.Error(t, err)
+		assert.True(t, core.IsInvalidInput(err))
+
+		validationErr, ok := core.IsValidationError(err)
+		require.True(t, ok)
+		require.Len(t, validationErr.Fields, 4)
+
+		assert.Equal(t, core.FieldError{
+			Field:   "log.level",
+			Message: "field 'log.level' value 'verbose' is not in allowed enum values: debug, info",
+			Rule:    "enum",
+		}, validationErr.Fields[0])
+		assert.Equal(t, core.FieldError{
+			Field:   "serer.port",
+			Message: "unknown configuration key 'serer.port'",
+			Rule:    "unknown",
+		}, validationErr.Fields[1])
+		assert.Equal(t, "required", validationErr.Fields[2].Rule)
+		assert.Equal(t, "server.host", validationErr.Fields[2].Field)
+		assert.Equal(t, "range", validationErr.Fields[3].Rule)
+		assert.Equal(t, "server.port", validationErr.Fields[3].Field)
+		assert.Equal(t, []string{"serer.port"}, validationErr.Context["unknown_keys"])
+		assert.Contains(t, err.Error(), "exceeds maximum 65535")
+	})
+
+	t.Run("is disabled by default", func(t *testing.T) {
+		err := newConfig(t, false).Validate(context.Background())
+		require.Error(t, err)
+
+		_, ok := core.IsValidationError(err)
+		assert.False(t, ok)
+		assert.Contains(t, err.Error(), "configuration validation failed:\n  - ")
+	})
+
+	t.Run("applies to validation on load", func(t *testing.T) {
+		_, err := New(context.Background(), LoadOptions{
+			Environment:          "test",
+			Sources:              []Source{&mockSource{name: "file", priority: 50, values: map[string]interface{}{}}},
+			Metadata:             &Metadata{Fields: map[string]Field{"server.host": {Name: "server.host", Required: true}}},
+			Validation:           true,
+			StructuredValidation: true,
+		})
+		require.Error(t, err)
+
+		validationErr, ok := core.IsValidationError(err)
+		require.True(t, ok)
+		assert.Equal(t, []core.FieldError{{
+			Field:   "server.host",
+			Message: "required configuration field 'server.host' is missing",
+			Rule:    "required",
+		}}, validationErr.Fields)
+	})
+}
+
+func TestConfig_FieldDefaults(t *testing.T) {
+	newMetadata := func() *Metadata {
+		return &Metadata{
+			Name: "test-config",
+			Fields: map[string]Field{
+				"server.port":    {Name: "server.port", Type: "int", DefaultValue: 8080},
+				"server.host":    {Name: "server.host", DefaultValue: "localhost"},
+				"server.timeout": {Name: "server.timeout", Required: true, DefaultValue: "30s"},
+				"server.name":    {Name: "server.name", Required: true},
+			},
+		}
+	}
+
+	t.Run("injects defaults for missing keys", func(t *testing.T) {
+		config, err := New(context.Background(), LoadOptions{
+			Environment: "test",
+			Metadata:    newMetadata(),
+			Sources:     []Source{&mockSource{values: map[string]interface{}{"server.name": "api"}}},
+		})
+		require.NoError(t, err)
+
+		port, err := config.GetInt("server.port")
+		require.NoError(t, err)
+		assert.Equal(t, 8080, port)
+
+		timeout, err := config.GetDuration("server.timeout")
+		require.NoError(t, err)
+		assert.Equal(t, 30*time.Second, timeout)
+
+		assert.NoError(t, config.Validate(context.Background()))
+	})
+
+	t.Run("does not override values from sources", func(t *testing.T) {
+		config, err := New(context.Background(), LoadOptions{
+			Environment: "test",
+			Metadata:    newMetadata(),
+			Sources: []Source{&mockSource{values: map[string]interface{}{
+				"server.name": "api",
+				"server.port": 9090,
+				"server.host": "",
+			}}},
+		})
+		require.NoError(t, err)
+
+		port, err := config.GetInt("server.port")
+		require.NoError(t, err)
+		assert.Equal(t, 9090, port)
+
+		host, err := config.GetString("server.host")
+		require.NoError(t, err)
+		assert.Empty(t, host)
+	})
+
+	t.Run("required fields without value or default still fail", func(t *testing.T) {
+		config, err := New(context.Background(), LoadOptions{
+			Environment: "test",
+			Metadata:    newMetadata(),
+			Sources:     []Source{&mockSource{values: map[string]interface{}{}}},
+		})
+		require.NoError(t, err)
+
+		err = config.Validate(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "'server.name' is missing")
+		assert.NotContains(t, err.Error(), "'server.timeout' is missing")
+	})
+
+	t.Run("reports metadata-default source", func(t *testing.T) {
+		source := &mockSource{values: map[string]interface{}{"server.name": "api", "server.port": 9090}}
+		config, err := New(context.Background(), LoadOptions{
+			Environment: "test",
+			Metadata:    newMetadata(),
+			Sources:     []Source{source},
+		})
+		require.NoError(t, err)
+
+		var names []string
+		for _, info := range config.GetSources() {
+			names = append(names, info.Name)
+		}
+		assert.Contains(t, names, MetadataDefaultSourceName)
+
+		changes := make(chan ConfigChange, 1)
+		unsubscribe := config.WatchKey("server.port", func(change ConfigChange) {
+			changes <- change
+		})
+		defer unsubscribe()
+
+		source.values = map[string]interface{}{"server.name": "api"}
+		require.NoError(t, config.Load(context.Background()))
+
+		select {
+		case change := <-changes:
+			assert.Equal(t, MetadataDefaultSourceName, change.Source)
+			assert.Equal(t, 8080, change.NewValue)
+		case <-time.After(time.Second):
+			t.Fatal("expected change notification")
+		}
+	})
+}
+
+func TestConfig_Watcher(t *testing.T) {
+	config := createTestConfig(t)
+
+	t.Run("notifies watchers of changes", func(t *testing.T) {
+		watcher := &mockWatcher{changes: make(chan map[string]ConfigChange, 1)}
+		config.AddWatcher(watcher)
+
+		// Add a new source to trigger changes
+		newSource := &mockSource{
+			priority: 200, // Higher priority
+			values: map[string]interface{}{
+				"test.key": "updated_value",
+				"new.key":  "new_value",
+			},
+		}
+
+		err := config.AddSource(newSource)
+		require.NoError(t, err)
+
+		// Reload to trigger change detection
+		ctx := context.Background()
+		err = config.Load(ctx)
+		require.NoError(t, err)
+
+		// Wait for change notification
+		select {
+		case changes := <-watcher.changes:
+			assert.Contains(t, changes, "test.key")
+			assert.Contains(t, changes, "new.key")
+
+			// Check change types
+			testKeyChange := changes["test.key"]
+			assert.Equal(t, ChangeActionUpdate, testKeyChange.Action)
+			assert.Equal(t, "test_value", testKeyChange.OldValue)
+			assert.Equal(t, "updated_value", testKeyChange.NewValue)
+
+			newKeyChange := changes["new.key"]
+			assert.Equal(t, ChangeActionAdd, newKeyChange.Action)
+			assert.Equal(t, "new_value", newKeyChange.NewValue)
+
+		case <-time.After(1 * time.Second):
+			t.Fatal("Did not receive change notification")
+		}
+	})
+
+	t.Run("removes watchers", func(t *testing.T) {
+		watcher := &mockWatcher{changes: make(chan map[string]ConfigChange, 1)}
+		config.AddWatcher(watcher)
+		config.RemoveWatcher(watcher)
+
+		// Add source to trigger potential changes
+		newSource := &mockSource{
+			priority: 150,
+			values:   map[string]interface{}{"test.key": "another_value"},
+		}
+		config.AddSource(newSource)
+		config.Load(context.Background())
+
+		// Should not receive notification
+		select {
+		case <-watcher.changes:
+			t.Fatal("Should not receive notification after watcher removal")
+		case <-time.After(100 * time.Millisecond):
+			// Expected - no notification
+		}
+	})
+}
+
+func TestConfig_Changes(t *testing.T) {
+	newChangingConfig := func(t *testing.T) (*Config, *mockSource) {
+		source := &mockSource{
+			name:     "changing",
+			priority: 50,
+			values:   map[string]interface{}{"counter": 0},
+		}
+		config, err := New(context.Background(), LoadOptions{
+			Environment: "test",
+			Sources:     []Source{source},
+		})
+		require.NoError(t, err)
+		return config, source
+	}
+
+	t.Run("delivers change sets", func(t *testing.T) {
+		config, source := newChangingConfig(t)
+		defer config.Close()
+
+		changes := config.Changes()
+		source.values["counter"] = 1
+		require.NoError(t, config.Load(context.Background()))
+
+		select {
+		case changed := <-changes:
+			require.Contains(t, changed, "counter")
+			assert.Equal(t, 1, changed["counter"].NewValue)
+			assert.Equal(t, ChangeActionUpdate, changed["counter"].Action)
+		case <-time.After(1 * time.Second):
+			t.Fatal("Did not receive change set")
+		}
+	})
+
+	t.Run("full channel does not block reloads", func(t *testing.T) {
+		config, source := newChangingConfig(t)
+		defer config.Close()
+
+		changes := config.Changes()
+		reloads := ChangesBufferSize + 10
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for i := 1; i <= reloads; i++ {
+				source.values["counter"] = i
+				assert.NoError(t, config.Load(context.Background()))
+			}
+		}()
+		select {
+		case <-done:
+		case <-time.After(2 * time.Second):
+			t.Fatal("Load blocked on a full change channel")
+		}
+
+		assert.Eventually(t, func() bool {
+			return len(changes) == ChangesBufferSize
+		}, 1*time.Second, 10*time.Millisecond)
+
+		// Deliveries are asynchronous, but the newest change set is kept
+		assert.Eventually(t, func() bool {
+			for {
+				select {
+				case changed := <-changes:
+					if changed["counter"].NewValue == reloads {
+						return true
+					}
+				default:
+					return false
+				}
+			}
+		}, 1*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("stop closes the channel", func(t *testing.T) {
+		config, source := newChangingConfig(t)
+		defer config.Close()
+
+		changes := config.Changes()
+		other := config.Changes()
+		config.StopChanges(changes)
+		config.StopChanges(changes)
+
+		_, open := <-changes
+		assert.False(t, open)
+
+		source.values["counter"] = 1
+		require.NoError(t, config.Load(context.Background()))
+		select {
+		case <-other:
+		case <-time.After(1 * time.Second):
+			t.Fatal("Remaining channel did not receive change set")
+		}
+	})
+
+	t.Run("close closes all channels", func(t *testing.T) {
+		config, _ := newChangingConfig(t)
+
+		changes := config.Changes()
+		require.NoError(t, config.Close())
+
+		_, open := <-changes
+		assert.False(t, open)
+
+		_, open = <-config.Changes()
+		assert.False(t, open, "channels requested after close are closed")
+	})
+}
+
+func TestConfig_Subscriptions(t *testing.T) {
+	t.Run("notifies key subscribers only for matching key", func(t *testing.T) {
+		config := createTestConfig(t)
+
+		received := make(chan ConfigChange, 1)
+		unsubscribe := config.WatchKey("test.key", func(change ConfigChange) {
+			received <- change
+		})
+		defer unsubscribe()
+
+		otherReceived := make(chan ConfigChange, 1)
+		unsubscribeOther := config.WatchKey("test.number", func(change ConfigChange) {
+			otherReceived <- change
+		})
+		defer unsubscribeOther()
+
+		err := config.AddSource(&mockSource{
+			name:     "override",
+			priority: 200,
+			values:   map[string]interface{}{"test.key": "updated_value"},
+		})
+		require.NoError(t, err)
+		require.NoError(t, config.Load(context.Background()))
+
+		select {
+		case change := <-received:
+			assert.Equal(t, "test.key", change.Key)
+			assert.Equal(t, ChangeActionUpdate, change.Action)
+			assert.Equal(t, "test_value", change.OldValue)
+			assert.Equal(t, "updated_value", change.NewValue)
+		case <-time.After(1 * time.Second):
+			t.Fatal("Did not receive key change notification")
+		}
+
+		select {
+		case <-otherReceived:
+			t.Fatal("Should not receive notification for unchanged key")
+		case <-time.After(100 * time.Millisecond):
+			// Expected - no notification
+		}
+	})
+
+	t.Run("notifies prefix subscribers with matching changes", func(t *testing.T) {
+		config := createTestConfig(t)
+
+		received := make(chan map[string]ConfigChange, 1)
+		unsubscribe := config.WatchPrefix("feature.", func(changes map[string]ConfigChange) {
+			received <- changes
+		})
+		defer unsubscribe()
+
+		err := config.AddSource(&mockSource{
+			name:     "features",
+			priority: 200,
+			values: map[string]interface{}{
+				"feature.a":   true,
+				"feature.b":   false,
+				"test.key":    "updated_value",
+				"featureless": "ignored",
+			},
+		})
+		require.NoError(t, err)
+		require.NoError(t, config.Load(context.Background()))
+
+		select {
+		case changes := <-received:
+			assert.Len(t, changes, 2)
+			assert.Contains(t, changes, "feature.a")
+			assert.Contains(t, changes, "feature.b")
+			assert.Equal(t, ChangeActionAdd, changes["feature.a"].Action)
+		case <-time.After(1 * time.Second):
+			t.Fatal("Did not receive prefix change notification")
+		}
+	})
+
+	t.Run("stops notifying after unsubscribe", func(t *testing.T) {
+		config := createTestConfig(t)
+
+		received := make(chan ConfigChange, 1)
+		unsubscribe := config.WatchKey("test.key", func(change ConfigChange) {
+			received <- change
+		})
+		unsubscribe()
+		unsubscribe() // Calling twice is safe
+
+		err := config.AddSource(&mockSource{
+			name:     "override",
+			priority: 200,
+			values:   map[string]interface{}{"test.key": "updated_value"},
+		})
+		require.NoError(t, err)
+		require.NoError(t, config.Load(context.Background()))
+
+		select {
+		case <-received:
+			t.Fatal("Should not receive notification after unsubscribe")
+		case <-time.After(100 * time.Millisecond):
+			// Expected - no notification
+		}
+	})
+
+	t.Run("handles concurrent registration during reload", func(t *testing.T) {
+		config := createTestConfig(t)
+
+		const numGoroutines = 20
+		done := make(chan bool, numGoroutines*2)
+
+		for i := 0; i < numGoroutines; i++ {
+			go func(id int) {
+				defer func() { done <- true }()
+				unsubscribe := config.WatchKey(fmt.Sprintf("key.%d", id), func(ConfigChange) {})
+				unsubscribe()
+			}(i)
+			go func() {
+				defer func() { done <- true }()
+				_ = config.Load(context.Background())
+			}()
+		}
+
+		for i := 0; i < numGoroutines*2; i++ {
+			select {
+			case <-done:
+				// Success
+			case <-time.After(5 * time.Second):
+				t.Fatal("Concurrent subscriptions timed out")
+			}
+		}
 	})
 
-	t.Run("rejects nil source", func(t *testing.T) {
-		err := config.AddSource(nil)
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "source cannot be nil")
-	})
-}
+	t.Run("ignores nil callbacks", func(t *testing.T) {
+		config := createTestConfig(t)
 
-func TestConfig_Validation(t *testing.T) {
-	t.Run("validates successfully with all required fields", func(t *testing.T) {
-		config := createTestConfigWithMetadata(t)
-		err := config.Validate(context.Background())
-		assert.NoError(t, err)
-	})
+		unsubscribe := config.WatchKey("test.key", nil)
+		require.NotNil(t, unsubscribe)
+		unsubscribe()
 
-	t.Run("fails validation with missing required field", func(t *testing.T) {
-		config := createTestConfigWithMissingRequired(t)
-		err := config.Validate(context.Background())
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "required configuration field")
+		assert.Empty(t, config.subscriptions)
 	})
+}
 
-	t.Run("validates field types", func(t *testing.T) {
+func TestConfig_ReloadValidated(t *testing.T) {
+	newValidatedConfig := func(t *testing.T) (*Config, *mockSource) {
 		metadata := &Metadata{
 			Name:        "test-config",
 			Environment: "test",
 			Fields: map[string]Field{
-				"test.number": {
-					Name: "test.number",
-					Type: "string", // Wrong type - should be int
+				"required.field": {
+					Name:     "required.field",
+					Required: true,
 				},
 			},
 		}
 
-		config := createTestConfigWithCustomMetadata(t, metadata)
-		err := config.Validate(context.Background())
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "has type")
-	})
-
-	t.Run("validates field ranges", func(t *testing.T) {
-		metadata := &Metadata{
-			Name:        "test-config",
-			Environment: "test",
-			Fields: map[string]Field{
-				"test.number": {
-					Name:     "test.number",
-					Type:     "integer",
-					MinValue: 50, // test.number is 42, should fail
-					MaxValue: 100,
-				},
-			},
+		mockSrc := &mockSource{
+			name:     "mock",
+			priority: 50,
+			values:   map[string]interface{}{"required.field": "present"},
 		}
 
-		config := createTestConfigWithCustomMetadata(t, metadata)
-		err := config.Validate(context.Background())
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "below minimum")
-	})
-
-	t.Run("validates enum values", func(t *testing.T) {
-		metadata := &Metadata{
-			Name:        "test-config",
+		config, err := New(context.Background(), LoadOptions{
 			Environment: "test",
-			Fields: map[string]Field{
-				"test.key": {
-					Name: "test.key",
-					Type: "string",
-					Enum: []string{"production", "staging"}, // test.key is "test_value"
-				},
-			},
-		}
+			Sources:     []Source{mockSrc},
+			Metadata:    metadata,
+			Validation:  true,
+		})
+		require.NoError(t, err)
 
-		config := createTestConfigWithCustomMetadata(t, metadata)
-		err := config.Validate(context.Background())
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "not in allowed enum values")
-	})
+		return config, mockSrc
+	}
 
-	t.Run("runs custom validators", func(t *testing.T) {
-		config := createTestConfig(t)
+	t.Run("applies valid configuration", func(t *testing.T) {
+		config, mockSrc := newValidatedConfig(t)
 
-		// Add a custom validator that fails - Fixed signature
-		config.AddValidator(func(key string, value interface{}) error {
-			if key == "test.key" {
-				if strValue, ok := value.(string); ok && strValue == "test_value" {
-					return fmt.Errorf("custom validation failed")
-				}
-			}
-			return nil
-		})
+		mockSrc.values = map[string]interface{}{"required.field": "updated"}
 
-		err := config.Validate(context.Background())
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "custom validation failed")
+		err := config.ReloadValidated(context.Background())
+		require.NoError(t, err)
+
+		value, exists := config.Get("required.field")
+		assert.True(t, exists)
+		assert.Equal(t, "updated", value)
+		assert.NoError(t, config.LastReloadError())
 	})
-}
 
-func TestConfig_Watcher(t *testing.T) {
-	config := createTestConfig(t)
+	t.Run("keeps previous values on validation failure", func(t *testing.T) {
+		config, mockSrc := newValidatedConfig(t)
 
-	t.Run("notifies watchers of changes", func(t *testing.T) {
 		watcher := &mockWatcher{changes: make(chan map[string]ConfigChange, 1)}
 		config.AddWatcher(watcher)
 
-		// Add a new source to trigger changes
-		newSource := &mockSource{
-			priority: 200, // Higher priority
-			values: map[string]interface{}{
-				"test.key": "updated_value",
-				"new.key":  "new_value",
-			},
-		}
+		mockSrc.values = map[string]interface{}{"other.field": "value"}
 
-		err := config.AddSource(newSource)
-		require.NoError(t, err)
+		err := config.ReloadValidated(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "required configuration field 'required.field' is missing")
 
-		// Reload to trigger change detection
-		ctx := context.Background()
-		err = config.Load(ctx)
-		require.NoError(t, err)
+		value, exists := config.Get("required.field")
+		assert.True(t, exists)
+		assert.Equal(t, "present", value)
+		assert.False(t, config.HasKey("other.field"))
+
+		assert.Equal(t, err, config.LastReloadError())
 
-		// Wait for change notification
 		select {
-		case changes := <-watcher.changes:
-			assert.Contains(t, changes, "test.key")
-			assert.Contains(t, changes, "new.key")
+		case <-watcher.changes:
+			t.Fatal("Should not notify watchers on failed reload")
+		case <-time.After(100 * time.Millisecond):
+			// Expected - no notification
+		}
+	})
 
-			// Check change types
-			testKeyChange := changes["test.key"]
-			assert.Equal(t, ChangeActionUpdate, testKeyChange.Action)
-			assert.Equal(t, "test_value", testKeyChange.OldValue)
-			assert.Equal(t, "updated_value", testKeyChange.NewValue)
+	t.Run("counts successful and failed reloads", func(t *testing.T) {
+		config, mockSrc := newValidatedConfig(t)
+		initial := config.ReloadStats()
+		assert.Equal(t, uint64(1), initial.Successful)
+		assert.Equal(t, uint64(0), initial.Failed)
 
-			newKeyChange := changes["new.key"]
-			assert.Equal(t, ChangeActionAdd, newKeyChange.Action)
-			assert.Equal(t, "new_value", newKeyChange.NewValue)
+		require.NoError(t, config.ReloadValidated(context.Background()))
 
-		case <-time.After(1 * time.Second):
-			t.Fatal("Did not receive change notification")
-		}
+		mockSrc.values = map[string]interface{}{}
+		require.Error(t, config.ReloadValidated(context.Background()))
+
+		stats := config.ReloadStats()
+		assert.Equal(t, uint64(2), stats.Successful)
+		assert.Equal(t, uint64(1), stats.Failed)
 	})
 
-	t.Run("removes watchers", func(t *testing.T) {
-		watcher := &mockWatcher{changes: make(chan map[string]ConfigChange, 1)}
-		config.AddWatcher(watcher)
-		config.RemoveWatcher(watcher)
+	t.Run("counts source load failures", func(t *testing.T) {
+		config := createTestConfig(t)
 
-		// Add source to trigger potential changes
-		newSource := &mockSource{
-			priority: 150,
-			values:   map[string]interface{}{"test.key": "another_value"},
-		}
-		config.AddSource(newSource)
-		config.Load(context.Background())
+		err := config.AddSource(&mockErrorSource{
+			mockSource: mockSource{name: "error", priority: 60},
+			loadError:  fmt.Errorf("mock load error"),
+		})
+		require.NoError(t, err)
 
-		// Should not receive notification
-		select {
-		case <-watcher.changes:
-			t.Fatal("Should not receive notification after watcher removal")
-		case <-time.After(100 * time.Millisecond):
-			// Expected - no notification
-		}
+		err = config.Load(context.Background())
+		require.Error(t, err)
+
+		assert.Equal(t, uint64(1), config.ReloadStats().Failed)
+		assert.Contains(t, config.LastReloadError().Error(), "mock load error")
 	})
 }
 
@@ -793,6 +2232,156 @@ func TestConfig_HotReload(t *testing.T) {
 	})
 }
 
+func TestConfig_WatchCoalescing(t *testing.T) {
+	newWatchedConfig := func(t *testing.T) (*Config, *mockCountingWatchableSource, *mockCountingWatchableSource) {
+		first := newMockCountingWatchableSource(map[string]interface{}{"first.key": "initial"})
+		second := newMockCountingWatchableSource(map[string]interface{}{"second.key": "initial"})
+		second.name = "counting-second"
+
+		config, err := New(context.Background(), LoadOptions{
+			Environment: "test",
+			Sources:     []Source{first, second},
+			HotReload:   true,
+		})
+		require.NoError(t, err)
+
+		for _, source := range []*mockCountingWatchableSource{first, second} {
+			select {
+			case <-source.watching:
+			case <-time.After(1 * time.Second):
+				t.Fatal("Source was not watched")
+			}
+		}
+
+		return config, first, second
+	}
+
+	t.Run("coalesces simultaneous changes of several sources", func(t *testing.T) {
+		config, first, second := newWatchedConfig(t)
+		defer config.Close()
+
+		initialLoads := first.LoadCount()
+		gate := make(chan struct{})
+		loading := make(chan struct{}, 1)
+		first.SetGate(gate, loading)
+
+		// Block the first reload, then let both sources report changes
+		first.TriggerChange()
+		select {
+		case <-loading:
+		case <-time.After(1 * time.Second):
+			t.Fatal("Reload was not started")
+		}
+
+		var wg sync.WaitGroup
+		for _, source := range []*mockCountingWatchableSource{first, second} {
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+				go func(source *mockCountingWatchableSource) {
+					defer wg.Done()
+					source.TriggerChange()
+				}(source)
+			}
+		}
+		wg.Wait()
+
+		first.SetGate(nil, nil)
+		close(gate)
+
+		assert.Eventually(t, func() bool {
+			return first.LoadCount() == initialLoads+2
+		}, 1*time.Second, 10*time.Millisecond)
+		time.Sleep(100 * time.Millisecond)
+		assert.Equal(t, initialLoads+2, first.LoadCount(), "pending changes reload once")
+		assert.Equal(t, initialLoads+2, second.LoadCount())
+	})
+
+	t.Run("close stops reloading", func(t *testing.T) {
+		config, first, second := newWatchedConfig(t)
+
+		initialLoads := first.LoadCount()
+		require.NoError(t, config.Close())
+
+		first.TriggerChange()
+		second.TriggerChange()
+		time.Sleep(50 * time.Millisecond)
+		assert.Equal(t, initialLoads, first.LoadCount())
+	})
+
+	t.Run("ignores repeated start", func(t *testing.T) {
+		config, first, _ := newWatchedConfig(t)
+		defer config.Close()
+
+		require.NoError(t, config.StartWatching(context.Background()))
+
+		initialLoads := first.LoadCount()
+		first.TriggerChange()
+		assert.Eventually(t, func() bool {
+			return first.LoadCount() == initialLoads+1
+		}, 1*time.Second, 10*time.Millisecond)
+	})
+}
+
+func TestConfig_ReloadDebounce(t *testing.T) {
+	newDebouncedConfig := func(t *testing.T, ctx context.Context) (*Config, *mockCountingWatchableSource) {
+		source := newMockCountingWatchableSource(map[string]interface{}{"test.key": "initial"})
+
+		config, err := New(ctx, LoadOptions{
+			Environment:    "test",
+			Sources:        []Source{source},
+			HotReload:      true,
+			ReloadDebounce: 50 * time.Millisecond,
+		})
+		require.NoError(t, err)
+
+		select {
+		case <-source.watching:
+		case <-time.After(1 * time.Second):
+			t.Fatal("Source was not watched")
+		}
+
+		return config, source
+	}
+
+	t.Run("coalesces rapid changes into a single reload", func(t *testing.T) {
+		config, source := newDebouncedConfig(t, context.Background())
+		defer config.Close()
+
+		initialLoads := source.LoadCount()
+		for i := 0; i < 5; i++ {
+			source.TriggerChange()
+			time.Sleep(5 * time.Millisecond)
+		}
+
+		time.Sleep(200 * time.Millisecond)
+		assert.Equal(t, initialLoads+1, source.LoadCount())
+	})
+
+	t.Run("skips pending reload after close", func(t *testing.T) {
+		config, source := newDebouncedConfig(t, context.Background())
+
+		initialLoads := source.LoadCount()
+		source.TriggerChange()
+		require.NoError(t, config.Close())
+
+		time.Sleep(150 * time.Millisecond)
+		assert.Equal(t, initialLoads, source.LoadCount())
+	})
+
+	t.Run("skips pending reload after context cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		config, source := newDebouncedConfig(t, ctx)
+		defer config.Close()
+
+		initialLoads := source.LoadCount()
+		source.TriggerChange()
+		cancel()
+
+		time.Sleep(150 * time.Millisecond)
+		assert.Equal(t, initialLoads, source.LoadCount())
+	})
+}
+
 // Test concurrent access
 func TestConfig_ConcurrentAccess(t *testing.T) {
 	config := createTestConfig(t)
@@ -1036,13 +2625,22 @@ func createTestConfigWithCustomMetadata(t *testing.T, metadata *Metadata) *Confi
 	return config
 }
 
-// Mock source for testing
+// Mock source for testing. Use setValues to change the values while a
+// watcher or signal handler may be loading concurrently.
 type mockSource struct {
+	mu       sync.Mutex
 	name     string
 	priority int
 	values   map[string]interface{}
 }
 
+// setValues replaces the values returned by Load
+func (m *mockSource) setValues(values map[string]interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.values = values
+}
+
 func (m *mockSource) Name() string {
 	if m.name != "" {
 		return m.name
@@ -1055,6 +2653,9 @@ func (m *mockSource) Priority() int {
 }
 
 func (m *mockSource) Load(ctx context.Context) (map[string]interface{}, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	result := make(map[string]interface{})
 	for k, v := range m.values {
 		result[k] = v
@@ -1108,6 +2709,71 @@ func (m *mockWatchableSource) TriggerChange(values map[string]interface{}) {
 	}
 }
 
+// Mock watchable source that counts loads and signals when watched
+type mockCountingWatchableSource struct {
+	mockSource
+	mu       sync.Mutex
+	loads    int
+	callback func(map[string]interface{})
+	watching chan struct{}
+
+	// gate, if set, blocks Load until closed; loading is signalled first
+	gate    chan struct{}
+	loading chan struct{}
+}
+
+func newMockCountingWatchableSource(values map[string]interface{}) *mockCountingWatchableSource {
+	return &mockCountingWatchableSource{
+		mockSource: mockSource{name: "counting", priority: 50, values: values},
+		watching:   make(chan struct{}),
+	}
+}
+
+func (m *mockCountingWatchableSource) Load(ctx context.Context) (map[string]interface{}, error) {
+	m.mu.Lock()
+	m.loads++
+	gate, loading := m.gate, m.loading
+	m.mu.Unlock()
+	if gate != nil {
+		select {
+		case loading <- struct{}{}:
+		default:
+		}
+		<-gate
+	}
+	return m.mockSource.Load(ctx)
+}
+
+func (m *mockCountingWatchableSource) SetGate(gate, loading chan struct{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.gate = gate
+	m.loading = loading
+}
+
+func (m *mockCountingWatchableSource) Watch(ctx context.Context, callback func(map[string]interface{})) error {
+	m.mu.Lock()
+	m.callback = callback
+	m.mu.Unlock()
+	close(m.watching)
+	return nil
+}
+
+func (m *mockCountingWatchableSource) TriggerChange() {
+	m.mu.Lock()
+	callback := m.callback
+	m.mu.Unlock()
+	if callback != nil {
+		callback(nil)
+	}
+}
+
+func (m *mockCountingWatchableSource) LoadCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.loads
+}
+
 // Mock watcher for testing
 type mockWatcher struct {
 	changes chan map[string]ConfigChange
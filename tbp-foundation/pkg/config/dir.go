@@ -0,0 +1,290 @@
+// File: dir.go
+// Title: Directory-Based Configuration Source for TBP
+// Description: Implements a configuration source for mounted Kubernetes
+//              ConfigMaps and Secrets, where every file in a directory holds
+//              one value and the filename is the key. Follows the atomic
+//              symlink swap Kubernetes performs on updates when watching.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.1
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial directory source with fsnotify watching
+// - 2026-10-16 v0.1.1: Log watcher errors via core.ContextLogger instead of stdout
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/msto63/tbp/tbp-foundation/pkg/core"
+)
+
+// DirSource implements the Source interface for directories containing one
+// file per configuration key, as mounted from Kubernetes ConfigMaps and
+// Secrets. Hidden entries (such as the ..data symlink and timestamped
+// directories managed by Kubernetes) and subdirectories are ignored.
+type DirSource struct {
+	// mu protects concurrent access to directory source data
+	mu sync.RWMutex
+
+	// path is the directory to load configuration from
+	path string
+
+	// prefix is prepended to every key
+	prefix string
+
+	// separator in filenames is translated into the key path separator
+	separator string
+
+	// optional indicates whether the directory is optional (no error if missing)
+	optional bool
+
+	// watchEnabled indicates whether directory watching is enabled
+	watchEnabled bool
+
+	// values stores the loaded configuration values
+	values map[string]interface{}
+
+	// callbacks stores registered change callbacks
+	callbacks []func(map[string]interface{})
+
+	// watcher is the active fsnotify watcher, if any
+	watcher *fsnotify.Watcher
+
+	// stopWatching is used to stop the directory watcher
+	stopWatching chan struct{}
+
+	// stopOnce ensures stopWatching is closed only once
+	stopOnce sync.Once
+
+	// priority sets the source priority for merging
+	priority int
+}
+
+// DirSourceOptions configures directory source creation
+type DirSourceOptions struct {
+	Path         string `json:"path"`
+	Prefix       string `json:"prefix"`        // key prefix, e.g. "app" turns file "port" into "app.port"
+	Separator    string `json:"separator"`     // filename separator for nested keys, e.g. "__" turns "db__host" into "db.host"
+	Optional     bool   `json:"optional"`      // true if directory is optional
+	WatchEnabled bool   `json:"watch_enabled"` // true to enable directory watching
+	Priority     int    `json:"priority"`      // source priority (default: 50)
+}
+
+// NewDirSource creates a new directory-based configuration source
+func NewDirSource(opts DirSourceOptions) (*DirSource, error) {
+	if opts.Path == "" {
+		return nil, core.New("directory path is required")
+	}
+
+	// Set default priority if not specified
+	if opts.Priority == 0 {
+		opts.Priority = 50 // Medium priority by default
+	}
+
+	return &DirSource{
+		path:         opts.Path,
+		prefix:       strings.TrimSuffix(opts.Prefix, "."),
+		separator:    opts.Separator,
+		optional:     opts.Optional,
+		watchEnabled: opts.WatchEnabled,
+		priority:     opts.Priority,
+		values:       make(map[string]interface{}),
+		callbacks:    make([]func(map[string]interface{}), 0),
+		stopWatching: make(chan struct{}),
+	}, nil
+}
+
+// Name implements the Source interface
+func (ds *DirSource) Name() string {
+	return fmt.Sprintf("dir:%s", ds.path)
+}
+
+// Priority implements the Source interface
+func (ds *DirSource) Priority() int {
+	return ds.priority
+}
+
+// Load implements the Source interface
+func (ds *DirSource) Load(ctx context.Context) (map[string]interface{}, error) {
+	values, err := ds.readDir()
+	if err != nil {
+		return nil, err
+	}
+
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	ds.values = values
+	return ds.copyValues(), nil
+}
+
+// readDir reads all value files of the directory
+func (ds *DirSource) readDir() (map[string]interface{}, error) {
+	values := make(map[string]interface{})
+
+	entries, err := os.ReadDir(ds.path)
+	if err != nil {
+		if os.IsNotExist(err) && ds.optional {
+			return values, nil
+		}
+		return nil, core.Wrapf(err, "failed to read configuration directory %s", ds.path)
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, ".") {
+			continue // Hidden files and Kubernetes ..data bookkeeping
+		}
+
+		// Stat follows the symlinks Kubernetes creates for each key
+		filePath := filepath.Join(ds.path, name)
+		info, err := os.Stat(filePath)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue // Dangling symlink during an update swap
+			}
+			return nil, core.Wrapf(err, "failed to access configuration file %s", filePath)
+		}
+		if info.IsDir() {
+			continue
+		}
+
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, core.Wrapf(err, "failed to read configuration file %s", filePath)
+		}
+
+		values[ds.keyFor(name)] = autoConvertString(strings.TrimSpace(string(content)))
+	}
+
+	return values, nil
+}
+
+// keyFor converts a filename into a configuration key
+func (ds *DirSource) keyFor(filename string) string {
+	key := filename
+	if ds.separator != "" {
+		key = strings.ReplaceAll(key, ds.separator, ".")
+	}
+	if ds.prefix != "" {
+		key = ds.prefix + "." + key
+	}
+	return key
+}
+
+// Watch implements the WatchableSource interface.
+// The directory itself is watched, so the atomic ..data symlink swap
+// Kubernetes performs on updates triggers a reload.
+func (ds *DirSource) Watch(ctx context.Context, callback func(map[string]interface{})) error {
+	if !ds.watchEnabled {
+		return nil // Watching is disabled
+	}
+
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	ds.callbacks = append(ds.callbacks, callback)
+	if ds.watcher != nil {
+		return nil // Already watching
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return core.Wrap(err, "failed to create directory watcher")
+	}
+	if err := watcher.Add(ds.path); err != nil {
+		watcher.Close()
+		return core.Wrapf(err, "failed to watch configuration directory %s", ds.path)
+	}
+	ds.watcher = watcher
+
+	go ds.watchDir(ctx, watcher)
+
+	return nil
+}
+
+// watchDir reloads the directory on file system events and notifies
+// callbacks when the values changed
+func (ds *DirSource) watchDir(ctx context.Context, watcher *fsnotify.Watcher) {
+	defer watcher.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ds.stopWatching:
+			return
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			core.ContextLogger(ctx).Error("failed to watch configuration directory",
+				"path", ds.path, "error", err)
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			ds.reload(ctx)
+		}
+	}
+}
+
+// reload re-reads the directory and notifies callbacks of changed values
+func (ds *DirSource) reload(ctx context.Context) {
+	values, err := ds.readDir()
+	if err != nil {
+		core.ContextLogger(ctx).Error("failed to reload configuration directory",
+			"path", ds.path, "error", err)
+		return
+	}
+
+	ds.mu.Lock()
+	if reflect.DeepEqual(values, ds.values) {
+		ds.mu.Unlock()
+		return
+	}
+	ds.values = values
+	callbacks := make([]func(map[string]interface{}), len(ds.callbacks))
+	copy(callbacks, ds.callbacks)
+	snapshot := ds.copyValues()
+	ds.mu.Unlock()
+
+	for _, callback := range callbacks {
+		cb := callback
+		core.SafeGo(ctx, func(context.Context) error {
+			cb(snapshot)
+			return nil
+		}, func(err error) {
+			core.ContextLogger(ctx).Error("directory watcher callback failed",
+				"path", ds.path, "error", err)
+		})
+	}
+}
+
+// Stop stops watching the directory
+func (ds *DirSource) Stop() {
+	ds.stopOnce.Do(func() { close(ds.stopWatching) })
+}
+
+// copyValues returns a copy of the current values to prevent external modification
+func (ds *DirSource) copyValues() map[string]interface{} {
+	result := make(map[string]interface{})
+	for key, value := range ds.values {
+		result[key] = value
+	}
+	return result
+}
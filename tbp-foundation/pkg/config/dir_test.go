@@ -0,0 +1,205 @@
+// File: dir_test.go
+// Title: Tests for Directory-Based Configuration Source
+// Description: Test suite for the directory source covering key mapping,
+//              type conversion, prefixes, nested key separators, ignored
+//              entries, the Kubernetes symlink layout, and watching updates.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeDirFiles writes one file per key into dir
+func writeDirFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+}
+
+// createConfigMapDir creates the layout Kubernetes uses for mounted
+// ConfigMaps: a timestamped data directory, a ..data symlink pointing to
+// it, and one symlink per key pointing into ..data
+func createConfigMapDir(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	dataDir := filepath.Join(dir, "..2026_10_16_00_00_00.000000001")
+	require.NoError(t, os.Mkdir(dataDir, 0755))
+	writeDirFiles(t, dataDir, files)
+
+	require.NoError(t, os.Symlink(filepath.Base(dataDir), filepath.Join(dir, "..data")))
+	for name := range files {
+		require.NoError(t, os.Symlink(filepath.Join("..data", name), filepath.Join(dir, name)))
+	}
+	return dir
+}
+
+// swapConfigMapDir performs the atomic update Kubernetes does for mounted
+// ConfigMaps by writing a new data directory and renaming a new ..data link
+func swapConfigMapDir(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+
+	dataDir := filepath.Join(dir, "..2026_10_16_00_00_00.000000002")
+	require.NoError(t, os.Mkdir(dataDir, 0755))
+	writeDirFiles(t, dataDir, files)
+
+	tmpLink := filepath.Join(dir, "..data_tmp")
+	require.NoError(t, os.Symlink(filepath.Base(dataDir), tmpLink))
+	require.NoError(t, os.Rename(tmpLink, filepath.Join(dir, "..data")))
+}
+
+func TestNewDirSource(t *testing.T) {
+	t.Run("requires path", func(t *testing.T) {
+		_, err := NewDirSource(DirSourceOptions{})
+		assert.Error(t, err)
+	})
+
+	t.Run("applies defaults", func(t *testing.T) {
+		source, err := NewDirSource(DirSourceOptions{Path: "/etc/config"})
+		require.NoError(t, err)
+		assert.Equal(t, "dir:/etc/config", source.Name())
+		assert.Equal(t, 50, source.Priority())
+	})
+}
+
+func TestDirSource_Load(t *testing.T) {
+	t.Run("uses filenames as keys with converted values", func(t *testing.T) {
+		dir := t.TempDir()
+		writeDirFiles(t, dir, map[string]string{
+			"host":    "db.example.com\n",
+			"port":    "5432\n",
+			"debug":   " true ",
+			"ratio":   "0.5",
+			".hidden": "ignored",
+		})
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "subdir"), 0755))
+
+		source, err := NewDirSource(DirSourceOptions{Path: dir})
+		require.NoError(t, err)
+
+		values, err := source.Load(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"host":  "db.example.com",
+			"port":  5432,
+			"debug": true,
+			"ratio": 0.5,
+		}, values)
+	})
+
+	t.Run("applies prefix and nested key separator", func(t *testing.T) {
+		dir := t.TempDir()
+		writeDirFiles(t, dir, map[string]string{
+			"database__host": "localhost",
+			"database__port": "5432",
+			"name":           "api",
+		})
+
+		source, err := NewDirSource(DirSourceOptions{Path: dir, Prefix: "app", Separator: "__"})
+		require.NoError(t, err)
+
+		values, err := source.Load(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "localhost", values["app.database.host"])
+		assert.Equal(t, 5432, values["app.database.port"])
+		assert.Equal(t, "api", values["app.name"])
+	})
+
+	t.Run("reads kubernetes symlink layout", func(t *testing.T) {
+		dir := createConfigMapDir(t, map[string]string{"password": "s3cret\n", "port": "8080"})
+
+		source, err := NewDirSource(DirSourceOptions{Path: dir})
+		require.NoError(t, err)
+
+		values, err := source.Load(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"password": "s3cret", "port": 8080}, values)
+	})
+
+	t.Run("handles missing directory", func(t *testing.T) {
+		missing := filepath.Join(t.TempDir(), "missing")
+
+		optional, err := NewDirSource(DirSourceOptions{Path: missing, Optional: true})
+		require.NoError(t, err)
+		values, err := optional.Load(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, values)
+
+		required, err := NewDirSource(DirSourceOptions{Path: missing})
+		require.NoError(t, err)
+		_, err = required.Load(context.Background())
+		assert.Error(t, err)
+	})
+
+	t.Run("integrates with config", func(t *testing.T) {
+		dir := t.TempDir()
+		writeDirFiles(t, dir, map[string]string{"server__port": "9090"})
+
+		source, err := NewDirSource(DirSourceOptions{Path: dir, Separator: "__"})
+		require.NoError(t, err)
+
+		config, err := New(context.Background(), LoadOptions{Environment: "test", Sources: []Source{source}})
+		require.NoError(t, err)
+
+		port, err := config.GetInt("server.port")
+		require.NoError(t, err)
+		assert.Equal(t, 9090, port)
+	})
+}
+
+func TestDirSource_Watch(t *testing.T) {
+	t.Run("follows kubernetes symlink swap", func(t *testing.T) {
+		dir := createConfigMapDir(t, map[string]string{"level": "info"})
+
+		source, err := NewDirSource(DirSourceOptions{Path: dir, WatchEnabled: true})
+		require.NoError(t, err)
+		defer source.Stop()
+
+		_, err = source.Load(context.Background())
+		require.NoError(t, err)
+
+		updates := make(chan map[string]interface{}, 10)
+		require.NoError(t, source.Watch(context.Background(), func(values map[string]interface{}) {
+			updates <- values
+		}))
+
+		swapConfigMapDir(t, dir, map[string]string{"level": "debug"})
+
+		select {
+		case values := <-updates:
+			assert.Equal(t, "debug", values["level"])
+		case <-time.After(2 * time.Second):
+			t.Fatal("expected update after symlink swap")
+		}
+	})
+
+	t.Run("does nothing when watching is disabled", func(t *testing.T) {
+		source, err := NewDirSource(DirSourceOptions{Path: t.TempDir()})
+		require.NoError(t, err)
+		assert.NoError(t, source.Watch(context.Background(), func(map[string]interface{}) {}))
+		assert.Nil(t, source.watcher)
+	})
+
+	t.Run("stop is idempotent", func(t *testing.T) {
+		source, err := NewDirSource(DirSourceOptions{Path: t.TempDir()})
+		require.NoError(t, err)
+		source.Stop()
+		source.Stop()
+	})
+}
@@ -0,0 +1,217 @@
+// File: encrypt.go
+// Title: Encryption at Rest for Sensitive Configuration Values
+// Description: Provides AES-GCM encryption of individual configuration
+//              values using the "enc:" prefix, so that files containing
+//              secrets can be committed to version control. File sources
+//              decrypt such values on load and re-encrypt sensitive keys
+//              on write.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.1
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial AES-GCM value encryption with enc: prefix
+// - 2026-10-16 v0.1.1: Match sensitive keys against flattened values on write
+
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/msto63/tbp/tbp-foundation/pkg/core"
+)
+
+// EncryptedValuePrefix marks encrypted configuration values
+const EncryptedValuePrefix = "enc:"
+
+// ErrCodeDecryptionFailed is the error code for values that cannot be
+// decrypted, either because no or a wrong key is configured or because the
+// payload is corrupt. Error messages never contain the payload.
+const ErrCodeDecryptionFailed = "DECRYPTION_FAILED"
+
+// EncryptValue encrypts plaintext with AES-GCM and returns it as
+// "enc:<base64(nonce|ciphertext)>". The key must be 16, 24, or 32 bytes
+// long to select AES-128, AES-192, or AES-256.
+func EncryptValue(plaintext string, key []byte) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", core.Wrap(err, "failed to generate nonce")
+	}
+
+	sealed := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	return EncryptedValuePrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// DecryptValue decrypts a value produced by EncryptValue.
+// Errors have code ErrCodeDecryptionFailed and do not include the value.
+func DecryptValue(value string, key []byte) (string, error) {
+	payload, ok := strings.CutPrefix(value, EncryptedValuePrefix)
+	if !ok {
+		return "", core.Newf("value is not encrypted (missing %q prefix)", EncryptedValuePrefix).
+			WithCode(ErrCodeDecryptionFailed)
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil || len(sealed) < gcm.NonceSize() {
+		return "", core.New("encrypted value is malformed").WithCode(ErrCodeDecryptionFailed)
+	}
+
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", core.New("failed to decrypt value: wrong key or corrupted data").
+			WithCode(ErrCodeDecryptionFailed)
+	}
+	return string(plaintext), nil
+}
+
+// IsEncryptedValue reports whether value carries the encrypted value prefix
+func IsEncryptedValue(value interface{}) bool {
+	str, ok := value.(string)
+	return ok && strings.HasPrefix(str, EncryptedValuePrefix)
+}
+
+// SensitiveKeys returns the sorted keys of fields marked Sensitive and of
+// registered Secrets, suitable for FileSourceOptions.SensitiveKeys
+func (m *Metadata) SensitiveKeys() []string {
+	seen := make(map[string]bool)
+	keys := make([]string, 0)
+
+	for name, field := range m.Fields {
+		if field.Sensitive && !seen[name] {
+			seen[name] = true
+			keys = append(keys, name)
+		}
+	}
+	for name := range m.Secrets {
+		if !seen[name] {
+			seen[name] = true
+			keys = append(keys, name)
+		}
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+// newGCM creates an AES-GCM cipher for the key
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if err := validateEncryptionKey(key); err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, core.Wrap(err, "failed to create cipher").WithCode(ErrCodeDecryptionFailed)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, core.Wrap(err, "failed to create GCM").WithCode(ErrCodeDecryptionFailed)
+	}
+	return gcm, nil
+}
+
+// validateEncryptionKey checks that key has a valid AES key length
+func validateEncryptionKey(key []byte) error {
+	switch len(key) {
+	case 16, 24, 32:
+		return nil
+	case 0:
+		return core.New("no encryption key configured").WithCode(ErrCodeDecryptionFailed)
+	default:
+		return core.Newf("invalid encryption key length %d: must be 16, 24, or 32 bytes", len(key)).
+			WithCode(core.ErrCodeInvalidInput)
+	}
+}
+
+// decryptValues replaces encrypted values in place, including string
+// elements of slices, and returns the keys that held encrypted values.
+// The caller must hold the lock.
+func (fs *FileSource) decryptValues(values map[string]interface{}) (map[string]bool, error) {
+	encrypted := make(map[string]bool)
+
+	for key, value := range values {
+		switch v := value.(type) {
+		case string:
+			if !IsEncryptedValue(v) {
+				continue
+			}
+			plaintext, err := DecryptValue(v, fs.decryptionKey)
+			if err != nil {
+				return nil, core.WrapPreservingCode(err, fmt.Sprintf("failed to decrypt configuration key '%s'", key))
+			}
+			values[key] = plaintext
+			encrypted[key] = true
+
+		case []interface{}:
+			var decrypted []interface{}
+			for i, item := range v {
+				if !IsEncryptedValue(item) {
+					continue
+				}
+				if decrypted == nil {
+					decrypted = append([]interface{}{}, v...)
+				}
+				plaintext, err := DecryptValue(item.(string), fs.decryptionKey)
+				if err != nil {
+					return nil, core.WrapPreservingCode(err, fmt.Sprintf("failed to decrypt configuration key '%s' element %d", key, i))
+				}
+				decrypted[i] = plaintext
+			}
+			if decrypted != nil {
+				values[key] = decrypted
+			}
+		}
+	}
+
+	return encrypted, nil
+}
+
+// encryptSensitiveValues returns a flattened copy of values in which the
+// configured sensitive keys and the keys loaded encrypted are encrypted.
+// Nested maps are flattened first, so {"db": {"password": ...}} matches
+// the sensitive key "db.password" like the dotted form does; writeValues
+// rebuilds the nested structure. Values that are already encrypted are
+// kept. The caller must hold the lock.
+func (fs *FileSource) encryptSensitiveValues(values map[string]interface{}) (map[string]interface{}, error) {
+	result := flattenValues(values, "")
+
+	for key, value := range result {
+		if !fs.sensitiveKeys[key] && !fs.encryptedKeys[key] {
+			continue
+		}
+		if value == nil || IsEncryptedValue(value) {
+			continue
+		}
+
+		plaintext, ok := value.(string)
+		if !ok {
+			plaintext = fmt.Sprintf("%v", value)
+		}
+		encrypted, err := EncryptValue(plaintext, fs.decryptionKey)
+		if err != nil {
+			return nil, core.Wrapf(err, "failed to encrypt sensitive configuration key '%s'", key)
+		}
+		result[key] = encrypted
+	}
+
+	return result, nil
+}
@@ -0,0 +1,250 @@
+// File: encrypt_test.go
+// Title: Tests for Configuration Value Encryption
+// Description: Tests AES-GCM value encryption helpers and the decryption
+//              and re-encryption of sensitive values by file sources.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.1
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial tests for value encryption
+// - 2026-10-16 v0.1.1: Added nested sensitive value write test
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/msto63/tbp/tbp-foundation/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testEncryptionKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestEncryptValue(t *testing.T) {
+	t.Run("round trips plaintext", func(t *testing.T) {
+		encrypted, err := EncryptValue("s3cret", testEncryptionKey)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(encrypted, EncryptedValuePrefix))
+		assert.NotContains(t, encrypted, "s3cret")
+
+		decrypted, err := DecryptValue(encrypted, testEncryptionKey)
+		require.NoError(t, err)
+		assert.Equal(t, "s3cret", decrypted)
+	})
+
+	t.Run("uses a random nonce", func(t *testing.T) {
+		first, err := EncryptValue("same", testEncryptionKey)
+		require.NoError(t, err)
+		second, err := EncryptValue("same", testEncryptionKey)
+		require.NoError(t, err)
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("rejects invalid key length", func(t *testing.T) {
+		_, err := EncryptValue("value", []byte("short"))
+		require.Error(t, err)
+		assert.True(t, core.IsCode(err, core.ErrCodeInvalidInput))
+	})
+
+	t.Run("decryption errors do not leak ciphertext", func(t *testing.T) {
+		encrypted, err := EncryptValue("value", testEncryptionKey)
+		require.NoError(t, err)
+
+		_, err = DecryptValue(encrypted, []byte("fedcba9876543210fedcba9876543210"))
+		require.Error(t, err)
+		assert.True(t, core.IsCode(err, ErrCodeDecryptionFailed))
+		assert.NotContains(t, err.Error(), strings.TrimPrefix(encrypted, EncryptedValuePrefix))
+
+		_, err = DecryptValue("enc:not-base64!", testEncryptionKey)
+		require.Error(t, err)
+		assert.True(t, core.IsCode(err, ErrCodeDecryptionFailed))
+		assert.NotContains(t, err.Error(), "not-base64")
+
+		_, err = DecryptValue("plain", testEncryptionKey)
+		assert.True(t, core.IsCode(err, ErrCodeDecryptionFailed))
+	})
+}
+
+func TestFileSource_EncryptedValues(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("decrypts values on load", func(t *testing.T) {
+		password, err := EncryptValue("hunter2", testEncryptionKey)
+		require.NoError(t, err)
+		token, err := EncryptValue("abc", testEncryptionKey)
+		require.NoError(t, err)
+
+		path := createTempFile(t, "config.json",
+			`{"database": {"host": "localhost", "password": "`+password+`"}, "tokens": ["plain", "`+token+`"]}`)
+
+		fs, err := NewFileSource(FileSourceOptions{Path: path, DecryptionKey: testEncryptionKey})
+		require.NoError(t, err)
+
+		values, err := fs.Load(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "localhost", values["database.host"])
+		assert.Equal(t, "hunter2", values["database.password"])
+		assert.Equal(t, []interface{}{"plain", "abc"}, values["tokens"])
+	})
+
+	t.Run("fails without key", func(t *testing.T) {
+		password, err := EncryptValue("hunter2", testEncryptionKey)
+		require.NoError(t, err)
+		path := createTempFile(t, "config.json", `{"password": "`+password+`"}`)
+
+		fs, err := NewFileSource(FileSourceOptions{Path: path})
+		require.NoError(t, err)
+
+		_, err = fs.Load(ctx)
+		require.Error(t, err)
+		assert.True(t, core.IsCode(err, ErrCodeDecryptionFailed))
+		assert.Contains(t, err.Error(), "password")
+		assert.NotContains(t, err.Error(), strings.TrimPrefix(password, EncryptedValuePrefix))
+	})
+
+	t.Run("rejects invalid key", func(t *testing.T) {
+		_, err := NewFileSource(FileSourceOptions{Path: "config.json", DecryptionKey: []byte("short")})
+		assert.Error(t, err)
+	})
+
+	t.Run("decrypts values from reader", func(t *testing.T) {
+		password, err := EncryptValue("hunter2", testEncryptionKey)
+		require.NoError(t, err)
+
+		fs, err := NewFileSource(FileSourceOptions{Path: "config.json", DecryptionKey: testEncryptionKey})
+		require.NoError(t, err)
+
+		values, err := fs.LoadFromReader(bytes.NewBufferString(`{"password": "`+password+`"}`), "json")
+		require.NoError(t, err)
+		assert.Equal(t, "hunter2", values["password"])
+	})
+
+	t.Run("round trips sensitive values through write and load", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		metadata := &Metadata{Fields: map[string]Field{
+			"database.password": {Name: "database.password", Sensitive: true},
+			"database.host":     {Name: "database.host"},
+		}}
+
+		fs, err := NewFileSource(FileSourceOptions{
+			Path:          path,
+			DecryptionKey: testEncryptionKey,
+			SensitiveKeys: metadata.SensitiveKeys(),
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, fs.WriteConfig(map[string]interface{}{
+			"database.host":     "localhost",
+			"database.password": "hunter2",
+		}))
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.NotContains(t, string(content), "hunter2")
+		assert.Contains(t, string(content), EncryptedValuePrefix)
+		assert.Contains(t, string(content), "localhost")
+
+		values, err := fs.Load(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "hunter2", values["database.password"])
+		assert.Equal(t, "localhost", values["database.host"])
+	})
+
+	t.Run("encrypts sensitive values in nested maps", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		fs, err := NewFileSource(FileSourceOptions{
+			Path:          path,
+			DecryptionKey: testEncryptionKey,
+			SensitiveKeys: []string{"db.password"},
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, fs.WriteConfig(map[string]interface{}{
+			"db": map[string]interface{}{
+				"host":     "localhost",
+				"password": "hunter2",
+			},
+		}))
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.NotContains(t, string(content), "hunter2")
+		assert.Contains(t, string(content), EncryptedValuePrefix)
+		assert.Contains(t, string(content), "localhost")
+
+		values, err := fs.Load(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "hunter2", values["db.password"])
+		assert.Equal(t, "localhost", values["db.host"])
+	})
+
+	t.Run("re-encrypts values loaded encrypted", func(t *testing.T) {
+		token, err := EncryptValue("abc", testEncryptionKey)
+		require.NoError(t, err)
+		path := createTempFile(t, "config.json", `{"api_token": "`+token+`", "name": "app"}`)
+
+		fs, err := NewFileSource(FileSourceOptions{Path: path, DecryptionKey: testEncryptionKey})
+		require.NoError(t, err)
+
+		values, err := fs.Load(ctx)
+		require.NoError(t, err)
+		values["name"] = "renamed"
+		require.NoError(t, fs.WriteConfig(values))
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.NotContains(t, string(content), `"abc"`)
+		assert.Contains(t, string(content), "renamed")
+	})
+
+	t.Run("merge keeps encrypted values", func(t *testing.T) {
+		token, err := EncryptValue("abc", testEncryptionKey)
+		require.NoError(t, err)
+		path := createTempFile(t, "config.json", `{"api_token": "`+token+`", "name": "app"}`)
+
+		fs, err := NewFileSource(FileSourceOptions{
+			Path:          path,
+			DecryptionKey: testEncryptionKey,
+			WriteMode:     WriteModeMerge,
+		})
+		require.NoError(t, err)
+		require.NoError(t, fs.WriteConfig(map[string]interface{}{"name": "renamed"}))
+
+		values, err := fs.Load(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "abc", values["api_token"])
+		assert.Equal(t, "renamed", values["name"])
+	})
+
+	t.Run("sensitive write without key fails", func(t *testing.T) {
+		fs, err := NewFileSource(FileSourceOptions{
+			Path:          filepath.Join(t.TempDir(), "config.json"),
+			SensitiveKeys: []string{"password"},
+		})
+		require.NoError(t, err)
+
+		err = fs.WriteConfig(map[string]interface{}{"password": "hunter2"})
+		assert.Error(t, err)
+	})
+}
+
+func TestMetadata_SensitiveKeys(t *testing.T) {
+	metadata := &Metadata{
+		Fields: map[string]Field{
+			"b.password": {Sensitive: true},
+			"a.host":     {},
+			"a.token":    {Sensitive: true},
+		},
+		Secrets: map[string]string{"c.key": "x", "a.token": "y"},
+	}
+
+	assert.Equal(t, []string{"a.token", "b.password", "c.key"}, metadata.SensitiveKeys())
+}
@@ -5,19 +5,28 @@
 //              and validation. Supports standard environment variable patterns
 //              with automatic type detection and secure handling.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.1
+// Version: v0.1.7
 // Created: 2025-05-26
-// Modified: 2025-05-27
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-05-26 v0.1.0: Initial environment variable configuration implementation
 // - 2025-05-27 v0.1.1: Enhanced type conversions, better error handling, expanded type support
+// - 2026-10-16 v0.1.2: Extracted shared string auto-conversion helpers
+// - 2026-10-16 v0.1.3: Added optional polling watch via WatchInterval
+// - 2026-10-16 v0.1.4: Added EnvSourceOptions.TimeFormats for custom time layouts
+// - 2026-10-16 v0.1.5: Added durationslice type hint; stricter duration auto-detection
+// - 2026-10-16 v0.1.6: Added EnvSourceOptions.SecretFiles for *_FILE secret references
+// - 2026-10-16 v0.1.7: Added bytes type hint for human-readable byte sizes
 
 package config
 
 import (
 	"context"
 	"os"
+	"reflect"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -51,6 +60,27 @@ type EnvSource struct {
 
 	// priority sets the source priority for merging
 	priority int
+
+	// timeFormats contains custom time layouts tried before the defaults
+	timeFormats []string
+
+	// watchInterval is the polling interval of Watch (0 = no watching)
+	watchInterval time.Duration
+
+	// secretFiles enables reading values from files referenced by *_FILE variables
+	secretFiles bool
+
+	// sensitiveKeys contains the keys whose values were read from secret files
+	sensitiveKeys map[string]bool
+
+	// loaded indicates that values holds the result of a Load
+	loaded bool
+
+	// stopWatching is used to stop the polling watcher
+	stopWatching chan struct{}
+
+	// stopOnce ensures stopWatching is closed only once
+	stopOnce sync.Once
 }
 
 // EnvSourceOptions configures environment variable source creation
@@ -61,6 +91,17 @@ type EnvSourceOptions struct {
 	TypeHints     map[string]string `json:"type_hints"`     // Type hints for conversion
 	CaseSensitive bool              `json:"case_sensitive"` // Case-sensitive key matching
 	Priority      int               `json:"priority"`       // Source priority (default: 100)
+	WatchInterval time.Duration     `json:"watch_interval"` // Polling interval for Watch (0 = no watching)
+	TimeFormats   []string          `json:"time_formats"`   // Custom time layouts tried before the defaults
+
+	// SecretFiles enables the Docker secrets convention: a variable with
+	// the suffix "FILE" (e.g. TBP_DB_PASSWORD_FILE=/run/secrets/db_pw)
+	// holds the path of a file whose trimmed content is stored under the
+	// key of the variable without the suffix ("db.password") and reported
+	// as sensitive. If the variable without the suffix is set as well, it
+	// takes precedence and the file is not read. The content is kept as a
+	// string unless a type hint exists for the key.
+	SecretFiles bool `json:"secret_files"`
 }
 
 // NewEnvSource creates a new environment variable-based configuration source
@@ -91,6 +132,11 @@ func NewEnvSource(opts EnvSourceOptions) (*EnvSource, error) {
 		keyMapping:    opts.KeyMapping,
 		typeHints:     opts.TypeHints,
 		caseSensitive: opts.CaseSensitive,
+		watchInterval: opts.WatchInterval,
+		timeFormats:   opts.TimeFormats,
+		secretFiles:   opts.SecretFiles,
+		sensitiveKeys: make(map[string]bool),
+		stopWatching:  make(chan struct{}),
 	}
 
 	if es.keyMapping == nil {
@@ -121,6 +167,10 @@ func (es *EnvSource) Load(ctx context.Context) (map[string]interface{}, error) {
 
 	values := make(map[string]interface{})
 
+	// secretFiles maps configuration keys to the *_FILE variable naming
+	// the file that holds their value
+	secretFiles := make(map[string]secretFileRef)
+
 	// Get all environment variables
 	environ := os.Environ()
 
@@ -139,6 +189,13 @@ func (es *EnvSource) Load(ctx context.Context) (map[string]interface{}, error) {
 			continue
 		}
 
+		if baseKey, isSecretFile := es.secretFileBaseKey(envKey); isSecretFile {
+			if configKey := es.envKeyToConfigKey(baseKey); configKey != "" {
+				secretFiles[configKey] = secretFileRef{envKey: envKey, path: envValue}
+			}
+			continue
+		}
+
 		// Convert environment variable name to configuration key
 		configKey := es.envKeyToConfigKey(envKey)
 		if configKey == "" {
@@ -154,20 +211,159 @@ func (es *EnvSource) Load(ctx context.Context) (map[string]interface{}, error) {
 		values[configKey] = convertedValue
 	}
 
+	sensitiveKeys := make(map[string]bool, len(secretFiles))
+	for configKey, ref := range secretFiles {
+		if _, isSet := values[configKey]; isSet {
+			continue // The variable itself takes precedence
+		}
+
+		value, err := es.readSecretFile(configKey, ref)
+		if err != nil {
+			return nil, err
+		}
+		values[configKey] = value
+		sensitiveKeys[configKey] = true
+	}
+
 	// Cache the values
 	es.values = values
+	es.sensitiveKeys = sensitiveKeys
+	es.loaded = true
 
 	return es.copyValues(), nil
 }
 
-// Watch implements the Source interface
+// Watch implements the Source interface.
+// Environment variables rarely change at runtime, so Watch is a no-op
+// unless EnvSourceOptions.WatchInterval is set. In that case the
+// environment is re-read at every interval and callback is invoked with
+// the new values whenever they differ from the last loaded values.
+// Polling stops when ctx is cancelled or Stop is called.
 func (es *EnvSource) Watch(ctx context.Context, callback func(map[string]interface{})) error {
-	// Environment variables don't typically change during runtime,
-	// so we don't implement active watching by default
-	// This could be extended with polling if needed
+	if es.watchInterval <= 0 {
+		return nil
+	}
+
+	es.mu.RLock()
+	loaded := es.loaded
+	es.mu.RUnlock()
+	if !loaded {
+		if _, err := es.Load(ctx); err != nil {
+			return core.Wrap(err, "failed to load environment before watching")
+		}
+	}
+
+	go es.pollEnvironment(ctx, callback)
+
 	return nil
 }
 
+// pollEnvironment re-reads the environment at every watch interval and
+// notifies callback of changed values
+func (es *EnvSource) pollEnvironment(ctx context.Context, callback func(map[string]interface{})) {
+	ticker := time.NewTicker(es.watchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-es.stopWatching:
+			return
+		case <-ticker.C:
+			es.mu.RLock()
+			previous := es.copyValues()
+			es.mu.RUnlock()
+
+			values, err := es.Load(ctx)
+			if err != nil {
+				core.ContextLogger(ctx).Error("failed to reload environment variables",
+					"source", es.Name(), "error", err)
+				continue
+			}
+			if reflect.DeepEqual(previous, values) {
+				continue
+			}
+
+			core.SafeGo(ctx, func(context.Context) error {
+				callback(values)
+				return nil
+			}, func(err error) {
+				core.ContextLogger(ctx).Error("environment watcher callback failed",
+					"source", es.Name(), "error", err)
+			})
+		}
+	}
+}
+
+// Stop stops the polling watcher started by Watch.
+// Config.Close calls Stop for all sources.
+func (es *EnvSource) Stop() {
+	es.stopOnce.Do(func() { close(es.stopWatching) })
+}
+
+// SensitiveKeys implements the SensitiveSource interface.
+// Values read from secret files (see EnvSourceOptions.SecretFiles) are
+// sensitive.
+func (es *EnvSource) SensitiveKeys() []string {
+	es.mu.RLock()
+	defer es.mu.RUnlock()
+
+	keys := make([]string, 0, len(es.sensitiveKeys))
+	for key := range es.sensitiveKeys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// secretFileBaseKey returns the variable name without the secret file
+// suffix if secret files are enabled and envKey has the suffix
+func (es *EnvSource) secretFileBaseKey(envKey string) (string, bool) {
+	if !es.secretFiles {
+		return "", false
+	}
+
+	suffix := es.separator + "FILE"
+	if len(envKey) <= len(suffix) {
+		return "", false
+	}
+	name, ending := envKey[:len(envKey)-len(suffix)], envKey[len(envKey)-len(suffix):]
+	if ending == suffix || (!es.caseSensitive && strings.EqualFold(ending, suffix)) {
+		return name, true
+	}
+	return "", false
+}
+
+// secretFileRef is a file referenced by a *_FILE environment variable
+type secretFileRef struct {
+	envKey string
+	path   string
+}
+
+// readSecretFile reads the value of configKey from a referenced file
+func (es *EnvSource) readSecretFile(configKey string, ref secretFileRef) (interface{}, error) {
+	data, err := os.ReadFile(ref.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, core.WrapWithCodef(err, core.ErrCodeNotFound,
+				"secret file %s referenced by environment variable %s does not exist", ref.path, ref.envKey)
+		}
+		return nil, core.WrapWithCodef(err, core.ErrCodeInternal,
+			"failed to read secret file %s referenced by environment variable %s", ref.path, ref.envKey)
+	}
+
+	value := strings.TrimSpace(string(data))
+	if typeHint, exists := es.typeHints[configKey]; exists {
+		converted, err := es.convertByType(value, typeHint)
+		if err != nil {
+			return nil, core.Wrapf(err, "failed to convert secret file referenced by environment variable %s", ref.envKey)
+		}
+		return converted, nil
+	}
+	return value, nil
+}
+
 // matchesPrefix checks if an environment variable name matches our prefix
 func (es *EnvSource) matchesPrefix(envKey string) bool {
 	if es.caseSensitive {
@@ -311,22 +507,15 @@ func (es *EnvSource) convertByType(value, typeHint string) (interface{}, error)
 		}
 		return duration, nil
 
+	case "bytes", "bytesize":
+		return parseByteSize(value)
+
 	case "time", "timestamp":
-		// Try multiple time formats
-		timeFormats := []string{
-			time.RFC3339,
-			time.RFC3339Nano,
-			"2006-01-02T15:04:05Z",
-			"2006-01-02 15:04:05",
-			"2006-01-02",
-		}
-		
-		for _, format := range timeFormats {
-			if t, err := time.Parse(format, value); err == nil {
-				return t, nil
-			}
+		t, err := parseTimeString(value, es.timeFormats)
+		if err != nil {
+			return nil, err
 		}
-		return nil, core.Newf("failed to parse '%s' as time - supported formats: RFC3339, ISO date", value)
+		return t, nil
 
 	case "stringslice", "[]string", "strings":
 		return es.parseStringSlice(value), nil
@@ -340,20 +529,34 @@ func (es *EnvSource) convertByType(value, typeHint string) (interface{}, error)
 	case "boolslice", "[]bool", "booleans":
 		return es.parseBoolSlice(value)
 
+	case "durationslice", "[]duration", "durations":
+		return es.parseDurationSlice(value)
+
 	default:
-		return nil, core.Newf("unsupported type hint '%s' - supported types: string, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, bool, duration, time, stringslice, intslice, floatslice, boolslice", typeHint)
+		return nil, core.Newf("unsupported type hint '%s' - supported types: string, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, bool, duration, bytes, time, stringslice, intslice, floatslice, boolslice, durationslice", typeHint)
 	}
 }
 
 // autoConvertValue automatically detects and converts the value type
 func (es *EnvSource) autoConvertValue(value string) interface{} {
+	return autoConvertString(value)
+}
+
+// durationPattern matches complete Go duration literals. Values such as
+// "15minutes" or "5 m" do not match and stay strings. Values that match but
+// are meant as strings need an explicit "string" type hint.
+var durationPattern = regexp.MustCompile(`^[-+]?((\d+(\.\d*)?|\.\d+)(ns|us|µs|ms|s|m|h))+$`)
+
+// autoConvertString detects the type of a string value and converts it.
+// Shared by sources that read untyped string values (env vars, INI files).
+func autoConvertString(value string) interface{} {
 	// Empty string stays as string
 	if value == "" {
 		return value
 	}
 
 	// Try boolean first (common for environment variables)
-	if boolVal, err := es.parseBool(value); err == nil {
+	if boolVal, err := parseBoolString(value); err == nil {
 		return boolVal
 	}
 
@@ -373,11 +576,9 @@ func (es *EnvSource) autoConvertValue(value string) interface{} {
 		return floatVal
 	}
 
-	// Try duration (if it looks like a duration)
-	if strings.ContainsAny(value, "hms") || 
-	   strings.HasSuffix(value, "us") || 
-	   strings.HasSuffix(value, "ns") || 
-	   strings.HasSuffix(value, "µs") {
+	// Try duration, but only for values that consist entirely of
+	// number-unit pairs such as "30s" or "1h30m"
+	if durationPattern.MatchString(value) {
 		if duration, err := time.ParseDuration(value); err == nil {
 			return duration
 		}
@@ -385,7 +586,7 @@ func (es *EnvSource) autoConvertValue(value string) interface{} {
 
 	// Check if it's a comma-separated list
 	if strings.Contains(value, ",") && len(strings.Split(value, ",")) > 1 {
-		return es.parseStringSlice(value)
+		return splitStringSlice(value)
 	}
 
 	// Default to string
@@ -394,6 +595,11 @@ func (es *EnvSource) autoConvertValue(value string) interface{} {
 
 // parseBool parses a boolean value from various string representations
 func (es *EnvSource) parseBool(value string) (bool, error) {
+	return parseBoolString(value)
+}
+
+// parseBoolString parses a boolean value from various string representations
+func parseBoolString(value string) (bool, error) {
 	lower := strings.ToLower(strings.TrimSpace(value))
 	switch lower {
 	case "true", "yes", "1", "on", "enable", "enabled", "y", "t":
@@ -407,6 +613,11 @@ func (es *EnvSource) parseBool(value string) (bool, error) {
 
 // parseStringSlice parses a comma-separated string into a slice of strings
 func (es *EnvSource) parseStringSlice(value string) []string {
+	return splitStringSlice(value)
+}
+
+// splitStringSlice splits a comma-separated string into trimmed, non-empty parts
+func splitStringSlice(value string) []string {
 	if value == "" {
 		return []string{}
 	}
@@ -478,6 +689,31 @@ func (es *EnvSource) parseFloatSlice(value string) ([]float64, error) {
 	return result, nil
 }
 
+// parseDurationSlice parses a comma-separated string into a slice of durations
+func (es *EnvSource) parseDurationSlice(value string) ([]time.Duration, error) {
+	if value == "" {
+		return []time.Duration{}, nil
+	}
+
+	parts := strings.Split(value, ",")
+	result := make([]time.Duration, 0, len(parts))
+
+	for i, part := range parts {
+		trimmed := strings.TrimSpace(part)
+		if trimmed == "" {
+			continue // Skip empty parts
+		}
+
+		duration, err := time.ParseDuration(trimmed)
+		if err != nil {
+			return nil, core.Wrapf(err, "failed to convert '%s' (element %d) to duration in slice", trimmed, i)
+		}
+		result = append(result, duration)
+	}
+
+	return result, nil
+}
+
 // parseBoolSlice parses a comma-separated string into a slice of booleans
 func (es *EnvSource) parseBoolSlice(value string) ([]bool, error) {
 	if value == "" {
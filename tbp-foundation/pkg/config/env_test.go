@@ -5,13 +5,16 @@
 //              validation, and edge cases. Tests performance characteristics
 //              and concurrent access patterns with enhanced type support.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.1
+// Version: v0.1.4
 // Created: 2025-05-26
-// Modified: 2025-05-27
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-05-26 v0.1.0: Initial test implementation with comprehensive coverage
 // - 2025-05-27 v0.1.1: Enhanced tests for expanded type conversions and new features
+// - 2026-10-16 v0.1.2: Added polling watch tests
+// - 2026-10-16 v0.1.3: Added duration slice and detection tests
+// - 2026-10-16 v0.1.4: Added secret file tests
 
 package config
 
@@ -19,9 +22,12 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/msto63/tbp/tbp-foundation/pkg/core"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -1038,4 +1044,227 @@ func BenchmarkEnvSource_GetSupportedTypes(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		_ = envSrc.GetSupportedTypes()
 	}
-}
\ No newline at end of file
+}
+func TestEnvSource_Watch(t *testing.T) {
+	t.Run("is a no-op without watch interval", func(t *testing.T) {
+		source, err := NewEnvSource(EnvSourceOptions{Prefix: "WATCHNOOP"})
+		require.NoError(t, err)
+
+		called := make(chan struct{}, 1)
+		require.NoError(t, source.Watch(context.Background(), func(map[string]interface{}) {
+			called <- struct{}{}
+		}))
+
+		t.Setenv("WATCHNOOP_KEY", "value")
+		select {
+		case <-called:
+			t.Fatal("callback must not fire without watch interval")
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+
+	t.Run("fires callback when environment changes", func(t *testing.T) {
+		t.Setenv("WATCHPOLL_STATIC", "unchanged")
+		source, err := NewEnvSource(EnvSourceOptions{Prefix: "WATCHPOLL", WatchInterval: 10 * time.Millisecond})
+		require.NoError(t, err)
+		defer source.Stop()
+
+		_, err = source.Load(context.Background())
+		require.NoError(t, err)
+
+		updates := make(chan map[string]interface{}, 10)
+		require.NoError(t, source.Watch(context.Background(), func(values map[string]interface{}) {
+			updates <- values
+		}))
+
+		// Unchanged environment does not fire the callback
+		select {
+		case <-updates:
+			t.Fatal("callback fired without changes")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		t.Setenv("WATCHPOLL_FEATURE_ENABLED", "true")
+
+		select {
+		case values := <-updates:
+			assert.Equal(t, true, values["feature.enabled"])
+			assert.Equal(t, "unchanged", values["static"])
+		case <-time.After(time.Second):
+			t.Fatal("callback did not fire after environment change")
+		}
+	})
+
+	t.Run("stops polling on Stop and context cancellation", func(t *testing.T) {
+		for name, stop := range map[string]func(*EnvSource, context.CancelFunc){
+			"stop":   func(s *EnvSource, _ context.CancelFunc) { s.Stop() },
+			"cancel": func(_ *EnvSource, cancel context.CancelFunc) { cancel() },
+		} {
+			t.Run(name, func(t *testing.T) {
+				prefix := "WATCHSTOP" + strings.ToUpper(name)
+				source, err := NewEnvSource(EnvSourceOptions{Prefix: prefix, WatchInterval: 10 * time.Millisecond})
+				require.NoError(t, err)
+
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+
+				updates := make(chan map[string]interface{}, 10)
+				require.NoError(t, source.Watch(ctx, func(values map[string]interface{}) {
+					updates <- values
+				}))
+
+				stop(source, cancel)
+				source.Stop() // Stop is idempotent
+				time.Sleep(30 * time.Millisecond)
+
+				t.Setenv(prefix+"_KEY", "value")
+				select {
+				case <-updates:
+					t.Fatal("callback fired after watching stopped")
+				case <-time.After(50 * time.Millisecond):
+				}
+			})
+		}
+	})
+}
+
+func TestEnvSource_DurationConversion(t *testing.T) {
+	envSrc, err := NewEnvSource(EnvSourceOptions{Prefix: "TEST"})
+	require.NoError(t, err)
+
+	t.Run("converts duration slices by type hint", func(t *testing.T) {
+		for _, hint := range []string{"durationslice", "[]duration", "durations"} {
+			result, err := envSrc.convertByType("30s, 1m,5m", hint)
+			require.NoError(t, err, hint)
+			assert.Equal(t, []time.Duration{30 * time.Second, time.Minute, 5 * time.Minute}, result, hint)
+		}
+
+		result, err := envSrc.convertByType("", "durationslice")
+		require.NoError(t, err)
+		assert.Equal(t, []time.Duration{}, result)
+
+		_, err = envSrc.convertByType("30s,soon", "durationslice")
+		assert.ErrorContains(t, err, "element 1")
+	})
+
+	t.Run("auto-detects only complete duration literals", func(t *testing.T) {
+		testCases := []struct {
+			input    string
+			expected interface{}
+		}{
+			{"5m", 5 * time.Minute},
+			{"1h30m", 90 * time.Minute},
+			{"-1.5s", -1500 * time.Millisecond},
+			{"15minutes", "15minutes"},
+			{"5 m", "5 m"},
+			{"ms", "ms"},
+			{"hms", "hms"},
+			{"30s,1m,5m", []string{"30s", "1m", "5m"}},
+		}
+
+		for _, tc := range testCases {
+			assert.Equal(t, tc.expected, envSrc.autoConvertValue(tc.input), "Failed for input: %s", tc.input)
+		}
+	})
+
+	t.Run("string type hint keeps duration-like values", func(t *testing.T) {
+		result, err := envSrc.convertByType("5m", "string")
+		require.NoError(t, err)
+		assert.Equal(t, "5m", result)
+	})
+}
+
+func TestEnvSource_SecretFiles(t *testing.T) {
+	writeSecret := func(t *testing.T, content string) string {
+		path := filepath.Join(t.TempDir(), "secret")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+		return path
+	}
+
+	t.Run("reads value from referenced file", func(t *testing.T) {
+		t.Setenv("SECRETFILE_DB_PASSWORD_FILE", writeSecret(t, "s3cr3t\n"))
+		t.Setenv("SECRETFILE_DB_HOST", "localhost")
+
+		envSrc, err := NewEnvSource(EnvSourceOptions{Prefix: "SECRETFILE", SecretFiles: true})
+		require.NoError(t, err)
+
+		values, err := envSrc.Load(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "s3cr3t", values["db.password"])
+		assert.Equal(t, "localhost", values["db.host"])
+		assert.NotContains(t, values, "db.password.file")
+		assert.Equal(t, []string{"db.password"}, envSrc.SensitiveKeys())
+	})
+
+	t.Run("keeps secrets as strings unless hinted", func(t *testing.T) {
+		t.Setenv("SECRETFILE_API_PIN_FILE", writeSecret(t, "007"))
+		t.Setenv("SECRETFILE_API_PORT_FILE", writeSecret(t, "8443"))
+
+		envSrc, err := NewEnvSource(EnvSourceOptions{
+			Prefix:      "SECRETFILE",
+			SecretFiles: true,
+			TypeHints:   map[string]string{"api.port": "int"},
+		})
+		require.NoError(t, err)
+
+		values, err := envSrc.Load(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "007", values["api.pin"])
+		assert.Equal(t, 8443, values["api.port"])
+	})
+
+	t.Run("direct variable takes precedence", func(t *testing.T) {
+		t.Setenv("SECRETFILE_DB_PASSWORD", "direct")
+		t.Setenv("SECRETFILE_DB_PASSWORD_FILE", filepath.Join(t.TempDir(), "missing"))
+
+		envSrc, err := NewEnvSource(EnvSourceOptions{Prefix: "SECRETFILE", SecretFiles: true})
+		require.NoError(t, err)
+
+		values, err := envSrc.Load(context.Background())
+		require.NoError(t, err, "file is not read when the direct variable is set")
+		assert.Equal(t, "direct", values["db.password"])
+		assert.Empty(t, envSrc.SensitiveKeys())
+	})
+
+	t.Run("reports missing file", func(t *testing.T) {
+		missing := filepath.Join(t.TempDir(), "missing")
+		t.Setenv("SECRETFILE_DB_PASSWORD_FILE", missing)
+
+		envSrc, err := NewEnvSource(EnvSourceOptions{Prefix: "SECRETFILE", SecretFiles: true})
+		require.NoError(t, err)
+
+		_, err = envSrc.Load(context.Background())
+		require.Error(t, err)
+		assert.True(t, core.IsNotFound(err))
+		assert.Contains(t, err.Error(), "SECRETFILE_DB_PASSWORD_FILE")
+		assert.Contains(t, err.Error(), missing)
+	})
+
+	t.Run("is disabled by default", func(t *testing.T) {
+		t.Setenv("SECRETFILE_LOG_FILE", "/var/log/app.log")
+
+		envSrc, err := NewEnvSource(EnvSourceOptions{Prefix: "SECRETFILE"})
+		require.NoError(t, err)
+
+		values, err := envSrc.Load(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "/var/log/app.log", values["log.file"])
+		assert.Empty(t, envSrc.SensitiveKeys())
+	})
+
+	t.Run("redacts secrets in config", func(t *testing.T) {
+		t.Setenv("SECRETFILE_DB_PASSWORD_FILE", writeSecret(t, "s3cr3t"))
+
+		envSrc, err := NewEnvSource(EnvSourceOptions{Prefix: "SECRETFILE", SecretFiles: true})
+		require.NoError(t, err)
+
+		config, err := New(context.Background(), LoadOptions{Environment: "test", Sources: []Source{envSrc}})
+		require.NoError(t, err)
+		defer config.Close()
+
+		password, err := config.GetString("db.password")
+		require.NoError(t, err)
+		assert.Equal(t, "s3cr3t", password)
+		assert.NotEqual(t, "s3cr3t", config.GetAll()["db.password"])
+	})
+}
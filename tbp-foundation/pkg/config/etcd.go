@@ -0,0 +1,445 @@
+// File: etcd.go
+// Title: etcd v3 Configuration Source for TBP
+// Description: Provides a configuration source that reads all keys below a
+//              prefix from etcd v3, converts them into dotted configuration
+//              keys with automatically typed values, and streams changes
+//              through etcd's watch API. Lost watches and connections are
+//              re-established with exponential backoff.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial etcd source with native watch and reconnect
+
+package config
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/msto63/tbp/tbp-foundation/pkg/core"
+)
+
+// etcd watch event types
+const (
+	EtcdEventPut    = "PUT"
+	EtcdEventDelete = "DELETE"
+)
+
+// EtcdKeyValue is a key-value pair read from etcd
+type EtcdKeyValue struct {
+	Key         string
+	Value       []byte
+	ModRevision int64
+}
+
+// EtcdEvent is a single change reported by a watch
+type EtcdEvent struct {
+	Type        string // EtcdEventPut or EtcdEventDelete
+	Key         string
+	Value       []byte // Empty for deletes
+	ModRevision int64
+}
+
+// EtcdWatchResponse is a batch of events, or the error that ended the watch
+type EtcdWatchResponse struct {
+	Events []EtcdEvent
+	Err    error
+}
+
+// EtcdClient is the subset of the etcd v3 API used by EtcdSource.
+// The foundation does not depend on the etcd client library; services
+// implement this interface with a thin adapter around clientv3.Client
+// and pass a dial function creating it (see EtcdDialFunc).
+type EtcdClient interface {
+	// GetPrefix returns all key-value pairs below prefix and the store
+	// revision at which they were read
+	GetPrefix(ctx context.Context, prefix string) ([]EtcdKeyValue, int64, error)
+
+	// WatchPrefix streams changes below prefix from revision on. The
+	// channel is closed when the watch ends, e.g. because ctx is done or
+	// the connection was lost.
+	WatchPrefix(ctx context.Context, prefix string, revision int64) <-chan EtcdWatchResponse
+
+	// Close closes the connection
+	Close() error
+}
+
+// EtcdClientConfig holds the connection settings passed to EtcdDialFunc
+type EtcdClientConfig struct {
+	Endpoints   []string
+	TLS         *tls.Config // nil = plain connection
+	Username    string      // Empty = no authentication
+	Password    string
+	DialTimeout time.Duration
+}
+
+// EtcdDialFunc connects to etcd, typically by calling clientv3.New with
+// the settings of config. It is called again to reconnect after the
+// connection was lost.
+type EtcdDialFunc func(ctx context.Context, config EtcdClientConfig) (EtcdClient, error)
+
+// EtcdSource implements the Source and WatchableSource interfaces for keys
+// stored in etcd v3. The key /tbp/prod/database/host is loaded as
+// database.host for the prefix /tbp/prod/, and values are typed like
+// environment variables (bool, int, float, duration, list, or string).
+type EtcdSource struct {
+	// mu protects concurrent access to etcd source data
+	mu sync.RWMutex
+
+	// dial creates the client
+	dial EtcdDialFunc
+
+	// clientConfig holds the connection settings
+	clientConfig EtcdClientConfig
+
+	// client is the current connection (nil = not connected)
+	client EtcdClient
+
+	// prefix is the key prefix, e.g. /tbp/prod/
+	prefix string
+
+	// priority sets the source priority for merging
+	priority int
+
+	// reconnect configures the backoff between reconnect attempts
+	reconnect core.RetryPolicy
+
+	// values stores the loaded configuration values
+	values map[string]interface{}
+
+	// revision is the etcd revision the values reflect
+	revision int64
+
+	// stopWatching is used to stop the watch loop
+	stopWatching chan struct{}
+
+	// stopOnce ensures stopWatching is closed only once
+	stopOnce sync.Once
+}
+
+// EtcdSourceOptions configures etcd source creation
+type EtcdSourceOptions struct {
+	Endpoints   []string         `json:"endpoints"`    // etcd endpoints, e.g. https://etcd:2379 (required)
+	Prefix      string           `json:"prefix"`       // Key prefix, e.g. /tbp/prod/ (required)
+	Dial        EtcdDialFunc     `json:"-"`            // Creates the client (required)
+	TLS         *tls.Config      `json:"-"`            // TLS configuration (optional)
+	Username    string           `json:"username"`     // Username for authentication (optional)
+	Password    string           `json:"-"`            // Password for authentication (optional)
+	DialTimeout time.Duration    `json:"dial_timeout"` // Connection timeout (default: 5s)
+	Priority    int              `json:"priority"`     // Source priority (default: 75)
+	Reconnect   core.RetryPolicy `json:"reconnect"`    // Reconnect backoff (default: 500ms doubling up to 30s); MaxAttempts is ignored
+}
+
+// NewEtcdSource creates a new etcd configuration source.
+// No connection is made until Load is called.
+func NewEtcdSource(opts EtcdSourceOptions) (*EtcdSource, error) {
+	if len(opts.Endpoints) == 0 {
+		return nil, core.New("etcd endpoints are required").WithCode(core.ErrCodeInvalidInput)
+	}
+	if opts.Prefix == "" {
+		return nil, core.New("etcd key prefix is required").WithCode(core.ErrCodeInvalidInput)
+	}
+	if opts.Dial == nil {
+		return nil, core.New("etcd dial function is required").WithCode(core.ErrCodeInvalidInput)
+	}
+	if opts.Username == "" && opts.Password != "" {
+		return nil, core.New("etcd password requires a username").WithCode(core.ErrCodeInvalidInput)
+	}
+
+	if opts.DialTimeout == 0 {
+		opts.DialTimeout = 5 * time.Second
+	}
+	if opts.Priority == 0 {
+		opts.Priority = 75 // Above files, below environment variables
+	}
+	if opts.Reconnect.InitialBackoff == 0 {
+		opts.Reconnect = core.RetryPolicy{
+			InitialBackoff: 500 * time.Millisecond,
+			MaxBackoff:     30 * time.Second,
+			Multiplier:     2,
+		}
+	}
+
+	return &EtcdSource{
+		dial: opts.Dial,
+		clientConfig: EtcdClientConfig{
+			Endpoints:   append([]string(nil), opts.Endpoints...),
+			TLS:         opts.TLS,
+			Username:    opts.Username,
+			Password:    opts.Password,
+			DialTimeout: opts.DialTimeout,
+		},
+		prefix:       opts.Prefix,
+		priority:     opts.Priority,
+		reconnect:    opts.Reconnect,
+		values:       make(map[string]interface{}),
+		stopWatching: make(chan struct{}),
+	}, nil
+}
+
+// Name implements the Source interface
+func (es *EtcdSource) Name() string {
+	return "etcd:" + es.prefix
+}
+
+// Priority implements the Source interface
+func (es *EtcdSource) Priority() int {
+	return es.priority
+}
+
+// Load implements the Source interface. It connects if necessary and
+// reads all keys below the prefix.
+func (es *EtcdSource) Load(ctx context.Context) (map[string]interface{}, error) {
+	client, err := es.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	kvs, revision, err := client.GetPrefix(ctx, es.prefix)
+	if err != nil {
+		return nil, core.Wrap(err, fmt.Sprintf("failed to read etcd prefix %s", es.prefix)).
+			WithCode(core.ErrCodeUnavailable)
+	}
+
+	values := make(map[string]interface{}, len(kvs))
+	for _, kv := range kvs {
+		if key := es.configKey(kv.Key); key != "" {
+			values[key] = autoConvertString(string(kv.Value))
+		}
+	}
+
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	es.values = values
+	es.revision = revision
+	return es.copyValues(), nil
+}
+
+// Watch implements the WatchableSource interface. Changes are streamed
+// with etcd's watch API from the revision of the last Load, and callback
+// is called with all values after each batch of changes. If the watch or
+// the connection is lost, the source reconnects with backoff, reloads all
+// keys, and calls callback if they changed meanwhile.
+func (es *EtcdSource) Watch(ctx context.Context, callback func(map[string]interface{})) error {
+	go es.watchLoop(ctx, callback)
+	return nil
+}
+
+// Stop stops the watch loop started by Watch and closes the connection.
+// Safe to call multiple times.
+func (es *EtcdSource) Stop() {
+	es.stopOnce.Do(func() {
+		close(es.stopWatching)
+
+		es.mu.Lock()
+		defer es.mu.Unlock()
+		if es.client != nil {
+			_ = es.client.Close()
+			es.client = nil
+		}
+	})
+}
+
+// watchLoop watches for changes and reconnects until the context is done
+// or Stop is called
+func (es *EtcdSource) watchLoop(ctx context.Context, callback func(map[string]interface{})) {
+	attempt := 0
+	for {
+		err := es.watch(ctx, callback)
+		if es.stopped(ctx) {
+			return
+		}
+		if err != nil {
+			core.ContextLogger(ctx).Warn("etcd watch interrupted, reconnecting",
+				"source", es.Name(), "error", err)
+		}
+
+		// Reconnect with backoff until a full reload succeeds
+		for {
+			attempt++
+			select {
+			case <-ctx.Done():
+				return
+			case <-es.stopWatching:
+				return
+			case <-time.After(es.reconnect.Backoff(attempt)):
+			}
+
+			es.disconnect()
+			es.mu.RLock()
+			previous := es.values
+			es.mu.RUnlock()
+
+			values, err := es.Load(ctx)
+			if err != nil {
+				if es.stopped(ctx) {
+					return
+				}
+				core.ContextLogger(ctx).Error("failed to reconnect to etcd",
+					"source", es.Name(), "attempt", attempt, "error", err)
+				continue
+			}
+
+			attempt = 0
+			if !reflect.DeepEqual(previous, values) {
+				es.notify(ctx, callback, values)
+			}
+			break
+		}
+	}
+}
+
+// watch streams changes into the values until the watch ends. Returns nil
+// if it ended because of the context or Stop.
+func (es *EtcdSource) watch(ctx context.Context, callback func(map[string]interface{})) error {
+	client, err := es.connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	es.mu.RLock()
+	revision := es.revision
+	es.mu.RUnlock()
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	responses := client.WatchPrefix(watchCtx, es.prefix, revision+1)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-es.stopWatching:
+			return nil
+		case response, ok := <-responses:
+			if !ok {
+				return core.Newf("etcd watch on %s was closed", es.prefix).WithCode(core.ErrCodeUnavailable)
+			}
+			if response.Err != nil {
+				return core.Wrap(response.Err, fmt.Sprintf("etcd watch on %s failed", es.prefix)).
+					WithCode(core.ErrCodeUnavailable)
+			}
+			if values, changed := es.apply(response.Events); changed {
+				es.notify(ctx, callback, values)
+			}
+		}
+	}
+}
+
+// apply applies watch events to the values. Returns a copy of the values
+// and whether any configuration key changed.
+func (es *EtcdSource) apply(events []EtcdEvent) (map[string]interface{}, bool) {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	changed := false
+	for _, event := range events {
+		if event.ModRevision > es.revision {
+			es.revision = event.ModRevision
+		}
+
+		key := es.configKey(event.Key)
+		if key == "" {
+			continue
+		}
+		switch event.Type {
+		case EtcdEventPut:
+			es.values[key] = autoConvertString(string(event.Value))
+			changed = true
+		case EtcdEventDelete:
+			if _, exists := es.values[key]; exists {
+				delete(es.values, key)
+				changed = true
+			}
+		}
+	}
+	return es.copyValues(), changed
+}
+
+// notify calls callback in a separate goroutine, recovering panics
+func (es *EtcdSource) notify(ctx context.Context, callback func(map[string]interface{}), values map[string]interface{}) {
+	core.SafeGo(ctx, func(context.Context) error {
+		callback(values)
+		return nil
+	}, func(err error) {
+		core.ContextLogger(ctx).Error("etcd watcher callback failed",
+			"source", es.Name(), "error", err)
+	})
+}
+
+// connect returns the current client, dialing if not connected
+func (es *EtcdSource) connect(ctx context.Context) (EtcdClient, error) {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	if es.client != nil {
+		return es.client, nil
+	}
+	select {
+	case <-es.stopWatching:
+		return nil, core.New("etcd source is stopped").WithCode(core.ErrCodeUnavailable)
+	default:
+	}
+
+	dialCtx, cancel := context.WithTimeout(ctx, es.clientConfig.DialTimeout)
+	defer cancel()
+
+	client, err := es.dial(dialCtx, es.clientConfig)
+	if err != nil {
+		return nil, core.Wrap(err, fmt.Sprintf("failed to connect to etcd at %s",
+			strings.Join(es.clientConfig.Endpoints, ","))).WithCode(core.ErrCodeUnavailable)
+	}
+	es.client = client
+	return client, nil
+}
+
+// disconnect closes the current client, if any
+func (es *EtcdSource) disconnect() {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	if es.client != nil {
+		_ = es.client.Close()
+		es.client = nil
+	}
+}
+
+// stopped reports whether the context is done or Stop was called
+func (es *EtcdSource) stopped(ctx context.Context) bool {
+	select {
+	case <-ctx.Done():
+		return true
+	case <-es.stopWatching:
+		return true
+	default:
+		return false
+	}
+}
+
+// configKey converts an etcd key below the prefix into a dotted key.
+// Returns an empty key for keys outside the prefix and the prefix itself.
+func (es *EtcdSource) configKey(etcdKey string) string {
+	relative, ok := strings.CutPrefix(etcdKey, es.prefix)
+	if !ok {
+		return ""
+	}
+	return nameToKey(relative)
+}
+
+// copyValues returns a copy of the values map. The caller must hold the lock.
+func (es *EtcdSource) copyValues() map[string]interface{} {
+	result := make(map[string]interface{}, len(es.values))
+	for key, value := range es.values {
+		result[key] = value
+	}
+	return result
+}
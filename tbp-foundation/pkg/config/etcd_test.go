@@ -0,0 +1,413 @@
+// File: etcd_test.go
+// Title: Tests for the etcd Configuration Source
+// Description: Tests reading a key prefix through a mocked etcd client,
+//              key conversion and typing, TLS and auth options, streaming
+//              watch events, and reconnecting after lost connections.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+
+package config
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/msto63/tbp/tbp-foundation/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockEtcd is an in-memory etcd cluster. Each dial creates a client whose
+// watches receive all later puts and deletes until it is disconnected.
+type mockEtcd struct {
+	mu       sync.Mutex
+	kvs      map[string]string
+	revision int64
+	clients  []*mockEtcdClient
+	configs  []EtcdClientConfig
+	dialErrs []error // Returned by the next dials, in order
+	getErr   error
+}
+
+func newMockEtcd(kvs map[string]string) *mockEtcd {
+	m := &mockEtcd{kvs: make(map[string]string), revision: 1}
+	for key, value := range kvs {
+		m.kvs[key] = value
+	}
+	return m
+}
+
+func (m *mockEtcd) dial(_ context.Context, config EtcdClientConfig) (EtcdClient, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.configs = append(m.configs, config)
+	if len(m.dialErrs) > 0 {
+		err := m.dialErrs[0]
+		m.dialErrs = m.dialErrs[1:]
+		return nil, err
+	}
+	client := &mockEtcdClient{cluster: m}
+	m.clients = append(m.clients, client)
+	return client, nil
+}
+
+func (m *mockEtcd) put(key, value string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.revision++
+	m.kvs[key] = value
+	m.broadcast(EtcdEvent{Type: EtcdEventPut, Key: key, Value: []byte(value), ModRevision: m.revision})
+}
+
+func (m *mockEtcd) delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.revision++
+	delete(m.kvs, key)
+	m.broadcast(EtcdEvent{Type: EtcdEventDelete, Key: key, ModRevision: m.revision})
+}
+
+// putSilently changes a key without notifying watchers, as if the change
+// happened while the connection was down
+func (m *mockEtcd) putSilently(key, value string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.revision++
+	m.kvs[key] = value
+}
+
+// broadcast sends event to all watches. The caller must hold the lock.
+func (m *mockEtcd) broadcast(event EtcdEvent) {
+	for _, client := range m.clients {
+		for _, watch := range client.watches {
+			watch <- EtcdWatchResponse{Events: []EtcdEvent{event}}
+		}
+	}
+}
+
+// breakWatches ends all watches with err, or by closing them if err is nil
+func (m *mockEtcd) breakWatches(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, client := range m.clients {
+		for _, watch := range client.watches {
+			if err != nil {
+				watch <- EtcdWatchResponse{Err: err}
+			}
+			close(watch)
+		}
+		client.watches = nil
+	}
+}
+
+func (m *mockEtcd) dials() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.configs)
+}
+
+func (m *mockEtcd) watchCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	count := 0
+	for _, client := range m.clients {
+		count += len(client.watches)
+	}
+	return count
+}
+
+type mockEtcdClient struct {
+	cluster *mockEtcd
+	watches []chan EtcdWatchResponse
+	closed  bool
+}
+
+func (c *mockEtcdClient) GetPrefix(_ context.Context, prefix string) ([]EtcdKeyValue, int64, error) {
+	c.cluster.mu.Lock()
+	defer c.cluster.mu.Unlock()
+
+	if c.cluster.getErr != nil {
+		return nil, 0, c.cluster.getErr
+	}
+	var kvs []EtcdKeyValue
+	for key, value := range c.cluster.kvs {
+		if len(key) >= len(prefix) && key[:len(prefix)] == prefix {
+			kvs = append(kvs, EtcdKeyValue{Key: key, Value: []byte(value)})
+		}
+	}
+	return kvs, c.cluster.revision, nil
+}
+
+func (c *mockEtcdClient) WatchPrefix(_ context.Context, _ string, _ int64) <-chan EtcdWatchResponse {
+	c.cluster.mu.Lock()
+	defer c.cluster.mu.Unlock()
+
+	watch := make(chan EtcdWatchResponse, 16)
+	c.watches = append(c.watches, watch)
+	return watch
+}
+
+func (c *mockEtcdClient) Close() error {
+	c.cluster.mu.Lock()
+	defer c.cluster.mu.Unlock()
+
+	c.closed = true
+	for _, watch := range c.watches {
+		close(watch)
+	}
+	c.watches = nil
+	return nil
+}
+
+func TestNewEtcdSource(t *testing.T) {
+	cluster := newMockEtcd(nil)
+
+	t.Run("requires endpoints, prefix, and dial function", func(t *testing.T) {
+		_, err := NewEtcdSource(EtcdSourceOptions{Prefix: "/tbp/", Dial: cluster.dial})
+		assert.True(t, core.IsInvalidInput(err))
+
+		_, err = NewEtcdSource(EtcdSourceOptions{Endpoints: []string{"etcd:2379"}, Dial: cluster.dial})
+		assert.True(t, core.IsInvalidInput(err))
+
+		_, err = NewEtcdSource(EtcdSourceOptions{Endpoints: []string{"etcd:2379"}, Prefix: "/tbp/"})
+		assert.True(t, core.IsInvalidInput(err))
+
+		_, err = NewEtcdSource(EtcdSourceOptions{
+			Endpoints: []string{"etcd:2379"}, Prefix: "/tbp/", Dial: cluster.dial, Password: "secret",
+		})
+		assert.True(t, core.IsInvalidInput(err))
+	})
+
+	t.Run("applies defaults", func(t *testing.T) {
+		source, err := NewEtcdSource(EtcdSourceOptions{
+			Endpoints: []string{"etcd:2379"}, Prefix: "/tbp/prod/", Dial: cluster.dial,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "etcd:/tbp/prod/", source.Name())
+		assert.Equal(t, 75, source.Priority())
+		assert.Equal(t, 5*time.Second, source.clientConfig.DialTimeout)
+		assert.Equal(t, 500*time.Millisecond, source.reconnect.InitialBackoff)
+		assert.Equal(t, 0, cluster.dials(), "connects lazily")
+	})
+}
+
+func TestEtcdSource_Load(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("reads prefix and converts keys and values", func(t *testing.T) {
+		cluster := newMockEtcd(map[string]string{
+			"/tbp/prod/database/host":    "db.example.com",
+			"/tbp/prod/database/port":    "5432",
+			"/tbp/prod/features/enabled": "true",
+			"/tbp/prod/server/timeout":   "30s",
+			"/tbp/prod/":                 "ignored",
+			"/tbp/staging/database/host": "other",
+		})
+		source, err := NewEtcdSource(EtcdSourceOptions{
+			Endpoints: []string{"etcd:2379"}, Prefix: "/tbp/prod/", Dial: cluster.dial,
+		})
+		require.NoError(t, err)
+
+		values, err := source.Load(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]interface{}{
+			"database.host":    "db.example.com",
+			"database.port":    5432,
+			"features.enabled": true,
+			"server.timeout":   30 * time.Second,
+		}, values)
+	})
+
+	t.Run("passes TLS and credentials to dial", func(t *testing.T) {
+		cluster := newMockEtcd(nil)
+		tlsConfig := &tls.Config{ServerName: "etcd.internal"}
+		source, err := NewEtcdSource(EtcdSourceOptions{
+			Endpoints:   []string{"https://etcd-1:2379", "https://etcd-2:2379"},
+			Prefix:      "/tbp/",
+			Dial:        cluster.dial,
+			TLS:         tlsConfig,
+			Username:    "tbp",
+			Password:    "secret",
+			DialTimeout: time.Second,
+		})
+		require.NoError(t, err)
+
+		_, err = source.Load(ctx)
+		require.NoError(t, err)
+		_, err = source.Load(ctx)
+		require.NoError(t, err)
+
+		require.Len(t, cluster.configs, 1, "reuses the connection")
+		assert.Equal(t, EtcdClientConfig{
+			Endpoints:   []string{"https://etcd-1:2379", "https://etcd-2:2379"},
+			TLS:         tlsConfig,
+			Username:    "tbp",
+			Password:    "secret",
+			DialTimeout: time.Second,
+		}, cluster.configs[0])
+	})
+
+	t.Run("wraps client errors", func(t *testing.T) {
+		cluster := newMockEtcd(nil)
+		cluster.dialErrs = []error{errors.New("connection refused")}
+		source, err := NewEtcdSource(EtcdSourceOptions{
+			Endpoints: []string{"etcd:2379"}, Prefix: "/tbp/", Dial: cluster.dial,
+		})
+		require.NoError(t, err)
+
+		_, err = source.Load(ctx)
+		require.Error(t, err)
+		assert.True(t, core.IsUnavailable(err))
+		assert.Contains(t, err.Error(), "failed to connect to etcd at etcd:2379")
+		assert.Contains(t, err.Error(), "connection refused")
+
+		cluster.getErr = errors.New("permission denied")
+		_, err = source.Load(ctx)
+		require.Error(t, err)
+		assert.True(t, core.IsUnavailable(err))
+		assert.Contains(t, err.Error(), "failed to read etcd prefix /tbp/")
+	})
+
+	t.Run("merges into config", func(t *testing.T) {
+		cluster := newMockEtcd(map[string]string{"/tbp/app/name": "orders"})
+		source, err := NewEtcdSource(EtcdSourceOptions{
+			Endpoints: []string{"etcd:2379"}, Prefix: "/tbp/", Dial: cluster.dial,
+		})
+		require.NoError(t, err)
+
+		config, err := New(ctx, LoadOptions{Environment: "test", Sources: []Source{source}})
+		require.NoError(t, err)
+		defer config.Close()
+
+		name, err := config.GetString("app.name")
+		require.NoError(t, err)
+		assert.Equal(t, "orders", name)
+	})
+}
+
+func TestEtcdSource_Watch(t *testing.T) {
+	newWatchedSource := func(t *testing.T, cluster *mockEtcd) (*EtcdSource, <-chan map[string]interface{}) {
+		source, err := NewEtcdSource(EtcdSourceOptions{
+			Endpoints: []string{"etcd:2379"},
+			Prefix:    "/tbp/",
+			Dial:      cluster.dial,
+			Reconnect: core.RetryPolicy{InitialBackoff: 5 * time.Millisecond, MaxBackoff: 20 * time.Millisecond, Multiplier: 2},
+		})
+		require.NoError(t, err)
+		t.Cleanup(source.Stop)
+
+		_, err = source.Load(context.Background())
+		require.NoError(t, err)
+
+		updates := make(chan map[string]interface{}, 16)
+		require.NoError(t, source.Watch(context.Background(), func(values map[string]interface{}) {
+			updates <- values
+		}))
+		require.Eventually(t, func() bool { return cluster.watchCount() == 1 }, time.Second, 5*time.Millisecond)
+		return source, updates
+	}
+
+	nextUpdate := func(t *testing.T, updates <-chan map[string]interface{}) map[string]interface{} {
+		select {
+		case values := <-updates:
+			return values
+		case <-time.After(time.Second):
+			t.Fatal("no update received")
+			return nil
+		}
+	}
+
+	t.Run("streams puts and deletes", func(t *testing.T) {
+		cluster := newMockEtcd(map[string]string{"/tbp/app/name": "orders"})
+		source, updates := newWatchedSource(t, cluster)
+
+		cluster.put("/tbp/app/workers", "8")
+		assert.Equal(t, map[string]interface{}{"app.name": "orders", "app.workers": 8}, nextUpdate(t, updates))
+
+		cluster.delete("/tbp/app/name")
+		assert.Equal(t, map[string]interface{}{"app.workers": 8}, nextUpdate(t, updates))
+
+		cluster.put("/other/key", "x")
+		cluster.delete("/tbp/app/missing")
+		select {
+		case values := <-updates:
+			t.Fatalf("unexpected update %v", values)
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		source.mu.RLock()
+		defer source.mu.RUnlock()
+		assert.Equal(t, cluster.revision, source.revision)
+	})
+
+	t.Run("reconnects after watch failure", func(t *testing.T) {
+		cluster := newMockEtcd(map[string]string{"/tbp/app/name": "orders"})
+		_, updates := newWatchedSource(t, cluster)
+
+		cluster.putSilently("/tbp/app/name", "billing")
+		cluster.breakWatches(errors.New("lease expired"))
+
+		assert.Equal(t, map[string]interface{}{"app.name": "billing"}, nextUpdate(t, updates),
+			"reloads changes missed while disconnected")
+		require.Eventually(t, func() bool { return cluster.watchCount() == 1 }, time.Second, 5*time.Millisecond)
+		assert.Equal(t, 2, cluster.dials())
+		assert.True(t, cluster.clients[0].closed)
+
+		cluster.put("/tbp/app/name", "shipping")
+		assert.Equal(t, map[string]interface{}{"app.name": "shipping"}, nextUpdate(t, updates))
+	})
+
+	t.Run("retries dial with backoff after connection loss", func(t *testing.T) {
+		cluster := newMockEtcd(map[string]string{"/tbp/app/name": "orders"})
+		_, updates := newWatchedSource(t, cluster)
+
+		cluster.mu.Lock()
+		cluster.dialErrs = []error{errors.New("connection refused"), errors.New("connection refused")}
+		cluster.mu.Unlock()
+		cluster.breakWatches(nil)
+
+		require.Eventually(t, func() bool { return cluster.watchCount() == 1 }, time.Second, 5*time.Millisecond)
+		assert.Equal(t, 4, cluster.dials())
+		select {
+		case values := <-updates:
+			t.Fatalf("unexpected update %v without changes", values)
+		default:
+		}
+
+		cluster.put("/tbp/app/name", "billing")
+		assert.Equal(t, map[string]interface{}{"app.name": "billing"}, nextUpdate(t, updates))
+	})
+
+	t.Run("stops watching and closes connection", func(t *testing.T) {
+		cluster := newMockEtcd(nil)
+		source, _ := newWatchedSource(t, cluster)
+
+		source.Stop()
+		source.Stop()
+
+		require.Eventually(t, func() bool { return cluster.watchCount() == 0 }, time.Second, 5*time.Millisecond)
+		assert.True(t, cluster.clients[0].closed)
+		time.Sleep(30 * time.Millisecond)
+		assert.Equal(t, 1, cluster.dials(), "does not reconnect after Stop")
+
+		_, err := source.Load(context.Background())
+		assert.True(t, core.IsUnavailable(err))
+	})
+}
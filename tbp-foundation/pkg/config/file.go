@@ -1,17 +1,26 @@
 // File: file.go
 // Title: File-based Configuration for TBP
 // Description: Provides file-based configuration loading with support for TOML,
-//              YAML, and JSON formats. Includes file watching for hot-reload,
+//              YAML, JSON, and INI formats. Includes file watching for hot-reload,
 //              environment variable substitution, and hierarchical configuration
 //              merging with validation and error handling.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.1
+// Version: v0.1.10
 // Created: 2025-05-26
-// Modified: 2025-05-27
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-05-26 v0.1.0: Initial file-based configuration implementation
 // - 2025-05-27 v0.1.1: Fixed array handling, race conditions, and YAML support
+// - 2026-10-16 v0.1.2: Added INI/properties format support
+// - 2026-10-16 v0.1.3: Left dotted ${key.path} references for post-merge interpolation
+// - 2026-10-16 v0.1.4: Recover file watcher callback panics via core.SafeGo
+// - 2026-10-16 v0.1.5: Log watcher errors via core.ContextLogger instead of stdout
+// - 2026-10-16 v0.1.6: Added WriteConfigMerge and merge write mode
+// - 2026-10-16 v0.1.7: Added decryption of enc: values and encryption of sensitive keys on write
+// - 2026-10-16 v0.1.8: Extracted flattenValues for reuse by other sources
+// - 2026-10-16 v0.1.9: Made Stop idempotent, added Close, and shared one watcher goroutine
+// - 2026-10-16 v0.1.10: Added snapshot for restoring a failed write-back
 
 package config
 
@@ -23,6 +32,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -41,7 +51,7 @@ type FileSource struct {
 	// path is the file path to load configuration from
 	path string
 
-	// format specifies the file format (toml, yaml, json, auto)
+	// format specifies the file format (toml, yaml, json, ini, auto)
 	format string
 
 	// optional indicates whether the file is optional (no error if missing)
@@ -62,17 +72,56 @@ type FileSource struct {
 	// stopWatching is used to stop the file watcher
 	stopWatching chan struct{}
 
+	// stopOnce ensures stopWatching is closed only once
+	stopOnce sync.Once
+
+	// watchDone is closed when the watcher goroutine has exited
+	// (nil = not watching)
+	watchDone chan struct{}
+
 	// priority sets the source priority for merging
 	priority int
+
+	// writeMode controls whether WriteConfig replaces or merges the file
+	writeMode WriteMode
+
+	// decryptionKey is the AES key for values with the enc: prefix
+	decryptionKey []byte
+
+	// sensitiveKeys are encrypted by WriteConfig
+	sensitiveKeys map[string]bool
+
+	// encryptedKeys tracks keys that were encrypted in the loaded file
+	encryptedKeys map[string]bool
 }
 
+// WriteMode determines how WriteConfig treats the existing file content
+type WriteMode string
+
+const (
+	// WriteModeReplace writes only the provided values (default)
+	WriteModeReplace WriteMode = "replace"
+
+	// WriteModeMerge overlays the provided values onto the existing file
+	WriteModeMerge WriteMode = "merge"
+)
+
 // FileSourceOptions configures file source creation
 type FileSourceOptions struct {
-	Path         string `json:"path"`
-	Format       string `json:"format"`        // toml, yaml, json, auto (default: auto)
-	Optional     bool   `json:"optional"`      // true if file is optional
-	WatchEnabled bool   `json:"watch_enabled"` // true to enable file watching
-	Priority     int    `json:"priority"`      // source priority (default: 50)
+	Path         string    `json:"path"`
+	Format       string    `json:"format"`        // toml, yaml, json, ini, auto (default: auto)
+	Optional     bool      `json:"optional"`      // true if file is optional
+	WatchEnabled bool      `json:"watch_enabled"` // true to enable file watching
+	Priority     int       `json:"priority"`      // source priority (default: 50)
+	WriteMode    WriteMode `json:"write_mode"`    // replace or merge (default: replace)
+
+	// DecryptionKey is the AES-128/192/256 key used to decrypt values with
+	// the "enc:" prefix on load and to encrypt sensitive values on write
+	DecryptionKey []byte `json:"-"`
+
+	// SensitiveKeys lists dot-notation keys that WriteConfig stores
+	// encrypted, typically Metadata.SensitiveKeys()
+	SensitiveKeys []string `json:"sensitive_keys"`
 }
 
 // NewFileSource creates a new file-based configuration source
@@ -91,7 +140,7 @@ func NewFileSource(opts FileSourceOptions) (*FileSource, error) {
 	}
 
 	// Validate format early
-	validFormats := []string{"auto", "toml", "yaml", "json"}
+	validFormats := []string{"auto", "toml", "yaml", "json", "ini"}
 	isValidFormat := false
 	for _, validFormat := range validFormats {
 		if opts.Format == validFormat {
@@ -103,15 +152,37 @@ func NewFileSource(opts FileSourceOptions) (*FileSource, error) {
 		return nil, core.Newf("unsupported configuration format: %s", opts.Format)
 	}
 
+	if opts.WriteMode == "" {
+		opts.WriteMode = WriteModeReplace
+	}
+	if opts.WriteMode != WriteModeReplace && opts.WriteMode != WriteModeMerge {
+		return nil, core.Newf("unsupported write mode: %s", opts.WriteMode)
+	}
+
+	if len(opts.DecryptionKey) > 0 {
+		if err := validateEncryptionKey(opts.DecryptionKey); err != nil {
+			return nil, err
+		}
+	}
+
+	sensitiveKeys := make(map[string]bool, len(opts.SensitiveKeys))
+	for _, key := range opts.SensitiveKeys {
+		sensitiveKeys[key] = true
+	}
+
 	fs := &FileSource{
-		path:         opts.Path,
-		format:       opts.Format,
-		optional:     opts.Optional,
-		watchEnabled: opts.WatchEnabled,
-		priority:     opts.Priority,
-		values:       make(map[string]interface{}),
-		callbacks:    make([]func(map[string]interface{}), 0),
-		stopWatching: make(chan struct{}),
+		path:          opts.Path,
+		format:        opts.Format,
+		optional:      opts.Optional,
+		watchEnabled:  opts.WatchEnabled,
+		priority:      opts.Priority,
+		values:        make(map[string]interface{}),
+		callbacks:     make([]func(map[string]interface{}), 0),
+		stopWatching:  make(chan struct{}),
+		writeMode:     opts.WriteMode,
+		decryptionKey: append([]byte(nil), opts.DecryptionKey...),
+		sensitiveKeys: sensitiveKeys,
+		encryptedKeys: make(map[string]bool),
 	}
 
 	return fs, nil
@@ -176,25 +247,41 @@ func (fs *FileSource) Load(ctx context.Context) (map[string]interface{}, error)
 	// Flatten nested structures for consistent key access
 	flatValues := fs.flattenMap(values, "")
 
+	// Decrypt values stored with the enc: prefix
+	encryptedKeys, err := fs.decryptValues(flatValues)
+	if err != nil {
+		return nil, core.WrapPreservingCode(err, fmt.Sprintf("failed to load configuration file %s", fs.path))
+	}
+
 	// Update cached values and modification time
 	fs.values = flatValues
+	fs.encryptedKeys = encryptedKeys
 	fs.lastModified = info.ModTime()
 
 	return fs.copyValues(), nil
 }
 
-// Watch implements the Source interface
+// Watch implements the Source interface. All callbacks share a single
+// watcher goroutine, which is started by the first call and runs until
+// its context is done or Stop is called. The callbacks are removed when
+// the goroutine exits, so a later Watch starts watching afresh.
 func (fs *FileSource) Watch(ctx context.Context, callback func(map[string]interface{})) error {
 	if !fs.watchEnabled {
 		return nil // Watching is disabled
 	}
 
 	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
 	fs.callbacks = append(fs.callbacks, callback)
-	fs.mu.Unlock()
+	if fs.watchDone != nil {
+		return nil // Already watching
+	}
 
 	// Start file watcher in a separate goroutine
-	go fs.watchFile(ctx)
+	done := make(chan struct{})
+	fs.watchDone = done
+	go fs.watchFile(ctx, done)
 
 	return nil
 }
@@ -209,6 +296,8 @@ func (fs *FileSource) detectFormat() string {
 		return "yaml"
 	case ".json":
 		return "json"
+	case ".ini", ".properties":
+		return "ini"
 	default:
 		// Default to TOML if extension is unknown
 		return "toml"
@@ -235,6 +324,13 @@ func (fs *FileSource) parseContent(content []byte, format string) (map[string]in
 			return nil, core.Wrap(err, "failed to parse JSON")
 		}
 
+	case "ini":
+		parsed, err := parseINI(content)
+		if err != nil {
+			return nil, core.Wrap(err, "failed to parse INI")
+		}
+		values = parsed
+
 	default:
 		return nil, core.Newf("unsupported configuration format: %s", format)
 	}
@@ -242,6 +338,164 @@ func (fs *FileSource) parseContent(content []byte, format string) (map[string]in
 	return values, nil
 }
 
+// parseINI parses INI/properties content into a nested map.
+// Sections map to key prefixes ([server] port=8080 becomes server.port),
+// both "=" and ":" separate keys from values, and lines starting with
+// ";" or "#" are comments. Unquoted values are type-converted like
+// environment variables; quoted values are kept as strings.
+// Duplicate keys and nested sections are rejected.
+func parseINI(content []byte) (map[string]interface{}, error) {
+	values := make(map[string]interface{})
+	current := values
+	section := ""
+
+	for i, rawLine := range strings.Split(string(content), "\n") {
+		lineNo := i + 1
+		line := strings.TrimSpace(rawLine)
+
+		// Skip empty lines and comments
+		if line == "" || strings.HasPrefix(line, ";") || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// Section header
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, core.Newf("line %d: malformed section header '%s'", lineNo, line)
+			}
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			if name == "" {
+				return nil, core.Newf("line %d: empty section name", lineNo)
+			}
+			if strings.ContainsAny(name, "[].") {
+				return nil, core.Newf("line %d: nested section '%s' is not supported", lineNo, name)
+			}
+
+			existing, exists := values[name]
+			if exists {
+				nested, ok := existing.(map[string]interface{})
+				if !ok {
+					return nil, core.Newf("line %d: section '%s' conflicts with key of the same name", lineNo, name)
+				}
+				current = nested
+			} else {
+				current = make(map[string]interface{})
+				values[name] = current
+			}
+			section = name
+			continue
+		}
+
+		// Key/value pair
+		idx := strings.IndexAny(line, "=:")
+		if idx <= 0 {
+			return nil, core.Newf("line %d: expected key=value, got '%s'", lineNo, line)
+		}
+		key := strings.TrimSpace(line[:idx])
+		rawValue := strings.TrimSpace(line[idx+1:])
+
+		if _, exists := current[key]; exists {
+			fullKey := key
+			if section != "" {
+				fullKey = section + "." + key
+			}
+			return nil, core.Newf("line %d: duplicate key '%s'", lineNo, fullKey)
+		}
+
+		current[key] = parseINIValue(rawValue)
+	}
+
+	return values, nil
+}
+
+// parseINIValue converts a raw INI value, keeping quoted values as strings
+func parseINIValue(rawValue string) interface{} {
+	if len(rawValue) >= 2 {
+		first, last := rawValue[0], rawValue[len(rawValue)-1]
+		if (first == '"' && last == '"') || (first == '\'' && last == '\'') {
+			return rawValue[1 : len(rawValue)-1]
+		}
+	}
+	return autoConvertString(rawValue)
+}
+
+// encodeINI serializes a nested map as INI content.
+// Top-level scalar values are written before any section; nested maps below
+// a section are written with dotted keys. Slices are written comma-separated.
+func encodeINI(values map[string]interface{}) []byte {
+	var buf strings.Builder
+
+	var rootKeys, sectionKeys []string
+	for key, value := range values {
+		if _, isMap := value.(map[string]interface{}); isMap {
+			sectionKeys = append(sectionKeys, key)
+		} else {
+			rootKeys = append(rootKeys, key)
+		}
+	}
+	sort.Strings(rootKeys)
+	sort.Strings(sectionKeys)
+
+	for _, key := range rootKeys {
+		fmt.Fprintf(&buf, "%s = %s\n", key, formatINIValue(values[key]))
+	}
+
+	for _, section := range sectionKeys {
+		if buf.Len() > 0 {
+			buf.WriteString("\n")
+		}
+		fmt.Fprintf(&buf, "[%s]\n", section)
+
+		flat := flattenINISection(values[section].(map[string]interface{}), "")
+		keys := make([]string, 0, len(flat))
+		for key := range flat {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			fmt.Fprintf(&buf, "%s = %s\n", key, formatINIValue(flat[key]))
+		}
+	}
+
+	return []byte(buf.String())
+}
+
+// flattenINISection flattens nested maps within a section into dotted keys
+func flattenINISection(data map[string]interface{}, prefix string) map[string]interface{} {
+	result := make(map[string]interface{})
+	for key, value := range data {
+		fullKey := key
+		if prefix != "" {
+			fullKey = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			for k, v := range flattenINISection(nested, fullKey) {
+				result[k] = v
+			}
+			continue
+		}
+		result[fullKey] = value
+	}
+	return result
+}
+
+// formatINIValue formats a value for INI output
+func formatINIValue(value interface{}) string {
+	switch v := value.(type) {
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = fmt.Sprintf("%v", item)
+		}
+		return strings.Join(parts, ", ")
+	case []string:
+		return strings.Join(v, ", ")
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
 // substituteEnvVars substitutes environment variables in the configuration content
 // Supports ${VAR} and ${VAR:-default} syntax
 func (fs *FileSource) substituteEnvVars(content []byte) ([]byte, error) {
@@ -261,6 +515,12 @@ func (fs *FileSource) substituteEnvVars(content []byte) ([]byte, error) {
 			varName = varSpec
 		}
 
+		// Dotted names reference configuration keys, not environment
+		// variables; they are resolved after all sources are merged
+		if strings.Contains(varName, ".") {
+			return match
+		}
+
 		// Get environment variable value
 		if value := os.Getenv(varName); value != "" {
 			return []byte(value)
@@ -279,6 +539,12 @@ func (fs *FileSource) substituteEnvVars(content []byte) ([]byte, error) {
 
 // flattenMap flattens nested maps into dot-separated keys and handles arrays with indexing
 func (fs *FileSource) flattenMap(data map[string]interface{}, prefix string) map[string]interface{} {
+	return flattenValues(data, prefix)
+}
+
+// flattenValues flattens nested maps into dot-separated keys. Arrays are
+// kept under their key and additionally exposed with indexed keys.
+func flattenValues(data map[string]interface{}, prefix string) map[string]interface{} {
 	result := make(map[string]interface{})
 
 	for key, value := range data {
@@ -290,7 +556,7 @@ func (fs *FileSource) flattenMap(data map[string]interface{}, prefix string) map
 		switch v := value.(type) {
 		case map[string]interface{}:
 			// Recursively flatten nested maps
-			for nestedKey, nestedValue := range fs.flattenMap(v, fullKey) {
+			for nestedKey, nestedValue := range flattenValues(v, fullKey) {
 				result[nestedKey] = nestedValue
 			}
 
@@ -304,7 +570,7 @@ func (fs *FileSource) flattenMap(data map[string]interface{}, prefix string) map
 
 				if nestedMap, ok := arrayItem.(map[string]interface{}); ok {
 					// Flatten nested objects in arrays
-					for nestedKey, nestedValue := range fs.flattenMap(nestedMap, indexedKey) {
+					for nestedKey, nestedValue := range flattenValues(nestedMap, indexedKey) {
 						result[nestedKey] = nestedValue
 					}
 				} else {
@@ -331,11 +597,20 @@ func (fs *FileSource) copyValues() map[string]interface{} {
 	return result
 }
 
-// watchFile monitors the configuration file for changes
-func (fs *FileSource) watchFile(ctx context.Context) {
+// watchFile monitors the configuration file for changes and closes done
+// after removing the callbacks when it exits
+func (fs *FileSource) watchFile(ctx context.Context, done chan struct{}) {
 	ticker := time.NewTicker(1 * time.Second) // Check for changes every second
 	defer ticker.Stop()
 
+	defer func() {
+		fs.mu.Lock()
+		fs.callbacks = nil
+		fs.watchDone = nil
+		fs.mu.Unlock()
+		close(done)
+	}()
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -354,7 +629,8 @@ func (fs *FileSource) checkForChanges(ctx context.Context) {
 	if err != nil {
 		if !os.IsNotExist(err) {
 			// Log error but continue watching
-			fmt.Printf("Error checking file %s: %v\n", fs.path, err)
+			core.ContextLogger(ctx).Error("failed to check configuration file",
+				"path", fs.path, "error", err)
 		}
 		return
 	}
@@ -369,7 +645,8 @@ func (fs *FileSource) checkForChanges(ctx context.Context) {
 		// File has changed, reload configuration
 		values, err := fs.Load(ctx)
 		if err != nil {
-			fmt.Printf("Error reloading configuration from %s: %v\n", fs.path, err)
+			core.ContextLogger(ctx).Error("failed to reload configuration file",
+				"path", fs.path, "error", err)
 			return
 		}
 
@@ -380,28 +657,150 @@ func (fs *FileSource) checkForChanges(ctx context.Context) {
 		fs.mu.RUnlock()
 
 		for _, callback := range callbacks {
-			go func(cb func(map[string]interface{})) {
-				defer func() {
-					if r := recover(); r != nil {
-						fmt.Printf("Panic in file watcher callback: %v\n", r)
-					}
-				}()
+			cb := callback
+			core.SafeGo(ctx, func(context.Context) error {
 				cb(values)
-			}(callback)
+				return nil
+			}, func(err error) {
+				core.ContextLogger(ctx).Error("file watcher callback failed",
+					"path", fs.path, "error", err)
+			})
 		}
 	}
 }
 
-// Stop stops the file watcher
+// Stop stops the file watcher and waits for its goroutine to exit.
+// Callbacks already running are not waited for. Safe to call multiple
+// times; watching cannot be restarted afterwards.
 func (fs *FileSource) Stop() {
-	close(fs.stopWatching)
+	fs.stopOnce.Do(func() { close(fs.stopWatching) })
+
+	fs.mu.RLock()
+	done := fs.watchDone
+	fs.mu.RUnlock()
+
+	if done != nil {
+		<-done
+	}
+}
+
+// Close implements io.Closer by stopping the file watcher. Always returns nil.
+func (fs *FileSource) Close() error {
+	fs.Stop()
+	return nil
 }
 
-// WriteConfig writes configuration values to the file
+// WriteConfig writes configuration values to the file.
+// With WriteModeReplace (default) the file contains only the given values
+// afterwards; with WriteModeMerge it behaves like WriteConfigMerge.
 func (fs *FileSource) WriteConfig(values map[string]interface{}) error {
+	if fs.writeMode == WriteModeMerge {
+		return fs.WriteConfigMerge(values)
+	}
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	return fs.writeValues(values)
+}
+
+// WriteConfigMerge overlays the given values onto the current file content
+// and writes the union back. Dotted keys are re-nested, a written key
+// replaces the whole subtree below it, and all other keys (including
+// arrays) are preserved as they are in the file. Environment variable
+// references in the file are kept unexpanded. A missing file is treated
+// as empty.
+func (fs *FileSource) WriteConfigMerge(values map[string]interface{}) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	current, err := fs.readFileValues()
+	if err != nil {
+		return err
+	}
+
+	for key, value := range fs.flattenMap(values, "") {
+		// Remove the subtree below the key and scalars that would
+		// otherwise have to become its parent sections
+		for existing, existingValue := range current {
+			if strings.HasPrefix(existing, key+".") {
+				delete(current, existing)
+				continue
+			}
+			if _, isSlice := existingValue.([]interface{}); !isSlice && strings.HasPrefix(key, existing+".") {
+				delete(current, existing)
+			}
+		}
+		current[key] = value
+	}
+
+	return fs.writeValues(current)
+}
+
+// readFileValues reads the flattened file content without substituting
+// environment variables. The caller must hold the lock.
+func (fs *FileSource) readFileValues() (map[string]interface{}, error) {
+	content, err := os.ReadFile(fs.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return make(map[string]interface{}), nil
+		}
+		return nil, core.Wrapf(err, "failed to read configuration file %s", fs.path)
+	}
+
+	format := fs.format
+	if format == "auto" {
+		format = fs.detectFormat()
+	}
+
+	values, err := fs.parseContent(content, format)
+	if err != nil {
+		return nil, core.Wrapf(err, "failed to parse configuration file %s as %s", fs.path, format)
+	}
+
+	return fs.flattenMap(values, ""), nil
+}
+
+// snapshot captures the raw file content and returns a function that
+// restores it exactly, removing the file if it did not exist. The cached
+// values are discarded on restore so that the next Load rereads the file.
+func (fs *FileSource) snapshot() (func() error, error) {
 	fs.mu.Lock()
 	defer fs.mu.Unlock()
 
+	content, err := os.ReadFile(fs.path)
+	missing := os.IsNotExist(err)
+	if err != nil && !missing {
+		return nil, core.Wrapf(err, "failed to read configuration file %s", fs.path)
+	}
+
+	return func() error {
+		fs.mu.Lock()
+		defer fs.mu.Unlock()
+
+		fs.lastModified = time.Time{}
+		if missing {
+			if err := os.Remove(fs.path); err != nil && !os.IsNotExist(err) {
+				return core.Wrapf(err, "failed to remove configuration file %s", fs.path)
+			}
+			return nil
+		}
+		if err := os.WriteFile(fs.path, content, 0644); err != nil {
+			return core.Wrapf(err, "failed to write configuration file %s", fs.path)
+		}
+		return nil
+	}, nil
+}
+
+// writeValues serializes the values in the file format and writes the
+// file. Sensitive values and values that were loaded encrypted are
+// encrypted before writing. The caller must hold the lock.
+func (fs *FileSource) writeValues(values map[string]interface{}) error {
+	values, err := fs.encryptSensitiveValues(values)
+	if err != nil {
+		return err
+	}
+
 	// Determine format for writing
 	format := fs.format
 	if format == "auto" {
@@ -413,7 +812,6 @@ func (fs *FileSource) WriteConfig(values map[string]interface{}) error {
 
 	// Serialize based on format
 	var content []byte
-	var err error
 
 	switch format {
 	case "toml":
@@ -436,6 +834,9 @@ func (fs *FileSource) WriteConfig(values map[string]interface{}) error {
 			return core.Wrap(err, "failed to encode JSON")
 		}
 
+	case "ini":
+		content = encodeINI(nestedValues)
+
 	default:
 		return core.Newf("unsupported format for writing: %s", format)
 	}
@@ -508,7 +909,7 @@ func (fs *FileSource) Validate() error {
 	}
 
 	// Validate format
-	validFormats := []string{"auto", "toml", "yaml", "json"}
+	validFormats := []string{"auto", "toml", "yaml", "json", "ini"}
 	validFormat := false
 	for _, format := range validFormats {
 		if fs.format == format {
@@ -600,6 +1001,10 @@ func (fs *FileSource) LoadFromReader(reader io.Reader, format string) (map[strin
 		return nil, core.Wrapf(err, "failed to parse content as %s", format)
 	}
 
-	// Flatten the values
-	return fs.flattenMap(values, ""), nil
+	// Flatten and decrypt the values
+	flatValues := fs.flattenMap(values, "")
+	if _, err := fs.decryptValues(flatValues); err != nil {
+		return nil, err
+	}
+	return flatValues, nil
 }
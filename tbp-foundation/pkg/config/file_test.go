@@ -1,17 +1,20 @@
 // File: file_test.go
 // Title: Tests for File-based Configuration Source
 // Description: Comprehensive test suite for file-based configuration loading
-//              including TOML, YAML, JSON parsing, file watching, environment variable
+//              including TOML, YAML, JSON, INI parsing, file watching, environment variable
 //              expansion, and error handling. Tests cover various file formats,
 //              hot-reloading scenarios, and edge cases.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.1
+// Version: v0.1.4
 // Created: 2025-05-26
-// Modified: 2025-05-27
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-05-26 v0.1.0: Initial test implementation for file-based configuration
 // - 2025-05-27 v0.1.1: Fixed tests for array indexing and YAML support
+// - 2026-10-16 v0.1.2: Added INI format tests
+// - 2026-10-16 v0.1.3: Added merge write mode tests
+// - 2026-10-16 v0.1.4: Added watcher cleanup tests
 
 package config
 
@@ -19,6 +22,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 	"time"
@@ -247,6 +251,62 @@ array_example:
 		assert.Equal(t, 8081, values["array_example.servers.1.port"])
 	})
 
+	t.Run("loads INI file successfully", func(t *testing.T) {
+		tmpFile := createTempFile(t, "config.ini", `
+; global settings
+environment = test
+debug = true
+
+[server]
+host = localhost
+port = 8080
+read_timeout = 30s
+
+# database settings
+[database]
+name: "12345"
+ratio = 0.75
+tags = primary, replica
+`)
+		defer os.Remove(tmpFile)
+
+		source, err := NewFileSource(FileSourceOptions{Path: tmpFile})
+		require.NoError(t, err)
+
+		values, err := source.Load(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, "test", values["environment"])
+		assert.Equal(t, true, values["debug"])
+		assert.Equal(t, "localhost", values["server.host"])
+		assert.Equal(t, 8080, values["server.port"])
+		assert.Equal(t, 30*time.Second, values["server.read_timeout"])
+		assert.Equal(t, "12345", values["database.name"]) // Quoted values stay strings
+		assert.Equal(t, 0.75, values["database.ratio"])
+		assert.Equal(t, []string{"primary", "replica"}, values["database.tags"])
+	})
+
+	t.Run("expands environment variables in INI file", func(t *testing.T) {
+		os.Setenv("TEST_INI_PORT", "9000")
+		defer os.Unsetenv("TEST_INI_PORT")
+
+		tmpFile := createTempFile(t, "config.ini", `
+[server]
+port = ${TEST_INI_PORT}
+host = ${TEST_INI_HOST:-example.com}
+`)
+		defer os.Remove(tmpFile)
+
+		source, err := NewFileSource(FileSourceOptions{Path: tmpFile, Format: "ini"})
+		require.NoError(t, err)
+
+		values, err := source.Load(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, 9000, values["server.port"])
+		assert.Equal(t, "example.com", values["server.host"])
+	})
+
 	t.Run("expands environment variables", func(t *testing.T) {
 		// Set test environment variable
 		os.Setenv("TEST_HOST", "example.com")
@@ -361,6 +421,34 @@ server:
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to parse YAML")
 	})
+
+	t.Run("returns error for invalid INI", func(t *testing.T) {
+		testCases := []struct {
+			name    string
+			content string
+			message string
+		}{
+			{"duplicate key", "[server]\nport = 8080\nport = 9090\n", "duplicate key 'server.port'"},
+			{"nested section", "[server.tls]\nenabled = true\n", "nested section"},
+			{"malformed section", "[server\nport = 8080\n", "malformed section header"},
+			{"missing separator", "[server]\nport\n", "expected key=value"},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				tmpFile := createTempFile(t, "invalid.ini", tc.content)
+				defer os.Remove(tmpFile)
+
+				source, err := NewFileSource(FileSourceOptions{Path: tmpFile})
+				require.NoError(t, err)
+
+				_, err = source.Load(context.Background())
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "failed to parse INI")
+				assert.Contains(t, err.Error(), tc.message)
+			})
+		}
+	})
 }
 
 func TestFileSource_FlattenMap(t *testing.T) {
@@ -528,6 +616,93 @@ new_key = "new_value"
 		// Should not return error, just do nothing
 		assert.NoError(t, err)
 	})
+
+	newWatchedSource := func(t *testing.T) *FileSource {
+		tmpFile := createTempFile(t, "config.toml", `environment = "test"`)
+		t.Cleanup(func() { os.Remove(tmpFile) })
+
+		source, err := NewFileSource(FileSourceOptions{
+			Path:         tmpFile,
+			Format:       "toml",
+			WatchEnabled: true,
+		})
+		require.NoError(t, err)
+		_, err = source.Load(context.Background())
+		require.NoError(t, err)
+		return source
+	}
+
+	t.Run("stops watcher goroutine on close", func(t *testing.T) {
+		source := newWatchedSource(t)
+
+		ctx := context.Background()
+		require.NoError(t, source.Watch(ctx, func(map[string]interface{}) {}))
+		source.mu.RLock()
+		done := source.watchDone
+		source.mu.RUnlock()
+		require.NotNil(t, done)
+
+		require.NoError(t, source.Watch(ctx, func(map[string]interface{}) {}))
+		source.mu.RLock()
+		assert.Equal(t, done, source.watchDone, "callbacks share one watcher goroutine")
+		assert.Len(t, source.callbacks, 2)
+		source.mu.RUnlock()
+
+		require.NoError(t, source.Close())
+		select {
+		case <-done:
+		default:
+			t.Fatal("Close returned before the watcher goroutine exited")
+		}
+		assert.Nil(t, source.watchDone)
+		assert.Empty(t, source.callbacks)
+
+		// Stopping again is safe
+		source.Stop()
+		assert.NoError(t, source.Close())
+	})
+
+	t.Run("cleans up when context is cancelled", func(t *testing.T) {
+		source := newWatchedSource(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		require.NoError(t, source.Watch(ctx, func(map[string]interface{}) {}))
+
+		assert.Eventually(t, func() bool {
+			source.mu.RLock()
+			defer source.mu.RUnlock()
+			return source.watchDone == nil && len(source.callbacks) == 0
+		}, time.Second, 5*time.Millisecond)
+		source.Stop()
+	})
+
+	t.Run("is stopped by config close", func(t *testing.T) {
+		before := runtime.NumGoroutine()
+		source := newWatchedSource(t)
+
+		config, err := New(context.Background(), LoadOptions{
+			Environment: "test",
+			Sources:     []Source{source},
+			HotReload:   true,
+		})
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			source.mu.RLock()
+			defer source.mu.RUnlock()
+			return source.watchDone != nil
+		}, time.Second, 5*time.Millisecond)
+
+		require.NoError(t, config.Close())
+		assert.Nil(t, source.watchDone)
+
+		// Poll without assert.Eventually, which runs its own goroutines
+		deadline := time.Now().Add(time.Second)
+		for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+			time.Sleep(5 * time.Millisecond)
+		}
+		assert.LessOrEqual(t, runtime.NumGoroutine(), before, "no watcher goroutines leak")
+	})
 }
 
 func TestFileSource_Validate(t *testing.T) {
@@ -649,6 +824,38 @@ func TestFileSource_WriteConfig(t *testing.T) {
 		assert.Equal(t, "localhost", loadedValues["server.host"])
 		assert.Equal(t, float64(8080), loadedValues["server.port"]) // JSON numbers are float64
 	})
+
+	t.Run("writes INI config", func(t *testing.T) {
+		tmpFile := filepath.Join(t.TempDir(), "write_test.ini")
+
+		source, err := NewFileSource(FileSourceOptions{Path: tmpFile})
+		require.NoError(t, err)
+
+		values := map[string]interface{}{
+			"environment":     "staging",
+			"server.host":     "0.0.0.0",
+			"server.port":     9000,
+			"server.tls.cert": "/etc/cert.pem",
+			"database.tags":   []interface{}{"primary", "replica"},
+		}
+
+		err = source.WriteConfig(values)
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(tmpFile)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "[server]")
+		assert.Contains(t, string(content), "tls.cert = /etc/cert.pem")
+
+		loadedValues, err := source.Load(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, "staging", loadedValues["environment"])
+		assert.Equal(t, "0.0.0.0", loadedValues["server.host"])
+		assert.Equal(t, 9000, loadedValues["server.port"])
+		assert.Equal(t, "/etc/cert.pem", loadedValues["server.tls.cert"])
+		assert.Equal(t, []string{"primary", "replica"}, loadedValues["database.tags"])
+	})
 }
 
 func TestFileSource_Utilities(t *testing.T) {
@@ -693,6 +900,91 @@ debug = true
 	})
 }
 
+func TestFileSource_WriteConfigMerge(t *testing.T) {
+	const existing = `{
+  "environment": "production",
+  "api_key": "${API_KEY}",
+  "server": {"host": "0.0.0.0", "port": 9000},
+  "features": {"beta": false, "search": true},
+  "tags": ["prod", "live"]
+}`
+
+	t.Run("preserves keys not present in the write set", func(t *testing.T) {
+		path := createTempFile(t, "merge.json", existing)
+		source, err := NewFileSource(FileSourceOptions{Path: path})
+		require.NoError(t, err)
+
+		require.NoError(t, source.WriteConfigMerge(map[string]interface{}{"features.beta": true}))
+
+		values, err := source.Load(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, true, values["features.beta"])
+		assert.Equal(t, true, values["features.search"])
+		assert.Equal(t, "production", values["environment"])
+		assert.Equal(t, "0.0.0.0", values["server.host"])
+		assert.Equal(t, float64(9000), values["server.port"])
+		assert.Equal(t, []interface{}{"prod", "live"}, values["tags"])
+		assert.Equal(t, "live", values["tags.1"])
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "${API_KEY}", "environment references must stay unexpanded")
+	})
+
+	t.Run("re-nests nested values and replaces subtrees", func(t *testing.T) {
+		path := createTempFile(t, "merge.yaml", "server:\n  host: 0.0.0.0\n  port: 9000\nlogging:\n  level: info\n")
+		source, err := NewFileSource(FileSourceOptions{Path: path})
+		require.NoError(t, err)
+
+		require.NoError(t, source.WriteConfigMerge(map[string]interface{}{
+			"server":        map[string]interface{}{"port": 8443, "tls": map[string]interface{}{"enabled": true}},
+			"logging":       "stdout",
+			"tags":          []interface{}{"canary"},
+			"database.name": "orders",
+		}))
+
+		values, err := source.Load(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "0.0.0.0", values["server.host"])
+		assert.Equal(t, 8443, values["server.port"])
+		assert.Equal(t, true, values["server.tls.enabled"])
+		assert.Equal(t, "stdout", values["logging"])
+		assert.NotContains(t, values, "logging.level")
+		assert.Equal(t, []interface{}{"canary"}, values["tags"])
+		assert.Equal(t, "orders", values["database.name"])
+	})
+
+	t.Run("creates missing file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "new.toml")
+		source, err := NewFileSource(FileSourceOptions{Path: path})
+		require.NoError(t, err)
+
+		require.NoError(t, source.WriteConfigMerge(map[string]interface{}{"server.port": 9000}))
+
+		values, err := source.Load(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int64(9000), values["server.port"])
+	})
+
+	t.Run("WriteConfig honors merge write mode", func(t *testing.T) {
+		path := createTempFile(t, "mode.json", existing)
+		source, err := NewFileSource(FileSourceOptions{Path: path, WriteMode: WriteModeMerge})
+		require.NoError(t, err)
+
+		require.NoError(t, source.WriteConfig(map[string]interface{}{"environment": "staging"}))
+
+		values, err := source.Load(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "staging", values["environment"])
+		assert.Equal(t, "0.0.0.0", values["server.host"])
+	})
+
+	t.Run("rejects unknown write mode", func(t *testing.T) {
+		_, err := NewFileSource(FileSourceOptions{Path: "config.json", WriteMode: "append"})
+		assert.Error(t, err)
+	})
+}
+
 // Helper function to create temporary files for testing
 func createTempFile(t *testing.T, name, content string) string {
 	t.Helper()
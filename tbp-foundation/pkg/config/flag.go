@@ -0,0 +1,198 @@
+// File: flag.go
+// Title: Command-line Flag Configuration for TBP
+// Description: Provides a command-line flag configuration source backed by the
+//              standard flag package. Maps flag names to dot-separated
+//              configuration keys and only includes flags that were explicitly
+//              set, so flag defaults never override lower priority sources.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial command-line flag configuration implementation
+
+package config
+
+import (
+	"context"
+	"flag"
+	"strings"
+	"sync"
+
+	"github.com/msto63/tbp/tbp-foundation/pkg/core"
+)
+
+// FlagSource implements the Source interface for command-line flag configuration.
+//
+// Flag names are converted to configuration keys by stripping the optional
+// prefix and replacing the separator with dots, so --server-port becomes
+// server.port. Leading dashes are never part of a flag name: the flag package
+// accepts both -server-port and --server-port, so pflag-style double-dash
+// arguments map to the same key. Flags defined with pflag must be registered
+// on a standard flag.FlagSet to be visible to this source.
+type FlagSource struct {
+	// mu protects concurrent access to flag source data
+	mu sync.RWMutex
+
+	// flagSet is the flag set values are read from
+	flagSet *flag.FlagSet
+
+	// prefix restricts the source to flags starting with the prefix
+	prefix string
+
+	// separator is used to separate nested keys in flag names (default: "-")
+	separator string
+
+	// keyMapping maps flag names to configuration keys
+	keyMapping map[string]string
+
+	// keyFunc converts flag names to configuration keys if set
+	keyFunc func(string) string
+
+	// priority sets the source priority for merging
+	priority int
+}
+
+// FlagSourceOptions configures command-line flag source creation
+type FlagSourceOptions struct {
+	FlagSet    *flag.FlagSet       `json:"-"`           // Flag set to read (default: flag.CommandLine)
+	Args       []string            `json:"args"`        // Arguments to parse if the flag set is not parsed yet
+	Prefix     string              `json:"prefix"`      // Flag name prefix (e.g., "tbp")
+	Separator  string              `json:"separator"`   // Key separator in flag names (default: "-")
+	KeyMapping map[string]string   `json:"key_mapping"` // Custom flag name to key mappings
+	KeyFunc    func(string) string `json:"-"`           // Custom flag name to key conversion
+	Priority   int                 `json:"priority"`    // Source priority (default: 200)
+}
+
+// NewFlagSource creates a new command-line flag-based configuration source
+func NewFlagSource(opts FlagSourceOptions) (*FlagSource, error) {
+	if opts.FlagSet == nil {
+		opts.FlagSet = flag.CommandLine
+	}
+
+	if opts.Separator == "" {
+		opts.Separator = "-"
+	}
+
+	// Set default priority if not specified
+	if opts.Priority == 0 {
+		opts.Priority = 200 // Highest priority by default
+	}
+
+	// Ensure prefix ends with separator for consistent matching
+	if opts.Prefix != "" && !strings.HasSuffix(opts.Prefix, opts.Separator) {
+		opts.Prefix += opts.Separator
+	}
+
+	// Parse arguments if the flag set has not been parsed yet
+	if opts.Args != nil && !opts.FlagSet.Parsed() {
+		if err := opts.FlagSet.Parse(opts.Args); err != nil {
+			return nil, core.Wrap(err, "failed to parse command-line flags")
+		}
+	}
+
+	fs := &FlagSource{
+		flagSet:    opts.FlagSet,
+		prefix:     opts.Prefix,
+		separator:  opts.Separator,
+		keyMapping: opts.KeyMapping,
+		keyFunc:    opts.KeyFunc,
+		priority:   opts.Priority,
+	}
+
+	if fs.keyMapping == nil {
+		fs.keyMapping = make(map[string]string)
+	}
+
+	return fs, nil
+}
+
+// Name implements the Source interface
+func (fs *FlagSource) Name() string {
+	return "flags:" + fs.flagSet.Name()
+}
+
+// Priority implements the Source interface
+func (fs *FlagSource) Priority() int {
+	return fs.priority
+}
+
+// Load implements the Source interface.
+// Only flags that were explicitly set on the command line are included.
+func (fs *FlagSource) Load(ctx context.Context) (map[string]interface{}, error) {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+
+	values := make(map[string]interface{})
+
+	fs.flagSet.Visit(func(f *flag.Flag) {
+		configKey := fs.flagNameToConfigKey(f.Name)
+		if configKey == "" {
+			return
+		}
+		values[configKey] = flagValue(f)
+	})
+
+	return values, nil
+}
+
+// flagNameToConfigKey converts a flag name to a configuration key.
+// Returns an empty string for flags outside the configured prefix.
+func (fs *FlagSource) flagNameToConfigKey(name string) string {
+	// Check for custom key mapping first
+	if mappedKey, exists := fs.keyMapping[name]; exists {
+		return mappedKey
+	}
+
+	if fs.prefix != "" {
+		if !strings.HasPrefix(name, fs.prefix) {
+			return ""
+		}
+		name = strings.TrimPrefix(name, fs.prefix)
+	}
+
+	if fs.keyFunc != nil {
+		return fs.keyFunc(name)
+	}
+
+	// Convert server-port to server.port
+	return strings.ToLower(strings.ReplaceAll(name, fs.separator, "."))
+}
+
+// flagValue returns the typed value of a flag if available
+func flagValue(f *flag.Flag) interface{} {
+	if getter, ok := f.Value.(flag.Getter); ok {
+		return getter.Get()
+	}
+	return f.Value.String()
+}
+
+// AddKeyMapping adds a custom flag name to configuration key mapping
+func (fs *FlagSource) AddKeyMapping(flagName, configKey string) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	fs.keyMapping[flagName] = configKey
+}
+
+// GetKeyMappings returns all key mappings
+func (fs *FlagSource) GetKeyMappings() map[string]string {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+
+	result := make(map[string]string)
+	for k, v := range fs.keyMapping {
+		result[k] = v
+	}
+	return result
+}
+
+// GetPrefix returns the flag name prefix (without separator)
+func (fs *FlagSource) GetPrefix() string {
+	return strings.TrimSuffix(fs.prefix, fs.separator)
+}
+
+// GetFlagSet returns the underlying flag set
+func (fs *FlagSource) GetFlagSet() *flag.FlagSet {
+	return fs.flagSet
+}
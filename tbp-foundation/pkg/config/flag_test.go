@@ -0,0 +1,175 @@
+// File: flag_test.go
+// Title: Tests for Command-line Flag Configuration
+// Description: Test suite for the command-line flag configuration source
+//              including key conversion, prefix filtering, key mapping,
+//              typed flag values, and priority over other sources.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+
+package config
+
+import (
+	"context"
+	"flag"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestFlagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Int("server-port", 8080, "server port")
+	fs.String("server-host", "localhost", "server host")
+	fs.Bool("debug", false, "debug mode")
+	fs.Duration("read-timeout", 30*time.Second, "read timeout")
+	return fs
+}
+
+func TestNewFlagSource(t *testing.T) {
+	t.Run("creates flag source with defaults", func(t *testing.T) {
+		flagSrc, err := NewFlagSource(FlagSourceOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, flag.CommandLine, flagSrc.GetFlagSet())
+		assert.Equal(t, "-", flagSrc.separator)
+		assert.Equal(t, 200, flagSrc.Priority())
+	})
+
+	t.Run("parses provided arguments", func(t *testing.T) {
+		fs := newTestFlagSet()
+		flagSrc, err := NewFlagSource(FlagSourceOptions{
+			FlagSet: fs,
+			Args:    []string{"--server-port=9090"},
+		})
+		require.NoError(t, err)
+		assert.True(t, fs.Parsed())
+		assert.Equal(t, "flags:test", flagSrc.Name())
+	})
+
+	t.Run("returns error for invalid arguments", func(t *testing.T) {
+		fs := newTestFlagSet()
+		fs.SetOutput(&strings.Builder{})
+		_, err := NewFlagSource(FlagSourceOptions{
+			FlagSet: fs,
+			Args:    []string{"--unknown-flag"},
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to parse command-line flags")
+	})
+}
+
+func TestFlagSource_Load(t *testing.T) {
+	t.Run("includes only explicitly set flags", func(t *testing.T) {
+		flagSrc, err := NewFlagSource(FlagSourceOptions{
+			FlagSet: newTestFlagSet(),
+			Args:    []string{"--server-port=9090", "-debug"},
+		})
+		require.NoError(t, err)
+
+		values, err := flagSrc.Load(context.Background())
+		require.NoError(t, err)
+
+		assert.Len(t, values, 2)
+		assert.Equal(t, 9090, values["server.port"])
+		assert.Equal(t, true, values["debug"])
+		assert.NotContains(t, values, "server.host")
+		assert.NotContains(t, values, "read.timeout")
+	})
+
+	t.Run("returns typed values", func(t *testing.T) {
+		flagSrc, err := NewFlagSource(FlagSourceOptions{
+			FlagSet: newTestFlagSet(),
+			Args:    []string{"--read-timeout=5s", "--server-host=example.com"},
+		})
+		require.NoError(t, err)
+
+		values, err := flagSrc.Load(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, 5*time.Second, values["read.timeout"])
+		assert.Equal(t, "example.com", values["server.host"])
+	})
+
+	t.Run("filters and strips prefix", func(t *testing.T) {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.Int("tbp-server-port", 0, "server port")
+		fs.String("other", "", "unrelated flag")
+
+		flagSrc, err := NewFlagSource(FlagSourceOptions{
+			FlagSet: fs,
+			Prefix:  "tbp",
+			Args:    []string{"--tbp-server-port=9090", "--other=value"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "tbp", flagSrc.GetPrefix())
+
+		values, err := flagSrc.Load(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]interface{}{"server.port": 9090}, values)
+	})
+
+	t.Run("uses key mapping and key function", func(t *testing.T) {
+		fs := newTestFlagSet()
+		flagSrc, err := NewFlagSource(FlagSourceOptions{
+			FlagSet:    fs,
+			KeyMapping: map[string]string{"debug": "logging.debug"},
+			KeyFunc: func(name string) string {
+				return "cli." + strings.ReplaceAll(name, "-", "_")
+			},
+			Args: []string{"--debug", "--server-port=9090"},
+		})
+		require.NoError(t, err)
+
+		values, err := flagSrc.Load(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, true, values["logging.debug"])
+		assert.Equal(t, 9090, values["cli.server_port"])
+
+		flagSrc.AddKeyMapping("server-port", "port")
+		assert.Equal(t, "port", flagSrc.GetKeyMappings()["server-port"])
+	})
+}
+
+func TestFlagSource_Priority(t *testing.T) {
+	t.Run("overrides lower priority sources", func(t *testing.T) {
+		flagSrc, err := NewFlagSource(FlagSourceOptions{
+			FlagSet: newTestFlagSet(),
+			Args:    []string{"--server-port=9090"},
+		})
+		require.NoError(t, err)
+
+		config, err := New(context.Background(), LoadOptions{
+			Environment: "test",
+			Sources: []Source{
+				flagSrc,
+				&mockSource{
+					name:     "file",
+					priority: 50,
+					values: map[string]interface{}{
+						"server.port": 8080,
+						"server.host": "file-host",
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		port, err := config.GetInt("server.port")
+		require.NoError(t, err)
+		assert.Equal(t, 9090, port)
+
+		// Unset flag defaults must not clobber lower layers
+		host, err := config.GetString("server.host")
+		require.NoError(t, err)
+		assert.Equal(t, "file-host", host)
+	})
+}
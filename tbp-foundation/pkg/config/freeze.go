@@ -0,0 +1,79 @@
+// File: freeze.go
+// Title: Read-Only Mode for Configuration
+// Description: Allows the configuration manager to be frozen after startup
+//              validation so that structural changes (sources, validators,
+//              field metadata) and writes are rejected, while reads and
+//              controlled reloads keep working.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.2
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial Freeze, Unfreeze and IsFrozen
+// - 2026-10-16 v0.1.1: Set is rejected while frozen
+// - 2026-10-16 v0.1.2: Freeze waits for a running Set
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/msto63/tbp/tbp-foundation/pkg/core"
+)
+
+// Freeze marks the configuration read-only. While frozen, the following
+// methods fail with an error coded core.ErrCodeForbidden:
+//
+//   - AddSource
+//   - WriteToSource
+//   - Set
+//   - AddValidator
+//   - AddFieldMetadata
+//   - RemoveFieldMetadata
+//
+// All Get* methods, Unmarshal, Validate, Load, Reload, ReloadValidated, and
+// reloads triggered by StartWatching or ReloadOnSignal keep working, as do
+// watcher and subscription management and Close. Freezing an already
+// frozen configuration has no effect.
+func (c *Config) Freeze() {
+	// Wait for a running Set so that it cannot apply its value after the
+	// configuration has been frozen
+	c.setMu.Lock()
+	defer c.setMu.Unlock()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.frozen = true
+}
+
+// Unfreeze lifts a previous Freeze. It is only permitted if the
+// configuration was created with LoadOptions.AllowUnfreeze and fails with
+// core.ErrCodeForbidden otherwise.
+func (c *Config) Unfreeze() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.allowUnfreeze {
+		return core.WrapWithCode(core.ErrForbidden, core.ErrCodeForbidden,
+			"cannot unfreeze configuration: unfreezing is not allowed")
+	}
+
+	c.frozen = false
+	return nil
+}
+
+// IsFrozen reports whether the configuration is read-only
+func (c *Config) IsFrozen() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.frozen
+}
+
+// frozenError returns the error for an operation rejected by Freeze
+func frozenError(operation string) error {
+	return core.WrapWithCode(core.ErrForbidden, core.ErrCodeForbidden,
+		fmt.Sprintf("cannot %s: configuration is frozen", operation))
+}
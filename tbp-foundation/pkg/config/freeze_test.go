@@ -0,0 +1,101 @@
+// File: freeze_test.go
+// Title: Tests for Configuration Read-Only Mode
+// Description: Tests that a frozen configuration rejects mutating methods
+//              while reads and reloads keep working.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+
+package config
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/msto63/tbp/tbp-foundation/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Freeze(t *testing.T) {
+	ctx := context.Background()
+
+	newFrozenConfig := func(t *testing.T, allowUnfreeze bool) (*Config, *mockSource, *FileSource) {
+		t.Helper()
+
+		source := &mockSource{name: "mock", priority: 10, values: map[string]interface{}{"app.name": "frozen"}}
+		fileSource, err := NewFileSource(FileSourceOptions{
+			Path:     filepath.Join(t.TempDir(), "config.json"),
+			Optional: true,
+		})
+		require.NoError(t, err)
+
+		config, err := New(ctx, LoadOptions{
+			Environment:   "test",
+			Sources:       []Source{source, fileSource},
+			AllowUnfreeze: allowUnfreeze,
+		})
+		require.NoError(t, err)
+
+		assert.False(t, config.IsFrozen())
+		config.Freeze()
+		assert.True(t, config.IsFrozen())
+		return config, source, fileSource
+	}
+
+	assertForbidden := func(t *testing.T, err error) {
+		t.Helper()
+		require.Error(t, err)
+		assert.True(t, core.IsCode(err, core.ErrCodeForbidden))
+		assert.True(t, errors.Is(err, core.ErrForbidden))
+		assert.Contains(t, err.Error(), "configuration is frozen")
+	}
+
+	t.Run("blocks mutating methods", func(t *testing.T) {
+		config, _, fileSource := newFrozenConfig(t, false)
+
+		assertForbidden(t, config.AddSource(&mockSource{name: "other"}))
+		assertForbidden(t, config.WriteToSource(fileSource.Name(), map[string]interface{}{"a": 1}))
+		assertForbidden(t, config.AddValidator(func(string, interface{}) error { return nil }))
+		assertForbidden(t, config.AddFieldMetadata("app.name", Field{Name: "app.name"}))
+		assertForbidden(t, config.RemoveFieldMetadata("app.name"))
+
+		assert.Len(t, config.GetSources(), 2)
+		assert.False(t, fileSource.Exists())
+	})
+
+	t.Run("allows reads and reloads", func(t *testing.T) {
+		config, source, _ := newFrozenConfig(t, false)
+
+		name, err := config.GetString("app.name")
+		require.NoError(t, err)
+		assert.Equal(t, "frozen", name)
+
+		source.values = map[string]interface{}{"app.name": "reloaded"}
+		require.NoError(t, config.Reload(ctx))
+		require.NoError(t, config.ReloadValidated(ctx))
+		assert.Equal(t, "reloaded", config.GetStringWithDefault("app.name", ""))
+		require.NoError(t, config.Validate(ctx))
+	})
+
+	t.Run("unfreeze requires permission", func(t *testing.T) {
+		config, _, _ := newFrozenConfig(t, false)
+		err := config.Unfreeze()
+		require.Error(t, err)
+		assert.True(t, core.IsCode(err, core.ErrCodeForbidden))
+		assert.True(t, config.IsFrozen())
+	})
+
+	t.Run("unfreeze lifts the freeze when allowed", func(t *testing.T) {
+		config, _, _ := newFrozenConfig(t, true)
+		require.NoError(t, config.Unfreeze())
+		assert.False(t, config.IsFrozen())
+		assert.NoError(t, config.AddFieldMetadata("app.name", Field{Name: "app.name"}))
+	})
+}
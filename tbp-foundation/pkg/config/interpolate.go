@@ -0,0 +1,153 @@
+// File: interpolate.go
+// Title: Configuration Key Interpolation for TBP
+// Description: Resolves ${key.path} references between configuration values
+//              after all sources have been merged, so references can cross
+//              source boundaries. Supports ${key:-default} fallbacks and
+//              detects reference cycles.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial configuration key interpolation implementation
+
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/msto63/tbp/tbp-foundation/pkg/core"
+)
+
+// keyReferencePattern matches ${key} and ${key:-default} references
+var keyReferencePattern = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// interpolator resolves key references against a merged set of values
+type interpolator struct {
+	// values is the merged configuration being interpolated
+	values map[string]interface{}
+
+	// resolved caches values whose references have been resolved
+	resolved map[string]interface{}
+
+	// stack tracks the keys currently being resolved for cycle detection
+	stack []string
+}
+
+// interpolateValues resolves ${key.path} references in string values
+// against the merged configuration, updating values in place.
+//
+// A value consisting of a single reference takes over the referenced value
+// including its type, so port = "${server.port}" stays an int. References
+// embedded in longer strings are formatted with %v. References to unknown
+// keys use the ${key:-default} fallback if given and are left unchanged
+// otherwise, so unresolved OS environment placeholders pass through.
+// A reference cycle returns an error naming the keys involved.
+func interpolateValues(values map[string]interface{}) error {
+	ip := &interpolator{
+		values:   values,
+		resolved: make(map[string]interface{}),
+	}
+
+	// Resolve in sorted order for deterministic cycle errors
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if _, err := ip.resolve(key); err != nil {
+			return err
+		}
+	}
+
+	for key, value := range ip.resolved {
+		values[key] = value
+	}
+
+	return nil
+}
+
+// resolve returns the fully interpolated value of a key
+func (ip *interpolator) resolve(key string) (interface{}, error) {
+	if value, ok := ip.resolved[key]; ok {
+		return value, nil
+	}
+
+	for i, pending := range ip.stack {
+		if pending == key {
+			cycle := append(append([]string{}, ip.stack[i:]...), key)
+			return nil, core.Newf("configuration interpolation cycle detected: %s", strings.Join(cycle, " -> "))
+		}
+	}
+
+	value := ip.values[key]
+	str, ok := value.(string)
+	if !ok || !strings.Contains(str, "${") {
+		ip.resolved[key] = value
+		return value, nil
+	}
+
+	ip.stack = append(ip.stack, key)
+	result, err := ip.expand(str)
+	ip.stack = ip.stack[:len(ip.stack)-1]
+	if err != nil {
+		return nil, err
+	}
+
+	ip.resolved[key] = result
+	return result, nil
+}
+
+// expand replaces all references in a string value
+func (ip *interpolator) expand(str string) (interface{}, error) {
+	// A single reference spanning the whole value keeps the referenced type
+	if loc := keyReferencePattern.FindStringSubmatchIndex(str); loc != nil && loc[0] == 0 && loc[1] == len(str) {
+		return ip.lookup(str[loc[2]:loc[3]], str)
+	}
+
+	var expandErr error
+	result := keyReferencePattern.ReplaceAllStringFunc(str, func(match string) string {
+		if expandErr != nil {
+			return match
+		}
+
+		value, err := ip.lookup(match[2:len(match)-1], match)
+		if err != nil {
+			expandErr = err
+			return match
+		}
+		return fmt.Sprintf("%v", value)
+	})
+
+	if expandErr != nil {
+		return nil, expandErr
+	}
+	return result, nil
+}
+
+// lookup resolves a single reference specification (key or key:-default)
+func (ip *interpolator) lookup(spec, original string) (interface{}, error) {
+	name := spec
+	defaultValue, hasDefault := "", false
+	if idx := strings.Index(spec, ":-"); idx != -1 {
+		name = spec[:idx]
+		defaultValue, hasDefault = spec[idx+2:], true
+	}
+
+	if _, exists := ip.values[name]; exists {
+		return ip.resolve(name)
+	}
+
+	if hasDefault {
+		return defaultValue, nil
+	}
+
+	// Unknown key - leave the reference untouched
+	return original, nil
+}
@@ -0,0 +1,167 @@
+// File: interpolate_test.go
+// Title: Tests for Configuration Key Interpolation
+// Description: Test suite for post-merge ${key.path} interpolation including
+//              typed references, default fallbacks, unknown references,
+//              cycle detection, and references across configuration sources.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+
+package config
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterpolateValues(t *testing.T) {
+	t.Run("resolves embedded references", func(t *testing.T) {
+		values := map[string]interface{}{
+			"server.host": "example.com",
+			"server.port": 8443,
+			"base_url":    "https://${server.host}:${server.port}",
+			"api_url":     "${base_url}/api",
+		}
+
+		require.NoError(t, interpolateValues(values))
+
+		assert.Equal(t, "https://example.com:8443", values["base_url"])
+		assert.Equal(t, "https://example.com:8443/api", values["api_url"])
+	})
+
+	t.Run("keeps type of whole-value references", func(t *testing.T) {
+		values := map[string]interface{}{
+			"server.port": 8443,
+			"proxy.port":  "${server.port}",
+		}
+
+		require.NoError(t, interpolateValues(values))
+		assert.Equal(t, 8443, values["proxy.port"])
+	})
+
+	t.Run("uses default for unknown keys", func(t *testing.T) {
+		values := map[string]interface{}{
+			"timeout": "${server.timeout:-30s}",
+			"url":     "http://${server.host:-localhost}/",
+		}
+
+		require.NoError(t, interpolateValues(values))
+
+		assert.Equal(t, "30s", values["timeout"])
+		assert.Equal(t, "http://localhost/", values["url"])
+	})
+
+	t.Run("leaves unknown references unchanged", func(t *testing.T) {
+		values := map[string]interface{}{
+			"path": "${HOME}/data/${missing.key}",
+		}
+
+		require.NoError(t, interpolateValues(values))
+		assert.Equal(t, "${HOME}/data/${missing.key}", values["path"])
+	})
+
+	t.Run("detects reference cycles", func(t *testing.T) {
+		values := map[string]interface{}{
+			"a": "${b}",
+			"b": "prefix-${c}",
+			"c": "${a}",
+		}
+
+		err := interpolateValues(values)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "interpolation cycle detected")
+		assert.Contains(t, err.Error(), "a -> b -> c -> a")
+	})
+
+	t.Run("detects self references", func(t *testing.T) {
+		values := map[string]interface{}{
+			"loop": "${loop}",
+		}
+
+		err := interpolateValues(values)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "loop -> loop")
+	})
+}
+
+func TestConfig_Interpolation(t *testing.T) {
+	t.Run("resolves references across sources", func(t *testing.T) {
+		config, err := New(context.Background(), LoadOptions{
+			Environment: "test",
+			Sources: []Source{
+				&mockSource{
+					name:     "env",
+					priority: 100,
+					values:   map[string]interface{}{"server.host": "prod.example.com"},
+				},
+				&mockSource{
+					name:     "file",
+					priority: 50,
+					values: map[string]interface{}{
+						"server.host": "localhost",
+						"server.port": 8080,
+						"base_url":    "https://${server.host}:${server.port}",
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		baseURL, err := config.GetString("base_url")
+		require.NoError(t, err)
+		assert.Equal(t, "https://prod.example.com:8080", baseURL)
+	})
+
+	t.Run("resolves references from file sources", func(t *testing.T) {
+		os.Setenv("TEST_INTERPOLATE_SCHEME", "https")
+		defer os.Unsetenv("TEST_INTERPOLATE_SCHEME")
+
+		tmpFile := createTempFile(t, "config.toml", `
+base_url = "${TEST_INTERPOLATE_SCHEME}://${server.host}:${server.port:-80}"
+
+[server]
+host = "example.com"
+port = 8443
+`)
+		defer os.Remove(tmpFile)
+
+		fileSrc, err := NewFileSource(FileSourceOptions{Path: tmpFile})
+		require.NoError(t, err)
+
+		config, err := New(context.Background(), LoadOptions{
+			Environment: "test",
+			Sources:     []Source{fileSrc},
+		})
+		require.NoError(t, err)
+
+		baseURL, err := config.GetString("base_url")
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com:8443", baseURL)
+	})
+
+	t.Run("fails to load on reference cycles", func(t *testing.T) {
+		_, err := New(context.Background(), LoadOptions{
+			Environment: "test",
+			Sources: []Source{
+				&mockSource{
+					name:     "file",
+					priority: 50,
+					values: map[string]interface{}{
+						"a": "${b}",
+						"b": "${a}",
+					},
+				},
+			},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "a -> b -> a")
+	})
+}
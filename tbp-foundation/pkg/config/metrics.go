@@ -0,0 +1,46 @@
+// File: metrics.go
+// Title: Configuration Metrics Hooks for TBP
+// Description: Defines a dependency-free metrics hook interface that the
+//              configuration manager calls to report load timing, reload
+//              outcomes, and validation results, so applications can bridge
+//              to their metrics system of choice.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial ConfigMetrics interface with no-op default
+
+package config
+
+import "time"
+
+// ConfigMetrics receives measurements from the configuration manager.
+// Methods are called synchronously while the configuration lock is held,
+// so implementations must be fast and must not call back into Config.
+type ConfigMetrics interface {
+	// ObserveLoad is called after every load attempt with the time taken
+	// to load and merge all sources and the number of sources
+	ObserveLoad(duration time.Duration, sourceCount int)
+
+	// ObserveReload is called with the outcome of every load attempt,
+	// including the initial load, manual reloads, and hot reloads
+	ObserveReload(success bool)
+
+	// ObserveValidation is called after every validation run with the time
+	// taken and the number of validation errors found
+	ObserveValidation(duration time.Duration, errCount int)
+}
+
+// noopMetrics is the default ConfigMetrics implementation
+type noopMetrics struct{}
+
+// ObserveLoad implements ConfigMetrics interface.
+func (noopMetrics) ObserveLoad(time.Duration, int) {}
+
+// ObserveReload implements ConfigMetrics interface.
+func (noopMetrics) ObserveReload(bool) {}
+
+// ObserveValidation implements ConfigMetrics interface.
+func (noopMetrics) ObserveValidation(time.Duration, int) {}
@@ -0,0 +1,120 @@
+// File: metrics_test.go
+// Title: Tests for Configuration Metrics Hooks
+// Description: Verifies that load, reload, and validation hooks fire with
+//              the expected arguments using a counting implementation.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+
+package config
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingMetrics records all observations for assertions
+type countingMetrics struct {
+	mu               sync.Mutex
+	loads            int
+	lastSourceCount  int
+	reloadSuccesses  int
+	reloadFailures   int
+	validations      int
+	lastErrCount     int
+	totalLoadTime    time.Duration
+	totalValidations time.Duration
+}
+
+func (m *countingMetrics) ObserveLoad(duration time.Duration, sourceCount int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.loads++
+	m.lastSourceCount = sourceCount
+	m.totalLoadTime += duration
+}
+
+func (m *countingMetrics) ObserveReload(success bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if success {
+		m.reloadSuccesses++
+	} else {
+		m.reloadFailures++
+	}
+}
+
+func (m *countingMetrics) ObserveValidation(duration time.Duration, errCount int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.validations++
+	m.lastErrCount = errCount
+	m.totalValidations += duration
+}
+
+func TestConfigMetrics(t *testing.T) {
+	t.Run("observes load, reload, and validation", func(t *testing.T) {
+		metrics := &countingMetrics{}
+		config, err := New(context.Background(), LoadOptions{
+			Environment: "test",
+			Metrics:     metrics,
+			Validation:  true,
+			Sources: []Source{
+				&mockSource{name: "a", priority: 10, values: map[string]interface{}{"app.name": "tbp"}},
+				&mockSource{name: "b", priority: 20, values: map[string]interface{}{"app.port": 8080}},
+			},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, metrics.loads)
+		assert.Equal(t, 2, metrics.lastSourceCount)
+		assert.Equal(t, 1, metrics.reloadSuccesses)
+		assert.Equal(t, 1, metrics.validations)
+		assert.Equal(t, 0, metrics.lastErrCount)
+
+		require.NoError(t, config.Reload(context.Background()))
+		assert.Equal(t, 2, metrics.loads)
+		assert.Equal(t, 2, metrics.reloadSuccesses)
+	})
+
+	t.Run("counts validation errors and failed reloads", func(t *testing.T) {
+		metrics := &countingMetrics{}
+		config, err := New(context.Background(), LoadOptions{
+			Environment: "test",
+			Metrics:     metrics,
+			Sources:     []Source{&mockSource{values: map[string]interface{}{"app.port": "not-a-number"}}},
+		})
+		require.NoError(t, err)
+
+		config.AddFieldMetadata("app.port", Field{Name: "app.port", Type: "int"})
+		config.AddFieldMetadata("app.name", Field{Name: "app.name", Required: true})
+
+		require.Error(t, config.Validate(context.Background()))
+		assert.Equal(t, 1, metrics.validations)
+		assert.Equal(t, 2, metrics.lastErrCount)
+
+		require.Error(t, config.ReloadValidated(context.Background()))
+		assert.Equal(t, 2, metrics.validations)
+		assert.Equal(t, 1, metrics.reloadFailures)
+		assert.Equal(t, 1, metrics.reloadSuccesses)
+	})
+
+	t.Run("defaults to no-op metrics", func(t *testing.T) {
+		config, err := New(context.Background(), LoadOptions{
+			Environment: "test",
+			Sources:     []Source{&mockSource{values: map[string]interface{}{"key": "value"}}},
+		})
+		require.NoError(t, err)
+		assert.NoError(t, config.Reload(context.Background()))
+		assert.NoError(t, config.Validate(context.Background()))
+	})
+}
@@ -0,0 +1,232 @@
+// File: override.go
+// Title: Runtime Overrides and Write-Back for Configuration
+// Description: Provides Config.Set for changing values at runtime, e.g.
+//              from an admin UI. Values are applied through a highest
+//              priority in-memory source and, if a write target is
+//              configured, persisted to that writable source so that they
+//              survive restarts.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.1
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial Set with runtime override source and write-back
+// - 2026-10-16 v0.1.1: Restore override and write target when reloading fails; recheck frozen under the lock
+
+package config
+
+import (
+	"context"
+	"math"
+	"sync"
+
+	"github.com/msto63/tbp/tbp-foundation/pkg/core"
+)
+
+// RuntimeOverrideSourceName is the name of the source holding values
+// changed with Config.Set
+const RuntimeOverrideSourceName = "runtime-overrides"
+
+// Set changes the value of key at runtime. The value is stored in an
+// in-memory source named RuntimeOverrideSourceName that takes precedence
+// over all other sources, the configuration is reloaded, and watchers and
+// subscriptions are notified of the change.
+//
+// If LoadOptions.WriteTarget names a source, the value is first written to
+// that source, and nothing is changed if writing fails. A FileSource keeps
+// its other values (as with WriteConfigMerge); other writable sources
+// receive their current values with key changed. Once persisted, the value
+// survives restarts, where the usual source priorities apply again.
+//
+// If the reload fails, the previous override of key and the previous
+// content of the write target are restored, so a failed Set changes
+// nothing and later reloads do not apply the rejected value.
+//
+// Set fails with ErrCodeForbidden if the configuration is frozen,
+// ErrCodeNotFound if the write target does not exist, and
+// ErrCodeInvalidInput if it is not a WritableSource.
+func (c *Config) Set(key string, value interface{}) error {
+	if key == "" {
+		return core.New("configuration key cannot be empty").WithCode(core.ErrCodeInvalidInput)
+	}
+
+	c.setMu.Lock()
+	defer c.setMu.Unlock()
+
+	ctx := context.Background()
+	target, err := c.writeTargetSource()
+	if err != nil {
+		return err
+	}
+	var restoreTarget func() error
+	if target != nil {
+		restoreTarget, err = writeBack(ctx, target, key, value)
+		if err != nil {
+			return core.Wrapf(err, "failed to persist configuration key '%s' to source %s", key, target.Name())
+		}
+	}
+
+	c.mu.Lock()
+	if c.frozen {
+		c.mu.Unlock()
+		return restoreAfterFailedSet(frozenError("set configuration values"), restoreTarget)
+	}
+	if c.overrides == nil {
+		c.overrides = &overrideSource{values: make(map[string]interface{})}
+		// The override source has the highest priority and goes first
+		c.sources = append([]Source{c.overrides}, c.sources...)
+	}
+	previous, existed := c.overrides.set(key, value)
+	c.mu.Unlock()
+
+	if err := c.Load(ctx); err != nil {
+		c.overrides.restore(key, previous, existed)
+		return restoreAfterFailedSet(err, restoreTarget)
+	}
+	return nil
+}
+
+// restoreAfterFailedSet restores the write target, if one was written,
+// and returns err, noting a failed restore in its context
+func restoreAfterFailedSet(err error, restoreTarget func() error) error {
+	if restoreTarget == nil {
+		return err
+	}
+	if restoreErr := restoreTarget(); restoreErr != nil {
+		return core.Wrap(err, "failed to set configuration value").
+			WithContext("restore_error", restoreErr.Error())
+	}
+	return err
+}
+
+// writeTargetSource returns the source configured as write target, or nil
+// if there is none. Fails if the configuration is frozen.
+func (c *Config) writeTargetSource() (WritableSource, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.frozen {
+		return nil, frozenError("set configuration values")
+	}
+	if c.writeTarget == "" {
+		return nil, nil
+	}
+
+	for _, source := range c.sources {
+		if source.Name() != c.writeTarget {
+			continue
+		}
+		if writable, ok := source.(WritableSource); ok {
+			return writable, nil
+		}
+		return nil, core.Newf("write target '%s' is not writable", c.writeTarget).WithCode(core.ErrCodeInvalidInput)
+	}
+	return nil, core.Newf("write target '%s' not found", c.writeTarget).WithCode(core.ErrCodeNotFound)
+}
+
+// mergeWritableSource is implemented by sources that can update single
+// keys while keeping their other values, such as FileSource
+type mergeWritableSource interface {
+	WriteConfigMerge(values map[string]interface{}) error
+}
+
+// snapshotSource is implemented by sources that can restore their exact
+// previous content, such as FileSource
+type snapshotSource interface {
+	snapshot() (restore func() error, err error)
+}
+
+// writeBack persists key to target, keeping the target's other values.
+// It returns a function that restores the target's previous content.
+func writeBack(ctx context.Context, target WritableSource, key string, value interface{}) (func() error, error) {
+	if merger, ok := target.(mergeWritableSource); ok {
+		restore, err := snapshotTarget(ctx, target)
+		if err != nil {
+			return nil, err
+		}
+		if err := merger.WriteConfigMerge(map[string]interface{}{key: value}); err != nil {
+			return nil, err
+		}
+		return restore, nil
+	}
+
+	values, err := target.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	updated := make(map[string]interface{}, len(values)+1)
+	for k, v := range values {
+		updated[k] = v
+	}
+	updated[key] = value
+	if err := target.WriteConfig(updated); err != nil {
+		return nil, err
+	}
+	return func() error { return target.WriteConfig(values) }, nil
+}
+
+// snapshotTarget captures the content of target and returns a function
+// that writes it back
+func snapshotTarget(ctx context.Context, target WritableSource) (func() error, error) {
+	if snapshotter, ok := target.(snapshotSource); ok {
+		return snapshotter.snapshot()
+	}
+
+	values, err := target.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return func() error { return target.WriteConfig(values) }, nil
+}
+
+// overrideSource is the in-memory source of values changed with Set
+type overrideSource struct {
+	mu     sync.RWMutex
+	values map[string]interface{}
+}
+
+// Name implements the Source interface
+func (s *overrideSource) Name() string {
+	return RuntimeOverrideSourceName
+}
+
+// Priority implements the Source interface
+func (s *overrideSource) Priority() int {
+	return math.MaxInt
+}
+
+// Load implements the Source interface
+func (s *overrideSource) Load(ctx context.Context) (map[string]interface{}, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make(map[string]interface{}, len(s.values))
+	for k, v := range s.values {
+		result[k] = v
+	}
+	return result, nil
+}
+
+// set stores the override of key and returns the previous override and
+// whether there was one
+func (s *overrideSource) set(key string, value interface{}) (interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, existed := s.values[key]
+	s.values[key] = value
+	return previous, existed
+}
+
+// restore reverts the override of key to a value returned by set
+func (s *overrideSource) restore(key string, previous interface{}, existed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existed {
+		s.values[key] = previous
+	} else {
+		delete(s.values, key)
+	}
+}
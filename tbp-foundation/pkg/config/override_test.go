@@ -0,0 +1,218 @@
+// File: override_test.go
+// Title: Tests for Runtime Overrides and Write-Back
+// Description: Tests Config.Set as an in-memory override, persisting to
+//              file and generic writable sources, and the errors for
+//              missing, non-writable, and frozen write targets.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.1
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+// - 2026-10-16 v0.1.1: Added tests for failed reloads after Set
+
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/msto63/tbp/tbp-foundation/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Set(t *testing.T) {
+	newConfig := func(t *testing.T, writeTarget string, sources ...Source) *Config {
+		config, err := New(context.Background(), LoadOptions{
+			Environment: "test",
+			Sources:     sources,
+			WriteTarget: writeTarget,
+		})
+		require.NoError(t, err)
+		t.Cleanup(func() { config.Close() })
+		return config
+	}
+
+	t.Run("overrides values in memory", func(t *testing.T) {
+		config := newConfig(t, "",
+			&mockSource{name: "flags", priority: 200, values: map[string]interface{}{"log.level": "info"}})
+		changes := config.Changes()
+
+		require.NoError(t, config.Set("log.level", "debug"))
+
+		level, err := config.GetString("log.level")
+		require.NoError(t, err)
+		assert.Equal(t, "debug", level, "overrides win over the highest priority source")
+
+		select {
+		case change := <-changes:
+			assert.Equal(t, ChangeActionUpdate, change["log.level"].Action)
+			assert.Equal(t, "info", change["log.level"].OldValue)
+		case <-time.After(time.Second):
+			t.Fatal("change was not published")
+		}
+
+		require.NoError(t, config.Set("feature.beta", true))
+		beta, err := config.GetBool("feature.beta")
+		require.NoError(t, err)
+		assert.True(t, beta)
+
+		require.NoError(t, config.Load(context.Background()))
+		level, _ = config.GetString("log.level")
+		assert.Equal(t, "debug", level, "overrides survive reloads")
+
+		sources := config.GetSources()
+		assert.Equal(t, RuntimeOverrideSourceName, sources[0].Name)
+	})
+
+	t.Run("persists to file target", func(t *testing.T) {
+		path := createTempFile(t, "app.yaml", "server:\n  host: 0.0.0.0\n  port: 9000\n")
+		file, err := NewFileSource(FileSourceOptions{Path: path})
+		require.NoError(t, err)
+		config := newConfig(t, file.Name(), file)
+
+		require.NoError(t, config.Set("server.port", 8443))
+
+		port, err := config.GetInt("server.port")
+		require.NoError(t, err)
+		assert.Equal(t, 8443, port)
+
+		reopened, err := NewFileSource(FileSourceOptions{Path: path})
+		require.NoError(t, err)
+		values, err := reopened.Load(context.Background())
+		require.NoError(t, err)
+		assert.EqualValues(t, 8443, values["server.port"])
+		assert.Equal(t, "0.0.0.0", values["server.host"], "other values are kept")
+	})
+
+	t.Run("persists to other writable sources", func(t *testing.T) {
+		target := &mockWritableSource{mockSource: mockSource{name: "store", priority: 50, values: map[string]interface{}{
+			"cache.ttl":  "1m",
+			"cache.size": 100,
+		}}}
+		config := newConfig(t, "store", target)
+
+		require.NoError(t, config.Set("cache.ttl", "5m"))
+		assert.Equal(t, map[string]interface{}{"cache.ttl": "5m", "cache.size": 100}, target.writtenValues)
+	})
+
+	t.Run("rejects non-writable target", func(t *testing.T) {
+		config := newConfig(t, "env",
+			&mockSource{name: "env", priority: 100, values: map[string]interface{}{"log.level": "info"}})
+
+		err := config.Set("log.level", "debug")
+		require.Error(t, err)
+		assert.True(t, core.IsInvalidInput(err))
+		assert.Contains(t, err.Error(), "not writable")
+
+		level, _ := config.GetString("log.level")
+		assert.Equal(t, "info", level, "failed Set changes nothing")
+	})
+
+	t.Run("rejects missing target", func(t *testing.T) {
+		config := newConfig(t, "missing", &mockSource{name: "env", priority: 100})
+
+		err := config.Set("log.level", "debug")
+		require.Error(t, err)
+		assert.True(t, core.IsNotFound(err))
+	})
+
+	t.Run("keeps values if writing fails", func(t *testing.T) {
+		target := &failingWritableSource{mockSource: mockSource{name: "store", priority: 50, values: map[string]interface{}{
+			"name": "orders",
+		}}}
+		config := newConfig(t, "store", target)
+
+		err := config.Set("name", "billing")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "disk full")
+
+		name, _ := config.GetString("name")
+		assert.Equal(t, "orders", name)
+		assert.NotEqual(t, RuntimeOverrideSourceName, config.GetSources()[0].Name)
+	})
+
+	t.Run("restores previous values if reloading fails", func(t *testing.T) {
+		flaky := &mockErrorSource{mockSource: mockSource{name: "flaky", priority: 10}}
+		config := newConfig(t, "", flaky,
+			&mockSource{name: "env", priority: 100, values: map[string]interface{}{"log.level": "info"}})
+		require.NoError(t, config.Set("log.level", "warn"))
+
+		flaky.loadError = errors.New("connection refused")
+		err := config.Set("log.level", "debug")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "connection refused")
+		err = config.Set("feature.beta", true)
+		require.Error(t, err)
+
+		level, _ := config.GetString("log.level")
+		assert.Equal(t, "warn", level)
+
+		flaky.loadError = nil
+		require.NoError(t, config.Load(context.Background()))
+		level, _ = config.GetString("log.level")
+		assert.Equal(t, "warn", level, "rejected value is not applied by later reloads")
+		_, exists := config.Get("feature.beta")
+		assert.False(t, exists, "rejected new key is removed from the overrides")
+	})
+
+	t.Run("restores write target if reloading fails", func(t *testing.T) {
+		original := "server:\n  host: 0.0.0.0\n  port: 9000\n"
+		path := createTempFile(t, "app.yaml", original)
+		file, err := NewFileSource(FileSourceOptions{Path: path})
+		require.NoError(t, err)
+		flaky := &mockErrorSource{mockSource: mockSource{name: "flaky", priority: 10}}
+		config := newConfig(t, file.Name(), file, flaky)
+
+		flaky.loadError = errors.New("connection refused")
+		err = config.Set("server.port", 8443)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "connection refused")
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, original, string(content))
+
+		flaky.loadError = nil
+		require.NoError(t, config.Load(context.Background()))
+		port, err := config.GetInt("server.port")
+		require.NoError(t, err)
+		assert.Equal(t, 9000, port)
+
+		store := &mockWritableSource{mockSource: mockSource{name: "store", priority: 50, values: map[string]interface{}{
+			"cache.ttl": "1m",
+		}}}
+		config = newConfig(t, "store", store, flaky)
+		flaky.loadError = errors.New("connection refused")
+		require.Error(t, config.Set("cache.ttl", "5m"))
+		assert.Equal(t, map[string]interface{}{"cache.ttl": "1m"}, store.writtenValues)
+	})
+
+	t.Run("is rejected while frozen", func(t *testing.T) {
+		config := newConfig(t, "", &mockSource{name: "env", priority: 100})
+		config.Freeze()
+
+		err := config.Set("log.level", "debug")
+		require.Error(t, err)
+		assert.True(t, core.IsForbidden(err))
+	})
+
+	t.Run("rejects empty key", func(t *testing.T) {
+		config := newConfig(t, "", &mockSource{name: "env", priority: 100})
+		assert.True(t, core.IsInvalidInput(config.Set("", 1)))
+	})
+}
+
+// failingWritableSource is a writable source whose writes fail
+type failingWritableSource struct {
+	mockSource
+}
+
+func (m *failingWritableSource) WriteConfig(values map[string]interface{}) error {
+	return errors.New("disk full")
+}
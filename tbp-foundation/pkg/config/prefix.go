@@ -0,0 +1,149 @@
+// File: prefix.go
+// Title: Prefix-Scoped Configuration Sources
+// Description: Provides a source wrapper that mounts the keys of another
+//              source under a key prefix, so that generic configuration
+//              files with bare keys can be reused by several subsystems.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial PrefixSource implementation
+
+package config
+
+import (
+	"context"
+	"strings"
+
+	"github.com/msto63/tbp/tbp-foundation/pkg/core"
+)
+
+// PrefixSource mounts the keys of an inner source under a prefix.
+// A key "port" of the inner source becomes "server.port" for prefix
+// "server". Name and Priority are those of the inner source. Watching,
+// validation, writing, and stopping are delegated if the inner source
+// supports them: Watch and Validate do nothing and WriteConfig fails
+// otherwise.
+type PrefixSource struct {
+	// inner is the wrapped source
+	inner Source
+
+	// prefix is the key prefix without trailing dot
+	prefix string
+}
+
+// NewPrefixSource creates a source that prepends "prefix." to every key
+// loaded from inner. A trailing dot in prefix is optional; an empty prefix
+// leaves keys unchanged. inner must not be nil.
+func NewPrefixSource(inner Source, prefix string) *PrefixSource {
+	return &PrefixSource{
+		inner:  inner,
+		prefix: strings.TrimSuffix(prefix, "."),
+	}
+}
+
+// Name implements the Source interface
+func (ps *PrefixSource) Name() string {
+	return ps.inner.Name()
+}
+
+// Priority implements the Source interface
+func (ps *PrefixSource) Priority() int {
+	return ps.inner.Priority()
+}
+
+// Prefix returns the key prefix without trailing dot
+func (ps *PrefixSource) Prefix() string {
+	return ps.prefix
+}
+
+// Inner returns the wrapped source
+func (ps *PrefixSource) Inner() Source {
+	return ps.inner
+}
+
+// Load implements the Source interface
+func (ps *PrefixSource) Load(ctx context.Context) (map[string]interface{}, error) {
+	values, err := ps.inner.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ps.addPrefix(values), nil
+}
+
+// Watch implements the WatchableSource interface. Changes reported by the
+// inner source are passed to callback with prefixed keys. Returns nil
+// without watching if the inner source is not watchable.
+func (ps *PrefixSource) Watch(ctx context.Context, callback func(map[string]interface{})) error {
+	watchable, ok := ps.inner.(WatchableSource)
+	if !ok {
+		return nil
+	}
+	return watchable.Watch(ctx, func(values map[string]interface{}) {
+		callback(ps.addPrefix(values))
+	})
+}
+
+// Validate implements the ValidatableSource interface.
+// Returns nil if the inner source does not support validation.
+func (ps *PrefixSource) Validate() error {
+	if validatable, ok := ps.inner.(ValidatableSource); ok {
+		return validatable.Validate()
+	}
+	return nil
+}
+
+// WriteConfig implements the WritableSource interface. The prefix is
+// stripped from all keys before they are written to the inner source;
+// keys outside the prefix are rejected. Fails if the inner source is not
+// writable.
+func (ps *PrefixSource) WriteConfig(values map[string]interface{}) error {
+	writable, ok := ps.inner.(WritableSource)
+	if !ok {
+		return core.Newf("source '%s' is not writable", ps.inner.Name())
+	}
+
+	stripped := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		innerKey, ok := ps.stripPrefix(key)
+		if !ok {
+			return core.NewWithCodef(core.ErrCodeInvalidInput,
+				"key '%s' is outside prefix '%s' of source '%s'", key, ps.prefix, ps.inner.Name())
+		}
+		stripped[innerKey] = value
+	}
+
+	return writable.WriteConfig(stripped)
+}
+
+// Stop stops the inner source if it supports stopping
+func (ps *PrefixSource) Stop() {
+	if stoppable, ok := ps.inner.(interface{ Stop() }); ok {
+		stoppable.Stop()
+	}
+}
+
+// addPrefix returns a copy of values with prefixed keys
+func (ps *PrefixSource) addPrefix(values map[string]interface{}) map[string]interface{} {
+	if ps.prefix == "" {
+		return values
+	}
+
+	result := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		result[ps.prefix+"."+key] = value
+	}
+	return result
+}
+
+// stripPrefix removes the prefix from key and reports whether key was
+// inside the prefix
+func (ps *PrefixSource) stripPrefix(key string) (string, bool) {
+	if ps.prefix == "" {
+		return key, true
+	}
+	innerKey, ok := strings.CutPrefix(key, ps.prefix+".")
+	return innerKey, ok && innerKey != ""
+}
@@ -0,0 +1,99 @@
+// File: prefix_test.go
+// Title: Tests for Prefix-Scoped Configuration Sources
+// Description: Tests key prefixing and the delegation of watch, validate,
+//              and write capabilities to the inner source.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+
+package config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/msto63/tbp/tbp-foundation/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrefixSource(t *testing.T) {
+	ctx := context.Background()
+	sharedValues := func() map[string]interface{} {
+		return map[string]interface{}{"host": "localhost", "port": 8080}
+	}
+
+	t.Run("prefixes loaded keys", func(t *testing.T) {
+		inner := &mockSource{name: "shared", priority: 30, values: sharedValues()}
+		source := NewPrefixSource(inner, "server.")
+
+		assert.Equal(t, "shared", source.Name())
+		assert.Equal(t, 30, source.Priority())
+		assert.Equal(t, "server", source.Prefix())
+		assert.Same(t, inner, source.Inner())
+
+		values, err := source.Load(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"server.host": "localhost", "server.port": 8080}, values)
+	})
+
+	t.Run("empty prefix leaves keys unchanged", func(t *testing.T) {
+		values, err := NewPrefixSource(&mockSource{values: sharedValues()}, "").Load(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, sharedValues(), values)
+	})
+
+	t.Run("mounts source in merged configuration", func(t *testing.T) {
+		config, err := New(ctx, LoadOptions{
+			Environment: "test",
+			Sources: []Source{
+				NewPrefixSource(&mockSource{name: "server", priority: 10, values: sharedValues()}, "server"),
+				NewPrefixSource(&mockSource{name: "admin", priority: 10, values: sharedValues()}, "admin"),
+			},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, 8080, config.GetIntWithDefault("server.port", 0))
+		assert.Equal(t, "localhost", config.GetStringWithDefault("admin.host", ""))
+		assert.False(t, config.HasKey("port"))
+	})
+
+	t.Run("passes watch changes with prefixed keys", func(t *testing.T) {
+		inner := &mockWatchableSource{mockSource: mockSource{values: sharedValues()}}
+		source := NewPrefixSource(inner, "server")
+
+		var received map[string]interface{}
+		require.NoError(t, source.Watch(ctx, func(values map[string]interface{}) {
+			received = values
+		}))
+		inner.TriggerChange(map[string]interface{}{"port": 9090})
+		assert.Equal(t, map[string]interface{}{"server.port": 9090}, received)
+
+		assert.NoError(t, NewPrefixSource(&mockSource{}, "server").Watch(ctx, func(map[string]interface{}) {}))
+	})
+
+	t.Run("delegates validation", func(t *testing.T) {
+		assert.Error(t, NewPrefixSource(&mockValidatableSource{valid: false}, "server").Validate())
+		assert.NoError(t, NewPrefixSource(&mockValidatableSource{valid: true}, "server").Validate())
+		assert.NoError(t, NewPrefixSource(&mockSource{}, "server").Validate())
+	})
+
+	t.Run("strips prefix when writing", func(t *testing.T) {
+		inner := &mockWritableSource{mockSource: mockSource{name: "shared"}}
+		source := NewPrefixSource(inner, "server")
+
+		require.NoError(t, source.WriteConfig(map[string]interface{}{"server.port": 9090, "server.tls.enabled": true}))
+		assert.Equal(t, map[string]interface{}{"port": 9090, "tls.enabled": true}, inner.writtenValues)
+
+		err := source.WriteConfig(map[string]interface{}{"client.port": 1})
+		require.Error(t, err)
+		assert.True(t, core.IsCode(err, core.ErrCodeInvalidInput))
+
+		err = NewPrefixSource(&mockSource{name: "readonly"}, "server").WriteConfig(map[string]interface{}{"server.port": 1})
+		assert.ErrorContains(t, err, "not writable")
+	})
+}
@@ -0,0 +1,86 @@
+// File: required.go
+// Title: Required Configuration Accessors
+// Description: Provides GetRequired* accessors for startup validation that
+//              return TBP-coded errors instead of plain messages: missing
+//              keys are reported as not found and unconvertible values as
+//              invalid input, with the key in the error context, so that
+//              error middleware can classify configuration errors.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial GetRequired accessors
+
+package config
+
+import (
+	"time"
+
+	"github.com/msto63/tbp/tbp-foundation/pkg/core"
+)
+
+// getRequired retrieves a value with get, coding a missing key as
+// core.ErrCodeNotFound (matching core.ErrNotFound) and a conversion failure
+// as core.ErrCodeInvalidInput. Both errors carry the key in the context
+// entry "key".
+func getRequired[T any](c *Config, key string, get func(string) (T, error)) (T, error) {
+	var zero T
+
+	if _, exists := c.Get(key); !exists {
+		return zero, core.WrapWithCodef(core.ErrNotFound, core.ErrCodeNotFound,
+			"required configuration key '%s' not found", key).WithContext("key", key)
+	}
+
+	value, err := get(key)
+	if err != nil {
+		return zero, core.WrapWithCodef(err, core.ErrCodeInvalidInput,
+			"invalid value for required configuration key '%s'", key).WithContext("key", key)
+	}
+	return value, nil
+}
+
+// GetRequiredString retrieves a string value like GetString, failing with
+// a core.ErrCodeNotFound error if the key is missing. Empty strings are
+// returned as they are.
+func (c *Config) GetRequiredString(key string) (string, error) {
+	return getRequired(c, key, c.GetString)
+}
+
+// GetRequiredInt retrieves an integer value like GetInt, failing with a
+// core.ErrCodeNotFound error if the key is missing and a
+// core.ErrCodeInvalidInput error if the value cannot be converted
+func (c *Config) GetRequiredInt(key string) (int, error) {
+	return getRequired(c, key, c.GetInt)
+}
+
+// GetRequiredInt64 retrieves a 64-bit integer value like GetInt64, with errors coded as for GetRequiredInt
+func (c *Config) GetRequiredInt64(key string) (int64, error) {
+	return getRequired(c, key, c.GetInt64)
+}
+
+// GetRequiredUint64 retrieves an unsigned 64-bit integer value like GetUint64, with errors coded as for GetRequiredInt
+func (c *Config) GetRequiredUint64(key string) (uint64, error) {
+	return getRequired(c, key, c.GetUint64)
+}
+
+// GetRequiredFloat retrieves a float value like GetFloat, with errors coded as for GetRequiredInt
+func (c *Config) GetRequiredFloat(key string) (float64, error) {
+	return getRequired(c, key, c.GetFloat)
+}
+
+// GetRequiredBool retrieves a boolean value like GetBool, with errors coded as for GetRequiredInt
+func (c *Config) GetRequiredBool(key string) (bool, error) {
+	return getRequired(c, key, c.GetBool)
+}
+
+// GetRequiredDuration retrieves a duration value like GetDuration, with errors coded as for GetRequiredInt
+func (c *Config) GetRequiredDuration(key string) (time.Duration, error) {
+	return getRequired(c, key, c.GetDuration)
+}
+
+// GetRequiredTime retrieves a time value like GetTime, with errors coded as for GetRequiredInt
+func (c *Config) GetRequiredTime(key string) (time.Time, error) {
+	return getRequired(c, key, c.GetTime)
+}
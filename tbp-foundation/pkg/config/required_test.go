@@ -0,0 +1,132 @@
+// File: required_test.go
+// Title: Tests for Required Configuration Accessors
+// Description: Tests the values and error codes returned by the
+//              GetRequired accessors for present, missing, and
+//              unconvertible keys.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+
+package config
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/msto63/tbp/tbp-foundation/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_GetRequired(t *testing.T) {
+	config, err := New(context.Background(), LoadOptions{
+		Environment: "test",
+		Sources: []Source{&mockSource{name: "file", priority: 50, values: map[string]interface{}{
+			"server.host":    "localhost",
+			"server.port":    "8080",
+			"server.timeout": "30s",
+			"server.debug":   "yes",
+			"limits.ratio":   0.75,
+			"limits.bytes":   int64(1 << 40),
+			"release.date":   "2026-10-16T00:00:00Z",
+			"invalid":        "not-a-number",
+		}}},
+	})
+	require.NoError(t, err)
+	defer config.Close()
+
+	t.Run("returns present values", func(t *testing.T) {
+		host, err := config.GetRequiredString("server.host")
+		require.NoError(t, err)
+		assert.Equal(t, "localhost", host)
+
+		port, err := config.GetRequiredInt("server.port")
+		require.NoError(t, err)
+		assert.Equal(t, 8080, port)
+
+		bytes, err := config.GetRequiredInt64("limits.bytes")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1<<40), bytes)
+
+		unsigned, err := config.GetRequiredUint64("server.port")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(8080), unsigned)
+
+		ratio, err := config.GetRequiredFloat("limits.ratio")
+		require.NoError(t, err)
+		assert.Equal(t, 0.75, ratio)
+
+		debug, err := config.GetRequiredBool("server.debug")
+		require.NoError(t, err)
+		assert.True(t, debug)
+
+		timeout, err := config.GetRequiredDuration("server.timeout")
+		require.NoError(t, err)
+		assert.Equal(t, 30*time.Second, timeout)
+
+		date, err := config.GetRequiredTime("release.date")
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), date.UTC())
+	})
+
+	t.Run("reports missing keys as not found", func(t *testing.T) {
+		getters := map[string]func(string) error{
+			"string":   func(key string) error { _, err := config.GetRequiredString(key); return err },
+			"int":      func(key string) error { _, err := config.GetRequiredInt(key); return err },
+			"int64":    func(key string) error { _, err := config.GetRequiredInt64(key); return err },
+			"uint64":   func(key string) error { _, err := config.GetRequiredUint64(key); return err },
+			"float":    func(key string) error { _, err := config.GetRequiredFloat(key); return err },
+			"bool":     func(key string) error { _, err := config.GetRequiredBool(key); return err },
+			"duration": func(key string) error { _, err := config.GetRequiredDuration(key); return err },
+			"time":     func(key string) error { _, err := config.GetRequiredTime(key); return err },
+		}
+
+		for name, get := range getters {
+			t.Run(name, func(t *testing.T) {
+				err := get("database.url")
+				require.Error(t, err)
+				assert.True(t, core.IsNotFound(err))
+				assert.True(t, errors.Is(err, core.ErrNotFound))
+				assert.Contains(t, err.Error(), "database.url")
+
+				var tbpErr *core.Error
+				require.ErrorAs(t, err, &tbpErr)
+				assert.Equal(t, "database.url", tbpErr.Context["key"])
+			})
+		}
+	})
+
+	t.Run("reports unconvertible values as invalid input", func(t *testing.T) {
+		getters := map[string]func(string) error{
+			"int":      func(key string) error { _, err := config.GetRequiredInt(key); return err },
+			"int64":    func(key string) error { _, err := config.GetRequiredInt64(key); return err },
+			"uint64":   func(key string) error { _, err := config.GetRequiredUint64(key); return err },
+			"float":    func(key string) error { _, err := config.GetRequiredFloat(key); return err },
+			"bool":     func(key string) error { _, err := config.GetRequiredBool(key); return err },
+			"duration": func(key string) error { _, err := config.GetRequiredDuration(key); return err },
+			"time":     func(key string) error { _, err := config.GetRequiredTime(key); return err },
+		}
+
+		for name, get := range getters {
+			t.Run(name, func(t *testing.T) {
+				err := get("invalid")
+				require.Error(t, err)
+				assert.True(t, core.IsInvalidInput(err))
+				assert.False(t, core.IsNotFound(err))
+
+				code, _ := core.GetCode(err)
+				assert.Equal(t, core.ErrCodeInvalidInput, code)
+
+				var tbpErr *core.Error
+				require.ErrorAs(t, err, &tbpErr)
+				assert.Equal(t, "invalid", tbpErr.Context["key"])
+			})
+		}
+	})
+}
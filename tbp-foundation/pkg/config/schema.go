@@ -0,0 +1,248 @@
+// File: schema.go
+// Title: JSON Schema Export for Configuration Metadata
+// Description: Translates the field metadata of a configuration into a
+//              JSON Schema (draft-07) document, so that configuration files
+//              can be validated by external tools, e.g. in CI before a
+//              deployment. Dotted field names become nested objects.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.1
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial JSON Schema export
+// - 2026-10-16 v0.1.1: Export minLength and maxLength
+
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/msto63/tbp/tbp-foundation/pkg/core"
+)
+
+// JSONSchemaDraft07 is the $schema URI of exported schemas
+const JSONSchemaDraft07 = "http://json-schema.org/draft-07/schema#"
+
+// ExportJSONSchema returns a draft-07 JSON Schema describing the fields of
+// the configuration metadata. A field "server.port" is described as the
+// property "port" of the object "server"; objects containing a required
+// field are required themselves. Field types are mapped as follows:
+//
+//   - int, int32, int64, integer: "integer"
+//   - float32, float64, float: "number"
+//   - bool, boolean: "boolean"
+//   - duration and duration hints (e.g. duration_ms): "string" or "number"
+//   - time: "string"
+//   - map, object: "object"
+//   - []T: "array" with items of type T
+//
+// Fields of other types are described without a type constraint. Enum,
+// Pattern, MinValue, MaxValue, MinLength, MaxLength, Description, and
+// DefaultValue are exported as enum, pattern, minimum, maximum, minLength,
+// maxLength, description, and default. Sensitive
+// fields are marked writeOnly and never export their default value.
+func (c *Config) ExportJSONSchema() ([]byte, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	root := newSchemaObject()
+	root["$schema"] = JSONSchemaDraft07
+	if c.metadata != nil && c.metadata.Name != "" {
+		root["title"] = c.metadata.Name
+	}
+
+	var fieldNames []string
+	if c.metadata != nil {
+		for fieldName := range c.metadata.Fields {
+			fieldNames = append(fieldNames, fieldName)
+		}
+	}
+	sort.Strings(fieldNames)
+
+	for _, fieldName := range fieldNames {
+		if err := c.addSchemaField(root, fieldName, c.metadata.Fields[fieldName]); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return nil, core.Wrap(err, "failed to encode JSON schema")
+	}
+	return data, nil
+}
+
+// addSchemaField adds the schema of a field below root, creating objects
+// for the segments of a dotted field name
+func (c *Config) addSchemaField(root map[string]interface{}, fieldName string, field Field) error {
+	segments := strings.Split(fieldName, ".")
+	parent := root
+
+	for i, segment := range segments[:len(segments)-1] {
+		properties := parent["properties"].(map[string]interface{})
+		node, exists := properties[segment].(map[string]interface{})
+		if !exists {
+			node = newSchemaObject()
+			properties[segment] = node
+		} else if _, isObject := node["properties"]; !isObject {
+			return core.Newf("field '%s' conflicts with field '%s'",
+				fieldName, strings.Join(segments[:i+1], ".")).WithCode(core.ErrCodeInvalidInput)
+		}
+		if field.Required {
+			addSchemaRequired(parent, segment)
+		}
+		parent = node
+	}
+
+	name := segments[len(segments)-1]
+	properties := parent["properties"].(map[string]interface{})
+	if _, exists := properties[name]; exists {
+		return core.Newf("field '%s' conflicts with a nested field", fieldName).WithCode(core.ErrCodeInvalidInput)
+	}
+	properties[name] = c.fieldSchema(field)
+	if field.Required {
+		addSchemaRequired(parent, name)
+	}
+	return nil
+}
+
+// fieldSchema returns the schema of a single field
+func (c *Config) fieldSchema(field Field) map[string]interface{} {
+	schema := make(map[string]interface{})
+
+	schemaType, items := c.schemaType(field.Type)
+	if schemaType != nil {
+		schema["type"] = schemaType
+	}
+	if items != nil {
+		schema["items"] = map[string]interface{}{"type": items}
+	}
+
+	if field.Description != "" {
+		schema["description"] = field.Description
+	}
+	if len(field.Enum) > 0 {
+		schema["enum"] = schemaEnum(field.Enum, schemaType)
+	}
+	if field.Pattern != "" {
+		schema["pattern"] = field.Pattern
+	}
+	if field.MinLength > 0 {
+		schema["minLength"] = field.MinLength
+	}
+	if field.MaxLength > 0 {
+		schema["maxLength"] = field.MaxLength
+	}
+	if schemaType == "integer" || schemaType == "number" {
+		if isNumber(field.MinValue) {
+			schema["minimum"] = field.MinValue
+		}
+		if isNumber(field.MaxValue) {
+			schema["maximum"] = field.MaxValue
+		}
+	}
+
+	if field.Sensitive {
+		schema["writeOnly"] = true
+	} else if field.DefaultValue != nil {
+		if d, ok := field.DefaultValue.(time.Duration); ok {
+			schema["default"] = d.String()
+		} else {
+			schema["default"] = field.DefaultValue
+		}
+	}
+
+	return schema
+}
+
+// schemaType maps a field type to a JSON Schema type, and for slices the
+// type of the items. Returns nil for types without a schema equivalent.
+func (c *Config) schemaType(fieldType string) (schemaType interface{}, items interface{}) {
+	if elem, isSlice := strings.CutPrefix(fieldType, "[]"); isSlice {
+		items, _ = c.schemaType(elem)
+		return "array", items
+	}
+	if _, isDurationHint := durationUnitHints[fieldType]; isDurationHint || fieldType == "duration" {
+		return []string{"string", "number"}, nil
+	}
+
+	switch c.normalizeTypeName(fieldType) {
+	case "integer":
+		return "integer", nil
+	case "float", "number":
+		return "number", nil
+	case "boolean":
+		return "boolean", nil
+	case "string", "time":
+		return "string", nil
+	case "map", "object":
+		return "object", nil
+	default:
+		return nil, nil
+	}
+}
+
+// schemaEnum converts enum values to the JSON type of the field, since
+// Field.Enum holds the string form of the allowed values
+func schemaEnum(values []string, schemaType interface{}) []interface{} {
+	enum := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		var converted interface{} = value
+		switch schemaType {
+		case "integer":
+			if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+				converted = n
+			}
+		case "number":
+			if f, err := strconv.ParseFloat(value, 64); err == nil {
+				converted = f
+			}
+		case "boolean":
+			if b, err := strconv.ParseBool(value); err == nil {
+				converted = b
+			}
+		}
+		enum = append(enum, converted)
+	}
+	return enum
+}
+
+// newSchemaObject returns the schema of an object without properties
+func newSchemaObject() map[string]interface{} {
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": make(map[string]interface{}),
+	}
+}
+
+// addSchemaRequired adds name to the required properties of an object schema
+func addSchemaRequired(schema map[string]interface{}, name string) {
+	required, _ := schema["required"].([]string)
+	for _, existing := range required {
+		if existing == name {
+			return
+		}
+	}
+	schema["required"] = append(required, name)
+}
+
+// isNumber reports whether value is of a numeric kind
+func isNumber(value interface{}) bool {
+	if value == nil {
+		return false
+	}
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,264 @@
+// File: schema_test.go
+// Title: Tests for JSON Schema Export
+// Description: Tests the translation of field metadata into a draft-07
+//              JSON Schema and checks the exported schema against known
+//              good and bad configuration documents.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.1
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+// - 2026-10-16 v0.1.1: Added length export tests
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/msto63/tbp/tbp-foundation/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func schemaTestConfig() *Config {
+	return &Config{
+		values: map[string]interface{}{},
+		metadata: &Metadata{
+			Name: "orders-service",
+			Fields: map[string]Field{
+				"server.port":     {Type: "int", Required: true, MinValue: 1, MaxValue: 65535, DefaultValue: 8080},
+				"server.host":     {Type: "string", Description: "Listen address"},
+				"server.timeout":  {Type: "duration_ms"},
+				"log.level":       {Type: "string", Enum: []string{"debug", "info", "error"}},
+				"log.sampling":    {Type: "float64", MinValue: 0.0, MaxValue: 1.0},
+				"database.dsn":    {Type: "string", Required: true, Sensitive: true, DefaultValue: "postgres://secret"},
+				"database.ssl":    {Type: "bool"},
+				"app.name":        {Type: "string", Pattern: "^[a-z-]+$", MinLength: 2, MaxLength: 32},
+				"app.tags":        {Type: "[]string"},
+				"app.replicas":    {Type: "int", Enum: []string{"1", "3", "5"}},
+				"features":        {Type: "map"},
+				"untyped.setting": {},
+			},
+		},
+	}
+}
+
+func TestConfig_ExportJSONSchema(t *testing.T) {
+	data, err := schemaTestConfig().ExportJSONSchema()
+	require.NoError(t, err)
+
+	var schema map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &schema))
+
+	t.Run("describes nested fields", func(t *testing.T) {
+		assert.Equal(t, JSONSchemaDraft07, schema["$schema"])
+		assert.Equal(t, "orders-service", schema["title"])
+		assert.ElementsMatch(t, []interface{}{"database", "server"}, schema["required"])
+
+		server := schemaProperty(t, schema, "server")
+		assert.Equal(t, []interface{}{"port"}, server["required"])
+
+		port := schemaProperty(t, server, "port")
+		assert.Equal(t, "integer", port["type"])
+		assert.Equal(t, float64(1), port["minimum"])
+		assert.Equal(t, float64(65535), port["maximum"])
+		assert.Equal(t, float64(8080), port["default"])
+
+		assert.Equal(t, "Listen address", schemaProperty(t, server, "host")["description"])
+		assert.Equal(t, []interface{}{"string", "number"}, schemaProperty(t, server, "timeout")["type"])
+	})
+
+	t.Run("maps enums, patterns, lengths, and arrays", func(t *testing.T) {
+		log := schemaProperty(t, schema, "log")
+		assert.Equal(t, []interface{}{"debug", "info", "error"}, schemaProperty(t, log, "level")["enum"])
+		assert.Equal(t, "number", schemaProperty(t, log, "sampling")["type"])
+
+		app := schemaProperty(t, schema, "app")
+		assert.Equal(t, "^[a-z-]+$", schemaProperty(t, app, "name")["pattern"])
+		assert.Equal(t, float64(2), schemaProperty(t, app, "name")["minLength"])
+		assert.Equal(t, float64(32), schemaProperty(t, app, "name")["maxLength"])
+		assert.NotContains(t, schemaProperty(t, schemaProperty(t, schema, "server"), "host"), "minLength")
+		assert.Equal(t, []interface{}{float64(1), float64(3), float64(5)}, schemaProperty(t, app, "replicas")["enum"])
+
+		tags := schemaProperty(t, app, "tags")
+		assert.Equal(t, "array", tags["type"])
+		assert.Equal(t, map[string]interface{}{"type": "string"}, tags["items"])
+
+		assert.Equal(t, "object", schemaProperty(t, schema, "features")["type"])
+		assert.NotContains(t, schemaProperty(t, schemaProperty(t, schema, "untyped"), "setting"), "type")
+	})
+
+	t.Run("does not expose sensitive defaults", func(t *testing.T) {
+		dsn := schemaProperty(t, schemaProperty(t, schema, "database"), "dsn")
+		assert.Equal(t, true, dsn["writeOnly"])
+		assert.NotContains(t, dsn, "default")
+		assert.NotContains(t, string(data), "postgres://secret")
+	})
+
+	t.Run("validates configuration documents", func(t *testing.T) {
+		good := `{
+			"server": {"port": 8080, "host": "0.0.0.0", "timeout": "30s"},
+			"log": {"level": "info", "sampling": 0.5},
+			"database": {"dsn": "postgres://db", "ssl": true},
+			"app": {"name": "orders", "tags": ["a", "b"], "replicas": 3},
+			"features": {"beta": true}
+		}`
+		assert.Empty(t, validateJSONSchema(t, schema, good))
+
+		bad := `{
+			"server": {"port": 70000, "timeout": true},
+			"log": {"level": "verbose", "sampling": 2},
+			"database": {"ssl": "yes"},
+			"app": {"name": "Orders!", "tags": [1], "replicas": 2}
+		}`
+		violations := validateJSONSchema(t, schema, bad)
+		assert.ElementsMatch(t, []string{
+			"server.port: above maximum",
+			"server.timeout: wrong type",
+			"log.level: not in enum",
+			"log.sampling: above maximum",
+			"database: missing required dsn",
+			"database.ssl: wrong type",
+			"app.name: does not match pattern",
+			"app.tags.0: wrong type",
+			"app.replicas: not in enum",
+		}, violations)
+
+		assert.Equal(t, []string{": missing required database", ": missing required server"},
+			validateJSONSchema(t, schema, `{}`))
+	})
+
+	t.Run("rejects conflicting fields", func(t *testing.T) {
+		config := &Config{metadata: &Metadata{Fields: map[string]Field{
+			"server":      {Type: "string"},
+			"server.port": {Type: "int"},
+		}}}
+		_, err := config.ExportJSONSchema()
+		require.Error(t, err)
+		assert.True(t, core.IsCode(err, core.ErrCodeInvalidInput))
+	})
+}
+
+// schemaProperty returns the schema of a property of an object schema
+func schemaProperty(t *testing.T, schema map[string]interface{}, name string) map[string]interface{} {
+	t.Helper()
+	properties, ok := schema["properties"].(map[string]interface{})
+	require.True(t, ok, "schema has no properties")
+	property, ok := properties[name].(map[string]interface{})
+	require.True(t, ok, "schema has no property %s", name)
+	return property
+}
+
+// validateJSONSchema checks a JSON document against the subset of draft-07
+// used by ExportJSONSchema and returns the violations found
+func validateJSONSchema(t *testing.T, schema map[string]interface{}, document string) []string {
+	t.Helper()
+	var value interface{}
+	require.NoError(t, json.Unmarshal([]byte(document), &value))
+
+	var violations []string
+	validateSchemaValue(schema, value, "", &violations)
+	return violations
+}
+
+func validateSchemaValue(schema map[string]interface{}, value interface{}, path string, violations *[]string) {
+	report := func(message string) {
+		*violations = append(*violations, path+": "+message)
+	}
+
+	if schemaType, ok := schema["type"]; ok && !schemaTypeMatches(schemaType, value) {
+		report("wrong type")
+		return
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, allowed := range enum {
+			found = found || reflect.DeepEqual(allowed, value)
+		}
+		if !found {
+			report("not in enum")
+		}
+	}
+	if pattern, ok := schema["pattern"].(string); ok {
+		if str, isString := value.(string); isString && !regexp.MustCompile(pattern).MatchString(str) {
+			report("does not match pattern")
+		}
+	}
+	if number, isNumber := value.(float64); isNumber {
+		if minimum, ok := schema["minimum"].(float64); ok && number < minimum {
+			report("below minimum")
+		}
+		if maximum, ok := schema["maximum"].(float64); ok && number > maximum {
+			report("above maximum")
+		}
+	}
+
+	join := func(name string) string {
+		if path == "" {
+			return name
+		}
+		return path + "." + name
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if required, ok := schema["required"].([]interface{}); ok {
+			for _, name := range required {
+				if _, exists := v[name.(string)]; !exists {
+					report(fmt.Sprintf("missing required %s", name))
+				}
+			}
+		}
+		properties, _ := schema["properties"].(map[string]interface{})
+		for name, property := range properties {
+			if child, exists := v[name]; exists {
+				validateSchemaValue(property.(map[string]interface{}), child, join(name), violations)
+			}
+		}
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				validateSchemaValue(items, item, join(fmt.Sprint(i)), violations)
+			}
+		}
+	}
+}
+
+func schemaTypeMatches(schemaType interface{}, value interface{}) bool {
+	if types, ok := schemaType.([]interface{}); ok {
+		for _, candidate := range types {
+			if schemaTypeMatches(candidate, value) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch schemaType {
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		number, ok := value.(float64)
+		return ok && number == float64(int64(number))
+	}
+	return true
+}
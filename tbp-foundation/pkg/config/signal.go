@@ -0,0 +1,52 @@
+// File: signal.go
+// Title: Signal-Triggered Configuration Reload for TBP
+// Description: Reloads configuration when the process receives an OS signal
+//              (SIGHUP by default), so operators can trigger reloads with
+//              kill -HUP in environments without file watching.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial ReloadOnSignal implementation
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// ReloadOnSignal reloads the configuration whenever one of the given
+// signals is received, defaulting to SIGHUP. Reloads behave like hot
+// reloads: they are validated if validation is enabled, and their outcome
+// is recorded in ReloadStats, LastReloadError, and the metrics hooks.
+// The handler is installed before ReloadOnSignal returns and is removed
+// when ctx is cancelled or the configuration is closed.
+func (c *Config) ReloadOnSignal(ctx context.Context, signals ...os.Signal) {
+	if len(signals) == 0 {
+		signals = []os.Signal{syscall.SIGHUP}
+	}
+
+	received := make(chan os.Signal, 1)
+	signal.Notify(received, signals...)
+
+	go func() {
+		defer signal.Stop(received)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-c.done:
+				return
+			case sig := <-received:
+				c.reloadFromWatch(ctx, fmt.Sprintf("signal %s", sig))
+			}
+		}
+	}()
+}
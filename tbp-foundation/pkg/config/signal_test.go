@@ -0,0 +1,94 @@
+// File: signal_test.go
+// Title: Tests for Signal-Triggered Configuration Reload
+// Description: Sends signals to the test process and verifies that the
+//              configuration is reloaded and that the handler stops.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.1
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+// - 2026-10-16 v0.1.1: Synchronized mock source updates; replaced sleeps with Eventually
+
+package config
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sendSignal sends a signal to the current process
+func sendSignal(t *testing.T, sig os.Signal) {
+	t.Helper()
+
+	process, err := os.FindProcess(os.Getpid())
+	require.NoError(t, err)
+	if err := process.Signal(sig); err != nil {
+		t.Skipf("sending %s is not supported: %v", sig, err)
+	}
+}
+
+func TestConfig_ReloadOnSignal(t *testing.T) {
+	t.Run("reloads on SIGHUP by default", func(t *testing.T) {
+		source := &mockSource{values: map[string]interface{}{"log.level": "info"}}
+		config, err := New(context.Background(), LoadOptions{Environment: "test", Sources: []Source{source}})
+		require.NoError(t, err)
+		defer config.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		config.ReloadOnSignal(ctx)
+
+		source.setValues(map[string]interface{}{"log.level": "debug"})
+		sendSignal(t, syscall.SIGHUP)
+
+		assert.Eventually(t, func() bool {
+			level, _ := config.GetString("log.level")
+			return level == "debug"
+		}, 2*time.Second, 10*time.Millisecond)
+		assert.Equal(t, uint64(2), config.ReloadStats().Successful)
+	})
+
+	t.Run("stops reloading after context cancellation", func(t *testing.T) {
+		config, err := New(context.Background(), LoadOptions{
+			Environment: "test",
+			Sources:     []Source{&mockSource{values: map[string]interface{}{"key": "value"}}},
+		})
+		require.NoError(t, err)
+		defer config.Close()
+
+		// Keep SIGHUP from terminating the process after the handler stops
+		ctx, cancel := context.WithCancel(context.Background())
+		keepAlive, stopKeepAlive := context.WithCancel(context.Background())
+		defer stopKeepAlive()
+		config.ReloadOnSignal(keepAlive, syscall.SIGHUP)
+		config.ReloadOnSignal(ctx, syscall.SIGHUP)
+
+		sendSignal(t, syscall.SIGHUP)
+		assert.Eventually(t, func() bool {
+			return config.ReloadStats().Successful >= 2
+		}, 2*time.Second, 10*time.Millisecond)
+
+		// The cancelled handler stops asynchronously; once it has, each
+		// signal is only handled by the keep-alive handler
+		cancel()
+		assert.Eventually(t, func() bool {
+			before := config.ReloadStats().Successful
+			sendSignal(t, syscall.SIGHUP)
+
+			deadline := time.Now().Add(time.Second)
+			for config.ReloadStats().Successful == before && time.Now().Before(deadline) {
+				time.Sleep(5 * time.Millisecond)
+			}
+			time.Sleep(20 * time.Millisecond) // Leave time for a second reload
+			return config.ReloadStats().Successful == before+1
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+}
@@ -0,0 +1,195 @@
+// File: snapshot.go
+// Title: Configuration Snapshots for TBP
+// Description: Captures immutable point-in-time copies of configuration
+//              values with their sources for change auditing, computes
+//              structured diffs between snapshots, and supports redaction
+//              of sensitive keys before logging or serialization.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.1
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial snapshot and diff implementation
+// - 2026-10-16 v0.1.1: Snapshots include keys reported by sensitive sources
+
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// RedactedValue replaces sensitive values in redacted snapshots
+const RedactedValue = "[REDACTED]"
+
+// ConfigSnapshot is an immutable point-in-time copy of configuration
+// values together with the time it was taken and the active sources.
+// Accessors return copies, so a snapshot never changes after creation.
+type ConfigSnapshot struct {
+	values    map[string]interface{}
+	takenAt   time.Time
+	sources   []SourceInfo
+	sensitive []string
+}
+
+// snapshotJSON is the serialized form of a ConfigSnapshot
+type snapshotJSON struct {
+	TakenAt time.Time              `json:"taken_at"`
+	Sources []SourceInfo           `json:"sources"`
+	Values  map[string]interface{} `json:"values"`
+}
+
+// Snapshot captures the current configuration values and sources.
+// Fields marked as Sensitive in the metadata and keys reported by a
+// SensitiveSource are remembered so that Redacted can mask them.
+func (c *Config) Snapshot() ConfigSnapshot {
+	sources := c.GetSources()
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var sensitive []string
+	for name, field := range c.metadata.Fields {
+		if field.Sensitive {
+			sensitive = append(sensitive, name)
+		}
+	}
+	for key := range c.sourceSensitiveKeys {
+		if field, exists := c.metadata.Fields[key]; !exists || !field.Sensitive {
+			sensitive = append(sensitive, key)
+		}
+	}
+
+	return ConfigSnapshot{
+		values:    copySnapshotValues(c.values),
+		takenAt:   time.Now(),
+		sources:   sources,
+		sensitive: sensitive,
+	}
+}
+
+// TakenAt returns when the snapshot was captured.
+func (s ConfigSnapshot) TakenAt() time.Time {
+	return s.takenAt
+}
+
+// Sources returns the sources active when the snapshot was captured.
+func (s ConfigSnapshot) Sources() []SourceInfo {
+	return append([]SourceInfo(nil), s.sources...)
+}
+
+// Values returns a copy of all captured configuration values.
+func (s ConfigSnapshot) Values() map[string]interface{} {
+	return copySnapshotValues(s.values)
+}
+
+// Get returns a copy of a captured configuration value.
+func (s ConfigSnapshot) Get(key string) (interface{}, bool) {
+	value, exists := s.values[key]
+	return copySnapshotValue(value), exists
+}
+
+// Len returns the number of captured configuration keys.
+func (s ConfigSnapshot) Len() int {
+	return len(s.values)
+}
+
+// Redacted returns a copy of the snapshot in which the values of sensitive
+// fields and of the given additional keys are replaced by RedactedValue.
+// A key also redacts all keys nested below it (e.g. "database" redacts
+// "database.password").
+func (s ConfigSnapshot) Redacted(keys ...string) ConfigSnapshot {
+	redactKeys := append(append([]string(nil), s.sensitive...), keys...)
+
+	values := copySnapshotValues(s.values)
+	for key := range values {
+		for _, redactKey := range redactKeys {
+			if key == redactKey || strings.HasPrefix(key, redactKey+".") {
+				values[key] = RedactedValue
+				break
+			}
+		}
+	}
+
+	return ConfigSnapshot{
+		values:    values,
+		takenAt:   s.takenAt,
+		sources:   s.Sources(),
+		sensitive: append([]string(nil), s.sensitive...),
+	}
+}
+
+// Equal reports whether two snapshots contain the same configuration
+// values. Capture time and sources are not compared.
+func (s ConfigSnapshot) Equal(other ConfigSnapshot) bool {
+	if len(s.values) != len(other.values) {
+		return false
+	}
+	return reflect.DeepEqual(s.values, other.values)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// Values are serialized as captured; use Redacted first to mask secrets.
+func (s ConfigSnapshot) MarshalJSON() ([]byte, error) {
+	values := s.values
+	if values == nil {
+		values = map[string]interface{}{}
+	}
+
+	return json.Marshal(snapshotJSON{
+		TakenAt: s.takenAt,
+		Sources: s.sources,
+		Values:  values,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (s *ConfigSnapshot) UnmarshalJSON(data []byte) error {
+	var decoded snapshotJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	*s = ConfigSnapshot{
+		values:  decoded.Values,
+		takenAt: decoded.TakenAt,
+		sources: decoded.Sources,
+	}
+	return nil
+}
+
+// DiffSnapshots computes the changes between two snapshots using the same
+// rules as change detection during reloads.
+func DiffSnapshots(before, after ConfigSnapshot) map[string]ConfigChange {
+	return diffValues(before.values, after.values)
+}
+
+// copySnapshotValues deep-copies a set of configuration values
+func copySnapshotValues(values map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		result[key] = copySnapshotValue(value)
+	}
+	return result
+}
+
+// copySnapshotValue deep-copies nested maps and slices of a configuration value
+func copySnapshotValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return copySnapshotValues(v)
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = copySnapshotValue(item)
+		}
+		return result
+	case []string:
+		return append([]string(nil), v...)
+	default:
+		return v
+	}
+}
@@ -0,0 +1,153 @@
+// File: snapshot_test.go
+// Title: Tests for Configuration Snapshots
+// Description: Test suite for configuration snapshots including immutability,
+//              diffs between snapshots, redaction of sensitive keys, equality,
+//              and JSON round trips.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSnapshotTestConfig(t *testing.T, source *mockSource) *Config {
+	config, err := New(context.Background(), LoadOptions{
+		Environment: "test",
+		Sources:     []Source{source},
+	})
+	require.NoError(t, err)
+	return config
+}
+
+func TestConfig_Snapshot(t *testing.T) {
+	t.Run("captures values and sources", func(t *testing.T) {
+		config := newSnapshotTestConfig(t, &mockSource{
+			name:     "file",
+			priority: 50,
+			values:   map[string]interface{}{"app.name": "tbp", "app.port": 8080},
+		})
+
+		snapshot := config.Snapshot()
+		assert.Equal(t, 2, snapshot.Len())
+		assert.False(t, snapshot.TakenAt().IsZero())
+		require.Len(t, snapshot.Sources(), 1)
+		assert.Equal(t, "file", snapshot.Sources()[0].Name)
+
+		port, ok := snapshot.Get("app.port")
+		require.True(t, ok)
+		assert.Equal(t, 8080, port)
+	})
+
+	t.Run("is not affected by later changes", func(t *testing.T) {
+		source := &mockSource{
+			values: map[string]interface{}{
+				"app.name":  "tbp",
+				"app.hosts": []interface{}{"a", "b"},
+			},
+		}
+		config := newSnapshotTestConfig(t, source)
+		snapshot := config.Snapshot()
+
+		source.values = map[string]interface{}{"app.name": "changed"}
+		require.NoError(t, config.Load(context.Background()))
+
+		values := snapshot.Values()
+		values["app.name"] = "mutated"
+		values["app.hosts"].([]interface{})[0] = "mutated"
+
+		name, _ := snapshot.Get("app.name")
+		hosts, _ := snapshot.Get("app.hosts")
+		assert.Equal(t, "tbp", name)
+		assert.Equal(t, []interface{}{"a", "b"}, hosts)
+	})
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	source := &mockSource{
+		values: map[string]interface{}{
+			"app.name":    "tbp",
+			"app.port":    8080,
+			"app.debug":   true,
+			"app.timeout": "30s",
+		},
+	}
+	config := newSnapshotTestConfig(t, source)
+	before := config.Snapshot()
+
+	source.values = map[string]interface{}{
+		"app.name":    "tbp",
+		"app.port":    9090,
+		"app.timeout": "30s",
+		"app.region":  "eu",
+	}
+	require.NoError(t, config.Load(context.Background()))
+	after := config.Snapshot()
+
+	changes := DiffSnapshots(before, after)
+	require.Len(t, changes, 3)
+
+	assert.Equal(t, ChangeActionUpdate, changes["app.port"].Action)
+	assert.Equal(t, 8080, changes["app.port"].OldValue)
+	assert.Equal(t, 9090, changes["app.port"].NewValue)
+	assert.Equal(t, ChangeActionDelete, changes["app.debug"].Action)
+	assert.Equal(t, ChangeActionAdd, changes["app.region"].Action)
+
+	assert.Empty(t, DiffSnapshots(after, after))
+	assert.False(t, before.Equal(after))
+	assert.True(t, after.Equal(config.Snapshot()))
+}
+
+func TestConfigSnapshot_Redacted(t *testing.T) {
+	config := newSnapshotTestConfig(t, &mockSource{
+		values: map[string]interface{}{
+			"app.name":          "tbp",
+			"database.password": "secret",
+			"api.key":           "key-123",
+			"auth.token.value":  "token",
+		},
+	})
+	config.AddFieldMetadata("database.password", Field{Name: "database.password", Sensitive: true})
+
+	snapshot := config.Snapshot()
+	redacted := snapshot.Redacted("auth")
+
+	values := redacted.Values()
+	assert.Equal(t, "tbp", values["app.name"])
+	assert.Equal(t, RedactedValue, values["database.password"])
+	assert.Equal(t, RedactedValue, values["auth.token.value"])
+	assert.Equal(t, "key-123", values["api.key"])
+
+	original, _ := snapshot.Get("database.password")
+	assert.Equal(t, "secret", original, "redaction must not modify the original snapshot")
+}
+
+func TestConfigSnapshot_JSON(t *testing.T) {
+	config := newSnapshotTestConfig(t, &mockSource{
+		name:   "file",
+		values: map[string]interface{}{"app.name": "tbp", "app.debug": true},
+	})
+	snapshot := config.Snapshot()
+
+	data, err := json.Marshal(snapshot)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"taken_at"`)
+	assert.Contains(t, string(data), `"app.name":"tbp"`)
+
+	var decoded ConfigSnapshot
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.True(t, snapshot.Equal(decoded))
+	assert.True(t, snapshot.TakenAt().Equal(decoded.TakenAt()))
+	assert.Equal(t, snapshot.Sources(), decoded.Sources())
+}
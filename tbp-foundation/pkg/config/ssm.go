@@ -0,0 +1,388 @@
+// File: ssm.go
+// Title: AWS SSM Parameter Store Configuration Source for TBP
+// Description: Provides a configuration source that reads all parameters
+//              below a path prefix from AWS Systems Manager Parameter Store,
+//              converts their names into dotted configuration keys, and
+//              marks decrypted SecureString parameters as sensitive.
+//              Secrets Manager secrets are read through the Parameter Store
+//              reference path. Optionally polls for changes.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial SSM Parameter Store source with polling watch
+
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/msto63/tbp/tbp-foundation/pkg/core"
+)
+
+// SSM parameter types
+const (
+	SSMParameterTypeString       = "String"
+	SSMParameterTypeStringList   = "StringList"
+	SSMParameterTypeSecureString = "SecureString"
+)
+
+// SSMSecretsManagerPrefix is the Parameter Store path under which Secrets
+// Manager secrets can be read
+const SSMSecretsManagerPrefix = "/aws/reference/secretsmanager/"
+
+// SSMParameter is a single Parameter Store parameter
+type SSMParameter struct {
+	Name    string // Full parameter name, e.g. /tbp/prod/database/host
+	Type    string // One of the SSMParameterType constants
+	Value   string // Parameter value, decrypted if requested
+	Version int64  // Parameter version
+}
+
+// SSMGetParametersByPathInput mirrors the input of the GetParametersByPath API
+type SSMGetParametersByPathInput struct {
+	Path           string
+	Recursive      bool
+	WithDecryption bool
+	NextToken      string
+}
+
+// SSMGetParametersByPathOutput mirrors the output of the GetParametersByPath API
+type SSMGetParametersByPathOutput struct {
+	Parameters []SSMParameter
+	NextToken  string // Empty on the last page
+}
+
+// SSMClient is the subset of the AWS SSM API used by SSMSource.
+// The foundation does not depend on the AWS SDK; services implement this
+// interface with a thin adapter around the SDK's ssm.Client, which is
+// created with config.LoadDefaultConfig and therefore takes region and
+// credentials from the standard AWS SDK chain:
+//
+//	type ssmAdapter struct{ client *ssm.Client }
+//
+//	func (a ssmAdapter) GetParametersByPath(ctx context.Context, in config.SSMGetParametersByPathInput) (*config.SSMGetParametersByPathOutput, error) {
+//		// Call a.client.GetParametersByPath and convert input and output
+//	}
+type SSMClient interface {
+	// GetParametersByPath returns one page of the parameters below a path
+	GetParametersByPath(ctx context.Context, input SSMGetParametersByPathInput) (*SSMGetParametersByPathOutput, error)
+
+	// GetParameter returns a single parameter
+	GetParameter(ctx context.Context, name string, withDecryption bool) (*SSMParameter, error)
+}
+
+// SSMSource implements the Source interface for parameters stored in AWS
+// Systems Manager Parameter Store. SecureString parameters and Secrets
+// Manager secrets are reported as sensitive, so Config redacts them in
+// GetAll, Summary, and snapshots. Parameter values are never included in
+// errors or log entries.
+type SSMSource struct {
+	// mu protects concurrent access to ssm source data
+	mu sync.RWMutex
+
+	// client performs the SSM API calls
+	client SSMClient
+
+	// path is the parameter path prefix, e.g. /tbp/prod/
+	path string
+
+	// recursive reads parameters in nested paths
+	recursive bool
+
+	// withDecryption decrypts SecureString parameters
+	withDecryption bool
+
+	// secretIDs are the Secrets Manager secrets to read
+	secretIDs []string
+
+	// keyPrefix is prepended to all loaded keys (optional)
+	keyPrefix string
+
+	// priority sets the source priority for merging
+	priority int
+
+	// pollInterval is the interval at which Watch re-reads parameters
+	pollInterval time.Duration
+
+	// values stores the loaded configuration values
+	values map[string]interface{}
+
+	// sensitive stores the keys of SecureString parameters and secrets
+	sensitive map[string]bool
+
+	// stopWatching is used to stop the polling loop
+	stopWatching chan struct{}
+
+	// stopOnce ensures stopWatching is closed only once
+	stopOnce sync.Once
+}
+
+// SSMSourceOptions configures SSM source creation
+type SSMSourceOptions struct {
+	Client       SSMClient     `json:"-"`             // SSM API client (required)
+	Path         string        `json:"path"`          // Parameter path prefix, e.g. /tbp/prod/ (required)
+	Recursive    *bool         `json:"recursive"`     // Read nested paths (default: true)
+	NoDecryption bool          `json:"no_decryption"` // Load SecureString parameters encrypted
+	SecretIDs    []string      `json:"secret_ids"`    // Secrets Manager secret names to read (optional)
+	KeyPrefix    string        `json:"key_prefix"`    // Prefix for all loaded keys (optional)
+	Priority     int           `json:"priority"`      // Source priority (default: 75)
+	PollInterval time.Duration `json:"poll_interval"` // Interval for polling changes (0 = no watching)
+}
+
+// NewSSMSource creates a new SSM Parameter Store configuration source.
+// No API call is made until Load is called.
+func NewSSMSource(opts SSMSourceOptions) (*SSMSource, error) {
+	if opts.Client == nil {
+		return nil, core.New("SSM client is required").WithCode(core.ErrCodeInvalidInput)
+	}
+	if !strings.HasPrefix(opts.Path, "/") {
+		return nil, core.New("SSM parameter path must start with '/'").WithCode(core.ErrCodeInvalidInput)
+	}
+
+	recursive := true
+	if opts.Recursive != nil {
+		recursive = *opts.Recursive
+	}
+	if opts.Priority == 0 {
+		opts.Priority = 75 // Above files, below environment variables
+	}
+
+	path := opts.Path
+	if !strings.HasSuffix(path, "/") {
+		path += "/"
+	}
+
+	return &SSMSource{
+		client:         opts.Client,
+		path:           path,
+		recursive:      recursive,
+		withDecryption: !opts.NoDecryption,
+		secretIDs:      append([]string(nil), opts.SecretIDs...),
+		keyPrefix:      strings.TrimSuffix(opts.KeyPrefix, "."),
+		priority:       opts.Priority,
+		pollInterval:   opts.PollInterval,
+		values:         make(map[string]interface{}),
+		sensitive:      make(map[string]bool),
+		stopWatching:   make(chan struct{}),
+	}, nil
+}
+
+// Name implements the Source interface
+func (ss *SSMSource) Name() string {
+	return "ssm:" + ss.path
+}
+
+// Priority implements the Source interface
+func (ss *SSMSource) Priority() int {
+	return ss.priority
+}
+
+// Load implements the Source interface. It reads all pages of parameters
+// below the path and the configured secrets. The parameter
+// /tbp/prod/database/host is loaded as database.host for the path
+// /tbp/prod/. StringList parameters are loaded as slices with indexed
+// keys, and secrets holding a JSON object are flattened below the
+// secret's key.
+func (ss *SSMSource) Load(ctx context.Context) (map[string]interface{}, error) {
+	values, sensitive, err := ss.read(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	ss.values = values
+	ss.sensitive = sensitive
+	return ss.copyValues(), nil
+}
+
+// SensitiveKeys implements the SensitiveSource interface. Returns the keys
+// of SecureString parameters and Secrets Manager secrets.
+func (ss *SSMSource) SensitiveKeys() []string {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+
+	keys := make([]string, 0, len(ss.sensitive))
+	for key := range ss.sensitive {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Watch implements the WatchableSource interface. Parameters are re-read
+// every PollInterval, and callback is called when any value has changed.
+// Returns nil without watching if no poll interval is configured.
+func (ss *SSMSource) Watch(ctx context.Context, callback func(map[string]interface{})) error {
+	if ss.pollInterval <= 0 {
+		return nil
+	}
+
+	go ss.pollLoop(ctx, callback)
+	return nil
+}
+
+// Stop stops the polling loop started by Watch. Safe to call multiple times.
+func (ss *SSMSource) Stop() {
+	ss.stopOnce.Do(func() { close(ss.stopWatching) })
+}
+
+// pollLoop re-reads the parameters until the context is done or Stop is called
+func (ss *SSMSource) pollLoop(ctx context.Context, callback func(map[string]interface{})) {
+	ticker := time.NewTicker(ss.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ss.stopWatching:
+			return
+		case <-ticker.C:
+		}
+
+		ss.mu.RLock()
+		previous := ss.values
+		ss.mu.RUnlock()
+
+		values, err := ss.Load(ctx)
+		if err != nil {
+			// The error never contains parameter values
+			core.ContextLogger(ctx).Error("failed to poll SSM parameters",
+				"source", ss.Name(), "error", err)
+			continue
+		}
+		if reflect.DeepEqual(previous, values) {
+			continue
+		}
+
+		core.SafeGo(ctx, func(context.Context) error {
+			callback(values)
+			return nil
+		}, func(err error) {
+			core.ContextLogger(ctx).Error("SSM watcher callback failed",
+				"source", ss.Name(), "error", err)
+		})
+	}
+}
+
+// read fetches all parameters and secrets without modifying the source
+func (ss *SSMSource) read(ctx context.Context) (map[string]interface{}, map[string]bool, error) {
+	values := make(map[string]interface{})
+	sensitive := make(map[string]bool)
+
+	input := SSMGetParametersByPathInput{
+		Path:           ss.path,
+		Recursive:      ss.recursive,
+		WithDecryption: ss.withDecryption,
+	}
+	seenTokens := make(map[string]bool)
+	for {
+		output, err := ss.client.GetParametersByPath(ctx, input)
+		if err != nil {
+			return nil, nil, core.Wrap(err, fmt.Sprintf("failed to read SSM parameters under %s", ss.path))
+		}
+
+		for _, param := range output.Parameters {
+			key := ss.parameterKey(param.Name)
+			if key == "" {
+				continue
+			}
+			// Flattening adds indexed keys for the items of StringList parameters
+			for flatKey, value := range flattenValues(map[string]interface{}{key: parameterValue(param)}, "") {
+				values[flatKey] = value
+				if param.Type == SSMParameterTypeSecureString {
+					sensitive[flatKey] = true
+				}
+			}
+		}
+
+		if output.NextToken == "" {
+			break
+		}
+		if seenTokens[output.NextToken] {
+			return nil, nil, core.Newf("SSM returned a repeated pagination token for %s", ss.path).
+				WithCode(core.ErrCodeInternal)
+		}
+		seenTokens[output.NextToken] = true
+		input.NextToken = output.NextToken
+	}
+
+	for _, secretID := range ss.secretIDs {
+		param, err := ss.client.GetParameter(ctx, SSMSecretsManagerPrefix+secretID, true)
+		if err != nil {
+			return nil, nil, core.Wrap(err, fmt.Sprintf("failed to read secret %s", secretID))
+		}
+
+		key := ss.prefixKey(nameToKey(secretID))
+		var object map[string]interface{}
+		if json.Unmarshal([]byte(param.Value), &object) == nil {
+			// Do not report decoding errors, which may quote the secret
+			for nestedKey, nestedValue := range flattenValues(object, key) {
+				values[nestedKey] = nestedValue
+				sensitive[nestedKey] = true
+			}
+			continue
+		}
+		values[key] = param.Value
+		sensitive[key] = true
+	}
+
+	return values, sensitive, nil
+}
+
+// parameterKey converts a parameter name below the path into a dotted key.
+// Returns an empty key for names outside the path.
+func (ss *SSMSource) parameterKey(name string) string {
+	relative, ok := strings.CutPrefix(name, ss.path)
+	if !ok {
+		return ""
+	}
+	return ss.prefixKey(nameToKey(relative))
+}
+
+// prefixKey prepends the key prefix, if any
+func (ss *SSMSource) prefixKey(key string) string {
+	if ss.keyPrefix == "" || key == "" {
+		return key
+	}
+	return ss.keyPrefix + "." + key
+}
+
+// copyValues returns a copy of the values map. The caller must hold the lock.
+func (ss *SSMSource) copyValues() map[string]interface{} {
+	result := make(map[string]interface{}, len(ss.values))
+	for key, value := range ss.values {
+		result[key] = value
+	}
+	return result
+}
+
+// nameToKey converts a /-separated name into a dotted key
+func nameToKey(name string) string {
+	return strings.ReplaceAll(strings.Trim(name, "/"), "/", ".")
+}
+
+// parameterValue returns the configuration value of a parameter
+func parameterValue(param SSMParameter) interface{} {
+	if param.Type == SSMParameterTypeStringList {
+		items := strings.Split(param.Value, ",")
+		list := make([]interface{}, len(items))
+		for i, item := range items {
+			list[i] = item
+		}
+		return list
+	}
+	return param.Value
+}
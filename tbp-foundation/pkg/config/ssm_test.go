@@ -0,0 +1,277 @@
+// File: ssm_test.go
+// Title: Tests for the SSM Parameter Store Configuration Source
+// Description: Tests reading parameters through a mocked SSM client,
+//              pagination, key conversion, sensitive SecureString keys,
+//              Secrets Manager references, error handling, and polling.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+
+package config
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/msto63/tbp/tbp-foundation/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockSSMClient serves parameters in pages of pageSize
+type mockSSMClient struct {
+	mu         sync.Mutex
+	parameters []SSMParameter
+	pageSize   int
+	err        error
+	calls      int
+	inputs     []SSMGetParametersByPathInput
+}
+
+func (m *mockSSMClient) setParameters(parameters []SSMParameter) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.parameters = parameters
+}
+
+func (m *mockSSMClient) callCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.calls
+}
+
+func (m *mockSSMClient) GetParametersByPath(ctx context.Context, input SSMGetParametersByPathInput) (*SSMGetParametersByPathOutput, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls++
+	m.inputs = append(m.inputs, input)
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	var matching []SSMParameter
+	for _, param := range m.parameters {
+		if !strings.HasPrefix(param.Name, input.Path) {
+			continue
+		}
+		if !input.Recursive && strings.Contains(strings.TrimPrefix(param.Name, input.Path), "/") {
+			continue
+		}
+		if param.Type == SSMParameterTypeSecureString && !input.WithDecryption {
+			param.Value = "encrypted:" + param.Name
+		}
+		matching = append(matching, param)
+	}
+
+	start := 0
+	if input.NextToken != "" {
+		start = len(input.NextToken)
+	}
+	end := len(matching)
+	if m.pageSize > 0 && start+m.pageSize < end {
+		end = start + m.pageSize
+	}
+
+	output := &SSMGetParametersByPathOutput{Parameters: matching[start:end]}
+	if end < len(matching) {
+		output.NextToken = strings.Repeat("n", end)
+	}
+	return output, nil
+}
+
+func (m *mockSSMClient) GetParameter(ctx context.Context, name string, withDecryption bool) (*SSMParameter, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, param := range m.parameters {
+		if param.Name == name {
+			return &param, nil
+		}
+	}
+	return nil, errors.New("ParameterNotFound")
+}
+
+func newMockSSMClient() *mockSSMClient {
+	return &mockSSMClient{
+		pageSize: 2,
+		parameters: []SSMParameter{
+			{Name: "/tbp/prod/database/host", Type: SSMParameterTypeString, Value: "db.internal"},
+			{Name: "/tbp/prod/database/port", Type: SSMParameterTypeString, Value: "5432"},
+			{Name: "/tbp/prod/database/password", Type: SSMParameterTypeSecureString, Value: "hunter2"},
+			{Name: "/tbp/prod/hosts", Type: SSMParameterTypeStringList, Value: "a,b"},
+			{Name: "/tbp/prod/log_level", Type: SSMParameterTypeString, Value: "info"},
+			{Name: "/tbp/staging/log_level", Type: SSMParameterTypeString, Value: "debug"},
+			{Name: SSMSecretsManagerPrefix + "prod/api", Type: SSMParameterTypeSecureString, Value: `{"key":"s3cr3t","client":"tbp"}`},
+			{Name: SSMSecretsManagerPrefix + "prod/token", Type: SSMParameterTypeSecureString, Value: "t0ken"},
+		},
+	}
+}
+
+func TestNewSSMSource(t *testing.T) {
+	t.Run("requires client and absolute path", func(t *testing.T) {
+		_, err := NewSSMSource(SSMSourceOptions{Path: "/tbp/prod/"})
+		assert.True(t, core.IsCode(err, core.ErrCodeInvalidInput))
+
+		_, err = NewSSMSource(SSMSourceOptions{Client: newMockSSMClient(), Path: "tbp/prod"})
+		assert.True(t, core.IsCode(err, core.ErrCodeInvalidInput))
+	})
+
+	t.Run("applies defaults", func(t *testing.T) {
+		source, err := NewSSMSource(SSMSourceOptions{Client: newMockSSMClient(), Path: "/tbp/prod"})
+		require.NoError(t, err)
+		assert.Equal(t, "ssm:/tbp/prod/", source.Name())
+		assert.Equal(t, 75, source.Priority())
+	})
+}
+
+func TestSSMSource_Load(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("reads all pages and converts names to keys", func(t *testing.T) {
+		client := newMockSSMClient()
+		source, err := NewSSMSource(SSMSourceOptions{Client: client, Path: "/tbp/prod/"})
+		require.NoError(t, err)
+
+		values, err := source.Load(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]interface{}{
+			"database.host":     "db.internal",
+			"database.port":     "5432",
+			"database.password": "hunter2",
+			"hosts":             []interface{}{"a", "b"},
+			"hosts.0":           "a",
+			"hosts.1":           "b",
+			"log_level":         "info",
+		}, values)
+		assert.Equal(t, 3, client.callCount())
+		assert.True(t, client.inputs[0].Recursive)
+		assert.True(t, client.inputs[0].WithDecryption)
+	})
+
+	t.Run("marks SecureString parameters sensitive", func(t *testing.T) {
+		source, err := NewSSMSource(SSMSourceOptions{Client: newMockSSMClient(), Path: "/tbp/prod/", KeyPrefix: "app."})
+		require.NoError(t, err)
+
+		_, err = source.Load(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"app.database.password"}, source.SensitiveKeys())
+	})
+
+	t.Run("honors recursion and decryption options", func(t *testing.T) {
+		recursive := false
+		source, err := NewSSMSource(SSMSourceOptions{
+			Client:       newMockSSMClient(),
+			Path:         "/tbp/prod/database/",
+			Recursive:    &recursive,
+			NoDecryption: true,
+		})
+		require.NoError(t, err)
+
+		values, err := source.Load(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "encrypted:/tbp/prod/database/password", values["password"])
+		assert.Equal(t, "db.internal", values["host"])
+	})
+
+	t.Run("reads Secrets Manager secrets", func(t *testing.T) {
+		source, err := NewSSMSource(SSMSourceOptions{
+			Client:    newMockSSMClient(),
+			Path:      "/tbp/none/",
+			SecretIDs: []string{"prod/api", "prod/token"},
+		})
+		require.NoError(t, err)
+
+		values, err := source.Load(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"prod.api.key":    "s3cr3t",
+			"prod.api.client": "tbp",
+			"prod.token":      "t0ken",
+		}, values)
+		assert.Equal(t, []string{"prod.api.client", "prod.api.key", "prod.token"}, source.SensitiveKeys())
+	})
+
+	t.Run("wraps client errors without values", func(t *testing.T) {
+		client := newMockSSMClient()
+		client.err = errors.New("AccessDeniedException")
+		source, err := NewSSMSource(SSMSourceOptions{Client: client, Path: "/tbp/prod/"})
+		require.NoError(t, err)
+
+		_, err = source.Load(ctx)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "/tbp/prod/")
+		assert.Contains(t, err.Error(), "AccessDeniedException")
+
+		source, err = NewSSMSource(SSMSourceOptions{Client: newMockSSMClient(), Path: "/tbp/prod/", SecretIDs: []string{"missing"}})
+		require.NoError(t, err)
+		_, err = source.Load(ctx)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "missing")
+		assert.NotContains(t, err.Error(), "hunter2")
+	})
+
+	t.Run("redacts values in config", func(t *testing.T) {
+		source, err := NewSSMSource(SSMSourceOptions{Client: newMockSSMClient(), Path: "/tbp/prod/"})
+		require.NoError(t, err)
+
+		config, err := New(ctx, LoadOptions{Environment: "test", Sources: []Source{source}})
+		require.NoError(t, err)
+
+		password, err := config.GetString("database.password")
+		require.NoError(t, err)
+		assert.Equal(t, "hunter2", password)
+		assert.Equal(t, RedactedValue, config.GetAll()["database.password"])
+		assert.Equal(t, "db.internal", config.GetAll()["database.host"])
+		assert.Contains(t, config.Summary().SensitiveFields, "database.password")
+	})
+}
+
+func TestSSMSource_Watch(t *testing.T) {
+	t.Run("does not watch without poll interval", func(t *testing.T) {
+		source, err := NewSSMSource(SSMSourceOptions{Client: newMockSSMClient(), Path: "/tbp/prod/"})
+		require.NoError(t, err)
+		assert.NoError(t, source.Watch(context.Background(), func(map[string]interface{}) {}))
+	})
+
+	t.Run("calls back on changes only", func(t *testing.T) {
+		client := newMockSSMClient()
+		source, err := NewSSMSource(SSMSourceOptions{Client: client, Path: "/tbp/prod/", PollInterval: 10 * time.Millisecond})
+		require.NoError(t, err)
+		_, err = source.Load(context.Background())
+		require.NoError(t, err)
+
+		changes := make(chan map[string]interface{}, 10)
+		require.NoError(t, source.Watch(context.Background(), func(values map[string]interface{}) {
+			changes <- values
+		}))
+		defer source.Stop()
+
+		callsBefore := client.callCount()
+		require.Eventually(t, func() bool { return client.callCount() > callsBefore+6 }, time.Second, 5*time.Millisecond)
+		assert.Empty(t, changes, "unchanged parameters do not trigger the callback")
+
+		client.setParameters([]SSMParameter{
+			{Name: "/tbp/prod/log_level", Type: SSMParameterTypeString, Value: "warn"},
+		})
+
+		select {
+		case values := <-changes:
+			assert.Equal(t, map[string]interface{}{"log_level": "warn"}, values)
+		case <-time.After(time.Second):
+			t.Fatal("callback was not called after change")
+		}
+
+		source.Stop()
+		source.Stop()
+	})
+}
@@ -0,0 +1,77 @@
+// File: timeformat.go
+// Title: Time Format Handling for Configuration Values
+// Description: Parses time values using caller-registered layouts before
+//              the built-in defaults and supports an epoch-milliseconds
+//              token for feeds that encode timestamps as integers.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial custom time formats with epoch_ms token
+
+package config
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/msto63/tbp/tbp-foundation/pkg/core"
+)
+
+// TimeFormatEpochMillis is a time format token for integer milliseconds
+// since the Unix epoch. When registered via LoadOptions.TimeFormats,
+// numeric configuration values are interpreted as milliseconds instead
+// of seconds as well.
+const TimeFormatEpochMillis = "epoch_ms"
+
+// defaultTimeFormats are the layouts tried after any custom formats
+var defaultTimeFormats = []string{
+	time.RFC3339,
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05Z",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// parseTimeString parses value with the custom formats in order, then the
+// default formats. The first matching format wins, so custom formats take
+// precedence for values that several layouts would accept.
+func parseTimeString(value string, customFormats []string) (time.Time, error) {
+	for _, format := range customFormats {
+		if format == TimeFormatEpochMillis {
+			if ms, err := strconv.ParseInt(value, 10, 64); err == nil {
+				return time.UnixMilli(ms), nil
+			}
+			continue
+		}
+		if t, err := time.Parse(format, value); err == nil {
+			return t, nil
+		}
+	}
+
+	for _, format := range defaultTimeFormats {
+		if t, err := time.Parse(format, value); err == nil {
+			return t, nil
+		}
+	}
+
+	supported := append([]string{}, customFormats...)
+	supported = append(supported, "RFC3339", "ISO date")
+	return time.Time{}, core.Newf("failed to parse '%s' as time - supported formats: %s",
+		value, strings.Join(supported, ", ")).WithCode(core.ErrCodeInvalidInput)
+}
+
+// unixTime converts a numeric timestamp to time, interpreting it as
+// milliseconds if TimeFormatEpochMillis is among the custom formats and as
+// seconds otherwise
+func unixTime(value int64, customFormats []string) time.Time {
+	for _, format := range customFormats {
+		if format == TimeFormatEpochMillis {
+			return time.UnixMilli(value)
+		}
+	}
+	return time.Unix(value, 0)
+}
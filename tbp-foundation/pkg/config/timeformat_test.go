@@ -0,0 +1,119 @@
+// File: timeformat_test.go
+// Title: Tests for Time Format Handling
+// Description: Test suite for custom time layouts, the epoch_ms token and
+//              their use by the configuration manager and env source.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+
+package config
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTimeString(t *testing.T) {
+	dayFirst := []string{"02/01/2006"}
+
+	t.Run("parses custom format", func(t *testing.T) {
+		value, err := parseTimeString("03/04/2024", dayFirst)
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2024, time.April, 3, 0, 0, 0, 0, time.UTC), value)
+	})
+
+	t.Run("custom formats take precedence over defaults", func(t *testing.T) {
+		// Year-day-month is ambiguous with the default ISO date layout
+		value, err := parseTimeString("2024-03-04", []string{"2006-02-01"})
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2024, time.April, 3, 0, 0, 0, 0, time.UTC), value)
+
+		value, err = parseTimeString("2024-03-04", nil)
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC), value)
+	})
+
+	t.Run("falls back to default formats", func(t *testing.T) {
+		value, err := parseTimeString("2024-01-15T10:30:00Z", dayFirst)
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC), value)
+	})
+
+	t.Run("rejects dates invalid in every format", func(t *testing.T) {
+		_, err := parseTimeString("13/13/2024", dayFirst)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "02/01/2006")
+
+		_, err = parseTimeString("31/02/2024", dayFirst)
+		assert.Error(t, err, "day out of range for month")
+	})
+
+	t.Run("parses epoch milliseconds token", func(t *testing.T) {
+		value, err := parseTimeString("1705314600123", []string{TimeFormatEpochMillis})
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 1, 15, 10, 30, 0, 123000000, time.UTC), value.UTC())
+
+		_, err = parseTimeString("1705314600123", nil)
+		assert.Error(t, err, "integers are not accepted without the token")
+
+		// Non-numeric values fall through to the remaining formats
+		value, err = parseTimeString("2024-01-15", []string{TimeFormatEpochMillis})
+		require.NoError(t, err)
+		assert.Equal(t, 2024, value.Year())
+	})
+}
+
+func TestConfig_TimeFormats(t *testing.T) {
+	config, err := New(context.Background(), LoadOptions{
+		Environment: "test",
+		TimeFormats: []string{"02/01/2006", TimeFormatEpochMillis},
+		Sources: []Source{&mockSource{values: map[string]interface{}{
+			"feed.date":      "03/04/2024",
+			"feed.millis":    "1705314600123",
+			"feed.numeric":   int64(1705314600123),
+			"feed.json":      float64(1705314600123),
+			"feed.timestamp": "2024-01-15T10:30:00Z",
+		}}},
+	})
+	require.NoError(t, err)
+
+	value, err := config.GetTime("feed.date")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, time.April, 3, 0, 0, 0, 0, time.UTC), value)
+
+	expected := time.Date(2024, 1, 15, 10, 30, 0, 123000000, time.UTC)
+	for _, key := range []string{"feed.millis", "feed.numeric", "feed.json"} {
+		value, err = config.GetTime(key)
+		require.NoError(t, err, key)
+		assert.True(t, expected.Equal(value), key)
+	}
+
+	value, err = config.GetTime("feed.timestamp")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC), value)
+}
+
+func TestEnvSource_TimeFormats(t *testing.T) {
+	t.Setenv("TIMEFMT_RELEASE_DATE", "03/04/2024")
+	t.Setenv("TIMEFMT_CREATED", "1705314600123")
+
+	source, err := NewEnvSource(EnvSourceOptions{
+		Prefix:      "TIMEFMT",
+		TimeFormats: []string{"02/01/2006", TimeFormatEpochMillis},
+		TypeHints:   map[string]string{"release.date": "time", "created": "timestamp"},
+	})
+	require.NoError(t, err)
+
+	values, err := source.Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, time.April, 3, 0, 0, 0, 0, time.UTC), values["release.date"])
+	assert.True(t, time.UnixMilli(1705314600123).Equal(values["created"].(time.Time)))
+}
@@ -0,0 +1,435 @@
+// File: vault.go
+// Title: HashiCorp Vault Configuration Source for TBP
+// Description: Provides a configuration source that reads secrets from a
+//              Vault KV version 2 engine over the Vault HTTP API, flattens
+//              them into configuration keys, and marks all of them as
+//              sensitive. Supports token and AppRole authentication and
+//              re-reads secrets before their lease expires.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial Vault KV v2 source with token and AppRole auth
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/msto63/tbp/tbp-foundation/pkg/core"
+)
+
+// VaultSource implements the Source interface for secrets stored in a
+// HashiCorp Vault KV version 2 secrets engine. All loaded keys are reported
+// as sensitive, so Config redacts them in GetAll, Summary, and snapshots.
+// Secret values are never included in errors or log entries.
+type VaultSource struct {
+	// mu protects concurrent access to vault source data
+	mu sync.RWMutex
+
+	// address is the Vault server address, e.g. https://vault:8200
+	address string
+
+	// mount is the mount path of the KV v2 engine
+	mount string
+
+	// path is the secret path within the mount
+	path string
+
+	// namespace is the Vault Enterprise namespace (optional)
+	namespace string
+
+	// keyPrefix is prepended to all loaded keys (optional)
+	keyPrefix string
+
+	// token is the current client token
+	token string
+
+	// tokenExpiry is when an AppRole token expires (zero = no expiry)
+	tokenExpiry time.Time
+
+	// roleID and secretID are the AppRole credentials
+	roleID   string
+	secretID string
+
+	// appRoleMount is the mount path of the AppRole auth method
+	appRoleMount string
+
+	// client performs the HTTP requests
+	client *http.Client
+
+	// priority sets the source priority for merging
+	priority int
+
+	// refreshInterval is used by Watch when no lease TTL is known
+	refreshInterval time.Duration
+
+	// leaseDuration is the lease TTL of the most recent read
+	leaseDuration time.Duration
+
+	// values stores the loaded configuration values
+	values map[string]interface{}
+
+	// stopWatching is used to stop the refresh loop
+	stopWatching chan struct{}
+
+	// stopOnce ensures stopWatching is closed only once
+	stopOnce sync.Once
+}
+
+// VaultSourceOptions configures Vault source creation.
+// Either Token or RoleID and SecretID must be set; the token wins if both are.
+type VaultSourceOptions struct {
+	Address         string        `json:"address"`          // Vault server address (required)
+	Mount           string        `json:"mount"`            // KV v2 mount path (default: secret)
+	Path            string        `json:"path"`             // Secret path within the mount (required)
+	Namespace       string        `json:"namespace"`        // Vault Enterprise namespace (optional)
+	KeyPrefix       string        `json:"key_prefix"`       // Prefix for all loaded keys (optional)
+	Token           string        `json:"-"`                // Token authentication
+	RoleID          string        `json:"role_id"`          // AppRole role ID
+	SecretID        string        `json:"-"`                // AppRole secret ID
+	AppRoleMount    string        `json:"app_role_mount"`   // AppRole auth mount path (default: approle)
+	Priority        int           `json:"priority"`         // Source priority (default: 75)
+	RefreshInterval time.Duration `json:"refresh_interval"` // Re-read interval for secrets without lease (0 = no watching)
+	Timeout         time.Duration `json:"timeout"`          // HTTP request timeout (default: 10s)
+	HTTPClient      *http.Client  `json:"-"`                // Custom HTTP client (optional, overrides Timeout)
+}
+
+// vaultRefreshRatio is the fraction of a lease after which secrets are re-read
+const vaultRefreshRatio = 2.0 / 3.0
+
+// NewVaultSource creates a new Vault KV v2 configuration source.
+// No connection is made until Load is called.
+func NewVaultSource(opts VaultSourceOptions) (*VaultSource, error) {
+	if opts.Address == "" {
+		return nil, core.New("vault address is required").WithCode(core.ErrCodeInvalidInput)
+	}
+	if opts.Path == "" {
+		return nil, core.New("vault secret path is required").WithCode(core.ErrCodeInvalidInput)
+	}
+	if opts.Token == "" && (opts.RoleID == "" || opts.SecretID == "") {
+		return nil, core.New("vault authentication requires a token or an AppRole role ID and secret ID").
+			WithCode(core.ErrCodeInvalidInput)
+	}
+
+	if opts.Mount == "" {
+		opts.Mount = "secret"
+	}
+	if opts.AppRoleMount == "" {
+		opts.AppRoleMount = "approle"
+	}
+	if opts.Priority == 0 {
+		opts.Priority = 75 // Above files, below environment variables
+	}
+
+	client := opts.HTTPClient
+	if client == nil {
+		timeout := opts.Timeout
+		if timeout == 0 {
+			timeout = 10 * time.Second
+		}
+		client = &http.Client{Timeout: timeout}
+	}
+
+	return &VaultSource{
+		address:         strings.TrimSuffix(opts.Address, "/"),
+		mount:           strings.Trim(opts.Mount, "/"),
+		path:            strings.Trim(opts.Path, "/"),
+		namespace:       opts.Namespace,
+		keyPrefix:       strings.TrimSuffix(opts.KeyPrefix, "."),
+		token:           opts.Token,
+		roleID:          opts.RoleID,
+		secretID:        opts.SecretID,
+		appRoleMount:    strings.Trim(opts.AppRoleMount, "/"),
+		client:          client,
+		priority:        opts.Priority,
+		refreshInterval: opts.RefreshInterval,
+		values:          make(map[string]interface{}),
+		stopWatching:    make(chan struct{}),
+	}, nil
+}
+
+// Name implements the Source interface
+func (vs *VaultSource) Name() string {
+	return fmt.Sprintf("vault:%s/%s", vs.mount, vs.path)
+}
+
+// Priority implements the Source interface
+func (vs *VaultSource) Priority() int {
+	return vs.priority
+}
+
+// Load implements the Source interface. It authenticates with AppRole if
+// needed, reads the secret, and flattens its data into dot-separated keys.
+func (vs *VaultSource) Load(ctx context.Context) (map[string]interface{}, error) {
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+
+	if err := vs.ensureToken(ctx); err != nil {
+		return nil, err
+	}
+
+	secret, err := vs.readSecret(ctx)
+	if err != nil && vs.usesAppRole() && core.IsCode(err, core.ErrCodeForbidden) {
+		// The token may have been revoked early; log in again once
+		vs.token = ""
+		if err := vs.ensureToken(ctx); err != nil {
+			return nil, err
+		}
+		secret, err = vs.readSecret(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	vs.values = flattenValues(secret.Data.Data, vs.keyPrefix)
+	vs.leaseDuration = time.Duration(secret.LeaseDuration) * time.Second
+
+	return vs.copyValues(), nil
+}
+
+// SensitiveKeys implements the SensitiveSource interface.
+// All keys loaded from Vault are sensitive.
+func (vs *VaultSource) SensitiveKeys() []string {
+	vs.mu.RLock()
+	defer vs.mu.RUnlock()
+
+	keys := make([]string, 0, len(vs.values))
+	for key := range vs.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Watch implements the WatchableSource interface. The secret is re-read
+// after two thirds of the shorter of its lease TTL and the AppRole token
+// TTL, or every RefreshInterval if neither is known, and callback is
+// called after each successful re-read. Returns nil without watching if
+// no refresh interval can be determined.
+func (vs *VaultSource) Watch(ctx context.Context, callback func(map[string]interface{})) error {
+	if vs.refreshDelay() <= 0 {
+		return nil
+	}
+
+	go vs.refreshLoop(ctx, callback)
+	return nil
+}
+
+// Stop stops the refresh loop started by Watch. Safe to call multiple times.
+func (vs *VaultSource) Stop() {
+	vs.stopOnce.Do(func() { close(vs.stopWatching) })
+}
+
+// refreshLoop re-reads the secret before its lease expires
+func (vs *VaultSource) refreshLoop(ctx context.Context, callback func(map[string]interface{})) {
+	delay := vs.refreshDelay()
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-vs.stopWatching:
+			return
+		case <-timer.C:
+		}
+
+		values, err := vs.Load(ctx)
+		if err != nil {
+			// The error never contains secret values
+			core.ContextLogger(ctx).Error("failed to refresh vault secret",
+				"source", vs.Name(), "error", err)
+		} else {
+			core.SafeGo(ctx, func(context.Context) error {
+				callback(values)
+				return nil
+			}, func(err error) {
+				core.ContextLogger(ctx).Error("vault watcher callback failed",
+					"source", vs.Name(), "error", err)
+			})
+		}
+
+		if next := vs.refreshDelay(); next > 0 {
+			delay = next
+		}
+		timer.Reset(delay)
+	}
+}
+
+// refreshDelay returns the time until the next re-read
+func (vs *VaultSource) refreshDelay() time.Duration {
+	vs.mu.RLock()
+	defer vs.mu.RUnlock()
+
+	ttl := vs.leaseDuration
+	if !vs.tokenExpiry.IsZero() {
+		if tokenTTL := time.Until(vs.tokenExpiry); tokenTTL > 0 && (ttl <= 0 || tokenTTL < ttl) {
+			ttl = tokenTTL
+		}
+	}
+
+	if ttl > 0 {
+		return time.Duration(float64(ttl) * vaultRefreshRatio)
+	}
+	return vs.refreshInterval
+}
+
+// usesAppRole reports whether the source authenticates with AppRole
+func (vs *VaultSource) usesAppRole() bool {
+	return vs.roleID != "" && vs.secretID != ""
+}
+
+// ensureToken logs in with AppRole if there is no valid token.
+// The caller must hold the lock.
+func (vs *VaultSource) ensureToken(ctx context.Context) error {
+	if vs.token != "" && (vs.tokenExpiry.IsZero() || time.Now().Before(vs.tokenExpiry)) {
+		return nil
+	}
+	if !vs.usesAppRole() {
+		return core.New("vault token has expired").WithCode(core.ErrCodeUnauthorized)
+	}
+
+	body, err := json.Marshal(map[string]string{"role_id": vs.roleID, "secret_id": vs.secretID})
+	if err != nil {
+		return core.Wrap(err, "failed to encode vault login request")
+	}
+
+	var response vaultLoginResponse
+	loginPath := fmt.Sprintf("auth/%s/login", vs.appRoleMount)
+	if err := vs.do(ctx, http.MethodPost, loginPath, body, &response); err != nil {
+		return core.WrapPreservingCode(err, "vault AppRole login failed")
+	}
+	if response.Auth.ClientToken == "" {
+		return core.New("vault AppRole login returned no token").WithCode(core.ErrCodeUnauthorized)
+	}
+
+	vs.token = response.Auth.ClientToken
+	vs.tokenExpiry = time.Time{}
+	if response.Auth.LeaseDuration > 0 {
+		vs.tokenExpiry = time.Now().Add(time.Duration(response.Auth.LeaseDuration) * time.Second)
+	}
+	return nil
+}
+
+// readSecret reads the KV v2 secret. The caller must hold the lock.
+func (vs *VaultSource) readSecret(ctx context.Context) (*vaultSecretResponse, error) {
+	var response vaultSecretResponse
+	secretPath := fmt.Sprintf("%s/data/%s", vs.mount, vs.path)
+	if err := vs.do(ctx, http.MethodGet, secretPath, nil, &response); err != nil {
+		return nil, core.WrapPreservingCode(err, fmt.Sprintf("failed to read vault secret %s/%s", vs.mount, vs.path))
+	}
+	if response.Data.Data == nil {
+		response.Data.Data = make(map[string]interface{})
+	}
+	return &response, nil
+}
+
+// do performs a Vault API request and decodes the JSON response into out.
+// Errors carry the HTTP status and Vault's error messages, never the
+// response body.
+func (vs *VaultSource) do(ctx context.Context, method, apiPath string, body []byte, out interface{}) error {
+	url := fmt.Sprintf("%s/v1/%s", vs.address, apiPath)
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return core.Wrap(err, "failed to create vault request")
+	}
+	if vs.token != "" {
+		req.Header.Set("X-Vault-Token", vs.token)
+	}
+	if vs.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", vs.namespace)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := vs.client.Do(req)
+	if err != nil {
+		return core.Wrap(err, fmt.Sprintf("failed to connect to vault at %s", vs.address)).
+			WithCode(core.ErrCodeUnavailable)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr vaultErrorResponse
+		_ = json.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(&apiErr)
+
+		message := fmt.Sprintf("vault returned status %d", resp.StatusCode)
+		if len(apiErr.Errors) > 0 {
+			message += ": " + strings.Join(apiErr.Errors, "; ")
+		}
+		return core.New(message).WithCode(vaultStatusCode(resp.StatusCode))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		// Do not wrap the decoder error, which may quote response content
+		return core.New("failed to decode vault response").WithCode(core.ErrCodeInternal)
+	}
+	return nil
+}
+
+// copyValues returns a copy of the values map. The caller must hold the lock.
+func (vs *VaultSource) copyValues() map[string]interface{} {
+	result := make(map[string]interface{}, len(vs.values))
+	for key, value := range vs.values {
+		result[key] = value
+	}
+	return result
+}
+
+// vaultStatusCode maps Vault HTTP status codes to TBP error codes
+func vaultStatusCode(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return core.ErrCodeInvalidInput
+	case http.StatusUnauthorized:
+		return core.ErrCodeUnauthorized
+	case http.StatusForbidden:
+		return core.ErrCodeForbidden
+	case http.StatusNotFound:
+		return core.ErrCodeNotFound
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return core.ErrCodeUnavailable
+	default:
+		return core.ErrCodeInternal
+	}
+}
+
+// vaultSecretResponse is the response of a KV v2 read
+type vaultSecretResponse struct {
+	LeaseDuration int `json:"lease_duration"`
+	Data          struct {
+		Data map[string]interface{} `json:"data"`
+	} `json:"data"`
+}
+
+// vaultLoginResponse is the response of an AppRole login
+type vaultLoginResponse struct {
+	Auth struct {
+		ClientToken   string `json:"client_token"`
+		LeaseDuration int    `json:"lease_duration"`
+	} `json:"auth"`
+}
+
+// vaultErrorResponse is the error body returned by the Vault API
+type vaultErrorResponse struct {
+	Errors []string `json:"errors"`
+}
@@ -0,0 +1,294 @@
+// File: vault_test.go
+// Title: Tests for the Vault Configuration Source
+// Description: Tests reading KV v2 secrets through a fake Vault HTTP API,
+//              token and AppRole authentication, sensitive key redaction,
+//              error handling, and lease-based refreshing.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/msto63/tbp/tbp-foundation/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeVault serves a single KV v2 secret and the AppRole login endpoint
+type fakeVault struct {
+	mu            sync.Mutex
+	data          map[string]interface{}
+	leaseDuration int
+	token         string
+	namespace     string
+	logins        int
+	reads         int
+}
+
+func (v *fakeVault) setData(data map[string]interface{}) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.data = data
+}
+
+func (v *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	writeError := func(status int, message string) {
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"errors": []string{message}})
+	}
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/v1/auth/approle/login":
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body["role_id"] != "role" || body["secret_id"] != "secret-id" {
+			writeError(http.StatusBadRequest, "invalid role or secret ID")
+			return
+		}
+		v.logins++
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"auth": map[string]interface{}{"client_token": v.token, "lease_duration": 3600},
+		})
+
+	case r.Method == http.MethodGet && r.URL.Path == "/v1/secret/data/myapp":
+		if r.Header.Get("X-Vault-Token") != v.token {
+			writeError(http.StatusForbidden, "permission denied")
+			return
+		}
+		if r.Header.Get("X-Vault-Namespace") != v.namespace {
+			writeError(http.StatusNotFound, "namespace not found")
+			return
+		}
+		v.reads++
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"lease_duration": v.leaseDuration,
+			"data":           map[string]interface{}{"data": v.data, "metadata": map[string]interface{}{"version": 1}},
+		})
+
+	default:
+		writeError(http.StatusNotFound, "no handler for route")
+	}
+}
+
+func TestNewVaultSource(t *testing.T) {
+	t.Run("requires address, path, and credentials", func(t *testing.T) {
+		invalid := []VaultSourceOptions{
+			{Path: "myapp", Token: "t"},
+			{Address: "http://vault", Token: "t"},
+			{Address: "http://vault", Path: "myapp"},
+			{Address: "http://vault", Path: "myapp", RoleID: "role"},
+		}
+		for _, opts := range invalid {
+			_, err := NewVaultSource(opts)
+			require.Error(t, err)
+			assert.True(t, core.IsCode(err, core.ErrCodeInvalidInput))
+		}
+	})
+
+	t.Run("applies defaults", func(t *testing.T) {
+		source, err := NewVaultSource(VaultSourceOptions{Address: "http://vault/", Path: "/myapp/", Token: "t"})
+		require.NoError(t, err)
+		assert.Equal(t, "vault:secret/myapp", source.Name())
+		assert.Equal(t, 75, source.Priority())
+	})
+}
+
+func TestVaultSource_Load(t *testing.T) {
+	ctx := context.Background()
+	secrets := map[string]interface{}{
+		"database": map[string]interface{}{"password": "hunter2", "user": "app"},
+		"api_key":  "abc123",
+	}
+
+	t.Run("reads and flattens secret with token", func(t *testing.T) {
+		vault := &fakeVault{data: secrets, token: "root", namespace: "team"}
+		server := httptest.NewServer(vault)
+		defer server.Close()
+
+		source, err := NewVaultSource(VaultSourceOptions{
+			Address:   server.URL,
+			Path:      "myapp",
+			Namespace: "team",
+			KeyPrefix: "secrets",
+			Token:     "root",
+		})
+		require.NoError(t, err)
+
+		values, err := source.Load(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"secrets.database.password": "hunter2",
+			"secrets.database.user":     "app",
+			"secrets.api_key":           "abc123",
+		}, values)
+		assert.Equal(t, []string{"secrets.api_key", "secrets.database.password", "secrets.database.user"}, source.SensitiveKeys())
+	})
+
+	t.Run("logs in with AppRole", func(t *testing.T) {
+		vault := &fakeVault{data: secrets, token: "approle-token"}
+		server := httptest.NewServer(vault)
+		defer server.Close()
+
+		source, err := NewVaultSource(VaultSourceOptions{
+			Address:  server.URL,
+			Path:     "myapp",
+			RoleID:   "role",
+			SecretID: "secret-id",
+		})
+		require.NoError(t, err)
+
+		values, err := source.Load(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "abc123", values["api_key"])
+
+		_, err = source.Load(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, vault.logins, "token is reused until it expires")
+
+		// A revoked token triggers a new login
+		vault.mu.Lock()
+		vault.token = "rotated-token"
+		vault.mu.Unlock()
+		_, err = source.Load(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, vault.logins)
+	})
+
+	t.Run("maps errors without leaking secrets", func(t *testing.T) {
+		vault := &fakeVault{data: secrets, token: "root"}
+		server := httptest.NewServer(vault)
+		defer server.Close()
+
+		source, err := NewVaultSource(VaultSourceOptions{Address: server.URL, Path: "other", Token: "root"})
+		require.NoError(t, err)
+		_, err = source.Load(ctx)
+		require.Error(t, err)
+		assert.True(t, core.IsCode(err, core.ErrCodeNotFound))
+
+		source, err = NewVaultSource(VaultSourceOptions{Address: server.URL, Path: "myapp", Token: "wrong"})
+		require.NoError(t, err)
+		_, err = source.Load(ctx)
+		require.Error(t, err)
+		assert.True(t, core.IsCode(err, core.ErrCodeForbidden))
+		assert.NotContains(t, err.Error(), "hunter2")
+	})
+
+	t.Run("wraps connection failures", func(t *testing.T) {
+		server := httptest.NewServer(&fakeVault{})
+		address := server.URL
+		server.Close()
+
+		source, err := NewVaultSource(VaultSourceOptions{Address: address, Path: "myapp", Token: "root", Timeout: time.Second})
+		require.NoError(t, err)
+		_, err = source.Load(ctx)
+		require.Error(t, err)
+		assert.True(t, core.IsCode(err, core.ErrCodeUnavailable))
+		assert.Contains(t, err.Error(), "failed to connect to vault")
+	})
+}
+
+func TestVaultSource_Sensitive(t *testing.T) {
+	vault := &fakeVault{data: map[string]interface{}{"db_password": "hunter2"}, token: "root"}
+	server := httptest.NewServer(vault)
+	defer server.Close()
+
+	source, err := NewVaultSource(VaultSourceOptions{Address: server.URL, Path: "myapp", Token: "root"})
+	require.NoError(t, err)
+
+	config, err := New(context.Background(), LoadOptions{
+		Environment: "test",
+		Sources: []Source{
+			source,
+			&mockSource{name: "mock", priority: 10, values: map[string]interface{}{"app.name": "orders"}},
+		},
+	})
+	require.NoError(t, err)
+
+	value, err := config.GetString("db_password")
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", value)
+
+	all := config.GetAll()
+	assert.Equal(t, RedactedValue, all["db_password"])
+	assert.Equal(t, "orders", all["app.name"])
+
+	assert.Contains(t, config.Summary().SensitiveFields, "db_password")
+	assert.Equal(t, RedactedValue, config.Snapshot().Redacted().Values()["db_password"])
+}
+
+func TestVaultSource_Watch(t *testing.T) {
+	t.Run("re-reads secret and notifies", func(t *testing.T) {
+		vault := &fakeVault{data: map[string]interface{}{"api_key": "v1"}, token: "root"}
+		server := httptest.NewServer(vault)
+		defer server.Close()
+
+		source, err := NewVaultSource(VaultSourceOptions{
+			Address:         server.URL,
+			Path:            "myapp",
+			Token:           "root",
+			RefreshInterval: 20 * time.Millisecond,
+		})
+		require.NoError(t, err)
+		defer source.Stop()
+
+		ctx := context.Background()
+		_, err = source.Load(ctx)
+		require.NoError(t, err)
+
+		received := make(chan map[string]interface{}, 10)
+		require.NoError(t, source.Watch(ctx, func(values map[string]interface{}) {
+			received <- values
+		}))
+
+		vault.setData(map[string]interface{}{"api_key": "v2"})
+
+		deadline := time.After(2 * time.Second)
+		for {
+			select {
+			case values := <-received:
+				if values["api_key"] == "v2" {
+					return
+				}
+			case <-deadline:
+				t.Fatal("vault source did not report renewed secret")
+			}
+		}
+	})
+
+	t.Run("refreshes before lease expiry", func(t *testing.T) {
+		source := &VaultSource{leaseDuration: 30 * time.Second, refreshInterval: time.Hour}
+		assert.Equal(t, 20*time.Second, source.refreshDelay())
+
+		source.tokenExpiry = time.Now().Add(3 * time.Second)
+		delay := source.refreshDelay()
+		assert.True(t, delay > time.Second && delay <= 2*time.Second, "delay %v", delay)
+
+		source = &VaultSource{refreshInterval: time.Minute}
+		assert.Equal(t, time.Minute, source.refreshDelay())
+	})
+
+	t.Run("does not watch without interval", func(t *testing.T) {
+		source, err := NewVaultSource(VaultSourceOptions{Address: "http://vault", Path: "myapp", Token: "root"})
+		require.NoError(t, err)
+		assert.NoError(t, source.Watch(context.Background(), func(map[string]interface{}) {}))
+
+		source.Stop()
+		source.Stop()
+	})
+}
@@ -0,0 +1,92 @@
+// File: warning.go
+// Title: Structured Configuration Warnings for TBP
+// Description: Collects non-fatal configuration problems such as deprecated
+//              fields or unavailable optional sources as structured data
+//              instead of printing them, and forwards them to an optional
+//              warning hook for logging.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial ConfigWarning type and warning collection
+
+package config
+
+import "fmt"
+
+// WarningSeverity classifies configuration warnings
+type WarningSeverity string
+
+const (
+	// WarningSeverityInfo marks purely informational warnings
+	WarningSeverityInfo WarningSeverity = "info"
+
+	// WarningSeverityWarning marks problems that should be fixed
+	WarningSeverityWarning WarningSeverity = "warning"
+)
+
+// String returns the string representation of the warning severity
+func (ws WarningSeverity) String() string {
+	return string(ws)
+}
+
+// ConfigWarning describes a non-fatal configuration problem.
+// Warnings are collected by the configuration manager (see Config.Warnings)
+// and passed to LoadOptions.OnWarning. The hook is called synchronously,
+// possibly while the configuration lock is held, so it must not call back
+// into Config.
+type ConfigWarning struct {
+	Key      string          `json:"key,omitempty"` // Configuration key, empty if not key-specific
+	Message  string          `json:"message"`
+	Severity WarningSeverity `json:"severity"`
+}
+
+// String returns a human-readable representation of the warning
+func (w ConfigWarning) String() string {
+	if w.Key == "" {
+		return fmt.Sprintf("%s: %s", w.Severity, w.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", w.Severity, w.Key, w.Message)
+}
+
+// Warnings returns the warnings raised while creating the configuration
+// manager followed by the warnings of the most recent validation run.
+func (c *Config) Warnings() []ConfigWarning {
+	c.warningsMu.Lock()
+	defer c.warningsMu.Unlock()
+
+	warnings := make([]ConfigWarning, 0, len(c.setupWarnings)+len(c.validationWarnings))
+	warnings = append(warnings, c.setupWarnings...)
+	warnings = append(warnings, c.validationWarnings...)
+	return warnings
+}
+
+// addSetupWarning records a warning raised while setting up sources
+func (c *Config) addSetupWarning(warning ConfigWarning) {
+	c.warningsMu.Lock()
+	c.setupWarnings = append(c.setupWarnings, warning)
+	c.warningsMu.Unlock()
+
+	c.emitWarnings([]ConfigWarning{warning})
+}
+
+// setValidationWarnings replaces the warnings of the previous validation run
+func (c *Config) setValidationWarnings(warnings []ConfigWarning) {
+	c.warningsMu.Lock()
+	c.validationWarnings = warnings
+	c.warningsMu.Unlock()
+
+	c.emitWarnings(warnings)
+}
+
+// emitWarnings passes warnings to the configured warning hook
+func (c *Config) emitWarnings(warnings []ConfigWarning) {
+	if c.onWarning == nil {
+		return
+	}
+	for _, warning := range warnings {
+		c.onWarning(warning)
+	}
+}
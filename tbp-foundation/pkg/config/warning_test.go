@@ -0,0 +1,90 @@
+// File: warning_test.go
+// Title: Tests for Structured Configuration Warnings
+// Description: Test suite for collecting deprecation and source warnings
+//              and forwarding them to the warning hook.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+
+package config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Warnings(t *testing.T) {
+	newMetadata := func() *Metadata {
+		return &Metadata{
+			Name: "test-config",
+			Fields: map[string]Field{
+				"server.addr": {Name: "server.addr", Deprecated: true, Description: "use server.host and server.port"},
+				"server.host": {Name: "server.host"},
+				"legacy.mode": {Name: "legacy.mode", Deprecated: true},
+			},
+		}
+	}
+
+	t.Run("deprecated fields produce warnings", func(t *testing.T) {
+		var received []ConfigWarning
+		config, err := New(context.Background(), LoadOptions{
+			Environment: "test",
+			Metadata:    newMetadata(),
+			Validation:  true,
+			Sources: []Source{&mockSource{values: map[string]interface{}{
+				"server.addr": ":8080",
+				"server.host": "localhost",
+				"legacy.mode": true,
+			}}},
+			OnWarning: func(w ConfigWarning) { received = append(received, w) },
+		})
+		require.NoError(t, err)
+
+		expected := []ConfigWarning{
+			{Key: "legacy.mode", Message: "configuration field is deprecated", Severity: WarningSeverityWarning},
+			{Key: "server.addr", Message: "configuration field is deprecated: use server.host and server.port", Severity: WarningSeverityWarning},
+		}
+		assert.Equal(t, expected, config.Warnings())
+		assert.Equal(t, expected, received)
+	})
+
+	t.Run("validation replaces previous warnings", func(t *testing.T) {
+		source := &mockSource{values: map[string]interface{}{"server.addr": ":8080"}}
+		config, err := New(context.Background(), LoadOptions{
+			Environment: "test",
+			Metadata:    newMetadata(),
+			Sources:     []Source{source},
+		})
+		require.NoError(t, err)
+		assert.Empty(t, config.Warnings(), "warnings are collected by validation")
+
+		require.NoError(t, config.Validate(context.Background()))
+		assert.Len(t, config.Warnings(), 1)
+
+		source.values = map[string]interface{}{"server.host": "localhost"}
+		require.NoError(t, config.Load(context.Background()))
+		require.NoError(t, config.Validate(context.Background()))
+		assert.Empty(t, config.Warnings())
+	})
+
+	t.Run("invalid config paths produce warnings", func(t *testing.T) {
+		config, err := New(context.Background(), LoadOptions{
+			Environment: "test",
+			ConfigPaths: []string{""},
+		})
+		require.NoError(t, err)
+
+		warnings := config.Warnings()
+		require.Len(t, warnings, 1)
+		assert.Empty(t, warnings[0].Key)
+		assert.Equal(t, WarningSeverityWarning, warnings[0].Severity)
+		assert.Contains(t, warnings[0].Message, "file path is required")
+	})
+}
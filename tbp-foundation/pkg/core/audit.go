@@ -0,0 +1,157 @@
+// File: audit.go
+// Title: Audit Events for TBP
+// Description: Provides structured audit records for authenticated actions.
+//              NewAuditEvent fills actor, tenant, and correlation data from
+//              the request context, and RecordAudit passes events to a
+//              package-level AuditSink, which discards them by default.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial AuditEvent and AuditSink implementation
+
+package core
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// AuditOutcome is the result of an audited action
+type AuditOutcome string
+
+const (
+	// AuditOutcomeSuccess records an action that completed
+	AuditOutcomeSuccess AuditOutcome = "success"
+
+	// AuditOutcomeFailure records an action that failed
+	AuditOutcomeFailure AuditOutcome = "failure"
+
+	// AuditOutcomeDenied records an action rejected by authentication or
+	// authorization
+	AuditOutcomeDenied AuditOutcome = "denied"
+)
+
+// AuditEvent is the audit record of an action, such as "order.cancel" on
+// the resource "order/42".
+type AuditEvent struct {
+	ActorID       string                 `json:"actor_id,omitempty"`
+	TenantID      string                 `json:"tenant_id,omitempty"`
+	Action        string                 `json:"action"`
+	Resource      string                 `json:"resource"`
+	Timestamp     time.Time              `json:"timestamp"`
+	Outcome       AuditOutcome           `json:"outcome"`
+	CorrelationID string                 `json:"correlation_id,omitempty"`
+	RequestID     string                 `json:"request_id,omitempty"`
+	Details       map[string]interface{} `json:"details,omitempty"`
+}
+
+// NewAuditEvent creates an audit event for action on resource with outcome
+// AuditOutcomeSuccess. Actor, tenant, request ID, and correlation ID are
+// taken from the context; the timestamp from the clock of the context (see
+// ClockFromContext).
+func NewAuditEvent(ctx context.Context, action, resource string) *AuditEvent {
+	event := &AuditEvent{
+		Action:    action,
+		Resource:  resource,
+		Timestamp: ClockFromContext(ctx).Now(),
+		Outcome:   AuditOutcomeSuccess,
+	}
+
+	event.ActorID, _ = GetUserID(ctx)
+	event.TenantID, _ = GetTenantID(ctx)
+	event.RequestID, _ = GetRequestID(ctx)
+	event.CorrelationID, _ = GetCorrelationID(ctx)
+
+	return event
+}
+
+// WithOutcome sets the outcome of the action.
+// Returns the event to allow chaining.
+func (e *AuditEvent) WithOutcome(outcome AuditOutcome) *AuditEvent {
+	e.Outcome = outcome
+	return e
+}
+
+// WithError records the error an action failed with. Unauthorized and
+// forbidden errors set the outcome AuditOutcomeDenied, other errors
+// AuditOutcomeFailure. The error message is stored in the details under
+// "error". A nil error leaves the event unchanged.
+// Returns the event to allow chaining.
+func (e *AuditEvent) WithError(err error) *AuditEvent {
+	if err == nil {
+		return e
+	}
+
+	if IsUnauthorized(err) || IsForbidden(err) {
+		e.Outcome = AuditOutcomeDenied
+	} else {
+		e.Outcome = AuditOutcomeFailure
+	}
+	return e.WithDetail("error", err.Error())
+}
+
+// WithDetail adds a detail, such as a changed field, to the event.
+// Returns the event to allow chaining.
+func (e *AuditEvent) WithDetail(key string, value interface{}) *AuditEvent {
+	if e.Details == nil {
+		e.Details = make(map[string]interface{})
+	}
+	e.Details[key] = value
+	return e
+}
+
+// AuditSink stores or forwards audit events, e.g. to a log or an audit
+// database. Implementations must be safe for concurrent use.
+type AuditSink interface {
+	// Record stores the event. Errors are handled by the sink itself, so
+	// that auditing never changes the outcome of the audited action.
+	Record(ctx context.Context, event *AuditEvent)
+}
+
+// AuditSinkFunc adapts a function to the AuditSink interface
+type AuditSinkFunc func(ctx context.Context, event *AuditEvent)
+
+// Record implements AuditSink interface.
+func (f AuditSinkFunc) Record(ctx context.Context, event *AuditEvent) {
+	f(ctx, event)
+}
+
+// noopAuditSink discards all audit events
+type noopAuditSink struct{}
+
+// Record implements AuditSink interface.
+func (noopAuditSink) Record(context.Context, *AuditEvent) {}
+
+// auditSinkHolder wraps the package audit sink for atomic replacement
+type auditSinkHolder struct {
+	sink AuditSink
+}
+
+// packageAuditSink is the sink used by RecordAudit
+var packageAuditSink atomic.Pointer[auditSinkHolder]
+
+func init() {
+	packageAuditSink.Store(&auditSinkHolder{sink: noopAuditSink{}})
+}
+
+// SetAuditSink replaces the package-level audit sink and returns the
+// previous one, so tests can restore it. A nil sink discards events.
+func SetAuditSink(sink AuditSink) AuditSink {
+	if sink == nil {
+		sink = noopAuditSink{}
+	}
+	return packageAuditSink.Swap(&auditSinkHolder{sink: sink}).sink
+}
+
+// RecordAudit passes the event to the package-level audit sink.
+// A nil event is ignored.
+func RecordAudit(ctx context.Context, event *AuditEvent) {
+	if event == nil {
+		return
+	}
+	packageAuditSink.Load().sink.Record(ctx, event)
+}
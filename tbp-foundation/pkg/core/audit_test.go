@@ -0,0 +1,149 @@
+// File: audit_test.go
+// Title: Tests for Audit Events
+// Description: Tests population of audit events from the request context,
+//              outcome handling, and recording through the package-level
+//              audit sink with a capturing sink.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// capturingAuditSink records all audit events
+type capturingAuditSink struct {
+	mu     sync.Mutex
+	events []*AuditEvent
+}
+
+func (s *capturingAuditSink) Record(ctx context.Context, event *AuditEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+}
+
+func (s *capturingAuditSink) Events() []*AuditEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*AuditEvent(nil), s.events...)
+}
+
+// withAuditSink sets a capturing sink for the duration of a test
+func withAuditSink(t *testing.T) *capturingAuditSink {
+	sink := &capturingAuditSink{}
+	previous := SetAuditSink(sink)
+	t.Cleanup(func() { SetAuditSink(previous) })
+	return sink
+}
+
+func TestNewAuditEvent(t *testing.T) {
+	t.Run("fills fields from context", func(t *testing.T) {
+		now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+		ctx := WithUser(context.Background(), &UserInfo{ID: "user-1", Username: "alice"})
+		ctx = WithTenant(ctx, &TenantInfo{ID: "acme", Name: "Acme"})
+		ctx = WithRequestID(ctx, "req-1")
+		ctx = WithCorrelationID(ctx, "corr-1")
+		ctx = WithClock(ctx, NewFakeClock(now))
+
+		event := NewAuditEvent(ctx, "order.cancel", "order/42")
+
+		assert.Equal(t, &AuditEvent{
+			ActorID:       "user-1",
+			TenantID:      "acme",
+			Action:        "order.cancel",
+			Resource:      "order/42",
+			Timestamp:     now,
+			Outcome:       AuditOutcomeSuccess,
+			CorrelationID: "corr-1",
+			RequestID:     "req-1",
+		}, event)
+	})
+
+	t.Run("leaves missing context fields empty", func(t *testing.T) {
+		event := NewAuditEvent(context.Background(), "login", "session")
+		assert.Empty(t, event.ActorID)
+		assert.Empty(t, event.TenantID)
+		assert.Empty(t, event.CorrelationID)
+		assert.False(t, event.Timestamp.IsZero())
+	})
+
+	t.Run("records outcome of errors", func(t *testing.T) {
+		ctx := context.Background()
+
+		event := NewAuditEvent(ctx, "order.cancel", "order/42").WithError(ErrForbidden)
+		assert.Equal(t, AuditOutcomeDenied, event.Outcome)
+		assert.Equal(t, ErrForbidden.Error(), event.Details["error"])
+
+		event = NewAuditEvent(ctx, "order.cancel", "order/42").WithError(New("database down"))
+		assert.Equal(t, AuditOutcomeFailure, event.Outcome)
+
+		event = NewAuditEvent(ctx, "order.cancel", "order/42").WithError(nil)
+		assert.Equal(t, AuditOutcomeSuccess, event.Outcome)
+		assert.Nil(t, event.Details)
+	})
+
+	t.Run("serializes with snake case names", func(t *testing.T) {
+		event := NewAuditEvent(WithUserID(context.Background(), "user-1"), "order.cancel", "order/42").
+			WithDetail("reason", "duplicate")
+
+		data, err := json.Marshal(event)
+		require.NoError(t, err)
+
+		var decoded map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, "user-1", decoded["actor_id"])
+		assert.Equal(t, "success", decoded["outcome"])
+		assert.Equal(t, map[string]interface{}{"reason": "duplicate"}, decoded["details"])
+		assert.NotContains(t, decoded, "tenant_id")
+	})
+}
+
+func TestAuditSink(t *testing.T) {
+	t.Run("discards events by default", func(t *testing.T) {
+		previous := SetAuditSink(nil)
+		defer SetAuditSink(previous)
+
+		assert.NotPanics(t, func() {
+			RecordAudit(context.Background(), NewAuditEvent(context.Background(), "login", "session"))
+		})
+	})
+
+	t.Run("records events in the package sink", func(t *testing.T) {
+		sink := withAuditSink(t)
+		ctx := NewUserContext(context.Background(), "user-1", "acme")
+
+		RecordAudit(ctx, NewAuditEvent(ctx, "order.create", "order/1"))
+		RecordAudit(ctx, nil)
+
+		events := sink.Events()
+		require.Len(t, events, 1)
+		assert.Equal(t, "user-1", events[0].ActorID)
+		assert.Equal(t, "acme", events[0].TenantID)
+		assert.Equal(t, "order.create", events[0].Action)
+	})
+
+	t.Run("accepts functions", func(t *testing.T) {
+		var recorded []string
+		previous := SetAuditSink(AuditSinkFunc(func(ctx context.Context, event *AuditEvent) {
+			recorded = append(recorded, event.Action)
+		}))
+		defer SetAuditSink(previous)
+
+		RecordAudit(context.Background(), NewAuditEvent(context.Background(), "logout", "session"))
+		assert.Equal(t, []string{"logout"}, recorded)
+	})
+}
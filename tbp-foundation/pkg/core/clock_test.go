@@ -0,0 +1,84 @@
+// File: clock_test.go
+// Title: Tests for the Clock Abstraction
+// Description: Test suite for the package-level and context-scoped clocks
+//              and the FakeClock test helper.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClock(t *testing.T) {
+	base := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	t.Run("system clock by default", func(t *testing.T) {
+		before := time.Now()
+		now := Now()
+		assert.False(t, now.Before(before))
+		assert.Equal(t, SystemClock(), ClockFromContext(context.Background()))
+	})
+
+	t.Run("fake clock advances manually", func(t *testing.T) {
+		clock := NewFakeClock(base)
+		assert.Equal(t, base, clock.Now())
+
+		clock.Advance(90 * time.Second)
+		assert.Equal(t, base.Add(90*time.Second), clock.Now())
+
+		clock.Set(base)
+		assert.Equal(t, base, clock.Now())
+	})
+
+	t.Run("SetClock replaces and restores package clock", func(t *testing.T) {
+		clock := NewFakeClock(base)
+		previous := SetClock(clock)
+		assert.Equal(t, SystemClock(), previous)
+		assert.Equal(t, base, Now())
+
+		SetClock(nil)
+		assert.NotEqual(t, base, Now())
+	})
+
+	t.Run("context clock overrides package clock", func(t *testing.T) {
+		clock := NewFakeClock(base)
+		ctx := WithClock(context.Background(), clock)
+		assert.Equal(t, clock, ClockFromContext(ctx))
+		assert.Equal(t, ctx, WithClock(ctx, nil))
+
+		ctx = WithRequestID(ctx, "req-1")
+		start, ok := GetStartTime(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, base, start)
+
+		clock.Advance(250 * time.Millisecond)
+		duration, _ := GetDuration(ctx)
+		assert.Equal(t, 250*time.Millisecond, duration)
+		assert.Equal(t, int64(250), ContextSummary(ctx)["duration_ms"])
+	})
+
+	t.Run("entities use the context clock", func(t *testing.T) {
+		clock := NewFakeClock(base)
+		ctx := WithClock(context.Background(), clock)
+
+		entity := &BaseEntity{Version: 1}
+		entity.IncrementVersionWithContext(ctx)
+		assert.Equal(t, base, entity.UpdatedAt)
+		assert.Equal(t, int64(2), entity.Version)
+
+		clock.Advance(time.Minute)
+		entity.TouchWithContext(ctx)
+		assert.Equal(t, base.Add(time.Minute), entity.UpdatedAt)
+	})
+}
@@ -0,0 +1,211 @@
+// File: clone.go
+// Title: Entity Clone and Diff Helpers for TBP
+// Description: Provides deep copies of entities and field-level diffs keyed
+//              by JSON field name, supporting optimistic updates, audit
+//              logging, and partial-update payloads.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial CloneEntity and DiffEntities implementation
+
+package core
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// FieldChange describes the old and new value of a changed field
+type FieldChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// CloneEntity returns a deep copy of the entity.
+// Pointers, slices, maps, and nested structs are copied recursively, so the
+// clone can be mutated without affecting the original. Unexported fields
+// are copied shallowly; time.Time values are preserved exactly.
+func CloneEntity[T Entity](e T) T {
+	value := reflect.ValueOf(&e).Elem()
+	clone := deepCopyValue(value, make(map[uintptr]reflect.Value))
+	return clone.Interface().(T)
+}
+
+// deepCopyValue recursively copies a value. copied maps already copied
+// pointers to their copies, so shared and cyclic references are preserved.
+func deepCopyValue(v reflect.Value, copied map[uintptr]reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		if existing, ok := copied[v.Pointer()]; ok && existing.Type() == v.Type() {
+			return existing
+		}
+		clone := reflect.New(v.Type().Elem())
+		copied[v.Pointer()] = clone
+		clone.Elem().Set(deepCopyValue(v.Elem(), copied))
+		return clone
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		clone := reflect.New(v.Type()).Elem()
+		clone.Set(deepCopyValue(v.Elem(), copied))
+		return clone
+
+	case reflect.Struct:
+		clone := reflect.New(v.Type()).Elem()
+		clone.Set(v) // Copies unexported fields shallowly
+		for i := 0; i < v.NumField(); i++ {
+			if field := clone.Field(i); field.CanSet() {
+				field.Set(deepCopyValue(v.Field(i), copied))
+			}
+		}
+		return clone
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		clone := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			clone.Index(i).Set(deepCopyValue(v.Index(i), copied))
+		}
+		return clone
+
+	case reflect.Array:
+		clone := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			clone.Index(i).Set(deepCopyValue(v.Index(i), copied))
+		}
+		return clone
+
+	case reflect.Map:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		clone := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			clone.SetMapIndex(deepCopyValue(iter.Key(), copied), deepCopyValue(iter.Value(), copied))
+		}
+		return clone
+
+	default:
+		return v
+	}
+}
+
+// DiffEntities reports the exported fields whose values differ between old
+// and new, keyed by JSON field name. Fields of embedded structs such as
+// BaseEntity are reported under their own names, unexported and
+// `json:"-"` fields are skipped, and time values are compared with
+// time.Time.Equal. Nested structs, slices, and maps are compared as a whole.
+// A nil entity is treated as having no field values.
+func DiffEntities[T Entity](old, new T) map[string]FieldChange {
+	oldFields := entityFields(reflect.ValueOf(old))
+	newFields := entityFields(reflect.ValueOf(new))
+
+	changes := make(map[string]FieldChange)
+	for name, oldValue := range oldFields {
+		newValue, exists := newFields[name]
+		if !exists || !fieldValuesEqual(oldValue, newValue) {
+			changes[name] = FieldChange{Old: oldValue, New: newValue}
+		}
+	}
+	for name, newValue := range newFields {
+		if _, exists := oldFields[name]; !exists {
+			changes[name] = FieldChange{New: newValue}
+		}
+	}
+	return changes
+}
+
+// entityFields returns the exported field values of a struct keyed by JSON
+// name, promoting fields of embedded structs like encoding/json does
+func entityFields(v reflect.Value) map[string]interface{} {
+	fields := make(map[string]interface{})
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return fields
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return fields
+	}
+
+	collectFields(v, fields)
+	return fields
+}
+
+// collectFields adds the fields of v to fields. Direct fields are added
+// before embedded ones so that shallower fields win, as in encoding/json.
+func collectFields(v reflect.Value, fields map[string]interface{}) {
+	var embedded []reflect.Value
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		fieldValue := v.Field(i)
+		if field.Anonymous && name == "" {
+			for fieldValue.Kind() == reflect.Pointer && !fieldValue.IsNil() {
+				fieldValue = fieldValue.Elem()
+			}
+			if fieldValue.Kind() == reflect.Struct {
+				embedded = append(embedded, fieldValue)
+				continue
+			}
+		}
+
+		if !field.IsExported() || !fieldValue.CanInterface() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if _, exists := fields[name]; !exists {
+			fields[name] = fieldValue.Interface()
+		}
+	}
+
+	for _, embeddedValue := range embedded {
+		nested := make(map[string]interface{})
+		collectFields(embeddedValue, nested)
+		for name, value := range nested {
+			if _, exists := fields[name]; !exists {
+				fields[name] = value
+			}
+		}
+	}
+}
+
+// fieldValuesEqual compares two field values, using time.Time.Equal for times
+func fieldValuesEqual(a, b interface{}) bool {
+	switch ta := a.(type) {
+	case time.Time:
+		if tb, ok := b.(time.Time); ok {
+			return ta.Equal(tb)
+		}
+	case *time.Time:
+		if tb, ok := b.(*time.Time); ok {
+			if ta == nil || tb == nil {
+				return ta == tb
+			}
+			return ta.Equal(*tb)
+		}
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,144 @@
+// File: clone_test.go
+// Title: Tests for Entity Clone and Diff Helpers
+// Description: Test suite for deep copying entities and computing
+//              field-level diffs including embedded, nested, and time fields.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// orderAddress is a nested value of orderEntity
+type orderAddress struct {
+	Street string `json:"street"`
+	City   string `json:"city"`
+}
+
+// orderEntity is a test entity with nested, time, and hidden fields
+type orderEntity struct {
+	BaseEntity
+	Customer string            `json:"customer"`
+	Address  *orderAddress     `json:"address,omitempty"`
+	Lines    []string          `json:"lines"`
+	Labels   map[string]string `json:"labels"`
+	DueAt    *time.Time        `json:"due_at,omitempty"`
+	Secret   string            `json:"-"`
+	Untagged int
+	internal string
+}
+
+func TestCloneEntity(t *testing.T) {
+	due := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	original := &orderEntity{
+		BaseEntity: BaseEntity{ID: ID("order-1"), Version: 3, CreatedAt: time.Now()},
+		Customer:   "acme",
+		Address:    &orderAddress{Street: "Main St 1", City: "Berlin"},
+		Lines:      []string{"widget"},
+		Labels:     map[string]string{"priority": "high"},
+		DueAt:      &due,
+		Secret:     "s3cr3t",
+		internal:   "cache",
+	}
+
+	clone := CloneEntity(original)
+
+	t.Run("copies all values", func(t *testing.T) {
+		require.NotSame(t, original, clone)
+		assert.Equal(t, original, clone)
+		assert.Equal(t, "cache", clone.internal)
+		assert.True(t, original.CreatedAt.Equal(clone.CreatedAt))
+	})
+
+	t.Run("clone is independent of original", func(t *testing.T) {
+		clone.Address.City = "Hamburg"
+		clone.Lines[0] = "gadget"
+		clone.Labels["priority"] = "low"
+		*clone.DueAt = due.Add(time.Hour)
+		clone.IncrementVersion()
+
+		assert.Equal(t, "Berlin", original.Address.City)
+		assert.Equal(t, "widget", original.Lines[0])
+		assert.Equal(t, "high", original.Labels["priority"])
+		assert.Equal(t, due, *original.DueAt)
+		assert.Equal(t, int64(3), original.Version)
+	})
+
+	t.Run("works with TestEntity and nil", func(t *testing.T) {
+		entity := &TestEntity{BaseEntity: BaseEntity{ID: ID("e1")}, Name: "name"}
+		assert.Equal(t, entity, CloneEntity(entity))
+		assert.Nil(t, CloneEntity[*TestEntity](nil))
+	})
+}
+
+func TestDiffEntities(t *testing.T) {
+	created := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	t.Run("reports changed fields by JSON name", func(t *testing.T) {
+		before := &TestEntity{
+			BaseEntity: BaseEntity{ID: ID("e1"), Version: 1, CreatedAt: created, UpdatedAt: created},
+			Name:       "Old",
+			Status:     StatusActive,
+		}
+		after := CloneEntity(before)
+		after.Name = "New"
+		after.Version = 2
+		after.UpdatedAt = created.Add(time.Minute)
+
+		changes := DiffEntities(before, after)
+		assert.Equal(t, map[string]FieldChange{
+			"name":       {Old: "Old", New: "New"},
+			"version":    {Old: int64(1), New: int64(2)},
+			"updated_at": {Old: created, New: created.Add(time.Minute)},
+		}, changes)
+	})
+
+	t.Run("compares times by instant", func(t *testing.T) {
+		before := &TestEntity{BaseEntity: BaseEntity{CreatedAt: created}}
+		after := &TestEntity{BaseEntity: BaseEntity{CreatedAt: created.In(time.FixedZone("CET", 3600))}}
+		assert.Empty(t, DiffEntities(before, after))
+	})
+
+	t.Run("handles nested, pointer, and hidden fields", func(t *testing.T) {
+		due := created.Add(24 * time.Hour)
+		before := &orderEntity{
+			Address: &orderAddress{City: "Berlin"},
+			Lines:   []string{"widget"},
+			DueAt:   &due,
+			Secret:  "a",
+		}
+		after := CloneEntity(before)
+		after.Address.City = "Hamburg"
+		after.Lines = append(after.Lines, "gadget")
+		*after.DueAt = due.UTC()
+		after.Secret = "b"
+		after.internal = "changed"
+		after.Untagged = 7
+
+		changes := DiffEntities(before, after)
+		assert.Len(t, changes, 3)
+		assert.Equal(t, "Hamburg", changes["address"].New.(*orderAddress).City)
+		assert.Equal(t, []string{"widget", "gadget"}, changes["lines"].New)
+		assert.Equal(t, 7, changes["Untagged"].New)
+		assert.NotContains(t, changes, "due_at")
+		assert.NotContains(t, changes, "Secret")
+	})
+
+	t.Run("nil entity", func(t *testing.T) {
+		after := &TestEntity{Name: "created"}
+		changes := DiffEntities[*TestEntity](nil, after)
+		assert.Equal(t, FieldChange{New: "created"}, changes["name"])
+		assert.Empty(t, DiffEntities[*TestEntity](nil, nil))
+	})
+}
@@ -5,12 +5,27 @@
 //              throughout the entire call chain in a type-safe manner.
 //              Extends Go's standard context.Context with enterprise features.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.15
 // Created: 2025-05-26
-// Modified: 2025-05-26
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-05-26 v0.1.0: Initial implementation with user, tenant, and request tracking
+// - 2026-10-16 v0.1.1: Added role hierarchy resolution and permission checks
+// - 2026-10-16 v0.1.2: Added request deadline budget tracking
+// - 2026-10-16 v0.1.3: Added locale and timezone propagation with header helpers
+// - 2026-10-16 v0.1.4: Store and return defensive copies of UserInfo and TenantInfo
+// - 2026-10-16 v0.1.5: Take the current time from the Clock abstraction
+// - 2026-10-16 v0.1.6: Added OAuth scopes with wildcard matching
+// - 2026-10-16 v0.1.7: Added DetachContext and MergeContextValues
+// - 2026-10-16 v0.1.8: Added feature flags in context
+// - 2026-10-16 v0.1.9: Added baggage with W3C Baggage header propagation
+// - 2026-10-16 v0.1.10: Added request-local Attributes for structured logging
+// - 2026-10-16 v0.1.11: Added ClientInfo with trusted-proxy aware ClientInfoFromRequest
+// - 2026-10-16 v0.1.12: Added idempotency key with header propagation
+// - 2026-10-16 v0.1.13: Added cancellation reasons via CancelWithReason
+// - 2026-10-16 v0.1.14: Added configurable request ID generators
+// - 2026-10-16 v0.1.15: Deadline budget start time taken from the context clock
 
 package core
 
@@ -18,7 +33,17 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
 	"time"
+
+	"golang.org/x/text/language"
 )
 
 // contextKey is a private type used for context keys to avoid collisions
@@ -34,14 +59,127 @@ const (
 	keyStartTime     contextKey = "tbp:start_time"
 	keyUserRoles     contextKey = "tbp:user_roles"
 	keySessionID     contextKey = "tbp:session_id"
+	keyPermissions   contextKey = "tbp:permissions"
+	keyBudget        contextKey = "tbp:budget"
+	keyLocale        contextKey = "tbp:locale"
+	keyTimezone      contextKey = "tbp:timezone"
+	keyFeatureFlags  contextKey = "tbp:feature_flags"
+	keyBaggage       contextKey = "tbp:baggage"
+	keyAttributes    contextKey = "tbp:attributes"
+	keyClientInfo    contextKey = "tbp:client_info"
+	keyIdempotency   contextKey = "tbp:idempotency_key"
+)
+
+// DefaultCancellationReason is recorded when a context created with
+// CancelWithReason is cancelled with an empty reason
+const DefaultCancellationReason = "cancelled without reason"
+
+// HTTP headers used to propagate context values across service calls
+const (
+	HeaderAcceptLanguage = "Accept-Language"
+	HeaderTimezone       = "X-Timezone"
+	HeaderBaggage        = "Baggage"
+	HeaderIdempotencyKey = "Idempotency-Key"
+)
+
+// HTTP headers set by reverse proxies to identify the client; they are
+// only evaluated for requests from trusted proxies (see SetTrustedProxies)
+const (
+	HeaderForwardedFor = "X-Forwarded-For"
+	HeaderRealIP       = "X-Real-IP"
+)
+
+// RoleResolver returns the roles directly implied by a role,
+// e.g. "admin" implying "editor". Implied roles are resolved transitively.
+type RoleResolver func(role string) []string
+
+var (
+	// roleResolverMu protects the package-level role resolver
+	roleResolverMu sync.RWMutex
+
+	// roleResolver expands roles for HasRole checks; nil means direct matches only
+	roleResolver RoleResolver
 )
 
+// SetRoleResolver sets the package-level resolver used to expand role
+// inheritance in HasRole, HasAnyRole and HasAllRoles. Passing nil restores
+// direct role matching.
+func SetRoleResolver(resolver func(role string) []string) {
+	roleResolverMu.Lock()
+	defer roleResolverMu.Unlock()
+	roleResolver = resolver
+}
+
+// getRoleResolver returns the current role resolver
+func getRoleResolver() RoleResolver {
+	roleResolverMu.RLock()
+	defer roleResolverMu.RUnlock()
+	return roleResolver
+}
+
+var (
+	// trustedProxiesMu protects the package-level trusted proxy networks
+	trustedProxiesMu sync.RWMutex
+
+	// trustedProxies are the networks whose forwarding headers are trusted;
+	// empty means forwarding headers are ignored
+	trustedProxies []*net.IPNet
+)
+
+// SetTrustedProxies sets the reverse proxies whose X-Forwarded-For and
+// X-Real-IP headers ClientInfoFromRequest trusts, given as IP addresses or
+// CIDR networks such as "10.0.0.0/8". Calling it without arguments restores
+// the default of trusting no proxy. Returns an ErrCodeInvalidInput error
+// and keeps the current setting if an entry cannot be parsed.
+func SetTrustedProxies(proxies ...string) error {
+	networks := make([]*net.IPNet, 0, len(proxies))
+	for _, proxy := range proxies {
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return NewWithCodef(ErrCodeInvalidInput, "invalid trusted proxy address: %s", proxy)
+			}
+			bits := 8 * net.IPv6len
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 8*net.IPv4len
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return WrapWithCodef(err, ErrCodeInvalidInput, "invalid trusted proxy network: %s", proxy)
+		}
+		networks = append(networks, network)
+	}
+
+	trustedProxiesMu.Lock()
+	defer trustedProxiesMu.Unlock()
+	trustedProxies = networks
+	return nil
+}
+
+// isTrustedProxy reports whether ip belongs to a trusted proxy network
+func isTrustedProxy(ip net.IP) bool {
+	trustedProxiesMu.RLock()
+	defer trustedProxiesMu.RUnlock()
+
+	for _, network := range trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
 // UserInfo represents user information stored in context
 type UserInfo struct {
 	ID       string    `json:"id"`
 	Username string    `json:"username,omitempty"`
 	Email    string    `json:"email,omitempty"`
 	Roles    []string  `json:"roles,omitempty"`
+	Scopes   []string  `json:"scopes,omitempty"` // OAuth scopes, e.g. "read:orders" or "orders:*"
 	TenantID string    `json:"tenant_id,omitempty"`
 	LoginAt  time.Time `json:"login_at,omitempty"`
 }
@@ -56,6 +194,57 @@ type TenantInfo struct {
 	Permissions []string          `json:"permissions,omitempty"`
 }
 
+// Clone returns a deep copy of the user information.
+// Returns nil if u is nil.
+func (u *UserInfo) Clone() *UserInfo {
+	if u == nil {
+		return nil
+	}
+	clone := *u
+	if u.Roles != nil {
+		clone.Roles = append([]string(nil), u.Roles...)
+	}
+	if u.Scopes != nil {
+		clone.Scopes = append([]string(nil), u.Scopes...)
+	}
+	return &clone
+}
+
+// Clone returns a deep copy of the tenant information.
+// Returns nil if t is nil.
+func (t *TenantInfo) Clone() *TenantInfo {
+	if t == nil {
+		return nil
+	}
+	clone := *t
+	if t.Settings != nil {
+		clone.Settings = make(map[string]string, len(t.Settings))
+		for key, value := range t.Settings {
+			clone.Settings[key] = value
+		}
+	}
+	if t.Permissions != nil {
+		clone.Permissions = append([]string(nil), t.Permissions...)
+	}
+	return &clone
+}
+
+// ClientInfo represents the caller of a request as seen by the service
+type ClientInfo struct {
+	IP           net.IP `json:"ip,omitempty"`
+	UserAgent    string `json:"user_agent,omitempty"`
+	ForwardedFor string `json:"forwarded_for,omitempty"` // X-Forwarded-For as received, unverified
+}
+
+// Clone returns a copy of the client information that does not share
+// the IP address
+func (c ClientInfo) Clone() ClientInfo {
+	if c.IP != nil {
+		c.IP = append(net.IP(nil), c.IP...)
+	}
+	return c
+}
+
 // RequestInfo represents request tracking information
 type RequestInfo struct {
 	ID            string        `json:"id"`
@@ -69,7 +258,8 @@ type RequestInfo struct {
 }
 
 // WithUser adds user information to the context.
-// Returns a new context with the user info attached.
+// A copy of user is stored, so later changes to user do not affect the
+// context. Returns a new context with the user info attached.
 func WithUser(ctx context.Context, user *UserInfo) context.Context {
 	if ctx == nil {
 		ctx = context.Background()
@@ -77,7 +267,7 @@ func WithUser(ctx context.Context, user *UserInfo) context.Context {
 	if user == nil {
 		return ctx
 	}
-	return context.WithValue(ctx, keyUserID, user)
+	return context.WithValue(ctx, keyUserID, user.Clone())
 }
 
 // WithUserID adds a user ID to the context.
@@ -91,12 +281,13 @@ func WithUserID(ctx context.Context, userID string) context.Context {
 }
 
 // WithTenant adds tenant information to the context.
-// Returns a new context with the tenant info attached.
+// A copy of tenant is stored, so later changes to tenant do not affect
+// the context. Returns a new context with the tenant info attached.
 func WithTenant(ctx context.Context, tenant *TenantInfo) context.Context {
 	if tenant == nil {
 		return ctx
 	}
-	return context.WithValue(ctx, keyTenantID, tenant)
+	return context.WithValue(ctx, keyTenantID, tenant.Clone())
 }
 
 // WithTenantID adds a tenant ID to the context.
@@ -110,7 +301,8 @@ func WithTenantID(ctx context.Context, tenantID string) context.Context {
 }
 
 // WithRequestID adds a request ID to the context.
-// If requestID is empty, a new UUID-like ID is generated automatically.
+// If requestID is empty, a new ID is generated with the configured
+// generator (see SetRequestIDGenerator).
 func WithRequestID(ctx context.Context, requestID string) context.Context {
 	if requestID == "" {
 		requestID = generateRequestID()
@@ -118,7 +310,7 @@ func WithRequestID(ctx context.Context, requestID string) context.Context {
 
 	request := &RequestInfo{
 		ID:        requestID,
-		StartTime: time.Now(),
+		StartTime: ClockFromContext(ctx).Now(),
 	}
 	return context.WithValue(ctx, keyRequestID, request)
 }
@@ -140,7 +332,7 @@ func WithCorrelationID(ctx context.Context, correlationID string) context.Contex
 	request := &RequestInfo{
 		ID:            generateRequestID(),
 		CorrelationID: correlationID,
-		StartTime:     time.Now(),
+		StartTime:     ClockFromContext(ctx).Now(),
 	}
 	return context.WithValue(ctx, keyRequestID, request)
 }
@@ -168,9 +360,177 @@ func WithSessionID(ctx context.Context, sessionID string) context.Context {
 	return context.WithValue(ctx, keySessionID, sessionID)
 }
 
+// WithPermissions adds a set of granted permissions to the context.
+// Duplicate permissions are removed; an empty set leaves the context unchanged.
+func WithPermissions(ctx context.Context, permissions []string) context.Context {
+	if len(permissions) == 0 {
+		return ctx
+	}
+
+	seen := make(map[string]bool, len(permissions))
+	unique := make([]string, 0, len(permissions))
+	for _, permission := range permissions {
+		if permission != "" && !seen[permission] {
+			seen[permission] = true
+			unique = append(unique, permission)
+		}
+	}
+	sort.Strings(unique)
+
+	return context.WithValue(ctx, keyPermissions, unique)
+}
+
+// WithLocale adds the caller's locale to the context.
+// An undetermined tag (language.Und) leaves the context unchanged.
+func WithLocale(ctx context.Context, locale language.Tag) context.Context {
+	if locale == language.Und {
+		return ctx
+	}
+	return context.WithValue(ctx, keyLocale, locale)
+}
+
+// WithTimezone adds the caller's timezone to the context.
+// A nil location leaves the context unchanged.
+func WithTimezone(ctx context.Context, timezone *time.Location) context.Context {
+	if timezone == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, keyTimezone, timezone)
+}
+
+// WithFeatureFlags adds the feature flags evaluated for the request to the
+// context, replacing flags set earlier. The map is copied, so later changes
+// to flags do not affect the context.
+func WithFeatureFlags(ctx context.Context, flags map[string]bool) context.Context {
+	copied := make(map[string]bool, len(flags))
+	for name, enabled := range flags {
+		copied[name] = enabled
+	}
+	return context.WithValue(ctx, keyFeatureFlags, copied)
+}
+
+// WithFeatureFlag sets a single feature flag, keeping the flags already in
+// the context. Parent contexts are not affected.
+func WithFeatureFlag(ctx context.Context, name string, enabled bool) context.Context {
+	existing, _ := ctx.Value(keyFeatureFlags).(map[string]bool)
+
+	flags := make(map[string]bool, len(existing)+1)
+	for flag, value := range existing {
+		flags[flag] = value
+	}
+	flags[name] = enabled
+	return context.WithValue(ctx, keyFeatureFlags, flags)
+}
+
+// WithBaggage adds a propagated label such as "region=eu" to the context.
+// Baggage travels with the request like the correlation ID: it appears in
+// ContextSummary and is propagated by InjectHeaders. The existing baggage
+// is copied, so parent contexts are not affected. An empty key leaves the
+// context unchanged.
+func WithBaggage(ctx context.Context, key, value string) context.Context {
+	if key == "" {
+		return ctx
+	}
+
+	existing, _ := ctx.Value(keyBaggage).(map[string]string)
+	baggage := make(map[string]string, len(existing)+1)
+	for k, v := range existing {
+		baggage[k] = v
+	}
+	baggage[key] = value
+	return context.WithValue(ctx, keyBaggage, baggage)
+}
+
+// WithAttributes adds a new, empty set of request attributes to the context
+// and returns it for recording values such as "db.query.count" while the
+// request is handled. Unlike baggage, attributes are mutable and stay
+// in-process: they appear in ContextSummary but are neither propagated by
+// InjectHeaders nor copied by MergeContextValues.
+func WithAttributes(ctx context.Context) (context.Context, *Attributes) {
+	attrs := &Attributes{values: make(map[string]interface{})}
+	return context.WithValue(ctx, keyAttributes, attrs), attrs
+}
+
+// WithClientInfo adds information about the caller, typically created by
+// ClientInfoFromRequest, to the context for rate limiting and audit.
+// A copy of client is stored.
+func WithClientInfo(ctx context.Context, client ClientInfo) context.Context {
+	return context.WithValue(ctx, keyClientInfo, client.Clone())
+}
+
+// ClientInfoFromRequest determines the client of an HTTP request. The
+// client IP is the peer address of the request unless the peer is a
+// trusted proxy (see SetTrustedProxies). Then the X-Forwarded-For chain is
+// walked from the right, skipping trusted proxies, and the first untrusted
+// address is the client; without X-Forwarded-For, X-Real-IP is used.
+// Forwarding headers of untrusted peers are ignored, since any client can
+// set them. ForwardedFor always holds the X-Forwarded-For header as
+// received.
+func ClientInfoFromRequest(r *http.Request) ClientInfo {
+	client := ClientInfo{
+		UserAgent:    r.UserAgent(),
+		ForwardedFor: strings.Join(r.Header.Values(HeaderForwardedFor), ", "),
+	}
+
+	client.IP = parseClientIP(r.RemoteAddr)
+	if client.IP == nil || !isTrustedProxy(client.IP) {
+		return client
+	}
+
+	if client.ForwardedFor != "" {
+		hops := strings.Split(client.ForwardedFor, ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			ip := parseClientIP(hops[i])
+			if ip == nil {
+				break // a malformed entry ends the verifiable part of the chain
+			}
+			client.IP = ip
+			if !isTrustedProxy(ip) {
+				break
+			}
+		}
+		return client
+	}
+
+	if ip := parseClientIP(r.Header.Get(HeaderRealIP)); ip != nil {
+		client.IP = ip
+	}
+	return client
+}
+
+// parseClientIP parses an IP address with optional port, as found in
+// RemoteAddr and forwarding headers. Returns nil if address is invalid.
+func parseClientIP(address string) net.IP {
+	address = strings.TrimSpace(address)
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		address = host
+	}
+	return net.ParseIP(strings.Trim(address, "[]"))
+}
+
+// WithIdempotencyKey adds the idempotency key of a mutating request to the
+// context, so that the service layer can detect retries of the same
+// request (see IdempotencyStore). An empty key leaves the context unchanged.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	if key == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, keyIdempotency, key)
+}
+
 // GetUser retrieves user information from the context.
-// Returns the UserInfo and true if found, nil and false otherwise.
+// Returns a copy of the UserInfo and true if found, nil and false otherwise.
+// Modifying the copy does not affect the context or other callers.
 func GetUser(ctx context.Context) (*UserInfo, bool) {
+	if user, ok := lookupUser(ctx); ok {
+		return user.Clone(), true
+	}
+	return nil, false
+}
+
+// lookupUser returns the stored user information without copying.
+// Callers must not modify the result.
+func lookupUser(ctx context.Context) (*UserInfo, bool) {
 	if user, ok := ctx.Value(keyUserID).(*UserInfo); ok && user != nil {
 		return user, true
 	}
@@ -180,15 +540,25 @@ func GetUser(ctx context.Context) (*UserInfo, bool) {
 // GetUserID retrieves the user ID from the context.
 // Returns the user ID and true if found, empty string and false otherwise.
 func GetUserID(ctx context.Context) (string, bool) {
-	if user, ok := GetUser(ctx); ok {
+	if user, ok := lookupUser(ctx); ok {
 		return user.ID, true
 	}
 	return "", false
 }
 
 // GetTenant retrieves tenant information from the context.
-// Returns the TenantInfo and true if found, nil and false otherwise.
+// Returns a copy of the TenantInfo and true if found, nil and false
+// otherwise. Modifying the copy does not affect the context.
 func GetTenant(ctx context.Context) (*TenantInfo, bool) {
+	if tenant, ok := lookupTenant(ctx); ok {
+		return tenant.Clone(), true
+	}
+	return nil, false
+}
+
+// lookupTenant returns the stored tenant information without copying.
+// Callers must not modify the result.
+func lookupTenant(ctx context.Context) (*TenantInfo, bool) {
 	if tenant, ok := ctx.Value(keyTenantID).(*TenantInfo); ok && tenant != nil {
 		return tenant, true
 	}
@@ -198,7 +568,7 @@ func GetTenant(ctx context.Context) (*TenantInfo, bool) {
 // GetTenantID retrieves the tenant ID from the context.
 // Returns the tenant ID and true if found, empty string and false otherwise.
 func GetTenantID(ctx context.Context) (string, bool) {
-	if tenant, ok := GetTenant(ctx); ok {
+	if tenant, ok := lookupTenant(ctx); ok {
 		return tenant.ID, true
 	}
 	return "", false
@@ -240,6 +610,167 @@ func GetSessionID(ctx context.Context) (string, bool) {
 	return "", false
 }
 
+// GetClientInfo retrieves information about the caller from the context.
+// Returns a copy of the ClientInfo and true if found, an empty ClientInfo
+// and false otherwise.
+func GetClientInfo(ctx context.Context) (ClientInfo, bool) {
+	if client, ok := ctx.Value(keyClientInfo).(ClientInfo); ok {
+		return client.Clone(), true
+	}
+	return ClientInfo{}, false
+}
+
+// GetIdempotencyKey retrieves the idempotency key from the context.
+// Returns the key and true if found, empty string and false otherwise.
+func GetIdempotencyKey(ctx context.Context) (string, bool) {
+	if key, ok := ctx.Value(keyIdempotency).(string); ok && key != "" {
+		return key, true
+	}
+	return "", false
+}
+
+// GetPermissions retrieves the granted permissions from the context.
+// Returns a copy of the sorted permission set and true if found, nil and false otherwise.
+func GetPermissions(ctx context.Context) ([]string, bool) {
+	if permissions, ok := ctx.Value(keyPermissions).([]string); ok && len(permissions) > 0 {
+		result := make([]string, len(permissions))
+		copy(result, permissions)
+		return result, true
+	}
+	return nil, false
+}
+
+// GetLocale retrieves the caller's locale from the context.
+// Returns the locale and true if found, language.Und and false otherwise.
+func GetLocale(ctx context.Context) (language.Tag, bool) {
+	if locale, ok := ctx.Value(keyLocale).(language.Tag); ok && locale != language.Und {
+		return locale, true
+	}
+	return language.Und, false
+}
+
+// GetTimezone retrieves the caller's timezone from the context.
+// Returns the location and true if found, nil and false otherwise.
+func GetTimezone(ctx context.Context) (*time.Location, bool) {
+	if timezone, ok := ctx.Value(keyTimezone).(*time.Location); ok && timezone != nil {
+		return timezone, true
+	}
+	return nil, false
+}
+
+// GetFeatureFlags retrieves the feature flags from the context.
+// Returns a copy of the flags and true if any are set, nil and false otherwise.
+func GetFeatureFlags(ctx context.Context) (map[string]bool, bool) {
+	flags, ok := ctx.Value(keyFeatureFlags).(map[string]bool)
+	if !ok || len(flags) == 0 {
+		return nil, false
+	}
+
+	result := make(map[string]bool, len(flags))
+	for name, enabled := range flags {
+		result[name] = enabled
+	}
+	return result, true
+}
+
+// IsFeatureEnabled reports whether the named feature flag is enabled in
+// the context. Unknown flags are disabled.
+func IsFeatureEnabled(ctx context.Context, name string) bool {
+	flags, _ := ctx.Value(keyFeatureFlags).(map[string]bool)
+	return flags[name]
+}
+
+// GetBaggage retrieves a baggage value from the context.
+// Returns the value and true if found, empty string and false otherwise.
+func GetBaggage(ctx context.Context, key string) (string, bool) {
+	baggage, _ := ctx.Value(keyBaggage).(map[string]string)
+	value, ok := baggage[key]
+	return value, ok
+}
+
+// AllBaggage returns a copy of all baggage in the context, or nil if
+// there is none.
+func AllBaggage(ctx context.Context) map[string]string {
+	baggage, _ := ctx.Value(keyBaggage).(map[string]string)
+	if len(baggage) == 0 {
+		return nil
+	}
+
+	result := make(map[string]string, len(baggage))
+	for key, value := range baggage {
+		result[key] = value
+	}
+	return result
+}
+
+// Attributes is a mutable set of request-local attributes, safe for
+// concurrent use. Methods on a nil *Attributes do nothing, so the result
+// of AttributesFromContext can be used without checking.
+type Attributes struct {
+	mu     sync.RWMutex
+	values map[string]interface{}
+}
+
+// AttributesFromContext retrieves the attributes added by WithAttributes.
+// Returns the attributes and true if found, nil and false otherwise.
+func AttributesFromContext(ctx context.Context) (*Attributes, bool) {
+	attrs, ok := ctx.Value(keyAttributes).(*Attributes)
+	return attrs, ok
+}
+
+// Set sets the attribute key to value, replacing any previous value
+func (a *Attributes) Set(key string, value interface{}) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.values[key] = value
+}
+
+// Get returns the value of the attribute key and whether it is set
+func (a *Attributes) Get(key string) (interface{}, bool) {
+	if a == nil {
+		return nil, false
+	}
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	value, ok := a.values[key]
+	return value, ok
+}
+
+// Snapshot returns a copy of all attributes, or nil if none are set
+func (a *Attributes) Snapshot() map[string]interface{} {
+	if a == nil {
+		return nil
+	}
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if len(a.values) == 0 {
+		return nil
+	}
+
+	result := make(map[string]interface{}, len(a.values))
+	for key, value := range a.values {
+		result[key] = value
+	}
+	return result
+}
+
+// enabledFeatureFlags returns the sorted names of the enabled flags
+func enabledFeatureFlags(ctx context.Context) []string {
+	flags, _ := ctx.Value(keyFeatureFlags).(map[string]bool)
+
+	var enabled []string
+	for name, on := range flags {
+		if on {
+			enabled = append(enabled, name)
+		}
+	}
+	sort.Strings(enabled)
+	return enabled
+}
+
 // GetStartTime retrieves the start time from the context.
 // Returns the start time and true if found, zero time and false otherwise.
 func GetStartTime(ctx context.Context) (time.Time, bool) {
@@ -253,11 +784,130 @@ func GetStartTime(ctx context.Context) (time.Time, bool) {
 // Returns the duration and true if start time is found, zero duration and false otherwise.
 func GetDuration(ctx context.Context) (time.Duration, bool) {
 	if startTime, ok := GetStartTime(ctx); ok {
-		return time.Since(startTime), true
+		return ClockFromContext(ctx).Now().Sub(startTime), true
+	}
+	return 0, false
+}
+
+// WithDeadlineBudget adds a time budget for the request to the context.
+// The budget is measured from the request start time, which is set to now
+// if the context has none, using the clock of the context (see
+// ClockFromContext) like RemainingBudget and IsBudgetExceeded. A real
+// deadline for the budget left at the time of the call is attached as with
+// context.WithDeadline, so downstream calls are cancelled once the budget
+// is spent; CancellationReason then reports that the budget was exceeded.
+// The returned cancel function must be called to release resources.
+func WithDeadlineBudget(ctx context.Context, total time.Duration) (context.Context, context.CancelFunc) {
+	clock := ClockFromContext(ctx)
+	startTime, ok := GetStartTime(ctx)
+	if !ok {
+		startTime = clock.Now()
+		ctx = WithStartTime(ctx, startTime)
+	}
+
+	// The deadline is a real timer; with an injected clock, the budget left
+	// on that clock is added to the real time
+	deadline := startTime.Add(total)
+	if _, isSystem := clock.(systemClock); !isSystem {
+		deadline = time.Now().Add(total - clock.Now().Sub(startTime))
+	}
+
+	ctx = context.WithValue(ctx, keyBudget, total)
+	return context.WithDeadlineCause(ctx, deadline, &cancellationCause{
+		reason: fmt.Sprintf("deadline budget of %v exceeded", total),
+		err:    context.DeadlineExceeded,
+	})
+}
+
+// GetBudget retrieves the total time budget from the context.
+// Returns the budget and true if found, zero duration and false otherwise.
+func GetBudget(ctx context.Context) (time.Duration, bool) {
+	if budget, ok := ctx.Value(keyBudget).(time.Duration); ok {
+		return budget, true
 	}
 	return 0, false
 }
 
+// RemainingBudget calculates how much of the time budget is left based on
+// the start time in the context. The result is never negative; use it for
+// logging or to derive per-call timeouts.
+// Returns the remaining time and true if a budget is set, zero duration and false otherwise.
+func RemainingBudget(ctx context.Context) (time.Duration, bool) {
+	budget, ok := GetBudget(ctx)
+	if !ok {
+		return 0, false
+	}
+
+	elapsed, ok := GetDuration(ctx)
+	if !ok {
+		return 0, false
+	}
+
+	if remaining := budget - elapsed; remaining > 0 {
+		return remaining, true
+	}
+	return 0, true
+}
+
+// IsBudgetExceeded checks if the time budget in the context has been spent.
+// Returns false if no budget is set.
+func IsBudgetExceeded(ctx context.Context) bool {
+	remaining, ok := RemainingBudget(ctx)
+	return ok && remaining <= 0
+}
+
+// cancellationCause is the cancellation cause recorded by CancelWithReason
+// and WithDeadlineBudget. It unwraps to the context error, so errors.Is
+// with context.Canceled or context.DeadlineExceeded works on
+// context.Cause.
+type cancellationCause struct {
+	reason string
+	err    error
+}
+
+// Error implements the error interface.
+func (c *cancellationCause) Error() string {
+	return c.err.Error() + ": " + c.reason
+}
+
+// Unwrap returns the context error.
+func (c *cancellationCause) Unwrap() error {
+	return c.err
+}
+
+// CancelWithReason returns a cancellable copy of ctx and a function that
+// cancels it with a human-readable reason such as "client disconnected",
+// which downstream code can retrieve with CancellationReason. An empty
+// reason records DefaultCancellationReason. Only the first call records
+// its reason. The cancel function must be called to release resources.
+func CancelWithReason(ctx context.Context) (context.Context, func(reason string)) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	return ctx, func(reason string) {
+		if reason == "" {
+			reason = DefaultCancellationReason
+		}
+		cancel(&cancellationCause{reason: reason, err: context.Canceled})
+	}
+}
+
+// CancellationReason retrieves why the context was cancelled. Returns the
+// reason recorded by CancelWithReason or WithDeadlineBudget on ctx or a
+// parent context; contexts cancelled otherwise report the message of
+// their cause, e.g. "context deadline exceeded".
+// Returns the reason and true if the context is done, an empty string and false otherwise.
+func CancellationReason(ctx context.Context) (string, bool) {
+	if ctx.Err() == nil {
+		return "", false
+	}
+
+	cause := context.Cause(ctx)
+	var reasoned *cancellationCause
+	if errors.As(cause, &reasoned) {
+		return reasoned.reason, true
+	}
+	return cause.Error(), true
+}
+
 // MustGetUserID retrieves the user ID from the context or panics if not found.
 // This should only be used in contexts where the user ID is guaranteed to exist.
 func MustGetUserID(ctx context.Context) string {
@@ -288,18 +938,59 @@ func MustGetRequestID(ctx context.Context) string {
 // IsAuthenticated checks if the context contains valid user information.
 // Returns true if user information is present and has a valid ID.
 func IsAuthenticated(ctx context.Context) bool {
-	if user, ok := GetUser(ctx); ok {
+	if user, ok := lookupUser(ctx); ok {
 		return user.ID != ""
 	}
 	return false
 }
 
 // HasRole checks if the authenticated user has a specific role.
-// Returns true if the user is authenticated and has the specified role.
+// Returns true if the user is authenticated and has the specified role,
+// either directly or implied through the resolver set by SetRoleResolver.
 func HasRole(ctx context.Context, role string) bool {
-	if user, ok := GetUser(ctx); ok {
-		for _, userRole := range user.Roles {
-			if userRole == role {
+	user, ok := lookupUser(ctx)
+	if !ok {
+		return false
+	}
+
+	for _, userRole := range user.Roles {
+		if userRole == role {
+			return true
+		}
+	}
+
+	resolver := getRoleResolver()
+	if resolver == nil {
+		return false
+	}
+	return expandRoles(user.Roles, resolver)[role]
+}
+
+// expandRoles returns the transitive closure of roles under the resolver.
+// Cyclic role definitions are tolerated.
+func expandRoles(roles []string, resolver RoleResolver) map[string]bool {
+	effective := make(map[string]bool, len(roles))
+	pending := append([]string{}, roles...)
+
+	for len(pending) > 0 {
+		role := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if effective[role] {
+			continue
+		}
+		effective[role] = true
+		pending = append(pending, resolver(role)...)
+	}
+
+	return effective
+}
+
+// HasPermission checks if the context grants a specific permission.
+// Returns true if the permission was added with WithPermissions.
+func HasPermission(ctx context.Context, permission string) bool {
+	if permissions, ok := ctx.Value(keyPermissions).([]string); ok {
+		for _, granted := range permissions {
+			if granted == permission {
 				return true
 			}
 		}
@@ -329,13 +1020,61 @@ func HasAllRoles(ctx context.Context, roles ...string) bool {
 	return len(roles) > 0 // Return false if no roles specified
 }
 
+// HasScope checks if the authenticated user was granted a specific OAuth scope.
+// A granted scope ending in "*" matches every scope with the preceding
+// prefix, so "orders:*" grants "orders:read" and "*" grants every scope.
+func HasScope(ctx context.Context, scope string) bool {
+	user, ok := lookupUser(ctx)
+	if !ok || scope == "" {
+		return false
+	}
+
+	for _, granted := range user.Scopes {
+		if scopeMatches(granted, scope) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAnyScope checks if the authenticated user has any of the specified scopes.
+// Returns true if the user is authenticated and has at least one of the scopes.
+func HasAnyScope(ctx context.Context, scopes ...string) bool {
+	for _, scope := range scopes {
+		if HasScope(ctx, scope) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAllScopes checks if the authenticated user has all of the specified scopes.
+// Returns true if the user is authenticated and has all of the scopes.
+func HasAllScopes(ctx context.Context, scopes ...string) bool {
+	for _, scope := range scopes {
+		if !HasScope(ctx, scope) {
+			return false
+		}
+	}
+	return len(scopes) > 0 // Return false if no scopes specified
+}
+
+// scopeMatches reports whether a granted scope, possibly ending in a
+// wildcard, covers the requested scope
+func scopeMatches(granted, requested string) bool {
+	if prefix, isWildcard := strings.CutSuffix(granted, "*"); isWildcard {
+		return strings.HasPrefix(requested, prefix)
+	}
+	return granted == requested
+}
+
 // NewRequestContext creates a new context with request tracking information.
 // This is typically called at the beginning of request handling.
 func NewRequestContext(ctx context.Context) context.Context {
 	requestID := generateRequestID()
 	request := &RequestInfo{
 		ID:        requestID,
-		StartTime: time.Now(),
+		StartTime: ClockFromContext(ctx).Now(),
 	}
 	return context.WithValue(ctx, keyRequestID, request)
 }
@@ -353,9 +1092,206 @@ func NewUserContext(ctx context.Context, userID, tenantID string) context.Contex
 	return ctx
 }
 
-// generateRequestID creates a new unique request ID.
-// Uses crypto/rand for cryptographically secure random bytes.
+// DetachContext returns a context that carries all TBP values of ctx but
+// is not cancelled with ctx and has no deadline. Use it for background
+// work forked from a request that must outlive the request while keeping
+// user, tenant, and request identifiers for logging.
+//
+// The deadline budget and the active transaction are not carried over,
+// since they belong to the lifetime of the original request.
+func DetachContext(ctx context.Context) context.Context {
+	return MergeContextValues(context.Background(), ctx)
+}
+
+// MergeContextValues copies the TBP values of src onto dst: user, tenant,
+// request information (including request and correlation IDs), client
+// information, session ID, idempotency key, permissions, locale, timezone,
+// feature flags, baggage, logger, and clock. Values present in src
+// replace those in dst; cancellation and deadline of dst are unchanged.
+// Request attributes are not copied, since they belong to the request of src.
+func MergeContextValues(dst, src context.Context) context.Context {
+	if dst == nil {
+		dst = context.Background()
+	}
+	if src == nil {
+		return dst
+	}
+
+	if user, ok := lookupUser(src); ok {
+		dst = WithUser(dst, user)
+	}
+	if tenant, ok := lookupTenant(src); ok {
+		dst = WithTenant(dst, tenant)
+	}
+	if req, ok := GetRequestInfo(src); ok {
+		request := *req
+		dst = context.WithValue(dst, keyRequestID, &request)
+	}
+	if client, ok := GetClientInfo(src); ok {
+		dst = WithClientInfo(dst, client)
+	}
+	if sessionID, ok := GetSessionID(src); ok {
+		dst = WithSessionID(dst, sessionID)
+	}
+	if key, ok := GetIdempotencyKey(src); ok {
+		dst = WithIdempotencyKey(dst, key)
+	}
+	if permissions, ok := GetPermissions(src); ok {
+		dst = WithPermissions(dst, permissions)
+	}
+	if locale, ok := GetLocale(src); ok {
+		dst = WithLocale(dst, locale)
+	}
+	if timezone, ok := GetTimezone(src); ok {
+		dst = WithTimezone(dst, timezone)
+	}
+	if flags, ok := GetFeatureFlags(src); ok {
+		dst = WithFeatureFlags(dst, flags)
+	}
+	for key, value := range AllBaggage(src) {
+		dst = WithBaggage(dst, key, value)
+	}
+	if logger, ok := src.Value(keyLogger).(Logger); ok {
+		dst = WithLogger(dst, logger)
+	}
+	if clock, ok := src.Value(keyClock).(Clock); ok {
+		dst = WithClock(dst, clock)
+	}
+
+	return dst
+}
+
+// ContextFromHeaders extracts propagated context values from HTTP headers.
+// The locale is taken from the highest weighted Accept-Language entry,
+// the timezone from the X-Timezone header as an IANA name, baggage from
+// the W3C Baggage header, and the idempotency key from the Idempotency-Key
+// header. Missing or invalid headers are ignored.
+func ContextFromHeaders(ctx context.Context, header http.Header) context.Context {
+	if acceptLanguage := header.Get(HeaderAcceptLanguage); acceptLanguage != "" {
+		if tags, _, err := language.ParseAcceptLanguage(acceptLanguage); err == nil && len(tags) > 0 {
+			ctx = WithLocale(ctx, tags[0])
+		}
+	}
+
+	if timezoneName := header.Get(HeaderTimezone); timezoneName != "" {
+		if timezone, err := time.LoadLocation(timezoneName); err == nil {
+			ctx = WithTimezone(ctx, timezone)
+		}
+	}
+
+	if key := strings.TrimSpace(header.Get(HeaderIdempotencyKey)); key != "" {
+		ctx = WithIdempotencyKey(ctx, key)
+	}
+
+	for _, line := range header.Values(HeaderBaggage) {
+		for _, member := range strings.Split(line, ",") {
+			// Drop member properties such as "key=value;ttl=60"
+			member, _, _ = strings.Cut(member, ";")
+			key, value, ok := strings.Cut(member, "=")
+			if !ok {
+				continue
+			}
+			key = strings.TrimSpace(key)
+			decoded, err := url.PathUnescape(strings.TrimSpace(value))
+			if err != nil {
+				continue
+			}
+			ctx = WithBaggage(ctx, key, decoded)
+		}
+	}
+
+	return ctx
+}
+
+// InjectHeaders writes propagated context values to HTTP headers for
+// outgoing service calls. It is the counterpart of ContextFromHeaders.
+func InjectHeaders(ctx context.Context, header http.Header) {
+	if locale, ok := GetLocale(ctx); ok {
+		header.Set(HeaderAcceptLanguage, locale.String())
+	}
+
+	if timezone, ok := GetTimezone(ctx); ok {
+		header.Set(HeaderTimezone, timezone.String())
+	}
+
+	if key, ok := GetIdempotencyKey(ctx); ok {
+		header.Set(HeaderIdempotencyKey, key)
+	}
+
+	if baggage := AllBaggage(ctx); len(baggage) > 0 {
+		keys := make([]string, 0, len(baggage))
+		for key := range baggage {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		members := make([]string, len(keys))
+		for i, key := range keys {
+			members[i] = key + "=" + escapeBaggageValue(baggage[key])
+		}
+		header.Set(HeaderBaggage, strings.Join(members, ","))
+	}
+}
+
+// escapeBaggageValue percent-encodes all bytes of a baggage value except
+// unreserved characters, so that separators and spaces survive propagation
+func escapeBaggageValue(value string) string {
+	const hexDigits = "0123456789ABCDEF"
+
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' ||
+			c == '-' || c == '.' || c == '_' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		b.WriteByte('%')
+		b.WriteByte(hexDigits[c>>4])
+		b.WriteByte(hexDigits[c&0x0F])
+	}
+	return b.String()
+}
+
+var (
+	// requestIDGeneratorMu protects the package-level request ID generator
+	requestIDGeneratorMu sync.RWMutex
+
+	// requestIDGenerator creates request IDs; nil means DefaultRequestID
+	requestIDGenerator func() string
+)
+
+// SetRequestIDGenerator sets the package-level generator used for request
+// IDs that are not provided, e.g. by WithRequestID(ctx, "") and
+// NewRequestContext. Use UUIDRequestID or ULIDRequestID to correlate with
+// external systems, or wrap a generator with PrefixedRequestID. Passing
+// nil restores DefaultRequestID. The generator must be safe for concurrent
+// use and return unique IDs.
+func SetRequestIDGenerator(generator func() string) {
+	requestIDGeneratorMu.Lock()
+	defer requestIDGeneratorMu.Unlock()
+	requestIDGenerator = generator
+}
+
+// generateRequestID creates a new request ID with the configured
+// generator. Falls back to DefaultRequestID if it returns an empty ID.
 func generateRequestID() string {
+	requestIDGeneratorMu.RLock()
+	generator := requestIDGenerator
+	requestIDGeneratorMu.RUnlock()
+
+	if generator != nil {
+		if id := generator(); id != "" {
+			return id
+		}
+	}
+	return DefaultRequestID()
+}
+
+// DefaultRequestID creates a request ID of 128 random bits in hex with a
+// "req_" prefix, e.g. "req_3f2a...". This is the default generator.
+// Uses crypto/rand for cryptographically secure random bytes.
+func DefaultRequestID() string {
 	bytes := make([]byte, 16) // 128-bit random ID
 	if _, err := rand.Read(bytes); err != nil {
 		// Fallback to timestamp-based ID if crypto/rand fails
@@ -364,6 +1300,52 @@ func generateRequestID() string {
 	return "req_" + hex.EncodeToString(bytes)
 }
 
+// UUIDRequestID creates a request ID in UUID version 4 format.
+func UUIDRequestID() string {
+	return newUUID()
+}
+
+// crockfordBase32 is the ULID alphabet
+const crockfordBase32 = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ULIDRequestID creates a request ID in ULID format: 26 characters of
+// Crockford base32 encoding a 48-bit millisecond timestamp from the
+// package-level clock followed by 80 random bits, so IDs sort by creation
+// time.
+func ULIDRequestID() string {
+	var ulid [16]byte
+	millis := uint64(Now().UnixMilli())
+	for i := 5; i >= 0; i-- {
+		ulid[i] = byte(millis)
+		millis >>= 8
+	}
+	if _, err := rand.Read(ulid[6:]); err != nil {
+		panic("failed to generate ULID: " + err.Error())
+	}
+
+	// Encode the 128 bits, preceded by two zero bits, in 26 groups of 5 bits
+	var buf [26]byte
+	for i := range buf {
+		var group byte
+		for bit := i*5 - 2; bit < i*5+3; bit++ {
+			group <<= 1
+			if bit >= 0 && ulid[bit/8]&(0x80>>(bit%8)) != 0 {
+				group |= 1
+			}
+		}
+		buf[i] = crockfordBase32[group]
+	}
+	return string(buf[:])
+}
+
+// PrefixedRequestID returns a generator that prepends prefix to the IDs
+// of generate, e.g. PrefixedRequestID("shop-", ULIDRequestID).
+func PrefixedRequestID(prefix string, generate func() string) func() string {
+	return func() string {
+		return prefix + generate()
+	}
+}
+
 // ContextSummary returns a summary of all context values for debugging.
 // This is useful for logging and troubleshooting context propagation.
 func ContextSummary(ctx context.Context) map[string]interface{} {
@@ -375,6 +1357,9 @@ func ContextSummary(ctx context.Context) map[string]interface{} {
 		if len(user.Roles) > 0 {
 			summary["roles"] = user.Roles
 		}
+		if len(user.Scopes) > 0 {
+			summary["scopes"] = user.Scopes
+		}
 	}
 
 	if tenant, ok := GetTenant(ctx); ok {
@@ -388,7 +1373,16 @@ func ContextSummary(ctx context.Context) map[string]interface{} {
 			summary["correlation_id"] = req.CorrelationID
 		}
 		if !req.StartTime.IsZero() {
-			summary["duration_ms"] = time.Since(req.StartTime).Milliseconds()
+			summary["duration_ms"] = ClockFromContext(ctx).Now().Sub(req.StartTime).Milliseconds()
+		}
+	}
+
+	if client, ok := GetClientInfo(ctx); ok {
+		if client.IP != nil {
+			summary["client_ip"] = client.IP.String()
+		}
+		if client.UserAgent != "" {
+			summary["user_agent"] = client.UserAgent
 		}
 	}
 
@@ -396,5 +1390,43 @@ func ContextSummary(ctx context.Context) map[string]interface{} {
 		summary["session_id"] = sessionID
 	}
 
+	if key, ok := GetIdempotencyKey(ctx); ok {
+		summary["idempotency_key"] = key
+	}
+
+	if permissions, ok := GetPermissions(ctx); ok {
+		summary["permissions"] = permissions
+	}
+
+	if remaining, ok := RemainingBudget(ctx); ok {
+		summary["budget_remaining_ms"] = remaining.Milliseconds()
+	}
+
+	if reason, ok := CancellationReason(ctx); ok {
+		summary["cancellation_reason"] = reason
+	}
+
+	if locale, ok := GetLocale(ctx); ok {
+		summary["locale"] = locale.String()
+	}
+
+	if timezone, ok := GetTimezone(ctx); ok {
+		summary["timezone"] = timezone.String()
+	}
+
+	if flags := enabledFeatureFlags(ctx); len(flags) > 0 {
+		summary["feature_flags"] = flags
+	}
+
+	if baggage := AllBaggage(ctx); baggage != nil {
+		summary["baggage"] = baggage
+	}
+
+	if attrs, ok := AttributesFromContext(ctx); ok {
+		if values := attrs.Snapshot(); values != nil {
+			summary["attributes"] = values
+		}
+	}
+
 	return summary
 }
@@ -5,17 +5,34 @@
 //              and all context manipulation functions. Tests edge cases,
 //              concurrent access, and performance characteristics.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.13
 // Created: 2025-05-26
-// Modified: 2025-05-26
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-05-26 v0.1.0: Initial test implementation with comprehensive coverage
+// - 2026-10-16 v0.1.1: Added role resolver and permission tests
+// - 2026-10-16 v0.1.2: Added deadline budget tests
+// - 2026-10-16 v0.1.3: Added locale, timezone, and header propagation tests
+// - 2026-10-16 v0.1.4: Added context value isolation tests
+// - 2026-10-16 v0.1.5: Replaced sleeps with FakeClock
+// - 2026-10-16 v0.1.6: Added scope tests
+// - 2026-10-16 v0.1.7: Added detached context tests
+// - 2026-10-16 v0.1.8: Added request attribute tests
+// - 2026-10-16 v0.1.9: Added client info and proxy chain tests
+// - 2026-10-16 v0.1.10: Added idempotency key tests
+// - 2026-10-16 v0.1.11: Added cancellation reason tests
+// - 2026-10-16 v0.1.12: Added request ID generator tests
+// - 2026-10-16 v0.1.13: Added deadline budget test with FakeClock
 
 package core
 
 import (
 	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"sync"
 	"testing"
@@ -23,6 +40,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
 )
 
 func TestWithUser(t *testing.T) {
@@ -116,6 +134,64 @@ func TestWithTenant(t *testing.T) {
 	})
 }
 
+func TestContextValueIsolation(t *testing.T) {
+	t.Run("mutating returned user does not affect context", func(t *testing.T) {
+		ctx := WithUser(context.Background(), &UserInfo{ID: "user123", Roles: []string{"viewer"}})
+
+		user, ok := GetUser(ctx)
+		require.True(t, ok)
+		user.Roles[0] = "admin"
+		user.Roles = append(user.Roles, "superuser")
+		user.ID = "attacker"
+
+		stored, ok := GetUser(ctx)
+		require.True(t, ok)
+		assert.Equal(t, "user123", stored.ID)
+		assert.Equal(t, []string{"viewer"}, stored.Roles)
+		assert.False(t, HasRole(ctx, "admin"))
+	})
+
+	t.Run("mutating original user does not affect context", func(t *testing.T) {
+		original := &UserInfo{ID: "user123", Roles: []string{"viewer"}}
+		ctx := WithUser(context.Background(), original)
+
+		original.Roles[0] = "admin"
+
+		assert.False(t, HasRole(ctx, "admin"))
+		assert.True(t, HasRole(ctx, "viewer"))
+	})
+
+	t.Run("mutating returned tenant does not affect context", func(t *testing.T) {
+		original := &TenantInfo{
+			ID:          "tenant123",
+			Settings:    map[string]string{"theme": "dark"},
+			Permissions: []string{"read"},
+		}
+		ctx := WithTenant(context.Background(), original)
+		original.Settings["theme"] = "changed"
+
+		tenant, ok := GetTenant(ctx)
+		require.True(t, ok)
+		tenant.Settings["theme"] = "light"
+		tenant.Permissions[0] = "write"
+
+		stored, ok := GetTenant(ctx)
+		require.True(t, ok)
+		assert.Equal(t, "dark", stored.Settings["theme"])
+		assert.Equal(t, []string{"read"}, stored.Permissions)
+	})
+
+	t.Run("clone handles nil values", func(t *testing.T) {
+		var user *UserInfo
+		var tenant *TenantInfo
+		assert.Nil(t, user.Clone())
+		assert.Nil(t, tenant.Clone())
+
+		clone := (&UserInfo{ID: "user123"}).Clone()
+		assert.Nil(t, clone.Roles)
+	})
+}
+
 func TestWithTenantID(t *testing.T) {
 	t.Run("creates tenant with ID", func(t *testing.T) {
 		ctx := context.Background()
@@ -162,7 +238,6 @@ func TestWithRequestID(t *testing.T) {
 		retrievedID, exists := GetRequestID(newCtx)
 		assert.True(t, exists)
 		assert.NotEmpty(t, retrievedID)
-		assert.True(t, strings.HasPrefix(retrievedID, "req_"))
 	})
 
 	t.Run("generated IDs are unique", func(t *testing.T) {
@@ -390,7 +465,6 @@ func TestConvenienceMethods(t *testing.T) {
 		requestID, exists := GetRequestID(newCtx)
 		assert.True(t, exists)
 		assert.NotEmpty(t, requestID)
-		assert.True(t, strings.HasPrefix(requestID, "req_"))
 
 		startTime, exists := GetStartTime(newCtx)
 		assert.True(t, exists)
@@ -433,17 +507,16 @@ func TestConvenienceMethods(t *testing.T) {
 
 func TestGetDuration(t *testing.T) {
 	t.Run("calculates duration correctly", func(t *testing.T) {
-		ctx := context.Background()
-		startTime := time.Now().Add(-100 * time.Millisecond)
+		clock := NewFakeClock(time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC))
+		ctx := WithClock(context.Background(), clock)
+		startTime := clock.Now().Add(-100 * time.Millisecond)
 		ctx = WithStartTime(ctx, startTime)
 
-		// Wait a bit to ensure some duration
-		time.Sleep(10 * time.Millisecond)
+		clock.Advance(10 * time.Millisecond)
 
 		duration, exists := GetDuration(ctx)
 		assert.True(t, exists)
-		assert.True(t, duration >= 10*time.Millisecond)
-		assert.True(t, duration <= 200*time.Millisecond) // Generous upper bound
+		assert.Equal(t, 110*time.Millisecond, duration)
 	})
 
 	t.Run("returns false when no start time", func(t *testing.T) {
@@ -494,6 +567,58 @@ func TestContextSummary(t *testing.T) {
 	})
 }
 
+func TestScopes(t *testing.T) {
+	ctx := WithUser(context.Background(), &UserInfo{
+		ID:     "user123",
+		Scopes: []string{"read:orders", "orders:*", "billing:invoices:*"},
+	})
+
+	t.Run("exact match", func(t *testing.T) {
+		assert.True(t, HasScope(ctx, "read:orders"))
+		assert.False(t, HasScope(ctx, "write:orders"))
+	})
+
+	t.Run("wildcard match", func(t *testing.T) {
+		assert.True(t, HasScope(ctx, "orders:read"))
+		assert.True(t, HasScope(ctx, "orders:write"))
+		assert.True(t, HasScope(ctx, "billing:invoices:read"))
+		assert.False(t, HasScope(ctx, "billing:payments:read"))
+		assert.False(t, HasScope(ctx, "ordersx:read"), "wildcard keeps the separator")
+		assert.False(t, HasScope(ctx, ""))
+	})
+
+	t.Run("global wildcard", func(t *testing.T) {
+		adminCtx := WithUser(context.Background(), &UserInfo{ID: "admin", Scopes: []string{"*"}})
+		assert.True(t, HasScope(adminCtx, "anything:at:all"))
+	})
+
+	t.Run("any and all", func(t *testing.T) {
+		assert.True(t, HasAnyScope(ctx, "write:orders", "orders:delete"))
+		assert.False(t, HasAnyScope(ctx, "write:orders", "users:read"))
+		assert.True(t, HasAllScopes(ctx, "read:orders", "orders:delete"))
+		assert.False(t, HasAllScopes(ctx, "read:orders", "write:orders"))
+		assert.False(t, HasAllScopes(ctx))
+	})
+
+	t.Run("empty user", func(t *testing.T) {
+		empty := context.Background()
+		assert.False(t, HasScope(empty, "read:orders"))
+		assert.False(t, HasAnyScope(empty, "read:orders"))
+		assert.False(t, HasAllScopes(empty, "read:orders"))
+
+		noScopes := WithUserID(context.Background(), "user456")
+		assert.False(t, HasScope(noScopes, "read:orders"))
+	})
+
+	t.Run("summary and isolation", func(t *testing.T) {
+		assert.Equal(t, []string{"read:orders", "orders:*", "billing:invoices:*"}, ContextSummary(ctx)["scopes"])
+
+		user, _ := GetUser(ctx)
+		user.Scopes[0] = "admin:*"
+		assert.False(t, HasScope(ctx, "admin:users"))
+	})
+}
+
 func TestConcurrentAccess(t *testing.T) {
 	t.Run("concurrent context creation", func(t *testing.T) {
 		const numGoroutines = 100
@@ -551,6 +676,439 @@ func TestSessionID(t *testing.T) {
 	})
 }
 
+func TestRoleResolver(t *testing.T) {
+	hierarchy := map[string][]string{
+		"admin":  {"editor"},
+		"editor": {"viewer"},
+		"viewer": {"admin"}, // Cycles must not loop forever
+	}
+	SetRoleResolver(func(role string) []string {
+		return hierarchy[role]
+	})
+	defer SetRoleResolver(nil)
+
+	ctx := WithUser(context.Background(), &UserInfo{
+		ID:    "user123",
+		Roles: []string{"admin"},
+	})
+
+	t.Run("HasRole resolves implied roles", func(t *testing.T) {
+		assert.True(t, HasRole(ctx, "admin"))
+		assert.True(t, HasRole(ctx, "editor"))
+		assert.True(t, HasRole(ctx, "viewer"))
+		assert.False(t, HasRole(ctx, "superadmin"))
+	})
+
+	t.Run("HasAnyRole and HasAllRoles use implied roles", func(t *testing.T) {
+		assert.True(t, HasAnyRole(ctx, "superadmin", "viewer"))
+		assert.True(t, HasAllRoles(ctx, "admin", "editor", "viewer"))
+		assert.False(t, HasAllRoles(ctx, "editor", "superadmin"))
+	})
+
+	t.Run("implied roles do not grant parent roles", func(t *testing.T) {
+		SetRoleResolver(func(role string) []string {
+			if role == "admin" {
+				return []string{"editor"}
+			}
+			return nil
+		})
+
+		editorCtx := WithUser(context.Background(), &UserInfo{
+			ID:    "user456",
+			Roles: []string{"editor"},
+		})
+		assert.True(t, HasRole(editorCtx, "editor"))
+		assert.False(t, HasRole(editorCtx, "admin"))
+	})
+
+	t.Run("uses direct matches without resolver", func(t *testing.T) {
+		SetRoleResolver(nil)
+		assert.True(t, HasRole(ctx, "admin"))
+		assert.False(t, HasRole(ctx, "editor"))
+	})
+}
+
+func TestPermissions(t *testing.T) {
+	t.Run("adds and checks permissions", func(t *testing.T) {
+		ctx := WithPermissions(context.Background(), []string{"orders:write", "orders:read", "orders:read"})
+
+		assert.True(t, HasPermission(ctx, "orders:read"))
+		assert.True(t, HasPermission(ctx, "orders:write"))
+		assert.False(t, HasPermission(ctx, "orders:delete"))
+
+		permissions, exists := GetPermissions(ctx)
+		assert.True(t, exists)
+		assert.Equal(t, []string{"orders:read", "orders:write"}, permissions)
+	})
+
+	t.Run("handles empty permissions", func(t *testing.T) {
+		ctx := WithPermissions(context.Background(), nil)
+
+		_, exists := GetPermissions(ctx)
+		assert.False(t, exists)
+		assert.False(t, HasPermission(ctx, "orders:read"))
+	})
+
+	t.Run("included in context summary", func(t *testing.T) {
+		ctx := WithPermissions(context.Background(), []string{"orders:read"})
+
+		summary := ContextSummary(ctx)
+		assert.Equal(t, []string{"orders:read"}, summary["permissions"])
+	})
+}
+
+func TestDeadlineBudget(t *testing.T) {
+	t.Run("tracks remaining budget from start time", func(t *testing.T) {
+		startTime := time.Now().Add(-200 * time.Millisecond)
+		ctx := WithStartTime(context.Background(), startTime)
+
+		ctx, cancel := WithDeadlineBudget(ctx, time.Second)
+		defer cancel()
+
+		budget, exists := GetBudget(ctx)
+		assert.True(t, exists)
+		assert.Equal(t, time.Second, budget)
+
+		remaining, exists := RemainingBudget(ctx)
+		assert.True(t, exists)
+		assert.True(t, remaining <= 800*time.Millisecond)
+		assert.True(t, remaining > 500*time.Millisecond)
+		assert.False(t, IsBudgetExceeded(ctx))
+
+		deadline, hasDeadline := ctx.Deadline()
+		assert.True(t, hasDeadline)
+		assert.Equal(t, startTime.Add(time.Second), deadline)
+	})
+
+	t.Run("sets start time if missing", func(t *testing.T) {
+		ctx, cancel := WithDeadlineBudget(context.Background(), time.Minute)
+		defer cancel()
+
+		_, exists := GetStartTime(ctx)
+		assert.True(t, exists)
+
+		remaining, exists := RemainingBudget(ctx)
+		assert.True(t, exists)
+		assert.True(t, remaining > 59*time.Second)
+	})
+
+	t.Run("cancels context when budget is spent", func(t *testing.T) {
+		ctx, cancel := WithDeadlineBudget(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		select {
+		case <-ctx.Done():
+			assert.Equal(t, context.DeadlineExceeded, ctx.Err())
+		case <-time.After(time.Second):
+			t.Fatal("context was not cancelled after budget was spent")
+		}
+
+		remaining, exists := RemainingBudget(ctx)
+		assert.True(t, exists)
+		assert.Equal(t, time.Duration(0), remaining)
+		assert.True(t, IsBudgetExceeded(ctx))
+	})
+
+	t.Run("measures budget with context clock", func(t *testing.T) {
+		clock := NewFakeClock(time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC))
+		ctx := WithClock(context.Background(), clock)
+
+		ctx, cancel := WithDeadlineBudget(ctx, time.Second)
+		defer cancel()
+
+		startTime, exists := GetStartTime(ctx)
+		assert.True(t, exists)
+		assert.Equal(t, clock.Now(), startTime)
+
+		remaining, exists := RemainingBudget(ctx)
+		assert.True(t, exists)
+		assert.Equal(t, time.Second, remaining)
+		assert.False(t, IsBudgetExceeded(ctx))
+		assert.NoError(t, ctx.Err(), "real deadline is not derived from the fake time")
+
+		clock.Advance(400 * time.Millisecond)
+		remaining, _ = RemainingBudget(ctx)
+		assert.Equal(t, 600*time.Millisecond, remaining)
+
+		clock.Advance(time.Second)
+		remaining, _ = RemainingBudget(ctx)
+		assert.Equal(t, time.Duration(0), remaining)
+		assert.True(t, IsBudgetExceeded(ctx))
+	})
+
+	t.Run("handles missing budget", func(t *testing.T) {
+		ctx := NewRequestContext(context.Background())
+
+		_, exists := RemainingBudget(ctx)
+		assert.False(t, exists)
+		assert.False(t, IsBudgetExceeded(ctx))
+	})
+
+	t.Run("included in context summary", func(t *testing.T) {
+		ctx, cancel := WithDeadlineBudget(context.Background(), time.Minute)
+		defer cancel()
+
+		summary := ContextSummary(ctx)
+		assert.Contains(t, summary, "budget_remaining_ms")
+	})
+}
+
+func TestRequestIDGenerator(t *testing.T) {
+	t.Run("default generator uses req_ prefix", func(t *testing.T) {
+		id := DefaultRequestID()
+		assert.Regexp(t, `^req_[0-9a-f]{32}$`, id)
+		assert.NotEqual(t, id, DefaultRequestID())
+
+		requestID, _ := GetRequestID(WithRequestID(context.Background(), ""))
+		assert.True(t, strings.HasPrefix(requestID, "req_"))
+	})
+
+	t.Run("uses configured generator", func(t *testing.T) {
+		counter := 0
+		SetRequestIDGenerator(func() string {
+			counter++
+			return fmt.Sprintf("test-%d", counter)
+		})
+		defer SetRequestIDGenerator(nil)
+
+		requestID, _ := GetRequestID(WithRequestID(context.Background(), ""))
+		assert.Equal(t, "test-1", requestID)
+		requestID, _ = GetRequestID(NewRequestContext(context.Background()))
+		assert.Equal(t, "test-2", requestID)
+		requestID, _ = GetRequestID(WithCorrelationID(context.Background(), "corr-1"))
+		assert.Equal(t, "test-3", requestID)
+
+		requestID, _ = GetRequestID(WithRequestID(context.Background(), "given"))
+		assert.Equal(t, "given", requestID)
+		assert.Equal(t, 3, counter)
+	})
+
+	t.Run("falls back to default for empty IDs", func(t *testing.T) {
+		SetRequestIDGenerator(func() string { return "" })
+		defer SetRequestIDGenerator(nil)
+
+		requestID, _ := GetRequestID(WithRequestID(context.Background(), ""))
+		assert.True(t, strings.HasPrefix(requestID, "req_"))
+	})
+
+	t.Run("restores default with nil", func(t *testing.T) {
+		SetRequestIDGenerator(UUIDRequestID)
+		SetRequestIDGenerator(nil)
+
+		requestID, _ := GetRequestID(WithRequestID(context.Background(), ""))
+		assert.True(t, strings.HasPrefix(requestID, "req_"))
+	})
+
+	t.Run("generates UUIDs", func(t *testing.T) {
+		id := UUIDRequestID()
+		assert.True(t, IsValidUUID(ID(id)))
+		assert.NotEqual(t, id, UUIDRequestID())
+	})
+
+	t.Run("generates time-ordered ULIDs", func(t *testing.T) {
+		clock := NewFakeClock(time.UnixMilli(1469918176385))
+		previous := SetClock(clock)
+		defer SetClock(previous)
+
+		id := ULIDRequestID()
+		assert.Regexp(t, `^[0-9A-HJKMNP-TV-Z]{26}$`, id)
+		assert.Equal(t, "01ARYZ6S41", id[:10], "encodes the timestamp")
+
+		clock.Advance(time.Millisecond)
+		later := ULIDRequestID()
+		assert.Equal(t, "01ARYZ6S42", later[:10])
+		assert.Less(t, id, later)
+
+		seen := make(map[string]bool)
+		for i := 0; i < 1000; i++ {
+			id := ULIDRequestID()
+			assert.False(t, seen[id], "duplicate ULID %s", id)
+			seen[id] = true
+		}
+	})
+
+	t.Run("prefixes generated IDs", func(t *testing.T) {
+		SetRequestIDGenerator(PrefixedRequestID("shop-", UUIDRequestID))
+		defer SetRequestIDGenerator(nil)
+
+		requestID, _ := GetRequestID(NewRequestContext(context.Background()))
+		require.True(t, strings.HasPrefix(requestID, "shop-"))
+		assert.True(t, IsValidUUID(ID(strings.TrimPrefix(requestID, "shop-"))))
+	})
+}
+
+func TestCancellationReason(t *testing.T) {
+	t.Run("records explicit reason", func(t *testing.T) {
+		ctx, cancel := CancelWithReason(NewRequestContext(context.Background()))
+
+		_, cancelled := CancellationReason(ctx)
+		assert.False(t, cancelled)
+		assert.NotContains(t, ContextSummary(ctx), "cancellation_reason")
+
+		cancel("client disconnected")
+		cancel("admin kill")
+
+		reason, cancelled := CancellationReason(ctx)
+		assert.True(t, cancelled)
+		assert.Equal(t, "client disconnected", reason, "first reason wins")
+		assert.Equal(t, context.Canceled, ctx.Err())
+		assert.ErrorIs(t, context.Cause(ctx), context.Canceled)
+		assert.Equal(t, "context canceled: client disconnected", context.Cause(ctx).Error())
+		assert.Equal(t, "client disconnected", ContextSummary(ctx)["cancellation_reason"])
+	})
+
+	t.Run("records default reason", func(t *testing.T) {
+		ctx, cancel := CancelWithReason(context.Background())
+		cancel("")
+
+		reason, cancelled := CancellationReason(ctx)
+		assert.True(t, cancelled)
+		assert.Equal(t, DefaultCancellationReason, reason)
+	})
+
+	t.Run("propagates reason to child contexts", func(t *testing.T) {
+		parent, cancel := CancelWithReason(context.Background())
+		child, childCancel := context.WithTimeout(WithRequestID(parent, "req-1"), time.Minute)
+		defer childCancel()
+
+		cancel("request timeout")
+
+		reason, cancelled := CancellationReason(child)
+		assert.True(t, cancelled)
+		assert.Equal(t, "request timeout", reason)
+	})
+
+	t.Run("reports cause of plain cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		reason, cancelled := CancellationReason(ctx)
+		assert.True(t, cancelled)
+		assert.Equal(t, "context canceled", reason)
+
+		_, cancelled = CancellationReason(context.Background())
+		assert.False(t, cancelled)
+	})
+
+	t.Run("reports exceeded deadline budget", func(t *testing.T) {
+		budgetCtx, cancel := WithDeadlineBudget(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		ctx, cancelWithReason := CancelWithReason(budgetCtx)
+		defer cancelWithReason("")
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Second):
+			t.Fatal("context was not cancelled after budget was spent")
+		}
+
+		reason, cancelled := CancellationReason(ctx)
+		assert.True(t, cancelled)
+		assert.Equal(t, "deadline budget of 20ms exceeded", reason)
+		assert.Equal(t, context.DeadlineExceeded, ctx.Err())
+		assert.ErrorIs(t, context.Cause(ctx), context.DeadlineExceeded)
+	})
+
+	t.Run("reports manual cancellation of budget", func(t *testing.T) {
+		ctx, cancel := WithDeadlineBudget(context.Background(), time.Minute)
+		cancel()
+
+		reason, cancelled := CancellationReason(ctx)
+		assert.True(t, cancelled)
+		assert.Equal(t, "context canceled", reason)
+	})
+}
+
+func TestLocaleAndTimezone(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	t.Run("adds and retrieves locale", func(t *testing.T) {
+		ctx := WithLocale(context.Background(), language.MustParse("de-CH"))
+
+		locale, exists := GetLocale(ctx)
+		assert.True(t, exists)
+		assert.Equal(t, "de-CH", locale.String())
+	})
+
+	t.Run("handles undetermined locale", func(t *testing.T) {
+		ctx := WithLocale(context.Background(), language.Und)
+
+		locale, exists := GetLocale(ctx)
+		assert.False(t, exists)
+		assert.Equal(t, language.Und, locale)
+	})
+
+	t.Run("adds and retrieves timezone", func(t *testing.T) {
+		ctx := WithTimezone(context.Background(), berlin)
+
+		timezone, exists := GetTimezone(ctx)
+		assert.True(t, exists)
+		assert.Equal(t, "Europe/Berlin", timezone.String())
+	})
+
+	t.Run("handles nil timezone", func(t *testing.T) {
+		ctx := WithTimezone(context.Background(), nil)
+
+		timezone, exists := GetTimezone(ctx)
+		assert.False(t, exists)
+		assert.Nil(t, timezone)
+	})
+
+	t.Run("included in context summary", func(t *testing.T) {
+		ctx := WithLocale(context.Background(), language.BritishEnglish)
+		ctx = WithTimezone(ctx, berlin)
+
+		summary := ContextSummary(ctx)
+		assert.Equal(t, "en-GB", summary["locale"])
+		assert.Equal(t, "Europe/Berlin", summary["timezone"])
+	})
+}
+
+func TestContextHeaders(t *testing.T) {
+	t.Run("extracts locale and timezone from headers", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("Accept-Language", "fr;q=0.8, de-AT, en;q=0.5")
+		header.Set("X-Timezone", "America/New_York")
+
+		ctx := ContextFromHeaders(context.Background(), header)
+
+		locale, exists := GetLocale(ctx)
+		assert.True(t, exists)
+		assert.Equal(t, "de-AT", locale.String())
+
+		timezone, exists := GetTimezone(ctx)
+		assert.True(t, exists)
+		assert.Equal(t, "America/New_York", timezone.String())
+	})
+
+	t.Run("ignores missing and invalid headers", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("X-Timezone", "Mars/Olympus_Mons")
+
+		ctx := ContextFromHeaders(context.Background(), header)
+
+		_, exists := GetLocale(ctx)
+		assert.False(t, exists)
+		_, exists = GetTimezone(ctx)
+		assert.False(t, exists)
+	})
+
+	t.Run("round-trips through injected headers", func(t *testing.T) {
+		ctx := WithLocale(context.Background(), language.MustParse("pt-BR"))
+		ctx = WithTimezone(ctx, time.UTC)
+
+		header := http.Header{}
+		InjectHeaders(ctx, header)
+		assert.Equal(t, "pt-BR", header.Get("Accept-Language"))
+		assert.Equal(t, "UTC", header.Get("X-Timezone"))
+
+		propagated := ContextFromHeaders(context.Background(), header)
+		assert.Equal(t, ContextSummary(ctx)["locale"], ContextSummary(propagated)["locale"])
+		assert.Equal(t, ContextSummary(ctx)["timezone"], ContextSummary(propagated)["timezone"])
+	})
+}
+
 // Benchmark tests for performance validation
 func BenchmarkWithUser(b *testing.B) {
 	ctx := context.Background()
@@ -684,3 +1242,533 @@ func BenchmarkHasAnyRole(b *testing.B) {
 		_ = HasAnyRole(ctx, searchRoles...)
 	}
 }
+
+func TestDetachContext(t *testing.T) {
+	newRequestCtx := func() (context.Context, context.CancelFunc) {
+		ctx := WithUser(context.Background(), &UserInfo{ID: "user-1", Roles: []string{"admin"}})
+		ctx = WithTenant(ctx, &TenantInfo{ID: "tenant-1", Name: "Acme"})
+		ctx = WithRequestID(ctx, "req-1")
+		ctx = WithCorrelationID(ctx, "corr-1")
+		ctx = WithSessionID(ctx, "session-1")
+		ctx = WithPermissions(ctx, []string{"orders:read"})
+		ctx = WithLocale(ctx, language.German)
+		ctx = WithTimezone(ctx, time.UTC)
+		return WithDeadlineBudget(ctx, time.Minute)
+	}
+
+	t.Run("keeps values but not cancellation", func(t *testing.T) {
+		ctx, cancel := newRequestCtx()
+		detached := DetachContext(ctx)
+		cancel()
+
+		require.Error(t, ctx.Err())
+		assert.NoError(t, detached.Err())
+		assert.Nil(t, detached.Done())
+		_, hasDeadline := detached.Deadline()
+		assert.False(t, hasDeadline)
+		_, hasBudget := GetBudget(detached)
+		assert.False(t, hasBudget)
+
+		assert.Equal(t, "user-1", MustGetUserID(detached))
+		assert.True(t, HasRole(detached, "admin"))
+		assert.Equal(t, "tenant-1", MustGetTenantID(detached))
+		assert.Equal(t, "req-1", MustGetRequestID(detached))
+		correlationID, _ := GetCorrelationID(detached)
+		assert.Equal(t, "corr-1", correlationID)
+		sessionID, _ := GetSessionID(detached)
+		assert.Equal(t, "session-1", sessionID)
+		assert.True(t, HasPermission(detached, "orders:read"))
+		locale, _ := GetLocale(detached)
+		assert.Equal(t, language.German, locale)
+		timezone, _ := GetTimezone(detached)
+		assert.Equal(t, time.UTC, timezone)
+	})
+
+	t.Run("request info is independent", func(t *testing.T) {
+		ctx, cancel := newRequestCtx()
+		defer cancel()
+		detached := DetachContext(ctx)
+
+		WithCorrelationID(detached, "changed")
+		correlationID, _ := GetCorrelationID(ctx)
+		assert.Equal(t, "corr-1", correlationID)
+	})
+
+	t.Run("carries logger and clock", func(t *testing.T) {
+		clock := NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+		logger := NopLogger()
+		ctx := WithLogger(WithClock(context.Background(), clock), logger)
+
+		detached := DetachContext(ctx)
+		assert.Equal(t, clock, ClockFromContext(detached))
+		assert.Equal(t, logger, LoggerFromContext(detached))
+	})
+
+	t.Run("merge keeps destination cancellation", func(t *testing.T) {
+		src := WithUserID(context.Background(), "user-2")
+		dst, cancel := context.WithCancel(WithTenantID(context.Background(), "tenant-2"))
+
+		merged := MergeContextValues(dst, src)
+		cancel()
+
+		assert.Error(t, merged.Err())
+		assert.Equal(t, "user-2", MustGetUserID(merged))
+		assert.Equal(t, "tenant-2", MustGetTenantID(merged))
+	})
+
+	t.Run("nil contexts", func(t *testing.T) {
+		assert.NotNil(t, DetachContext(nil))
+		ctx := WithUserID(context.Background(), "user-3")
+		assert.Equal(t, ctx, MergeContextValues(ctx, nil))
+		assert.Equal(t, "user-3", MustGetUserID(MergeContextValues(nil, ctx)))
+	})
+}
+
+func TestFeatureFlags(t *testing.T) {
+	t.Run("unknown flags are disabled", func(t *testing.T) {
+		ctx := context.Background()
+		assert.False(t, IsFeatureEnabled(ctx, "new-checkout"))
+
+		flags, ok := GetFeatureFlags(ctx)
+		assert.False(t, ok)
+		assert.Nil(t, flags)
+
+		ctx = WithFeatureFlags(ctx, map[string]bool{"new-checkout": true})
+		assert.False(t, IsFeatureEnabled(ctx, "dark-mode"))
+	})
+
+	t.Run("sets and copies flags", func(t *testing.T) {
+		input := map[string]bool{"new-checkout": true, "dark-mode": false}
+		ctx := WithFeatureFlags(context.Background(), input)
+		input["dark-mode"] = true
+
+		assert.True(t, IsFeatureEnabled(ctx, "new-checkout"))
+		assert.False(t, IsFeatureEnabled(ctx, "dark-mode"))
+
+		flags, ok := GetFeatureFlags(ctx)
+		require.True(t, ok)
+		flags["new-checkout"] = false
+		assert.True(t, IsFeatureEnabled(ctx, "new-checkout"))
+	})
+
+	t.Run("WithFeatureFlags replaces earlier flags", func(t *testing.T) {
+		ctx := WithFeatureFlags(context.Background(), map[string]bool{"a": true})
+		ctx = WithFeatureFlags(ctx, map[string]bool{"b": true})
+
+		assert.False(t, IsFeatureEnabled(ctx, "a"))
+		assert.True(t, IsFeatureEnabled(ctx, "b"))
+	})
+
+	t.Run("WithFeatureFlag overlays existing flags", func(t *testing.T) {
+		base := WithFeatureFlags(context.Background(), map[string]bool{"a": true, "b": true})
+		overlay := WithFeatureFlag(base, "b", false)
+		overlay = WithFeatureFlag(overlay, "c", true)
+
+		assert.True(t, IsFeatureEnabled(overlay, "a"))
+		assert.False(t, IsFeatureEnabled(overlay, "b"))
+		assert.True(t, IsFeatureEnabled(overlay, "c"))
+
+		// The parent context is unchanged
+		assert.True(t, IsFeatureEnabled(base, "b"))
+		assert.False(t, IsFeatureEnabled(base, "c"))
+
+		single := WithFeatureFlag(context.Background(), "solo", true)
+		assert.True(t, IsFeatureEnabled(single, "solo"))
+	})
+
+	t.Run("summary lists enabled flags", func(t *testing.T) {
+		ctx := WithFeatureFlags(context.Background(), map[string]bool{"b": true, "a": true, "off": false})
+		assert.Equal(t, []string{"a", "b"}, ContextSummary(ctx)["feature_flags"])
+
+		ctx = WithFeatureFlags(context.Background(), map[string]bool{"off": false})
+		assert.NotContains(t, ContextSummary(ctx), "feature_flags")
+	})
+
+	t.Run("detached contexts keep flags", func(t *testing.T) {
+		ctx := WithFeatureFlag(context.Background(), "new-checkout", true)
+		assert.True(t, IsFeatureEnabled(DetachContext(ctx), "new-checkout"))
+	})
+}
+
+func TestBaggage(t *testing.T) {
+	t.Run("sets and gets values", func(t *testing.T) {
+		ctx := WithBaggage(context.Background(), "region", "eu")
+		ctx = WithBaggage(ctx, "experiment", "A")
+
+		value, ok := GetBaggage(ctx, "region")
+		assert.True(t, ok)
+		assert.Equal(t, "eu", value)
+
+		_, ok = GetBaggage(ctx, "missing")
+		assert.False(t, ok)
+
+		assert.Equal(t, map[string]string{"region": "eu", "experiment": "A"}, AllBaggage(ctx))
+		assert.Nil(t, AllBaggage(context.Background()))
+		assert.Equal(t, context.Background(), WithBaggage(context.Background(), "", "ignored"))
+	})
+
+	t.Run("does not affect parent contexts or returned copies", func(t *testing.T) {
+		parent := WithBaggage(context.Background(), "region", "eu")
+		child := WithBaggage(parent, "region", "us")
+
+		value, _ := GetBaggage(parent, "region")
+		assert.Equal(t, "eu", value)
+		value, _ = GetBaggage(child, "region")
+		assert.Equal(t, "us", value)
+
+		all := AllBaggage(parent)
+		all["region"] = "changed"
+		value, _ = GetBaggage(parent, "region")
+		assert.Equal(t, "eu", value)
+	})
+
+	t.Run("appears in summary", func(t *testing.T) {
+		ctx := WithBaggage(context.Background(), "region", "eu")
+		assert.Equal(t, map[string]string{"region": "eu"}, ContextSummary(ctx)["baggage"])
+		assert.NotContains(t, ContextSummary(context.Background()), "baggage")
+	})
+
+	t.Run("round-trips through headers", func(t *testing.T) {
+		ctx := WithBaggage(context.Background(), "region", "eu")
+		ctx = WithBaggage(ctx, "note", "a, b=c; d")
+
+		header := http.Header{}
+		InjectHeaders(ctx, header)
+		assert.Equal(t, "note=a%2C%20b%3Dc%3B%20d,region=eu", header.Get(HeaderBaggage))
+
+		propagated := ContextFromHeaders(context.Background(), header)
+		assert.Equal(t, AllBaggage(ctx), AllBaggage(propagated))
+	})
+
+	t.Run("extracts W3C baggage headers", func(t *testing.T) {
+		header := http.Header{}
+		header.Add(HeaderBaggage, "region = eu ; ttl=60, experiment=A")
+		header.Add(HeaderBaggage, "user%20type=beta, invalid, =novalue, bad=%zz")
+
+		ctx := ContextFromHeaders(context.Background(), header)
+		assert.Equal(t, map[string]string{
+			"region":      "eu",
+			"experiment":  "A",
+			"user%20type": "beta",
+		}, AllBaggage(ctx))
+	})
+
+	t.Run("detached contexts keep baggage", func(t *testing.T) {
+		ctx := WithBaggage(context.Background(), "region", "eu")
+		value, ok := GetBaggage(DetachContext(ctx), "region")
+		assert.True(t, ok)
+		assert.Equal(t, "eu", value)
+	})
+
+	t.Run("is safe for concurrent middleware layers", func(t *testing.T) {
+		parent := WithBaggage(context.Background(), "region", "eu")
+
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				ctx := parent
+				for layer := 0; layer < 5; layer++ {
+					ctx = WithBaggage(ctx, fmt.Sprintf("layer%d", layer), fmt.Sprintf("%d-%d", i, layer))
+					_ = AllBaggage(ctx)
+					_ = ContextSummary(ctx)
+				}
+
+				value, ok := GetBaggage(ctx, "layer4")
+				assert.True(t, ok)
+				assert.Equal(t, fmt.Sprintf("%d-4", i), value)
+				assert.Len(t, AllBaggage(ctx), 6)
+			}(i)
+		}
+		wg.Wait()
+
+		assert.Equal(t, map[string]string{"region": "eu"}, AllBaggage(parent))
+	})
+}
+
+func TestAttributes(t *testing.T) {
+	t.Run("are recorded through the context", func(t *testing.T) {
+		ctx, attrs := WithAttributes(context.Background())
+		attrs.Set("cache.hits", 3)
+
+		fromCtx, ok := AttributesFromContext(ctx)
+		require.True(t, ok)
+		fromCtx.Set("db.query.count", 2)
+
+		value, ok := attrs.Get("db.query.count")
+		assert.True(t, ok)
+		assert.Equal(t, 2, value)
+
+		_, ok = attrs.Get("missing")
+		assert.False(t, ok)
+	})
+
+	t.Run("snapshot is a copy", func(t *testing.T) {
+		_, attrs := WithAttributes(context.Background())
+		assert.Nil(t, attrs.Snapshot())
+
+		attrs.Set("cache.hits", 1)
+		snapshot := attrs.Snapshot()
+		snapshot["cache.hits"] = 99
+
+		value, _ := attrs.Get("cache.hits")
+		assert.Equal(t, 1, value)
+	})
+
+	t.Run("missing attributes are a no-op", func(t *testing.T) {
+		attrs, ok := AttributesFromContext(context.Background())
+		assert.False(t, ok)
+		assert.Nil(t, attrs)
+
+		attrs.Set("cache.hits", 1)
+		_, ok = attrs.Get("cache.hits")
+		assert.False(t, ok)
+		assert.Nil(t, attrs.Snapshot())
+	})
+
+	t.Run("appear in context summary", func(t *testing.T) {
+		ctx, attrs := WithAttributes(context.Background())
+		assert.NotContains(t, ContextSummary(ctx), "attributes")
+
+		attrs.Set("db.query.count", 4)
+		assert.Equal(t, map[string]interface{}{"db.query.count": 4}, ContextSummary(ctx)["attributes"])
+	})
+
+	t.Run("are not propagated", func(t *testing.T) {
+		ctx, attrs := WithAttributes(context.Background())
+		attrs.Set("cache.hits", 1)
+
+		_, ok := AttributesFromContext(DetachContext(ctx))
+		assert.False(t, ok)
+
+		header := http.Header{}
+		InjectHeaders(ctx, header)
+		assert.Empty(t, header.Get(HeaderBaggage))
+	})
+
+	t.Run("supports concurrent Set", func(t *testing.T) {
+		ctx, _ := WithAttributes(context.Background())
+
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				attrs, _ := AttributesFromContext(ctx)
+				for j := 0; j < 20; j++ {
+					attrs.Set(fmt.Sprintf("worker%d.step", i), j)
+					attrs.Set("shared", i)
+					_, _ = attrs.Get("shared")
+					_ = ContextSummary(ctx)
+				}
+			}(i)
+		}
+		wg.Wait()
+
+		attrs, _ := AttributesFromContext(ctx)
+		snapshot := attrs.Snapshot()
+		assert.Len(t, snapshot, 51)
+		assert.Equal(t, 19, snapshot["worker7.step"])
+	})
+}
+
+func TestClientInfo(t *testing.T) {
+	// withTrustedProxies trusts proxies for the duration of a test
+	withTrustedProxies := func(t *testing.T, proxies ...string) {
+		require.NoError(t, SetTrustedProxies(proxies...))
+		t.Cleanup(func() { _ = SetTrustedProxies() })
+	}
+
+	newRequest := func(remoteAddr string, headers map[string]string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		r.RemoteAddr = remoteAddr
+		for name, value := range headers {
+			r.Header.Set(name, value)
+		}
+		return r
+	}
+
+	t.Run("stores a copy in the context", func(t *testing.T) {
+		client := ClientInfo{IP: net.ParseIP("203.0.113.7"), UserAgent: "curl/8.0"}
+		ctx := WithClientInfo(context.Background(), client)
+		client.IP[len(client.IP)-1] = 9
+
+		stored, ok := GetClientInfo(ctx)
+		require.True(t, ok)
+		assert.Equal(t, "203.0.113.7", stored.IP.String())
+		assert.Equal(t, "curl/8.0", stored.UserAgent)
+
+		_, ok = GetClientInfo(context.Background())
+		assert.False(t, ok)
+	})
+
+	t.Run("uses peer address without proxies", func(t *testing.T) {
+		client := ClientInfoFromRequest(newRequest("203.0.113.7:52000", map[string]string{
+			"User-Agent": "curl/8.0",
+		}))
+		assert.Equal(t, "203.0.113.7", client.IP.String())
+		assert.Equal(t, "curl/8.0", client.UserAgent)
+		assert.Empty(t, client.ForwardedFor)
+	})
+
+	t.Run("ignores headers of untrusted peers", func(t *testing.T) {
+		withTrustedProxies(t, "10.0.0.0/8")
+
+		client := ClientInfoFromRequest(newRequest("203.0.113.7:52000", map[string]string{
+			HeaderForwardedFor: "198.51.100.1",
+			HeaderRealIP:       "198.51.100.2",
+		}))
+		assert.Equal(t, "203.0.113.7", client.IP.String(), "spoofed headers are not trusted")
+		assert.Equal(t, "198.51.100.1", client.ForwardedFor)
+	})
+
+	t.Run("ignores headers when no proxy is trusted", func(t *testing.T) {
+		client := ClientInfoFromRequest(newRequest("10.0.0.5:443", map[string]string{
+			HeaderForwardedFor: "198.51.100.1",
+		}))
+		assert.Equal(t, "10.0.0.5", client.IP.String())
+	})
+
+	t.Run("walks proxy chain from the right", func(t *testing.T) {
+		withTrustedProxies(t, "10.0.0.0/8", "192.0.2.10")
+
+		client := ClientInfoFromRequest(newRequest("10.0.0.5:443", map[string]string{
+			HeaderForwardedFor: "198.51.100.99, 203.0.113.7, 192.0.2.10, 10.1.2.3",
+		}))
+		assert.Equal(t, "203.0.113.7", client.IP.String(), "left of the first untrusted hop is spoofable")
+		assert.Equal(t, "198.51.100.99, 203.0.113.7, 192.0.2.10, 10.1.2.3", client.ForwardedFor)
+	})
+
+	t.Run("joins repeated forwarding headers", func(t *testing.T) {
+		withTrustedProxies(t, "10.0.0.0/8")
+
+		r := newRequest("10.0.0.5:443", nil)
+		r.Header.Add(HeaderForwardedFor, "203.0.113.7")
+		r.Header.Add(HeaderForwardedFor, "10.1.2.3")
+
+		client := ClientInfoFromRequest(r)
+		assert.Equal(t, "203.0.113.7", client.IP.String())
+		assert.Equal(t, "203.0.113.7, 10.1.2.3", client.ForwardedFor)
+	})
+
+	t.Run("uses leftmost hop if all are trusted", func(t *testing.T) {
+		withTrustedProxies(t, "10.0.0.0/8")
+
+		client := ClientInfoFromRequest(newRequest("10.0.0.5:443", map[string]string{
+			HeaderForwardedFor: "10.9.9.9, 10.1.2.3",
+		}))
+		assert.Equal(t, "10.9.9.9", client.IP.String())
+	})
+
+	t.Run("stops at malformed hops", func(t *testing.T) {
+		withTrustedProxies(t, "10.0.0.0/8")
+
+		client := ClientInfoFromRequest(newRequest("10.0.0.5:443", map[string]string{
+			HeaderForwardedFor: "203.0.113.7, unknown, 10.1.2.3",
+		}))
+		assert.Equal(t, "10.1.2.3", client.IP.String())
+	})
+
+	t.Run("parses ports and IPv6", func(t *testing.T) {
+		withTrustedProxies(t, "::1")
+
+		client := ClientInfoFromRequest(newRequest("[::1]:443", map[string]string{
+			HeaderForwardedFor: "[2001:db8::7]:52000",
+		}))
+		assert.Equal(t, "2001:db8::7", client.IP.String())
+	})
+
+	t.Run("falls back to X-Real-IP", func(t *testing.T) {
+		withTrustedProxies(t, "10.0.0.5")
+
+		client := ClientInfoFromRequest(newRequest("10.0.0.5:443", map[string]string{
+			HeaderRealIP: "203.0.113.7",
+		}))
+		assert.Equal(t, "203.0.113.7", client.IP.String())
+
+		client = ClientInfoFromRequest(newRequest("10.0.0.5:443", map[string]string{
+			HeaderRealIP: "not-an-ip",
+		}))
+		assert.Equal(t, "10.0.0.5", client.IP.String())
+	})
+
+	t.Run("rejects invalid proxies", func(t *testing.T) {
+		withTrustedProxies(t, "10.0.0.5")
+
+		err := SetTrustedProxies("10.0.0.0/8", "proxy.internal")
+		require.Error(t, err)
+		assert.True(t, IsInvalidInput(err))
+		assert.True(t, IsInvalidInput(SetTrustedProxies("10.0.0.0/33")))
+
+		client := ClientInfoFromRequest(newRequest("10.0.0.5:443", map[string]string{
+			HeaderRealIP: "203.0.113.7",
+		}))
+		assert.Equal(t, "203.0.113.7", client.IP.String(), "previous setting is kept")
+	})
+
+	t.Run("appears in summary and detached contexts", func(t *testing.T) {
+		ctx := WithClientInfo(context.Background(), ClientInfo{IP: net.ParseIP("203.0.113.7"), UserAgent: "curl/8.0"})
+
+		summary := ContextSummary(ctx)
+		assert.Equal(t, "203.0.113.7", summary["client_ip"])
+		assert.Equal(t, "curl/8.0", summary["user_agent"])
+
+		client, ok := GetClientInfo(DetachContext(ctx))
+		require.True(t, ok)
+		assert.Equal(t, "203.0.113.7", client.IP.String())
+
+		assert.NotContains(t, ContextSummary(context.Background()), "client_ip")
+	})
+}
+
+func TestIdempotencyKey(t *testing.T) {
+	t.Run("is unset by default", func(t *testing.T) {
+		key, ok := GetIdempotencyKey(context.Background())
+		assert.False(t, ok)
+		assert.Empty(t, key)
+
+		ctx := WithIdempotencyKey(context.Background(), "")
+		_, ok = GetIdempotencyKey(ctx)
+		assert.False(t, ok)
+		assert.NotContains(t, ContextSummary(ctx), "idempotency_key")
+	})
+
+	t.Run("is stored in the context", func(t *testing.T) {
+		ctx := WithIdempotencyKey(context.Background(), "8e03978e-40d5-43e8-bc93-6894a57f9324")
+
+		key, ok := GetIdempotencyKey(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, "8e03978e-40d5-43e8-bc93-6894a57f9324", key)
+		assert.Equal(t, key, ContextSummary(ctx)["idempotency_key"])
+
+		key, _ = GetIdempotencyKey(DetachContext(ctx))
+		assert.Equal(t, "8e03978e-40d5-43e8-bc93-6894a57f9324", key)
+	})
+
+	t.Run("is extracted from headers", func(t *testing.T) {
+		header := http.Header{}
+		header.Set(HeaderIdempotencyKey, "  order-42  ")
+
+		key, ok := GetIdempotencyKey(ContextFromHeaders(context.Background(), header))
+		assert.True(t, ok)
+		assert.Equal(t, "order-42", key)
+
+		header.Set(HeaderIdempotencyKey, "   ")
+		_, ok = GetIdempotencyKey(ContextFromHeaders(context.Background(), header))
+		assert.False(t, ok, "blank header is ignored")
+
+		_, ok = GetIdempotencyKey(ContextFromHeaders(context.Background(), http.Header{}))
+		assert.False(t, ok)
+	})
+
+	t.Run("round-trips through injected headers", func(t *testing.T) {
+		header := http.Header{}
+		InjectHeaders(WithIdempotencyKey(context.Background(), "order-42"), header)
+		assert.Equal(t, "order-42", header.Get(HeaderIdempotencyKey))
+
+		header = http.Header{}
+		InjectHeaders(context.Background(), header)
+		assert.NotContains(t, header, HeaderIdempotencyKey)
+	})
+}
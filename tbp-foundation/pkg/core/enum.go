@@ -0,0 +1,123 @@
+// File: enum.go
+// Title: Generic Enumeration Sets for TBP
+// Description: Provides EnumSet, a registry of the allowed values of a small
+//              enumeration type such as Status. It implements validation,
+//              parsing, listing, and JSON encoding once, so that enumeration
+//              types only declare their values and delegate to the set.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial EnumSet implementation
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// EnumSet holds the allowed values of an enumeration type. The name of a
+// value is its fmt representation, i.e. the result of String() for types
+// implementing fmt.Stringer. An enumeration type declares its set once:
+//
+//	var StatusSet = core.NewEnumSet(StatusActive, StatusInactive)
+//
+//	func (s Status) IsValid() bool { return StatusSet.IsValid(s) }
+//
+// An EnumSet is immutable and safe for concurrent use.
+type EnumSet[T comparable] struct {
+	values   []T
+	valid    map[T]bool
+	byName   map[string]T
+	typeName string
+}
+
+// NewEnumSet creates a set of the given values in declaration order.
+// Duplicate values are ignored. Names are matched case-insensitively by
+// Parse, so values should have distinct names regardless of case.
+func NewEnumSet[T comparable](values ...T) *EnumSet[T] {
+	var zero T
+	typeName := strings.ToLower(reflect.TypeOf(&zero).Elem().Name())
+	if typeName == "" {
+		typeName = "enum value"
+	}
+
+	set := &EnumSet[T]{
+		valid:    make(map[T]bool, len(values)),
+		byName:   make(map[string]T, len(values)),
+		typeName: typeName,
+	}
+	for _, value := range values {
+		if set.valid[value] {
+			continue
+		}
+		set.values = append(set.values, value)
+		set.valid[value] = true
+
+		name := strings.ToLower(fmt.Sprint(value))
+		if _, exists := set.byName[name]; !exists {
+			set.byName[name] = value
+		}
+	}
+	return set
+}
+
+// IsValid reports whether value is one of the allowed values.
+func (s *EnumSet[T]) IsValid(value T) bool {
+	return s.valid[value]
+}
+
+// Values returns the allowed values in declaration order.
+// The returned slice is a copy.
+func (s *EnumSet[T]) Values() []T {
+	result := make([]T, len(s.values))
+	copy(result, s.values)
+	return result
+}
+
+// Parse returns the value with the given name, ignoring case and
+// surrounding whitespace. Unknown names return an ErrCodeInvalidInput error.
+func (s *EnumSet[T]) Parse(name string) (T, error) {
+	if value, ok := s.byName[strings.ToLower(strings.TrimSpace(name))]; ok {
+		return value, nil
+	}
+
+	var zero T
+	return zero, Newf("invalid %s: %s", s.typeName, name).WithCode(ErrCodeInvalidInput)
+}
+
+// EncodeJSON encodes value as its name. Values outside the set return
+// an ErrCodeInvalidInput error instead of producing unparsable output.
+// Enumeration types call it from their own MarshalJSON method.
+func (s *EnumSet[T]) EncodeJSON(value T) ([]byte, error) {
+	if !s.IsValid(value) {
+		return nil, Newf("invalid %s: %v", s.typeName, value).WithCode(ErrCodeInvalidInput)
+	}
+	return json.Marshal(fmt.Sprint(value))
+}
+
+// DecodeJSON decodes a name produced by EncodeJSON into target using
+// Parse. JSON null leaves target unchanged. Enumeration types call it from
+// their own UnmarshalJSON method.
+func (s *EnumSet[T]) DecodeJSON(data []byte, target *T) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return WrapWithCodef(err, ErrCodeInvalidInput, "invalid %s: %s", s.typeName, data)
+	}
+
+	value, err := s.Parse(name)
+	if err != nil {
+		return err
+	}
+	*target = value
+	return nil
+}
@@ -0,0 +1,128 @@
+// File: enum_test.go
+// Title: Tests for Generic Enumeration Sets
+// Description: Tests validation, parsing, listing, and JSON encoding of
+//              EnumSet for string and integer based enumerations, and the
+//              StatusSet of the built-in Status type.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+
+package core
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testColor is an integer enumeration declared with an EnumSet
+type testColor int
+
+const (
+	testColorRed testColor = iota + 1
+	testColorGreen
+	testColorBlue
+)
+
+var testColorSet = NewEnumSet(testColorRed, testColorGreen, testColorBlue)
+
+func (c testColor) String() string {
+	switch c {
+	case testColorRed:
+		return "red"
+	case testColorGreen:
+		return "green"
+	case testColorBlue:
+		return "blue"
+	default:
+		return "unknown"
+	}
+}
+
+func (c testColor) MarshalJSON() ([]byte, error) {
+	return testColorSet.EncodeJSON(c)
+}
+
+func (c *testColor) UnmarshalJSON(data []byte) error {
+	return testColorSet.DecodeJSON(data, c)
+}
+
+func TestEnumSet(t *testing.T) {
+	t.Run("validates values", func(t *testing.T) {
+		assert.True(t, testColorSet.IsValid(testColorGreen))
+		assert.False(t, testColorSet.IsValid(testColor(0)), "zero value is not declared")
+		assert.False(t, testColorSet.IsValid(testColor(4)))
+	})
+
+	t.Run("lists values in declaration order", func(t *testing.T) {
+		values := testColorSet.Values()
+		assert.Equal(t, []testColor{testColorRed, testColorGreen, testColorBlue}, values)
+
+		values[0] = testColorBlue
+		assert.Equal(t, testColorRed, testColorSet.Values()[0], "returns a copy")
+	})
+
+	t.Run("ignores duplicates", func(t *testing.T) {
+		set := NewEnumSet("a", "b", "a")
+		assert.Equal(t, []string{"a", "b"}, set.Values())
+	})
+
+	t.Run("parses names", func(t *testing.T) {
+		color, err := testColorSet.Parse(" Blue ")
+		require.NoError(t, err)
+		assert.Equal(t, testColorBlue, color)
+
+		color, err = testColorSet.Parse("unknown")
+		require.Error(t, err)
+		assert.True(t, IsInvalidInput(err))
+		assert.Equal(t, "invalid testcolor: unknown", err.Error())
+		assert.Equal(t, testColor(0), color)
+	})
+
+	t.Run("encodes JSON", func(t *testing.T) {
+		data, err := json.Marshal(map[string]testColor{"color": testColorGreen})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"color":"green"}`, string(data))
+
+		_, err = json.Marshal(testColor(0))
+		require.Error(t, err, "invalid values are not encoded")
+	})
+
+	t.Run("decodes JSON", func(t *testing.T) {
+		var decoded struct {
+			Color testColor  `json:"color"`
+			Other *testColor `json:"other"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(`{"color":"RED","other":null}`), &decoded))
+		assert.Equal(t, testColorRed, decoded.Color)
+		assert.Nil(t, decoded.Other)
+
+		err := json.Unmarshal([]byte(`{"color":"purple"}`), &decoded)
+		assert.Error(t, err)
+
+		var color testColor
+		err = testColorSet.DecodeJSON([]byte(`2`), &color)
+		require.Error(t, err)
+		assert.True(t, IsInvalidInput(err))
+	})
+}
+
+func TestStatusSet(t *testing.T) {
+	assert.Len(t, StatusSet.Values(), 6)
+	for _, status := range StatusSet.Values() {
+		assert.True(t, status.IsValid())
+	}
+
+	status, err := StatusSet.Parse("Completed")
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, status)
+
+	_, err = StatusSet.Parse("archived")
+	assert.Equal(t, "invalid status: archived", err.Error())
+}
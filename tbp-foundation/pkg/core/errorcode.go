@@ -0,0 +1,85 @@
+// File: errorcode.go
+// Title: Namespaced Error Codes for TBP
+// Description: Provides composite error codes such as ORDERS.NOT_FOUND,
+//              made of a domain namespace and a code name, so that codes
+//              of different domains do not collide. Includes helpers to
+//              build codes and to split and match them by namespace.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial namespaced error code helpers
+
+package core
+
+import "strings"
+
+// CodeNamespaceSeparator separates the namespace of a composite error
+// code from its name, e.g. "ORDERS.NOT_FOUND". Namespaces may be nested
+// ("ORDERS.PAYMENT.DECLINED"); the name is the part after the last
+// separator. The predefined codes such as ErrCodeNotFound have no
+// namespace.
+const CodeNamespaceSeparator = "."
+
+// NamespacedCode builds the composite code of name in namespace.
+// Returns name unchanged if namespace is empty.
+func NamespacedCode(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + CodeNamespaceSeparator + name
+}
+
+// WithCodeNS sets the composite code of name in namespace, e.g.
+// WithCodeNS("ORDERS", ErrCodeNotFound) sets "ORDERS.NOT_FOUND".
+// Returns a new error like WithCode.
+func (e *Error) WithCodeNS(namespace, name string) *Error {
+	return e.WithCode(NamespacedCode(namespace, name))
+}
+
+// SplitCode splits a code at its last separator into namespace and name.
+// Codes without namespace return an empty namespace.
+func SplitCode(code string) (namespace, name string) {
+	i := strings.LastIndex(code, CodeNamespaceSeparator)
+	if i < 0 {
+		return "", code
+	}
+	return code[:i], code[i+len(CodeNamespaceSeparator):]
+}
+
+// CodeNamespace returns the namespace of the error's code (see GetCode),
+// e.g. "ORDERS" for "ORDERS.NOT_FOUND". Returns an empty string for codes
+// without namespace and for errors without code.
+func CodeNamespace(err error) string {
+	code, _ := GetCode(err)
+	namespace, _ := SplitCode(code)
+	return namespace
+}
+
+// CodeName returns the name of the error's code (see GetCode) without its
+// namespace, e.g. "NOT_FOUND" for "ORDERS.NOT_FOUND". Codes without
+// namespace are returned unchanged; errors without code return an empty
+// string.
+func CodeName(err error) string {
+	code, _ := GetCode(err)
+	_, name := SplitCode(code)
+	return name
+}
+
+// IsCodeIn checks if any error in the error tree has a code in namespace
+// or one of its nested namespaces, so "ORDERS" matches "ORDERS.NOT_FOUND"
+// and "ORDERS.PAYMENT.DECLINED" but not "ORDERSX.NOT_FOUND". Codes without
+// namespace never match. Use IsCode to match a complete code exactly.
+func IsCodeIn(err error, namespace string) bool {
+	if namespace == "" {
+		return false
+	}
+
+	prefix := namespace + CodeNamespaceSeparator
+	return !walkErrors(err, func(current error) bool {
+		tbpErr, ok := current.(*Error)
+		return !ok || !strings.HasPrefix(tbpErr.Code, prefix)
+	})
+}
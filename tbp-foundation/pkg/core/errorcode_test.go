@@ -0,0 +1,92 @@
+// File: errorcode_test.go
+// Title: Tests for Namespaced Error Codes
+// Description: Tests building and splitting composite codes and matching
+//              namespaced and global codes mixed in error chains.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamespacedCodes(t *testing.T) {
+	t.Run("builds and splits codes", func(t *testing.T) {
+		assert.Equal(t, "ORDERS.NOT_FOUND", NamespacedCode("ORDERS", ErrCodeNotFound))
+		assert.Equal(t, ErrCodeNotFound, NamespacedCode("", ErrCodeNotFound))
+
+		namespace, name := SplitCode("ORDERS.PAYMENT.DECLINED")
+		assert.Equal(t, "ORDERS.PAYMENT", namespace)
+		assert.Equal(t, "DECLINED", name)
+
+		namespace, name = SplitCode(ErrCodeNotFound)
+		assert.Empty(t, namespace)
+		assert.Equal(t, ErrCodeNotFound, name)
+	})
+
+	t.Run("sets composite code", func(t *testing.T) {
+		err := New("order not found").WithCodeNS("ORDERS", ErrCodeNotFound)
+
+		assert.Equal(t, "ORDERS.NOT_FOUND", err.Code)
+		assert.Equal(t, "ORDERS", CodeNamespace(err))
+		assert.Equal(t, ErrCodeNotFound, CodeName(err))
+	})
+
+	t.Run("keeps global codes unchanged", func(t *testing.T) {
+		err := NewWithCode(ErrCodeNotFound, "not found")
+
+		assert.Empty(t, CodeNamespace(err))
+		assert.Equal(t, ErrCodeNotFound, CodeName(err))
+		assert.True(t, IsNotFound(err))
+		assert.False(t, IsCodeIn(err, "ORDERS"))
+
+		assert.Empty(t, CodeNamespace(errors.New("plain")))
+		assert.Empty(t, CodeName(errors.New("plain")))
+		assert.Empty(t, CodeName(nil))
+	})
+
+	t.Run("matches mixed codes in a chain", func(t *testing.T) {
+		inner := New("payment declined").WithCodeNS("ORDERS.PAYMENT", "DECLINED")
+		middle := WrapWithCode(inner, ErrCodeConflict, "checkout failed")
+		outer := fmt.Errorf("request failed: %w", Wrap(middle, "handler"))
+
+		assert.True(t, IsCode(outer, "ORDERS.PAYMENT.DECLINED"))
+		assert.True(t, IsCode(outer, ErrCodeConflict))
+		assert.False(t, IsCode(outer, "DECLINED"), "IsCode matches the full code only")
+		assert.False(t, IsNotFound(outer))
+
+		assert.True(t, IsCodeIn(outer, "ORDERS"))
+		assert.True(t, IsCodeIn(outer, "ORDERS.PAYMENT"))
+		assert.False(t, IsCodeIn(outer, "ORDER"))
+		assert.False(t, IsCodeIn(outer, "PAYMENT"))
+		assert.False(t, IsCodeIn(outer, ""))
+
+		// The outermost code determines namespace and name
+		assert.Empty(t, CodeNamespace(outer))
+		assert.Equal(t, ErrCodeConflict, CodeName(outer))
+		assert.Equal(t, "ORDERS.PAYMENT", CodeNamespace(inner))
+		assert.Equal(t, "DECLINED", CodeName(inner))
+	})
+
+	t.Run("matches joined errors", func(t *testing.T) {
+		joined := JoinErrors(
+			NewWithCode(ErrCodeInvalidInput, "bad input"),
+			New("no stock").WithCodeNS("INVENTORY", ErrCodeConflict),
+		)
+
+		assert.True(t, IsCodeIn(joined, "INVENTORY"))
+		assert.False(t, IsCodeIn(joined, "ORDERS"))
+		assert.True(t, IsCode(joined, "INVENTORY.CONFLICT"))
+		assert.False(t, IsConflict(joined))
+	})
+}
@@ -0,0 +1,159 @@
+// File: errorcontext.go
+// Title: Error Context Size Limits for TBP
+// Description: Provides opt-in limits on the number of error context
+//              entries and the size of individual context values, so that
+//              large payloads such as request bodies do not bloat logs.
+//              Oversized values are truncated and excess entries dropped
+//              with a marker.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial context entry and value size limits
+
+package core
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"unicode/utf8"
+)
+
+// ContextTruncatedMarker is appended to context values that were
+// shortened because they exceeded ContextLimits.MaxValueSize
+const ContextTruncatedMarker = "...[truncated]"
+
+// ContextDroppedKey is the context key holding the number of entries
+// dropped because the context reached ContextLimits.MaxEntries
+const ContextDroppedKey = "context_dropped"
+
+// ContextLimits bounds the context attached to errors by WithContext and
+// WrapWithContext. Zero values mean no limit, which is the default.
+type ContextLimits struct {
+	// MaxEntries is the maximum number of context entries per error.
+	// Further entries are dropped and counted under ContextDroppedKey.
+	// The reserved keys RetryAfterKey and SeverityKey are always kept.
+	MaxEntries int
+
+	// MaxValueSize is the maximum size in bytes of a context value's text
+	// representation. Longer values are replaced by their truncated text
+	// followed by ContextTruncatedMarker.
+	MaxValueSize int
+}
+
+var (
+	contextLimitsMu sync.RWMutex
+	contextLimits   ContextLimits
+)
+
+// SetContextLimits sets the limits applied to error context from now on.
+// Errors created earlier are not changed. Pass ContextLimits{} to remove
+// all limits.
+func SetContextLimits(limits ContextLimits) {
+	contextLimitsMu.Lock()
+	defer contextLimitsMu.Unlock()
+	contextLimits = limits
+}
+
+// GetContextLimits returns the limits currently applied to error context.
+func GetContextLimits() ContextLimits {
+	contextLimitsMu.RLock()
+	defer contextLimitsMu.RUnlock()
+	return contextLimits
+}
+
+// enabled reports whether any limit is set
+func (l ContextLimits) enabled() bool {
+	return l.MaxEntries > 0 || l.MaxValueSize > 0
+}
+
+// addContextEntry sets key in context, truncating the value and dropping
+// new entries beyond the limits. Reserved keys are neither dropped nor
+// truncated.
+func addContextEntry(context map[string]interface{}, key string, value interface{}, limits ContextLimits) {
+	if isReservedContextKey(key) {
+		context[key] = value
+		return
+	}
+
+	if _, exists := context[key]; !exists && limits.MaxEntries > 0 && countContextEntries(context) >= limits.MaxEntries {
+		dropped, _ := context[ContextDroppedKey].(int)
+		context[ContextDroppedKey] = dropped + 1
+		return
+	}
+	context[key] = truncateContextValue(value, limits.MaxValueSize)
+}
+
+// limitContext returns a copy of context with the limits applied. Entries
+// are kept in key order so that the result is deterministic. Returns
+// context unchanged if no limit is set.
+func limitContext(context map[string]interface{}, limits ContextLimits) map[string]interface{} {
+	if context == nil || !limits.enabled() {
+		return context
+	}
+
+	keys := make([]string, 0, len(context))
+	for key := range context {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	limited := make(map[string]interface{}, len(context))
+	for _, key := range keys {
+		addContextEntry(limited, key, context[key], limits)
+	}
+	return limited
+}
+
+// countContextEntries counts the entries subject to MaxEntries
+func countContextEntries(context map[string]interface{}) int {
+	count := 0
+	for key := range context {
+		if key != ContextDroppedKey && !isReservedContextKey(key) {
+			count++
+		}
+	}
+	return count
+}
+
+// isReservedContextKey reports whether key carries error metadata that
+// must not be dropped
+func isReservedContextKey(key string) bool {
+	return key == RetryAfterKey || key == SeverityKey
+}
+
+// truncateContextValue shortens values whose text representation exceeds
+// maxSize bytes. Values within the limit are returned unchanged.
+func truncateContextValue(value interface{}, maxSize int) interface{} {
+	if maxSize <= 0 || value == nil {
+		return value
+	}
+
+	var text string
+	switch v := value.(type) {
+	case string:
+		text = v
+	case []byte:
+		text = string(v)
+	case error:
+		text = v.Error()
+	case fmt.Stringer:
+		text = v.String()
+	default:
+		text = fmt.Sprintf("%v", v)
+	}
+
+	if len(text) <= maxSize {
+		return value
+	}
+
+	// Cut at a rune boundary to keep the result valid UTF-8
+	cut := maxSize
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	return text[:cut] + ContextTruncatedMarker
+}
@@ -0,0 +1,135 @@
+// File: errorcontext_test.go
+// Title: Tests for Error Context Size Limits
+// Description: Tests truncation of oversized context values, dropping of
+//              entries beyond the configured limit, reserved keys, and
+//              redaction of sensitive context in error output.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+
+package core
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withContextLimits sets limits for the duration of a test
+func withContextLimits(t *testing.T, limits ContextLimits) {
+	previous := GetContextLimits()
+	SetContextLimits(limits)
+	t.Cleanup(func() { SetContextLimits(previous) })
+}
+
+func TestContextLimits(t *testing.T) {
+	t.Run("are disabled by default", func(t *testing.T) {
+		assert.Equal(t, ContextLimits{}, GetContextLimits())
+
+		body := strings.Repeat("x", 10000)
+		err := New("request failed").WithContext("body", body)
+		for i := 0; i < 50; i++ {
+			err = err.WithContext(strings.Repeat("k", i+1), i)
+		}
+
+		assert.Equal(t, body, err.Context["body"])
+		assert.Len(t, err.Context, 51)
+	})
+
+	t.Run("truncates oversized values", func(t *testing.T) {
+		withContextLimits(t, ContextLimits{MaxValueSize: 8})
+
+		err := New("request failed").
+			WithContext("body", strings.Repeat("x", 100)).
+			WithContext("raw", []byte("0123456789")).
+			WithContext("list", []int{1, 2, 3, 4, 5}).
+			WithContext("short", "ok").
+			WithContext("count", 42)
+
+		assert.Equal(t, "xxxxxxxx"+ContextTruncatedMarker, err.Context["body"])
+		assert.Equal(t, "01234567"+ContextTruncatedMarker, err.Context["raw"])
+		assert.Equal(t, "[1 2 3 4"+ContextTruncatedMarker, err.Context["list"])
+		assert.Equal(t, "ok", err.Context["short"])
+		assert.Equal(t, 42, err.Context["count"])
+	})
+
+	t.Run("truncates at rune boundaries", func(t *testing.T) {
+		withContextLimits(t, ContextLimits{MaxValueSize: 5})
+
+		err := New("failed").WithContext("name", "ääää")
+		value := err.Context["name"].(string)
+		assert.Equal(t, "ää"+ContextTruncatedMarker, value)
+	})
+
+	t.Run("drops entries beyond the limit", func(t *testing.T) {
+		withContextLimits(t, ContextLimits{MaxEntries: 2})
+
+		err := New("failed").
+			WithContext("a", 1).
+			WithContext("b", 2).
+			WithContext("c", 3).
+			WithContext("d", 4).
+			WithContext("a", 10)
+
+		assert.Equal(t, map[string]interface{}{"a": 10, "b": 2, ContextDroppedKey: 2}, err.Context)
+	})
+
+	t.Run("keeps reserved keys", func(t *testing.T) {
+		withContextLimits(t, ContextLimits{MaxEntries: 1, MaxValueSize: 1})
+
+		err := New("failed").
+			WithContext("a", 1).
+			WithContext("b", 2).
+			WithSeverity("critical").
+			WithRetryAfter(90 * time.Second)
+
+		retryAfter, ok := GetRetryAfter(err)
+		assert.True(t, ok)
+		assert.Equal(t, 90*time.Second, retryAfter)
+		assert.Equal(t, "critical", err.Context[SeverityKey])
+		assert.Equal(t, 1, err.Context[ContextDroppedKey])
+	})
+
+	t.Run("applies to WrapWithContext", func(t *testing.T) {
+		withContextLimits(t, ContextLimits{MaxEntries: 2, MaxValueSize: 4})
+
+		context := map[string]interface{}{
+			"c": "third",
+			"a": "first",
+			"b": "second",
+		}
+		err := WrapWithContext(New("cause"), "failed", context)
+
+		assert.Equal(t, map[string]interface{}{
+			"a":               "firs" + ContextTruncatedMarker,
+			"b":               "seco" + ContextTruncatedMarker,
+			ContextDroppedKey: 1,
+		}, err.Context)
+		assert.Len(t, context, 3, "caller's map is not modified")
+	})
+}
+
+func TestSensitiveContextRedaction(t *testing.T) {
+	RegisterSensitiveContextKeys("errorcontext_test_password")
+
+	err := WrapWithContext(New("cause"), "login failed", map[string]interface{}{
+		"errorcontext_test_password": "hunter2",
+		"user":                       "alice",
+	}).WithContext("Errorcontext_Test_Password", "hunter3")
+
+	assert.NotContains(t, err.Error(), "hunter")
+
+	data, marshalErr := json.Marshal(err)
+	require.NoError(t, marshalErr)
+	assert.NotContains(t, string(data), "hunter")
+	assert.Contains(t, string(data), RedactedContextValue)
+	assert.Contains(t, string(data), "alice")
+}
@@ -0,0 +1,139 @@
+// File: errorgroup.go
+// Title: Error Grouping for Batch Reporting
+// Description: Provides GroupErrors, which deduplicates large numbers of
+//              errors, e.g. from import jobs, into groups of identical
+//              errors by code and message. Each group keeps a
+//              representative error, the number of occurrences, and a few
+//              sample contexts for compact logs and API responses.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial GroupErrors and ErrorGroup implementation
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// MaxErrorGroupSamples is the maximum number of sample contexts kept per
+// ErrorGroup
+const MaxErrorGroupSamples = 3
+
+// ErrorGroup is a set of errors with the same code and message
+type ErrorGroup struct {
+	// Code is the error code from GetCode, empty for uncoded errors
+	Code string `json:"code,omitempty"`
+
+	// Message is the message shared by all errors of the group
+	Message string `json:"message"`
+
+	// Count is the number of errors in the group
+	Count int `json:"count"`
+
+	// SampleContexts holds the contexts of the first errors of the group
+	// that have a context, at most MaxErrorGroupSamples. Sensitive values
+	// are redacted.
+	SampleContexts []map[string]interface{} `json:"sample_contexts,omitempty"`
+
+	// Representative is the first error of the group
+	Representative error `json:"-"`
+}
+
+// String returns the group as "CODE: message (xCount)", or
+// "message (xCount)" for uncoded errors.
+func (g ErrorGroup) String() string {
+	if g.Code == "" {
+		return fmt.Sprintf("%s (x%d)", g.Message, g.Count)
+	}
+	return fmt.Sprintf("%s: %s (x%d)", g.Code, g.Message, g.Count)
+}
+
+// errorGroupKey identifies the group of an error
+type errorGroupKey struct {
+	code    string
+	message string
+}
+
+// GroupErrors groups errors by their code (see GetCode) and message. The
+// message of a TBP error is its own Message without the cause, so errors
+// wrapping different causes under the same message are grouped together;
+// for other errors it is the Error() text. Nil errors are skipped and the
+// errors of a MultiError are grouped individually. Groups are sorted by
+// count, most frequent first, and groups of equal count keep the order of
+// their first occurrence.
+func GroupErrors(errs []error) []ErrorGroup {
+	var groups []ErrorGroup
+	index := make(map[errorGroupKey]int)
+
+	var add func(err error)
+	add = func(err error) {
+		if err == nil {
+			return
+		}
+		if multi, ok := err.(*MultiError); ok {
+			for _, nested := range multi.Errors {
+				add(nested)
+			}
+			return
+		}
+
+		code, _ := GetCode(err)
+		key := errorGroupKey{code: code, message: groupMessage(err)}
+
+		i, exists := index[key]
+		if !exists {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, ErrorGroup{Code: key.code, Message: key.message, Representative: err})
+		}
+
+		group := &groups[i]
+		group.Count++
+		if len(group.SampleContexts) < MaxErrorGroupSamples {
+			if context := sampleContext(err); context != nil {
+				group.SampleContexts = append(group.SampleContexts, context)
+			}
+		}
+	}
+
+	for _, err := range errs {
+		add(err)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Count > groups[j].Count
+	})
+	return groups
+}
+
+// groupMessage returns the message an error is grouped by
+func groupMessage(err error) string {
+	if tbpErr, ok := err.(*Error); ok {
+		return tbpErr.Message
+	}
+	return err.Error()
+}
+
+// sampleContext returns a copy of the context of the first TBP error in
+// the chain with sensitive values redacted, or nil if there is none
+func sampleContext(err error) map[string]interface{} {
+	var tbpErr *Error
+	if !errors.As(err, &tbpErr) || len(tbpErr.Context) == 0 {
+		return nil
+	}
+
+	context := make(map[string]interface{}, len(tbpErr.Context))
+	for key, value := range tbpErr.Context {
+		if IsSensitiveContextKey(key) {
+			value = RedactedContextValue
+		}
+		context[key] = value
+	}
+	return context
+}
@@ -0,0 +1,101 @@
+// File: errorgroup_test.go
+// Title: Tests for Error Grouping
+// Description: Tests grouping of mixed coded and uncoded errors, counts,
+//              ordering by frequency, sample contexts, and rendering.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupErrors(t *testing.T) {
+	t.Run("groups mixed errors by code and message", func(t *testing.T) {
+		var errs []error
+		for row := 1; row <= 1423; row++ {
+			errs = append(errs, NewWithCode(ErrCodeInvalidInput, "missing name").WithContext("row", row))
+		}
+		for i := 0; i < 5; i++ {
+			errs = append(errs, errors.New("connection reset"))
+		}
+		errs = append(errs,
+			NewWithCode(ErrCodeConflict, "missing name"),
+			New("missing name"),
+			nil,
+			WrapWithCode(errors.New("parse error"), ErrCodeInvalidInput, "missing name"),
+		)
+
+		groups := GroupErrors(errs)
+		require.Len(t, groups, 4)
+
+		assert.Equal(t, "INVALID_INPUT: missing name (x1424)", groups[0].String())
+		assert.Equal(t, "connection reset (x5)", groups[1].String())
+		assert.Equal(t, "CONFLICT: missing name (x1)", groups[2].String())
+		assert.Equal(t, "missing name (x1)", groups[3].String())
+
+		assert.Same(t, errs[0], groups[0].Representative)
+		assert.Equal(t, []map[string]interface{}{{"row": 1}, {"row": 2}, {"row": 3}}, groups[0].SampleContexts)
+		assert.Empty(t, groups[1].SampleContexts)
+	})
+
+	t.Run("orders by count and first occurrence", func(t *testing.T) {
+		groups := GroupErrors([]error{
+			errors.New("a"),
+			errors.New("b"),
+			errors.New("c"),
+			errors.New("c"),
+			errors.New("b"),
+		})
+
+		var messages []string
+		for _, group := range groups {
+			messages = append(messages, group.Message)
+		}
+		assert.Equal(t, []string{"b", "c", "a"}, messages)
+	})
+
+	t.Run("flattens multi errors and keeps wrapper text", func(t *testing.T) {
+		multi := &MultiError{}
+		multi.Append(New("missing name"), New("missing name"))
+
+		groups := GroupErrors([]error{multi, fmt.Errorf("row 7: %w", NewWithCode(ErrCodeNotFound, "no customer"))})
+		require.Len(t, groups, 2)
+		assert.Equal(t, "missing name (x2)", groups[0].String())
+		assert.Equal(t, "NOT_FOUND: row 7: no customer (x1)", groups[1].String())
+	})
+
+	t.Run("redacts sensitive sample context", func(t *testing.T) {
+		RegisterSensitiveContextKeys("errorgroup_test_token")
+
+		groups := GroupErrors([]error{
+			New("login failed").WithContext("errorgroup_test_token", "s3cr3t").WithContext("user", "alice"),
+		})
+		require.Len(t, groups, 1)
+		assert.Equal(t, RedactedContextValue, groups[0].SampleContexts[0]["errorgroup_test_token"])
+		assert.Equal(t, "alice", groups[0].SampleContexts[0]["user"])
+	})
+
+	t.Run("renders compact JSON", func(t *testing.T) {
+		data, err := json.Marshal(GroupErrors([]error{NewWithCode(ErrCodeInvalidInput, "missing name")}))
+		require.NoError(t, err)
+		assert.JSONEq(t, `[{"code":"INVALID_INPUT","message":"missing name","count":1}]`, string(data))
+	})
+
+	t.Run("handles empty input", func(t *testing.T) {
+		assert.Empty(t, GroupErrors(nil))
+		assert.Empty(t, GroupErrors([]error{nil}))
+	})
+}
@@ -0,0 +1,168 @@
+// File: errorjson.go
+// Title: Structured JSON Rendering of TBP Errors
+// Description: Implements JSON marshaling for TBP errors including their
+//              complete cause chain, an optional severity, and redaction of
+//              sensitive context values for structured logging.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation with MarshalJSON, ErrorJSON and context redaction
+
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// SeverityKey is the context key holding the severity of an error.
+// It is rendered as a top-level "severity" field in JSON output.
+const SeverityKey = "severity"
+
+// RedactedContextValue replaces sensitive context values in JSON output
+const RedactedContextValue = "[REDACTED]"
+
+// sensitiveContextKeys holds the registered context keys (lower case)
+// whose values are redacted in JSON output.
+var (
+	sensitiveContextMu   sync.RWMutex
+	sensitiveContextKeys = make(map[string]struct{})
+)
+
+// RegisterSensitiveContextKeys registers error context keys whose values
+// must never appear in JSON output, e.g. "password" or "api_key".
+// Keys are matched case-insensitively.
+func RegisterSensitiveContextKeys(keys ...string) {
+	sensitiveContextMu.Lock()
+	defer sensitiveContextMu.Unlock()
+
+	for _, key := range keys {
+		sensitiveContextKeys[strings.ToLower(key)] = struct{}{}
+	}
+}
+
+// IsSensitiveContextKey reports whether values for key are redacted.
+func IsSensitiveContextKey(key string) bool {
+	sensitiveContextMu.RLock()
+	defer sensitiveContextMu.RUnlock()
+
+	_, exists := sensitiveContextKeys[strings.ToLower(key)]
+	return exists
+}
+
+// WithSeverity sets the severity of the error, e.g. "warning" or "critical".
+// Returns a new error with the severity in its context.
+func (e *Error) WithSeverity(severity string) *Error {
+	return e.WithContext(SeverityKey, severity)
+}
+
+// errorJSON is the JSON representation of an error in a chain
+type errorJSON struct {
+	Message  string                     `json:"message"`
+	Code     string                     `json:"code,omitempty"`
+	Severity string                     `json:"severity,omitempty"`
+	Context  map[string]json.RawMessage `json:"context,omitempty"`
+	Cause    interface{}                `json:"cause,omitempty"`
+	Errors   []interface{}              `json:"errors,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+// The cause chain is rendered recursively: TBP errors become nested
+// objects, other errors their Error() string. Values of registered
+// sensitive context keys are redacted.
+func (e *Error) MarshalJSON() ([]byte, error) {
+	if e == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(e.toJSON())
+}
+
+// ErrorJSON renders any error and its complete chain as JSON.
+// It never fails; nil renders as null.
+func ErrorJSON(err error) []byte {
+	if err == nil {
+		return []byte("null")
+	}
+
+	data, marshalErr := json.Marshal(renderErrorJSON(err))
+	if marshalErr != nil {
+		data, _ = json.Marshal(err.Error())
+	}
+	return data
+}
+
+// toJSON converts the error into its JSON representation
+func (e *Error) toJSON() *errorJSON {
+	out := &errorJSON{
+		Message: e.Message,
+		Code:    e.Code,
+	}
+
+	for key, value := range e.Context {
+		if key == SeverityKey {
+			if severity, ok := value.(string); ok {
+				out.Severity = severity
+				continue
+			}
+		}
+		if out.Context == nil {
+			out.Context = make(map[string]json.RawMessage, len(e.Context))
+		}
+		out.Context[key] = marshalContextValue(key, value)
+	}
+
+	if e.Cause != nil {
+		out.Cause = renderErrorJSON(e.Cause)
+	}
+
+	return out
+}
+
+// renderErrorJSON renders an error of any type.
+// Non-TBP errors that wrap further errors become objects so that TBP
+// errors deeper in the chain are not lost; leaf errors become strings.
+func renderErrorJSON(err error) interface{} {
+	switch e := err.(type) {
+	case *Error:
+		if e == nil {
+			return nil
+		}
+		return e.toJSON()
+	case interface{ Unwrap() []error }:
+		out := &errorJSON{Message: err.Error()}
+		for _, inner := range e.Unwrap() {
+			if inner != nil {
+				out.Errors = append(out.Errors, renderErrorJSON(inner))
+			}
+		}
+		return out
+	}
+
+	if cause := errors.Unwrap(err); cause != nil {
+		return &errorJSON{Message: err.Error(), Cause: renderErrorJSON(cause)}
+	}
+	return err.Error()
+}
+
+// marshalContextValue marshals a single context value, redacting
+// sensitive keys and falling back to the formatted value for types that
+// cannot be represented in JSON.
+func marshalContextValue(key string, value interface{}) json.RawMessage {
+	if IsSensitiveContextKey(key) {
+		value = RedactedContextValue
+	} else if err, ok := value.(error); ok {
+		value = err.Error()
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		data, _ = json.Marshal(fmt.Sprintf("%v", value))
+	}
+	return data
+}
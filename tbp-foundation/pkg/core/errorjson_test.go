@@ -0,0 +1,110 @@
+// File: errorjson_test.go
+// Title: Tests for Structured JSON Rendering of TBP Errors
+// Description: Test suite for MarshalJSON, ErrorJSON, severity rendering
+//              and redaction of sensitive context values.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestError_MarshalJSON(t *testing.T) {
+	t.Run("renders fields and nested cause chain", func(t *testing.T) {
+		root := errors.New("connection refused")
+		inner := WrapWithCode(root, ErrCodeUnavailable, "database unreachable").
+			WithContext("host", "db-1")
+		outer := Wrap(inner, "loading customer").
+			WithCode(ErrCodeInternal).
+			WithSeverity("critical")
+
+		data, err := json.Marshal(outer)
+		require.NoError(t, err)
+
+		assert.JSONEq(t, `{
+			"message": "loading customer",
+			"code": "INTERNAL_ERROR",
+			"severity": "critical",
+			"cause": {
+				"message": "database unreachable",
+				"code": "UNAVAILABLE",
+				"context": {"host": "db-1"},
+				"cause": "connection refused"
+			}
+		}`, string(data))
+	})
+
+	t.Run("keeps TBP errors behind non-TBP wrappers", func(t *testing.T) {
+		inner := New("not found").WithCode(ErrCodeNotFound)
+		outer := Wrap(fmt.Errorf("lookup: %w", inner), "request failed")
+
+		var decoded map[string]interface{}
+		require.NoError(t, json.Unmarshal(ErrorJSON(outer), &decoded))
+
+		cause := decoded["cause"].(map[string]interface{})
+		assert.Equal(t, "lookup: not found", cause["message"])
+		assert.Equal(t, "NOT_FOUND", cause["cause"].(map[string]interface{})["code"])
+	})
+
+	t.Run("redacts registered sensitive context keys", func(t *testing.T) {
+		RegisterSensitiveContextKeys("errorjson_test_token")
+		assert.True(t, IsSensitiveContextKey("ERRORJSON_TEST_TOKEN"))
+
+		err := New("auth failed").
+			WithContext("errorjson_test_token", "s3cr3t").
+			WithContext("user", "alice")
+
+		data := string(ErrorJSON(Wrap(err, "login")))
+		assert.NotContains(t, data, "s3cr3t")
+		assert.Contains(t, data, RedactedContextValue)
+		assert.Contains(t, data, "alice")
+	})
+
+	t.Run("renders unsupported context values as strings", func(t *testing.T) {
+		err := New("bad").
+			WithContext("callback", func() {}).
+			WithContext("reason", errors.New("boom"))
+
+		var decoded struct {
+			Context map[string]interface{} `json:"context"`
+		}
+		require.NoError(t, json.Unmarshal(ErrorJSON(err), &decoded))
+		assert.IsType(t, "", decoded.Context["callback"])
+		assert.Equal(t, "boom", decoded.Context["reason"])
+	})
+}
+
+func TestErrorJSON(t *testing.T) {
+	t.Run("nil error", func(t *testing.T) {
+		assert.Equal(t, "null", string(ErrorJSON(nil)))
+	})
+
+	t.Run("plain error", func(t *testing.T) {
+		assert.Equal(t, `"boom"`, string(ErrorJSON(errors.New("boom"))))
+	})
+
+	t.Run("joined errors", func(t *testing.T) {
+		joined := errors.Join(New("first").WithCode(ErrCodeConflict), errors.New("second"))
+
+		var decoded struct {
+			Errors []json.RawMessage `json:"errors"`
+		}
+		require.NoError(t, json.Unmarshal(ErrorJSON(joined), &decoded))
+		require.Len(t, decoded.Errors, 2)
+		assert.Contains(t, string(decoded.Errors[0]), "CONFLICT")
+		assert.Equal(t, `"second"`, string(decoded.Errors[1]))
+	})
+}
@@ -5,18 +5,32 @@
 //              comprehensive error system in the errors package.
 //              Implements Go 1.13+ error wrapping with TBP-specific extensions.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.12
 // Created: 2025-05-26
-// Modified: 2025-05-26
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-05-26 v0.1.0: Initial implementation with basic error types and wrapping
+// - 2026-10-16 v0.1.1: Added WrapPreservingCode for code-inheriting wrappers
+// - 2026-10-16 v0.1.2: Added DefineError and DefineErrorf sentinel helpers
+// - 2026-10-16 v0.1.3: Added retry-after hints with WithRetryAfter and GetRetryAfter
+// - 2026-10-16 v0.1.4: Added MultiError for aggregated errors
+// - 2026-10-16 v0.1.5: Added NewWithCode, NewWithCodef and WrapWithCodef
+// - 2026-10-16 v0.1.6: Apply opt-in context limits in WithContext and WrapWithContext
+// - 2026-10-16 v0.1.7: Added FindByCode and AllTBPErrors
+// - 2026-10-16 v0.1.8: Added ErrVersionConflict and IsVersionConflict
+// - 2026-10-16 v0.1.9: IsCode, GetCode, and GetRetryAfter search the branches of joined errors
+// - 2026-10-16 v0.1.10: WrapPreservingCode inherits the deepest code in the chain
+// - 2026-10-16 v0.1.11: Added ErrCodeCanceled
+// - 2026-10-16 v0.1.12: IsConflict reports version conflicts
 
 package core
 
 import (
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 )
 
 // Error represents a basic TBP error with additional context.
@@ -46,6 +60,8 @@ func (e *Error) Error() string {
 
 // Unwrap implements the Go 1.13+ error unwrapping interface.
 // This allows errors.Is() and errors.As() to work with wrapped errors.
+// An error with several causes uses a joined error (see JoinErrors) as
+// Cause, whose branches are traversed by errors.Is, errors.As, and IsCode.
 func (e *Error) Unwrap() error {
 	return e.Cause
 }
@@ -67,7 +83,9 @@ func (e *Error) Is(target error) bool {
 }
 
 // WithContext adds context information to the error.
-// Returns a new error with the additional context.
+// Returns a new error with the additional context. If context limits are
+// set (see SetContextLimits), oversized values are truncated and entries
+// beyond the limit are dropped.
 func (e *Error) WithContext(key string, value interface{}) *Error {
 	newErr := &Error{
 		Message: e.Message,
@@ -82,7 +100,7 @@ func (e *Error) WithContext(key string, value interface{}) *Error {
 	}
 	
 	// Add new context
-	newErr.Context[key] = value
+	addContextEntry(newErr.Context, key, value, GetContextLimits())
 	
 	return newErr
 }
@@ -98,6 +116,17 @@ func (e *Error) WithCode(code string) *Error {
 	}
 }
 
+// RetryAfterKey is the context key holding the retry-after hint of an error
+const RetryAfterKey = "retry_after"
+
+// WithRetryAfter attaches a hint how long callers should wait before
+// retrying, e.g. from a rate limit or a Retry-After response header.
+// Errors carrying a hint are considered retryable by IsRetryable.
+// Returns a new error with the hint in its context.
+func (e *Error) WithRetryAfter(d time.Duration) *Error {
+	return e.WithContext(RetryAfterKey, d)
+}
+
 // GetContext retrieves a context value by key.
 // Returns the value and true if found, nil and false otherwise.
 func (e *Error) GetContext(key string) (interface{}, bool) {
@@ -134,6 +163,13 @@ const (
 	
 	// ErrCodeUnavailable represents a service unavailability
 	ErrCodeUnavailable = "UNAVAILABLE"
+
+	// ErrCodeVersionConflict represents an optimistic locking conflict
+	ErrCodeVersionConflict = "VERSION_CONFLICT"
+
+	// ErrCodeCanceled represents an operation abandoned because its
+	// context was canceled; it is not retryable
+	ErrCodeCanceled = "CANCELED"
 )
 
 // Predefined error instances for common scenarios.
@@ -186,8 +222,37 @@ var (
 		Message: "service unavailable",
 		Code:    ErrCodeUnavailable,
 	}
+
+	// ErrVersionConflict represents a write based on an outdated entity
+	// version, see CheckVersion. It is not retryable as is: the same write
+	// fails again, so callers should refetch the entity, reapply their
+	// change, and retry.
+	ErrVersionConflict = &Error{
+		Message: "version conflict",
+		Code:    ErrCodeVersionConflict,
+	}
 )
 
+// DefineError creates a sentinel error with the given code and message for
+// package-level declarations, constructed like the predefined errors above:
+//
+//	var ErrQuotaExceeded = core.DefineError("QUOTA_EXCEEDED", "quota exceeded")
+//
+// Sentinels match any error with the same code via IsCode and errors.Is,
+// including errors that wrap them. Sentinels should not be modified.
+func DefineError(code, message string) *Error {
+	return &Error{
+		Message: message,
+		Code:    code,
+	}
+}
+
+// DefineErrorf creates a sentinel error with the given code and a
+// formatted message. See DefineError.
+func DefineErrorf(code, format string, args ...interface{}) *Error {
+	return DefineError(code, fmt.Sprintf(format, args...))
+}
+
 // New creates a new TBP error with the given message.
 // This is similar to errors.New() but creates a TBP Error instance.
 func New(message string) *Error {
@@ -204,7 +269,26 @@ func Newf(format string, args ...interface{}) *Error {
 	}
 }
 
+// NewWithCode creates a new TBP error with the given error code and message.
+func NewWithCode(code, message string) *Error {
+	return &Error{
+		Message: message,
+		Code:    code,
+	}
+}
+
+// NewWithCodef creates a new TBP error with the given error code and a
+// formatted message.
+func NewWithCodef(code, format string, args ...interface{}) *Error {
+	return &Error{
+		Message: fmt.Sprintf(format, args...),
+		Code:    code,
+	}
+}
+
 // Wrap wraps an existing error with additional context.
+// The wrapper does not inherit the cause's code; use WrapPreservingCode
+// to keep the classification on the outermost error.
 // If the provided error is nil, returns nil.
 func Wrap(err error, message string) *Error {
 	if err == nil {
@@ -244,53 +328,64 @@ func WrapWithCode(err error, code, message string) *Error {
 	}
 }
 
-// WrapWithContext wraps an existing error with additional context.
+// WrapWithCodef wraps an existing error with a formatted message and error code.
 // If the provided error is nil, returns nil.
-func WrapWithContext(err error, message string, context map[string]interface{}) *Error {
+func WrapWithCodef(err error, code, format string, args ...interface{}) *Error {
 	if err == nil {
 		return nil
 	}
+
+	return &Error{
+		Message: fmt.Sprintf(format, args...),
+		Code:    code,
+		Cause:   err,
+	}
+}
+
+// WrapPreservingCode wraps an existing error and inherits its error code.
+// Unlike Wrap, which leaves the wrapper's Code empty, the wrapper receives
+// the deepest code in the wrapped chain, i.e. the code closest to the root
+// cause, so WrapPreservingCode(WrapWithCode(notFound, ErrCodeInternal, ...))
+// is classified as ErrCodeNotFound. In joined errors the deepest code of
+// all branches wins, the first branch on equal depth.
+// If the chain carries no code, the wrapper's Code stays empty.
+// If the provided error is nil, returns nil.
+func WrapPreservingCode(err error, message string) *Error {
+	if err == nil {
+		return nil
+	}
+	
+	code, _ := deepestCode(err, 0)
 	
 	return &Error{
 		Message: message,
+		Code:    code,
 		Cause:   err,
-		Context: context,
 	}
 }
 
-// IsCode checks if an error has a specific error code.
-// Works with both TBP errors and standard errors.
-func IsCode(err error, code string) bool {
+// WrapWithContext wraps an existing error with additional context.
+// If the provided error is nil, returns nil. If context limits are set
+// (see SetContextLimits), the context is copied with the limits applied.
+func WrapWithContext(err error, message string, context map[string]interface{}) *Error {
 	if err == nil {
-		return false
+		return nil
 	}
 	
-	// Walk through the error chain to find any error with the specified code
-	current := err
-	for current != nil {
-		// Check if current error is a TBP error with the specified code
-		if tbpErr, ok := current.(*Error); ok && tbpErr.Code == code {
-			return true
-		}
-		
-		// Try to get the next error in the chain
-		var next error
-		
-		// First try TBP Error's Cause field
-		if tbpErr, ok := current.(*Error); ok && tbpErr.Cause != nil {
-			next = tbpErr.Cause
-		} else if unwrapper, ok := current.(interface{ Unwrap() error }); ok {
-			// Then try standard Unwrap interface
-			next = unwrapper.Unwrap()
-		}
-		
-		if next == nil || next == current {
-			break // Avoid infinite loops
-		}
-		current = next
+	return &Error{
+		Message: message,
+		Cause:   err,
+		Context: limitContext(context, GetContextLimits()),
 	}
-	
-	return false
+}
+
+// IsCode checks if an error has a specific error code.
+// Works with both TBP errors and standard errors. The whole error tree is
+// searched, including the branches of joined errors (MultiError,
+// errors.Join).
+func IsCode(err error, code string) bool {
+	_, found := FindByCode(err, code)
+	return found
 }
 
 // IsInternal checks if an error is an internal error.
@@ -318,9 +413,17 @@ func IsForbidden(err error) bool {
 	return IsCode(err, ErrCodeForbidden)
 }
 
-// IsConflict checks if an error is a conflict error.
+// IsConflict checks if an error is a conflict error, including
+// optimistic locking conflicts coded ErrCodeVersionConflict.
 func IsConflict(err error) bool {
-	return IsCode(err, ErrCodeConflict)
+	return IsCode(err, ErrCodeConflict) || IsVersionConflict(err)
+}
+
+// IsVersionConflict checks if an error is an optimistic locking conflict.
+// Such errors can be resolved by refetching the entity and retrying, but
+// are not retryable according to IsRetryable.
+func IsVersionConflict(err error) bool {
+	return IsCode(err, ErrCodeVersionConflict)
 }
 
 // IsTimeout checks if an error is a timeout error.
@@ -334,38 +437,54 @@ func IsUnavailable(err error) bool {
 }
 
 // GetCode extracts the error code from an error.
+// Returns the first code found searching the error tree depth-first,
+// outermost first, including the branches of joined errors.
 // Returns the code and true if found, empty string and false otherwise.
 func GetCode(err error) (string, bool) {
-	if err == nil {
-		return "", false
-	}
-	
-	// Walk through the error chain to find the first error with a code
-	current := err
-	for current != nil {
-		// Check if current error is a TBP error with a code
+	var code string
+	walkErrors(err, func(current error) bool {
 		if tbpErr, ok := current.(*Error); ok && tbpErr.Code != "" {
-			return tbpErr.Code, true
+			code = tbpErr.Code
+			return false
 		}
-		
-		// Try to get the next error in the chain
-		var next error
-		
-		// First try TBP Error's Cause field
-		if tbpErr, ok := current.(*Error); ok && tbpErr.Cause != nil {
-			next = tbpErr.Cause
-		} else if unwrapper, ok := current.(interface{ Unwrap() error }); ok {
-			// Then try standard Unwrap interface
-			next = unwrapper.Unwrap()
+		return true
+	})
+	return code, code != ""
+}
+
+// deepestCode returns the code of the deepest *Error with a code in the
+// error tree below err and its depth, or -1 if there is none. err itself
+// is at the given depth.
+func deepestCode(err error, depth int) (string, int) {
+	code, codeDepth := "", -1
+	for err != nil {
+		if tbpErr, ok := err.(*Error); ok && tbpErr.Code != "" {
+			code, codeDepth = tbpErr.Code, depth
 		}
-		
-		if next == nil || next == current {
-			break // Avoid infinite loops
+
+		switch wrapper := err.(type) {
+		case interface{ Unwrap() []error }:
+			for _, inner := range wrapper.Unwrap() {
+				if inner == err {
+					continue
+				}
+				if innerCode, innerDepth := deepestCode(inner, depth+1); innerDepth > codeDepth {
+					code, codeDepth = innerCode, innerDepth
+				}
+			}
+			return code, codeDepth
+		case interface{ Unwrap() error }:
+			next := wrapper.Unwrap()
+			if next == err {
+				return code, codeDepth // Avoid infinite loops
+			}
+			err = next
+			depth++
+		default:
+			return code, codeDepth
 		}
-		current = next
 	}
-	
-	return "", false
+	return code, codeDepth
 }
 
 // GetRootCause returns the root cause of an error by unwrapping all layers.
@@ -415,6 +534,65 @@ func ErrorMessages(err error) []string {
 	return messages
 }
 
+// FindByCode returns the first TBP error in the chain of err with the
+// given code, outermost first. Errors wrapped with fmt.Errorf("%w") and
+// the branches of joined errors (MultiError, errors.Join) are searched too.
+// Returns nil and false if no error has the code.
+func FindByCode(err error, code string) (*Error, bool) {
+	var found *Error
+	walkErrors(err, func(current error) bool {
+		if tbpErr, ok := current.(*Error); ok && tbpErr.Code == code {
+			found = tbpErr
+			return false
+		}
+		return true
+	})
+	return found, found != nil
+}
+
+// AllTBPErrors returns all TBP errors in the chain of err, outermost first,
+// including those in the branches of joined errors. Returns nil if the
+// chain contains no TBP error.
+func AllTBPErrors(err error) []*Error {
+	var result []*Error
+	walkErrors(err, func(current error) bool {
+		if tbpErr, ok := current.(*Error); ok {
+			result = append(result, tbpErr)
+		}
+		return true
+	})
+	return result
+}
+
+// walkErrors visits err and the errors it wraps depth-first until visit
+// returns false. Reports whether the walk was completed.
+func walkErrors(err error, visit func(error) bool) bool {
+	for err != nil {
+		if !visit(err) {
+			return false
+		}
+
+		switch wrapper := err.(type) {
+		case interface{ Unwrap() []error }:
+			for _, inner := range wrapper.Unwrap() {
+				if inner != err && !walkErrors(inner, visit) {
+					return false
+				}
+			}
+			return true
+		case interface{ Unwrap() error }:
+			next := wrapper.Unwrap()
+			if next == err {
+				return true // Avoid infinite loops
+			}
+			err = next
+		default:
+			return true
+		}
+	}
+	return true
+}
+
 // JoinErrors combines multiple errors into a single error.
 // Uses Go 1.20+ errors.Join if available, otherwise creates a TBP error.
 func JoinErrors(errs ...error) error {
@@ -439,6 +617,82 @@ func JoinErrors(errs ...error) error {
 	return errors.Join(validErrors...)
 }
 
+// GetRetryAfter returns the first retry-after hint found in the error tree,
+// including the branches of joined errors.
+// Returns the duration and true if found, zero and false otherwise.
+func GetRetryAfter(err error) (time.Duration, bool) {
+	var retryAfter time.Duration
+	found := !walkErrors(err, func(current error) bool {
+		if tbpErr, ok := current.(*Error); ok {
+			if value, exists := tbpErr.GetContext(RetryAfterKey); exists {
+				if d, ok := value.(time.Duration); ok {
+					retryAfter = d
+					return false
+				}
+			}
+		}
+		return true
+	})
+	return retryAfter, found
+}
+
+// MultiError aggregates several independent errors, such as all field
+// validation failures of an entity. It supports errors.Is and errors.As
+// on the contained errors via Unwrap() []error.
+type MultiError struct {
+	// Errors contains the aggregated errors in the order they were added
+	Errors []error `json:"errors"`
+}
+
+// Error implements the error interface.
+// A single error is reported as is, several errors are listed.
+func (m *MultiError) Error() string {
+	switch len(m.Errors) {
+	case 0:
+		return "no errors"
+	case 1:
+		return m.Errors[0].Error()
+	}
+
+	messages := make([]string, len(m.Errors))
+	for i, err := range m.Errors {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("%d errors occurred: %s", len(m.Errors), strings.Join(messages, "; "))
+}
+
+// Unwrap returns the aggregated errors for errors.Is and errors.As.
+func (m *MultiError) Unwrap() []error {
+	return m.Errors
+}
+
+// Append adds errors, skipping nil values. Nested MultiErrors are flattened.
+func (m *MultiError) Append(errs ...error) {
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if nested, ok := err.(*MultiError); ok {
+			m.Append(nested.Errors...)
+			continue
+		}
+		m.Errors = append(m.Errors, err)
+	}
+}
+
+// Len returns the number of aggregated errors.
+func (m *MultiError) Len() int {
+	return len(m.Errors)
+}
+
+// ErrorOrNil returns nil if no errors were added and the MultiError otherwise.
+func (m *MultiError) ErrorOrNil() error {
+	if m == nil || len(m.Errors) == 0 {
+		return nil
+	}
+	return m
+}
+
 // RetryableError indicates whether an error might succeed if retried.
 // This is a basic implementation that can be extended by the errors package.
 type RetryableError interface {
@@ -447,7 +701,8 @@ type RetryableError interface {
 }
 
 // IsRetryable checks if an error might succeed if retried.
-// Returns true for timeout and unavailable errors by default.
+// Returns true for timeout and unavailable errors and for errors carrying
+// a retry-after hint by default.
 func IsRetryable(err error) bool {
 	if err == nil {
 		return false
@@ -458,6 +713,11 @@ func IsRetryable(err error) bool {
 		return retryable.IsRetryable()
 	}
 	
+	// Errors with an explicit retry-after hint are meant to be retried
+	if _, ok := GetRetryAfter(err); ok {
+		return true
+	}
+
 	// Default retry logic for known error types
 	return IsTimeout(err) || IsUnavailable(err)
 }
@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -218,6 +219,57 @@ func TestNewf(t *testing.T) {
 	})
 }
 
+func TestNewWithCode(t *testing.T) {
+	err := NewWithCode(ErrCodeNotFound, "user not found")
+
+	assert.Equal(t, "user not found", err.Message)
+	assert.Equal(t, ErrCodeNotFound, err.Code)
+	assert.Nil(t, err.Cause)
+	assert.True(t, IsNotFound(err))
+}
+
+func TestNewWithCodef(t *testing.T) {
+	err := NewWithCodef(ErrCodeInvalidInput, "invalid value %d for %s", 42, "port")
+
+	assert.Equal(t, "invalid value 42 for port", err.Message)
+	assert.Equal(t, ErrCodeInvalidInput, err.Code)
+	assert.Nil(t, err.Cause)
+}
+
+func TestDefineError(t *testing.T) {
+	errQuotaExceeded := DefineError("QUOTA_EXCEEDED", "quota exceeded")
+
+	t.Run("creates sentinel with code and message", func(t *testing.T) {
+		assert.Equal(t, "quota exceeded", errQuotaExceeded.Message)
+		assert.Equal(t, "QUOTA_EXCEEDED", errQuotaExceeded.Code)
+		assert.Nil(t, errQuotaExceeded.Cause)
+		assert.Nil(t, errQuotaExceeded.Context)
+	})
+
+	t.Run("creates formatted sentinel", func(t *testing.T) {
+		err := DefineErrorf("LIMIT_REACHED", "limit of %d reached", 10)
+		assert.Equal(t, "limit of 10 reached", err.Message)
+		assert.Equal(t, "LIMIT_REACHED", err.Code)
+	})
+
+	t.Run("matches through wrapping", func(t *testing.T) {
+		wrapped := Wrap(errQuotaExceeded, "upload failed")
+		assert.True(t, errors.Is(wrapped, errQuotaExceeded))
+		assert.True(t, IsCode(wrapped, "QUOTA_EXCEEDED"))
+
+		stdWrapped := fmt.Errorf("request: %w", wrapped)
+		assert.True(t, errors.Is(stdWrapped, errQuotaExceeded))
+		assert.True(t, IsCode(stdWrapped, "QUOTA_EXCEEDED"))
+	})
+
+	t.Run("matches other errors with same code", func(t *testing.T) {
+		err := Wrap(New("tenant over limit").WithCode("QUOTA_EXCEEDED"), "upload failed")
+		assert.True(t, errors.Is(err, errQuotaExceeded))
+		assert.False(t, errors.Is(err, ErrNotFound))
+		assert.False(t, errors.Is(ErrNotFound, errQuotaExceeded))
+	})
+}
+
 func TestWrap(t *testing.T) {
 	t.Run("wraps error with message", func(t *testing.T) {
 		cause := errors.New("underlying error")
@@ -274,6 +326,94 @@ func TestWrapWithCode(t *testing.T) {
 	})
 }
 
+func TestWrapWithCodef(t *testing.T) {
+	t.Run("wraps error with code and formatted message", func(t *testing.T) {
+		cause := errors.New("underlying error")
+		err := WrapWithCodef(cause, "TEST_CODE", "failed to load %s: attempt %d", "config", 3)
+
+		assert.Equal(t, "failed to load config: attempt 3", err.Message)
+		assert.Equal(t, "TEST_CODE", err.Code)
+		assert.Equal(t, cause, err.Cause)
+	})
+
+	t.Run("returns nil for nil error", func(t *testing.T) {
+		err := WrapWithCodef(nil, "TEST_CODE", "failed to load %s", "config")
+		assert.Nil(t, err)
+	})
+}
+
+func TestWrapPreservingCode(t *testing.T) {
+	t.Run("inherits code from TBP error", func(t *testing.T) {
+		cause := &Error{Message: "original error", Code: ErrCodeNotFound}
+		err := WrapPreservingCode(cause, "wrapper message")
+
+		assert.Equal(t, "wrapper message", err.Message)
+		assert.Equal(t, ErrCodeNotFound, err.Code)
+		assert.Equal(t, cause, err.Cause)
+		assert.True(t, IsNotFound(err))
+	})
+
+	t.Run("inherits code through nested wrapping", func(t *testing.T) {
+		root := &Error{Message: "root error", Code: ErrCodeConflict}
+		middle := Wrap(root, "middle layer")
+		err := WrapPreservingCode(middle, "outer layer")
+
+		assert.Equal(t, ErrCodeConflict, err.Code)
+
+		outer := WrapPreservingCode(err, "outermost layer")
+		assert.Equal(t, ErrCodeConflict, outer.Code)
+		assert.True(t, IsConflict(outer))
+		assert.Equal(t, root, GetRootCause(outer))
+	})
+
+	t.Run("inherits code through standard wrapping", func(t *testing.T) {
+		root := &Error{Message: "root error", Code: ErrCodeTimeout}
+		stdWrapped := fmt.Errorf("std wrapper: %w", root)
+		err := WrapPreservingCode(stdWrapped, "outer layer")
+
+		assert.Equal(t, ErrCodeTimeout, err.Code)
+	})
+
+	t.Run("inherits deepest code of mixed chain", func(t *testing.T) {
+		notFound := NewWithCode(ErrCodeNotFound, "user not found")
+		internal := WrapWithCode(notFound, ErrCodeInternal, "lookup failed")
+		conflict := WrapWithCode(fmt.Errorf("std wrapper: %w", internal), ErrCodeConflict, "update failed")
+
+		err := WrapPreservingCode(internal, "handler")
+		assert.Equal(t, ErrCodeNotFound, err.Code)
+		assert.True(t, IsNotFound(err))
+
+		err = WrapPreservingCode(conflict, "handler")
+		assert.Equal(t, ErrCodeNotFound, err.Code)
+
+		uncodedRoot := WrapWithCode(errors.New("disk full"), ErrCodeUnavailable, "write failed")
+		err = WrapPreservingCode(Wrap(uncodedRoot, "save"), "handler")
+		assert.Equal(t, ErrCodeUnavailable, err.Code)
+	})
+
+	t.Run("inherits deepest code of joined errors", func(t *testing.T) {
+		joined := errors.Join(
+			NewWithCode(ErrCodeInvalidInput, "bad input"),
+			WrapWithCode(NewWithCode(ErrCodeTimeout, "slow"), ErrCodeInternal, "call failed"),
+		)
+		err := WrapPreservingCode(WrapWithCode(joined, ErrCodeConflict, "batch failed"), "handler")
+		assert.Equal(t, ErrCodeTimeout, err.Code)
+	})
+
+	t.Run("leaves code empty for standard error", func(t *testing.T) {
+		cause := errors.New("underlying error")
+		err := WrapPreservingCode(cause, "wrapper message")
+
+		assert.Empty(t, err.Code)
+		assert.Equal(t, "wrapper message: underlying error", err.Error())
+	})
+
+	t.Run("returns nil for nil error", func(t *testing.T) {
+		err := WrapPreservingCode(nil, "wrapper message")
+		assert.Nil(t, err)
+	})
+}
+
 func TestWrapWithContext(t *testing.T) {
 	t.Run("wraps error with context", func(t *testing.T) {
 		cause := errors.New("underlying error")
@@ -352,6 +492,7 @@ func TestPredefinedErrorCheckers(t *testing.T) {
 		{"IsConflict", IsConflict, ErrCodeConflict, ErrConflict},
 		{"IsTimeout", IsTimeout, ErrCodeTimeout, ErrTimeout},
 		{"IsUnavailable", IsUnavailable, ErrCodeUnavailable, ErrUnavailable},
+		{"IsVersionConflict", IsVersionConflict, ErrCodeVersionConflict, ErrVersionConflict},
 	}
 
 	for _, tt := range tests {
@@ -375,6 +516,12 @@ func TestPredefinedErrorCheckers(t *testing.T) {
 			assert.False(t, tt.checker(stdErr))
 		})
 	}
+
+	t.Run("IsConflict includes version conflicts", func(t *testing.T) {
+		assert.True(t, IsConflict(ErrVersionConflict))
+		assert.True(t, IsConflict(fmt.Errorf("save failed: %w", ErrVersionConflict)))
+		assert.False(t, IsVersionConflict(ErrConflict))
+	})
 }
 
 func TestGetCode(t *testing.T) {
@@ -600,14 +747,15 @@ func TestGo113Compatibility(t *testing.T) {
 
 func TestPredefinedErrors(t *testing.T) {
 	predefinedErrors := map[string]*Error{
-		"ErrInternal":     ErrInternal,
-		"ErrInvalidInput": ErrInvalidInput,
-		"ErrNotFound":     ErrNotFound,
-		"ErrUnauthorized": ErrUnauthorized,
-		"ErrForbidden":    ErrForbidden,
-		"ErrConflict":     ErrConflict,
-		"ErrTimeout":      ErrTimeout,
-		"ErrUnavailable":  ErrUnavailable,
+		"ErrInternal":        ErrInternal,
+		"ErrInvalidInput":    ErrInvalidInput,
+		"ErrNotFound":        ErrNotFound,
+		"ErrUnauthorized":    ErrUnauthorized,
+		"ErrForbidden":       ErrForbidden,
+		"ErrConflict":        ErrConflict,
+		"ErrTimeout":         ErrTimeout,
+		"ErrUnavailable":     ErrUnavailable,
+		"ErrVersionConflict": ErrVersionConflict,
 	}
 
 	for name, err := range predefinedErrors {
@@ -645,6 +793,133 @@ func (e *mockTemporaryError) Temporary() bool {
 	return e.temporary
 }
 
+func TestFindByCode(t *testing.T) {
+	validation := New("email is invalid").WithCode(ErrCodeInvalidInput)
+	chain := Wrap(
+		fmt.Errorf("handler: %w",
+			WrapWithCode(
+				fmt.Errorf("service: %w", validation),
+				ErrCodeInternal, "create user failed")),
+		"request failed")
+
+	t.Run("finds error deep in mixed chain", func(t *testing.T) {
+		found, ok := FindByCode(chain, ErrCodeInvalidInput)
+		require.True(t, ok)
+		assert.Same(t, validation, found)
+	})
+
+	t.Run("returns outermost match", func(t *testing.T) {
+		inner := New("inner").WithCode(ErrCodeConflict)
+		outer := WrapWithCode(fmt.Errorf("wrapped: %w", inner), ErrCodeConflict, "outer")
+
+		found, ok := FindByCode(outer, ErrCodeConflict)
+		require.True(t, ok)
+		assert.Same(t, outer, found)
+	})
+
+	t.Run("searches joined errors", func(t *testing.T) {
+		notFound := New("missing").WithCode(ErrCodeNotFound)
+		joined := fmt.Errorf("batch: %w", errors.Join(errors.New("plain"), Wrap(notFound, "item 2")))
+
+		found, ok := FindByCode(joined, ErrCodeNotFound)
+		require.True(t, ok)
+		assert.Same(t, notFound, found)
+
+		multi := &MultiError{}
+		multi.Append(errors.New("plain"), notFound)
+		found, ok = FindByCode(multi, ErrCodeNotFound)
+		require.True(t, ok)
+		assert.Same(t, notFound, found)
+	})
+
+	t.Run("reports missing code", func(t *testing.T) {
+		found, ok := FindByCode(chain, ErrCodeTimeout)
+		assert.False(t, ok)
+		assert.Nil(t, found)
+
+		found, ok = FindByCode(nil, ErrCodeTimeout)
+		assert.False(t, ok)
+		assert.Nil(t, found)
+
+		_, ok = FindByCode(fmt.Errorf("plain: %w", errors.New("root")), ErrCodeInternal)
+		assert.False(t, ok)
+	})
+}
+
+func TestAllTBPErrors(t *testing.T) {
+	t.Run("collects TBP errors in order", func(t *testing.T) {
+		root := New("root").WithCode(ErrCodeNotFound)
+		middle := Wrap(fmt.Errorf("std: %w", root), "middle")
+		outer := WrapWithCode(fmt.Errorf("handler: %w", middle), ErrCodeInternal, "outer")
+
+		assert.Equal(t, []*Error{outer, middle, root}, AllTBPErrors(fmt.Errorf("top: %w", outer)))
+	})
+
+	t.Run("includes joined branches", func(t *testing.T) {
+		first := New("first")
+		second := New("second")
+		joined := errors.Join(first, errors.New("plain"), fmt.Errorf("wrapped: %w", second))
+
+		assert.Equal(t, []*Error{first, second}, AllTBPErrors(joined))
+	})
+
+	t.Run("returns nil without TBP errors", func(t *testing.T) {
+		assert.Nil(t, AllTBPErrors(nil))
+		assert.Nil(t, AllTBPErrors(fmt.Errorf("a: %w", errors.New("b"))))
+	})
+}
+
+func TestBranchingErrorTrees(t *testing.T) {
+	timeout := Wrap(ErrTimeout, "inventory service")
+	notFound := NewWithCode(ErrCodeNotFound, "customer not found")
+
+	t.Run("IsCode searches all branches", func(t *testing.T) {
+		joined := JoinErrors(errors.New("plain"), fmt.Errorf("pricing: %w", timeout))
+		wrapped := Wrap(joined, "checkout failed")
+
+		assert.True(t, IsTimeout(joined))
+		assert.True(t, IsTimeout(wrapped))
+		assert.True(t, errors.Is(wrapped, ErrTimeout))
+		assert.True(t, IsRetryable(wrapped), "timeout buried in a joined error is retryable")
+		assert.False(t, IsNotFound(wrapped))
+	})
+
+	t.Run("IsCode searches nested multi errors", func(t *testing.T) {
+		inner := &MultiError{}
+		inner.Errors = append(inner.Errors, errors.New("first"), Wrap(notFound, "lookup"))
+		outer := &MultiError{}
+		outer.Errors = append(outer.Errors, errors.New("other"), Wrap(inner, "batch"))
+
+		assert.True(t, IsNotFound(outer))
+		assert.True(t, errors.Is(outer, ErrNotFound))
+	})
+
+	t.Run("GetCode returns the first code depth-first", func(t *testing.T) {
+		multi := &MultiError{}
+		multi.Append(errors.New("plain"), notFound, timeout)
+
+		code, ok := GetCode(multi)
+		assert.True(t, ok)
+		assert.Equal(t, ErrCodeNotFound, code)
+
+		code, ok = GetCode(Wrap(multi, "outer").WithCode(ErrCodeInternal))
+		assert.True(t, ok)
+		assert.Equal(t, ErrCodeInternal, code, "outermost code wins")
+
+		_, ok = GetCode(JoinErrors(errors.New("a"), errors.New("b")))
+		assert.False(t, ok)
+	})
+
+	t.Run("GetRetryAfter searches all branches", func(t *testing.T) {
+		limited := New("rate limited").WithRetryAfter(30 * time.Second)
+		joined := JoinErrors(errors.New("plain"), limited)
+
+		retryAfter, ok := GetRetryAfter(Wrap(joined, "sync failed"))
+		assert.True(t, ok)
+		assert.Equal(t, 30*time.Second, retryAfter)
+	})
+}
+
 // Benchmark tests for performance validation
 
 func BenchmarkNew(b *testing.B) {
@@ -663,6 +938,22 @@ func BenchmarkNewf(b *testing.B) {
 	}
 }
 
+func BenchmarkNewWithCode(b *testing.B) {
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = NewWithCode("TEST_CODE", "test error")
+	}
+}
+
+func BenchmarkNewWithCodef(b *testing.B) {
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = NewWithCodef("TEST_CODE", "error %d: %s", 42, "test")
+	}
+}
+
 func BenchmarkWrap(b *testing.B) {
 	cause := errors.New("underlying error")
 	b.ResetTimer()
@@ -690,6 +981,15 @@ func BenchmarkWrapWithCode(b *testing.B) {
 	}
 }
 
+func BenchmarkWrapWithCodef(b *testing.B) {
+	cause := errors.New("underlying error")
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = WrapWithCodef(cause, "TEST_CODE", "error %d: %s", 42, "test")
+	}
+}
+
 func BenchmarkWrapWithContext(b *testing.B) {
 	cause := errors.New("underlying error")
 	context := map[string]interface{}{
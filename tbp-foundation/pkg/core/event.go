@@ -0,0 +1,167 @@
+// File: event.go
+// Title: Event Serialization Registry for TBP
+// Description: Provides a thread-safe registry mapping event type names to Go
+//              payload types, so events can be created from typed payloads
+//              and their JSON data decoded back into concrete structs for
+//              event sourcing.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.1
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial event registry with type-based decoding
+// - 2026-10-16 v0.1.1: Take the current time from the Clock abstraction
+
+package core
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+)
+
+// EventDataProvider is implemented by events carrying a serialized payload.
+// BaseEvent implements it; custom Event implementations should as well to
+// be decodable.
+type EventDataProvider interface {
+	EventData() []byte
+}
+
+// EventRegistry maps event type names to payload types.
+// It is safe for concurrent use.
+type EventRegistry struct {
+	mu sync.RWMutex
+
+	// types maps event type names to payload types
+	types map[string]reflect.Type
+
+	// names maps payload types to event type names
+	names map[reflect.Type]string
+}
+
+// defaultEventRegistry backs the package-level event functions
+var defaultEventRegistry = NewEventRegistry()
+
+// NewEventRegistry creates an empty event registry.
+func NewEventRegistry() *EventRegistry {
+	return &EventRegistry{
+		types: make(map[string]reflect.Type),
+		names: make(map[reflect.Type]string),
+	}
+}
+
+// Register associates an event type name with the type of the prototype.
+// The prototype may be a struct value or a pointer to one. Registering a
+// name again replaces its payload type. Panics if the name is empty, the
+// prototype is nil, or the payload type is already registered under a
+// different name, as these are programming errors.
+func (r *EventRegistry) Register(eventType string, prototype interface{}) {
+	if eventType == "" {
+		panic("event type cannot be empty")
+	}
+	if prototype == nil {
+		panic("event prototype cannot be nil")
+	}
+
+	payloadType := reflect.TypeOf(prototype)
+	for payloadType.Kind() == reflect.Ptr {
+		payloadType = payloadType.Elem()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.names[payloadType]; ok && existing != eventType {
+		panic("payload type " + payloadType.String() + " is already registered as event type " + existing)
+	}
+
+	if previous, ok := r.types[eventType]; ok {
+		delete(r.names, previous)
+	}
+	r.types[eventType] = payloadType
+	r.names[payloadType] = eventType
+}
+
+// Decode unmarshals the event data into a new instance of the payload type
+// registered for the event type. Returns a pointer to the new instance.
+// Unknown event types return an ErrCodeNotFound error.
+func (r *EventRegistry) Decode(e Event) (interface{}, error) {
+	eventType := e.EventType()
+
+	r.mu.RLock()
+	payloadType, exists := r.types[eventType]
+	r.mu.RUnlock()
+
+	if !exists {
+		return nil, Newf("event type '%s' is not registered", eventType).WithCode(ErrCodeNotFound)
+	}
+
+	payload := reflect.New(payloadType).Interface()
+
+	var data []byte
+	if provider, ok := e.(EventDataProvider); ok {
+		data = provider.EventData()
+	}
+	if len(data) == 0 {
+		return payload, nil
+	}
+
+	if err := json.Unmarshal(data, payload); err != nil {
+		return nil, Wrapf(err, "failed to decode event type '%s'", eventType).WithCode(ErrCodeInvalidInput)
+	}
+	return payload, nil
+}
+
+// NewEvent creates an event for the aggregate with the JSON-encoded payload.
+// The event type is looked up from the payload type; unregistered payload
+// types return an ErrCodeNotFound error.
+func (r *EventRegistry) NewEvent(aggregateID string, payload interface{}) (*BaseEvent, error) {
+	if payload == nil {
+		return nil, New("event payload cannot be nil").WithCode(ErrCodeInvalidInput)
+	}
+
+	payloadType := reflect.TypeOf(payload)
+	for payloadType.Kind() == reflect.Ptr {
+		payloadType = payloadType.Elem()
+	}
+
+	r.mu.RLock()
+	eventType, exists := r.names[payloadType]
+	r.mu.RUnlock()
+
+	if !exists {
+		return nil, Newf("no event type registered for payload type %s", payloadType).WithCode(ErrCodeNotFound)
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, Wrapf(err, "failed to encode event type '%s'", eventType)
+	}
+
+	return &BaseEvent{
+		ID:          NewID().String(),
+		Type:        eventType,
+		AggregateId: aggregateID,
+		OccurredAt:  Now().UTC(),
+		Data:        data,
+	}, nil
+}
+
+// RegisterEvent registers an event type in the default registry.
+// See EventRegistry.Register.
+func RegisterEvent(eventType string, prototype interface{}) {
+	defaultEventRegistry.Register(eventType, prototype)
+}
+
+// DecodeEvent decodes an event payload using the default registry.
+// See EventRegistry.Decode.
+func DecodeEvent(e Event) (interface{}, error) {
+	return defaultEventRegistry.Decode(e)
+}
+
+// NewEvent creates an event using the default registry.
+// See EventRegistry.NewEvent.
+func NewEvent(aggregateID string, payload interface{}) (*BaseEvent, error) {
+	return defaultEventRegistry.NewEvent(aggregateID, payload)
+}
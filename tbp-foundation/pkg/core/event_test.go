@@ -0,0 +1,154 @@
+// File: event_test.go
+// Title: Tests for Event Serialization Registry
+// Description: Test suite for the event registry including registration,
+//              event creation from typed payloads, type-based decoding,
+//              error codes for unknown types, and concurrent access.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+
+package core
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type orderPlaced struct {
+	OrderID string `json:"order_id"`
+	Amount  int64  `json:"amount"`
+}
+
+type orderCancelled struct {
+	OrderID string `json:"order_id"`
+	Reason  string `json:"reason"`
+}
+
+func TestEventRegistry(t *testing.T) {
+	t.Run("creates and decodes registered events", func(t *testing.T) {
+		registry := NewEventRegistry()
+		registry.Register("order.placed", orderPlaced{})
+
+		event, err := registry.NewEvent("order-1", &orderPlaced{OrderID: "order-1", Amount: 1250})
+		require.NoError(t, err)
+
+		assert.Equal(t, "order.placed", event.EventType())
+		assert.Equal(t, "order-1", event.AggregateID())
+		assert.True(t, IsValidUUID(ID(event.EventID())))
+		assert.False(t, event.Timestamp().IsZero())
+		assert.JSONEq(t, `{"order_id":"order-1","amount":1250}`, string(event.Data))
+
+		decoded, err := registry.Decode(event)
+		require.NoError(t, err)
+		assert.Equal(t, &orderPlaced{OrderID: "order-1", Amount: 1250}, decoded)
+	})
+
+	t.Run("decodes into fresh instances", func(t *testing.T) {
+		registry := NewEventRegistry()
+		registry.Register("order.cancelled", &orderCancelled{})
+
+		first, err := registry.Decode(&BaseEvent{Type: "order.cancelled", Data: []byte(`{"order_id":"a"}`)})
+		require.NoError(t, err)
+		second, err := registry.Decode(&BaseEvent{Type: "order.cancelled", Data: []byte(`{"order_id":"b"}`)})
+		require.NoError(t, err)
+
+		assert.Equal(t, "a", first.(*orderCancelled).OrderID)
+		assert.Equal(t, "b", second.(*orderCancelled).OrderID)
+	})
+
+	t.Run("returns empty payload for events without data", func(t *testing.T) {
+		registry := NewEventRegistry()
+		registry.Register("order.placed", orderPlaced{})
+
+		decoded, err := registry.Decode(&BaseEvent{Type: "order.placed"})
+		require.NoError(t, err)
+		assert.Equal(t, &orderPlaced{}, decoded)
+	})
+
+	t.Run("unknown types return not found errors", func(t *testing.T) {
+		registry := NewEventRegistry()
+
+		_, err := registry.Decode(&BaseEvent{Type: "order.shipped"})
+		require.Error(t, err)
+		assert.True(t, IsNotFound(err))
+
+		_, err = registry.NewEvent("order-1", orderPlaced{})
+		require.Error(t, err)
+		assert.True(t, IsNotFound(err))
+	})
+
+	t.Run("invalid data returns invalid input error", func(t *testing.T) {
+		registry := NewEventRegistry()
+		registry.Register("order.placed", orderPlaced{})
+
+		_, err := registry.Decode(&BaseEvent{Type: "order.placed", Data: []byte(`{invalid`)})
+		require.Error(t, err)
+		assert.True(t, IsInvalidInput(err))
+	})
+
+	t.Run("rejects invalid registrations", func(t *testing.T) {
+		registry := NewEventRegistry()
+		registry.Register("order.placed", orderPlaced{})
+
+		assert.Panics(t, func() { registry.Register("", orderPlaced{}) })
+		assert.Panics(t, func() { registry.Register("order.created", nil) })
+		assert.Panics(t, func() { registry.Register("order.created", &orderPlaced{}) })
+
+		_, err := registry.NewEvent("order-1", nil)
+		assert.True(t, IsInvalidInput(err))
+	})
+
+	t.Run("re-registering a name replaces the payload type", func(t *testing.T) {
+		registry := NewEventRegistry()
+		registry.Register("order.event", orderPlaced{})
+		registry.Register("order.event", orderCancelled{})
+
+		_, err := registry.NewEvent("order-1", orderPlaced{})
+		assert.True(t, IsNotFound(err))
+
+		event, err := registry.NewEvent("order-1", orderCancelled{OrderID: "order-1"})
+		require.NoError(t, err)
+		assert.Equal(t, "order.event", event.Type)
+	})
+
+	t.Run("concurrent registration and decoding", func(t *testing.T) {
+		registry := NewEventRegistry()
+		registry.Register("order.placed", orderPlaced{})
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				registry.Register("order.cancelled", orderCancelled{})
+			}()
+			go func() {
+				defer wg.Done()
+				event, err := registry.NewEvent("order-1", orderPlaced{OrderID: "order-1"})
+				if assert.NoError(t, err) {
+					_, err = registry.Decode(event)
+					assert.NoError(t, err)
+				}
+			}()
+		}
+		wg.Wait()
+	})
+}
+
+func TestDefaultEventRegistry(t *testing.T) {
+	RegisterEvent("test.default_registry", orderPlaced{})
+
+	event, err := NewEvent("order-1", orderPlaced{OrderID: "order-1", Amount: 5})
+	require.NoError(t, err)
+
+	decoded, err := DecodeEvent(event)
+	require.NoError(t, err)
+	assert.Equal(t, &orderPlaced{OrderID: "order-1", Amount: 5}, decoded)
+}
@@ -0,0 +1,185 @@
+// File: eventstore.go
+// Title: In-Memory Event Store for TBP
+// Description: Provides a concurrency-safe in-memory event store that
+//              enforces contiguous aggregate versions, loads event streams
+//              by aggregate or time range, and decodes payloads through the
+//              event registry. Intended for tests and small deployments.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial in-memory event store
+
+package core
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// InMemoryEventStore stores events in memory, grouped by aggregate.
+// Each aggregate's versions must start at 1 and increase by exactly one.
+// It is safe for concurrent use.
+type InMemoryEventStore struct {
+	mu sync.RWMutex
+
+	// streams maps aggregate IDs to their events in version order
+	streams map[string][]Event
+
+	// all contains every event in append order
+	all []Event
+
+	// registry decodes event payloads
+	registry *EventRegistry
+}
+
+// NewInMemoryEventStore creates an empty event store that decodes payloads
+// with registry. A nil registry uses the default registry (see RegisterEvent).
+func NewInMemoryEventStore(registry *EventRegistry) *InMemoryEventStore {
+	if registry == nil {
+		registry = defaultEventRegistry
+	}
+	return &InMemoryEventStore{
+		streams:  make(map[string][]Event),
+		registry: registry,
+	}
+}
+
+// Append stores the events atomically: either all events are stored or
+// none. Each event's version must be exactly one higher than the previous
+// version of its aggregate, including earlier events of the same call.
+// Gaps and duplicate versions return an ErrCodeConflict error; nil events
+// or events without aggregate ID return an ErrCodeInvalidInput error.
+func (s *InMemoryEventStore) Append(ctx context.Context, events ...Event) error {
+	if err := ctx.Err(); err != nil {
+		return Wrap(err, "append cancelled").WithCode(ErrCodeTimeout)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Validate the whole batch before storing anything
+	pending := make(map[string]int64)
+	for i, event := range events {
+		if event == nil {
+			return Newf("event %d is nil", i).WithCode(ErrCodeInvalidInput)
+		}
+
+		aggregateID := event.AggregateID()
+		if aggregateID == "" {
+			return Newf("event %d has no aggregate ID", i).WithCode(ErrCodeInvalidInput)
+		}
+
+		current, ok := pending[aggregateID]
+		if !ok {
+			current = s.currentVersion(aggregateID)
+		}
+
+		if expected := current + 1; event.Version() != expected {
+			return Newf("version conflict for aggregate '%s': expected version %d, got %d",
+				aggregateID, expected, event.Version()).
+				WithCode(ErrCodeConflict).
+				WithContext("aggregate_id", aggregateID).
+				WithContext("expected_version", expected).
+				WithContext("actual_version", event.Version())
+		}
+		pending[aggregateID] = event.Version()
+	}
+
+	for _, event := range events {
+		s.streams[event.AggregateID()] = append(s.streams[event.AggregateID()], event)
+		s.all = append(s.all, event)
+	}
+	return nil
+}
+
+// Load returns the events of the aggregate with a version of at least
+// fromVersion in version order. Unknown aggregates return an empty slice.
+func (s *InMemoryEventStore) Load(ctx context.Context, aggregateID string, fromVersion int64) ([]Event, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, Wrap(err, "load cancelled").WithCode(ErrCodeTimeout)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stream := s.streams[aggregateID]
+	result := make([]Event, 0, len(stream))
+	for _, event := range stream {
+		if event.Version() >= fromVersion {
+			result = append(result, event)
+		}
+	}
+	return result, nil
+}
+
+// LoadByTimeRange returns the events of all aggregates that occurred at or
+// after from and before to, ordered by timestamp. Events with equal
+// timestamps keep their append order. A zero from or to leaves that end
+// of the range open.
+func (s *InMemoryEventStore) LoadByTimeRange(ctx context.Context, from, to time.Time) ([]Event, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, Wrap(err, "load cancelled").WithCode(ErrCodeTimeout)
+	}
+
+	s.mu.RLock()
+	result := make([]Event, 0)
+	for _, event := range s.all {
+		timestamp := event.Timestamp()
+		if !from.IsZero() && timestamp.Before(from) {
+			continue
+		}
+		if !to.IsZero() && !timestamp.Before(to) {
+			continue
+		}
+		result = append(result, event)
+	}
+	s.mu.RUnlock()
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Timestamp().Before(result[j].Timestamp())
+	})
+	return result, nil
+}
+
+// LoadDecoded loads the events of the aggregate like Load and decodes
+// their payloads with the store's registry (see EventRegistry.Decode).
+func (s *InMemoryEventStore) LoadDecoded(ctx context.Context, aggregateID string, fromVersion int64) ([]interface{}, error) {
+	events, err := s.Load(ctx, aggregateID, fromVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	payloads := make([]interface{}, 0, len(events))
+	for _, event := range events {
+		payload, err := s.registry.Decode(event)
+		if err != nil {
+			return nil, WrapPreservingCode(err, "failed to decode event "+event.EventID())
+		}
+		payloads = append(payloads, payload)
+	}
+	return payloads, nil
+}
+
+// Version returns the current version of the aggregate, or 0 if it has
+// no events.
+func (s *InMemoryEventStore) Version(aggregateID string) int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.currentVersion(aggregateID)
+}
+
+// currentVersion returns the aggregate's latest version. The caller must
+// hold the lock.
+func (s *InMemoryEventStore) currentVersion(aggregateID string) int64 {
+	stream := s.streams[aggregateID]
+	if len(stream) == 0 {
+		return 0
+	}
+	return stream[len(stream)-1].Version()
+}
@@ -0,0 +1,176 @@
+// File: eventstore_test.go
+// Title: Tests for In-Memory Event Store
+// Description: Tests version enforcement, aggregate and time range loading,
+//              payload decoding, and concurrent appends of the in-memory
+//              event store.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryEventStore(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	newEvent := func(aggregateID string, version int64, offset time.Duration) *BaseEvent {
+		return &BaseEvent{
+			ID:          fmt.Sprintf("%s-%d", aggregateID, version),
+			Type:        "order.placed",
+			AggregateId: aggregateID,
+			Ver:         version,
+			OccurredAt:  base.Add(offset),
+		}
+	}
+
+	t.Run("appends and loads by aggregate", func(t *testing.T) {
+		store := NewInMemoryEventStore(nil)
+		require.NoError(t, store.Append(ctx, newEvent("a", 1, 0), newEvent("b", 1, time.Second), newEvent("a", 2, 2*time.Second)))
+		require.NoError(t, store.Append(ctx, newEvent("a", 3, 3*time.Second)))
+
+		events, err := store.Load(ctx, "a", 0)
+		require.NoError(t, err)
+		require.Len(t, events, 3)
+		for i, event := range events {
+			assert.Equal(t, int64(i+1), event.Version())
+		}
+
+		events, err = store.Load(ctx, "a", 2)
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+		assert.Equal(t, int64(2), events[0].Version())
+
+		events, err = store.Load(ctx, "unknown", 0)
+		require.NoError(t, err)
+		assert.Empty(t, events)
+
+		assert.Equal(t, int64(3), store.Version("a"))
+		assert.Equal(t, int64(0), store.Version("unknown"))
+	})
+
+	t.Run("rejects gaps and duplicates", func(t *testing.T) {
+		store := NewInMemoryEventStore(nil)
+		require.NoError(t, store.Append(ctx, newEvent("a", 1, 0)))
+
+		err := store.Append(ctx, newEvent("a", 1, 0))
+		require.Error(t, err)
+		assert.True(t, IsConflict(err))
+
+		err = store.Append(ctx, newEvent("a", 3, 0))
+		require.Error(t, err)
+		assert.True(t, IsConflict(err))
+		assert.Contains(t, err.Error(), "expected version 2, got 3")
+
+		err = store.Append(ctx, newEvent("b", 2, 0))
+		assert.True(t, IsConflict(err))
+	})
+
+	t.Run("appends batches atomically", func(t *testing.T) {
+		store := NewInMemoryEventStore(nil)
+
+		err := store.Append(ctx, newEvent("a", 1, 0), newEvent("a", 2, 0), newEvent("a", 2, 0))
+		require.Error(t, err)
+		assert.True(t, IsConflict(err))
+		assert.Equal(t, int64(0), store.Version("a"))
+	})
+
+	t.Run("rejects invalid events", func(t *testing.T) {
+		store := NewInMemoryEventStore(nil)
+
+		assert.True(t, IsInvalidInput(store.Append(ctx, nil)))
+		assert.True(t, IsInvalidInput(store.Append(ctx, newEvent("", 1, 0))))
+	})
+
+	t.Run("loads by time range", func(t *testing.T) {
+		store := NewInMemoryEventStore(nil)
+		require.NoError(t, store.Append(ctx, newEvent("a", 1, 3*time.Minute)))
+		require.NoError(t, store.Append(ctx, newEvent("b", 1, time.Minute)))
+		require.NoError(t, store.Append(ctx, newEvent("c", 1, 2*time.Minute)))
+		require.NoError(t, store.Append(ctx, newEvent("a", 2, 5*time.Minute)))
+
+		events, err := store.LoadByTimeRange(ctx, base.Add(time.Minute), base.Add(5*time.Minute))
+		require.NoError(t, err)
+		ids := make([]string, 0, len(events))
+		for _, event := range events {
+			ids = append(ids, event.EventID())
+		}
+		assert.Equal(t, []string{"b-1", "c-1", "a-1"}, ids)
+
+		events, err = store.LoadByTimeRange(ctx, time.Time{}, time.Time{})
+		require.NoError(t, err)
+		assert.Len(t, events, 4)
+	})
+
+	t.Run("decodes payloads with registry", func(t *testing.T) {
+		registry := NewEventRegistry()
+		registry.Register("order.placed", orderPlaced{})
+		registry.Register("order.cancelled", orderCancelled{})
+		store := NewInMemoryEventStore(registry)
+
+		placed, err := registry.NewEvent("order-1", orderPlaced{OrderID: "order-1", Amount: 100})
+		require.NoError(t, err)
+		placed.Ver = 1
+		cancelled, err := registry.NewEvent("order-1", orderCancelled{OrderID: "order-1", Reason: "duplicate"})
+		require.NoError(t, err)
+		cancelled.Ver = 2
+		require.NoError(t, store.Append(ctx, placed, cancelled))
+
+		payloads, err := store.LoadDecoded(ctx, "order-1", 1)
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{
+			&orderPlaced{OrderID: "order-1", Amount: 100},
+			&orderCancelled{OrderID: "order-1", Reason: "duplicate"},
+		}, payloads)
+
+		unknown := &BaseEvent{ID: "x", Type: "unknown", AggregateId: "order-2", Ver: 1}
+		require.NoError(t, store.Append(ctx, unknown))
+		_, err = store.LoadDecoded(ctx, "order-2", 0)
+		assert.True(t, IsNotFound(err))
+	})
+
+	t.Run("respects cancelled context", func(t *testing.T) {
+		store := NewInMemoryEventStore(nil)
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		assert.Error(t, store.Append(cancelled, newEvent("a", 1, 0)))
+		_, err := store.Load(cancelled, "a", 0)
+		assert.Error(t, err)
+		_, err = store.LoadByTimeRange(cancelled, time.Time{}, time.Time{})
+		assert.Error(t, err)
+	})
+
+	t.Run("is safe for concurrent appends", func(t *testing.T) {
+		store := NewInMemoryEventStore(nil)
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(aggregateID string) {
+				defer wg.Done()
+				for version := int64(1); version <= 20; version++ {
+					assert.NoError(t, store.Append(ctx, newEvent(aggregateID, version, 0)))
+				}
+			}(fmt.Sprintf("agg-%d", i))
+		}
+		wg.Wait()
+
+		events, err := store.LoadByTimeRange(ctx, time.Time{}, time.Time{})
+		require.NoError(t, err)
+		assert.Len(t, events, 200)
+	})
+}
@@ -0,0 +1,163 @@
+// File: health.go
+// Title: Health Aggregation for TBP Services
+// Description: Aggregates the health of multiple components into a single
+//              HealthStatus and runs health checks of services concurrently
+//              with a timeout, as the backbone for /healthz endpoints.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial health aggregation and concurrent checks
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AggregateHealth combines component statuses into one status.
+// The result is unhealthy if any component is unhealthy, degraded if any
+// component is degraded (or reports an unknown status) but none is
+// unhealthy, and healthy otherwise. Details maps each component name to
+// its status, followed by its message if present.
+func AggregateHealth(statuses map[string]HealthStatus) HealthStatus {
+	details := make(map[string]string, len(statuses))
+	var unhealthy, degraded []string
+
+	for name, status := range statuses {
+		detail := status.Status
+		if status.Message != "" {
+			detail += ": " + status.Message
+		}
+		details[name] = detail
+
+		switch status.Status {
+		case HealthStatusHealthy:
+		case HealthStatusUnhealthy:
+			unhealthy = append(unhealthy, name)
+		default:
+			degraded = append(degraded, name)
+		}
+	}
+
+	sort.Strings(unhealthy)
+	sort.Strings(degraded)
+
+	switch {
+	case len(unhealthy) > 0:
+		return HealthStatus{
+			Status:  HealthStatusUnhealthy,
+			Message: "unhealthy components: " + strings.Join(unhealthy, ", "),
+			Details: details,
+		}
+	case len(degraded) > 0:
+		return HealthStatus{
+			Status:  HealthStatusDegraded,
+			Message: "degraded components: " + strings.Join(degraded, ", "),
+			Details: details,
+		}
+	default:
+		return HealthStatus{
+			Status:  HealthStatusHealthy,
+			Message: fmt.Sprintf("%d components healthy", len(statuses)),
+			Details: details,
+		}
+	}
+}
+
+// ServiceHealth adapts a Service to the HealthChecker interface.
+// A nil error from Service.Health is reported as healthy, any other error
+// as unhealthy with the error message.
+func ServiceHealth(svc Service) HealthChecker {
+	return serviceHealthChecker{service: svc}
+}
+
+// serviceHealthChecker implements HealthChecker for a Service
+type serviceHealthChecker struct {
+	service Service
+}
+
+// Health implements HealthChecker interface.
+func (c serviceHealthChecker) Health(ctx context.Context) HealthStatus {
+	if err := c.service.Health(ctx); err != nil {
+		return HealthStatus{Status: HealthStatusUnhealthy, Message: err.Error()}
+	}
+	return HealthStatus{Status: HealthStatusHealthy}
+}
+
+// CheckHealth runs all health checks concurrently and aggregates the
+// results with AggregateHealth. Each check receives a context limited by
+// timeout (0 = no limit); checks that do not return in time are reported
+// as unhealthy.
+func CheckHealth(ctx context.Context, timeout time.Duration, checkers map[string]HealthChecker) HealthStatus {
+	checkCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		checkCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	statuses := make(map[string]HealthStatus, len(checkers))
+
+	for name, checker := range checkers {
+		wg.Add(1)
+		go func(name string, checker HealthChecker) {
+			defer wg.Done()
+			status := runHealthCheck(checkCtx, checker)
+
+			mu.Lock()
+			statuses[name] = status
+			mu.Unlock()
+		}(name, checker)
+	}
+	wg.Wait()
+
+	return AggregateHealth(statuses)
+}
+
+// CheckServices runs Service.Health for all services concurrently, keyed
+// by service name. See CheckHealth.
+func CheckServices(ctx context.Context, timeout time.Duration, services ...Service) HealthStatus {
+	checkers := make(map[string]HealthChecker, len(services))
+	for _, svc := range services {
+		checkers[svc.Name()] = ServiceHealth(svc)
+	}
+	return CheckHealth(ctx, timeout, checkers)
+}
+
+// runHealthCheck runs a single check, giving up when ctx is done.
+// Panicking checks are reported as unhealthy.
+func runHealthCheck(ctx context.Context, checker HealthChecker) HealthStatus {
+	result := make(chan HealthStatus, 1)
+
+	go func() {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				result <- HealthStatus{
+					Status:  HealthStatusUnhealthy,
+					Message: RecoverToError(recovered).Error(),
+				}
+			}
+		}()
+		result <- checker.Health(ctx)
+	}()
+
+	select {
+	case status := <-result:
+		return status
+	case <-ctx.Done():
+		return HealthStatus{
+			Status:  HealthStatusUnhealthy,
+			Message: "health check did not complete: " + ctx.Err().Error(),
+		}
+	}
+}
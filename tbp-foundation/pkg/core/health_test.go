@@ -0,0 +1,142 @@
+// File: health_test.go
+// Title: Tests for Health Aggregation
+// Description: Test suite for aggregating component health, adapting
+//              services, and running concurrent checks with timeouts.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeService is a Service with configurable health behavior
+type fakeService struct {
+	name  string
+	err   error
+	delay time.Duration
+}
+
+func (s *fakeService) Name() string {
+	return s.name
+}
+
+func (s *fakeService) Health(ctx context.Context) error {
+	if s.delay > 0 {
+		select {
+		case <-time.After(s.delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return s.err
+}
+
+// staticChecker is a HealthChecker returning a fixed status
+type staticChecker HealthStatus
+
+func (c staticChecker) Health(ctx context.Context) HealthStatus {
+	return HealthStatus(c)
+}
+
+func TestAggregateHealth(t *testing.T) {
+	t.Run("all healthy", func(t *testing.T) {
+		result := AggregateHealth(map[string]HealthStatus{
+			"db":    {Status: HealthStatusHealthy},
+			"cache": {Status: HealthStatusHealthy},
+		})
+		assert.Equal(t, HealthStatusHealthy, result.Status)
+		assert.Equal(t, "2 components healthy", result.Message)
+		assert.Equal(t, map[string]string{"db": "healthy", "cache": "healthy"}, result.Details)
+	})
+
+	t.Run("degraded without unhealthy", func(t *testing.T) {
+		result := AggregateHealth(map[string]HealthStatus{
+			"db":    {Status: HealthStatusHealthy},
+			"cache": {Status: HealthStatusDegraded, Message: "high latency"},
+		})
+		assert.Equal(t, HealthStatusDegraded, result.Status)
+		assert.Equal(t, "degraded: high latency", result.Details["cache"])
+	})
+
+	t.Run("unhealthy wins over degraded", func(t *testing.T) {
+		result := AggregateHealth(map[string]HealthStatus{
+			"db":     {Status: HealthStatusUnhealthy, Message: "connection refused"},
+			"cache":  {Status: HealthStatusDegraded},
+			"search": {Status: HealthStatusUnhealthy},
+		})
+		assert.Equal(t, HealthStatusUnhealthy, result.Status)
+		assert.Equal(t, "unhealthy components: db, search", result.Message)
+		assert.Len(t, result.Details, 3)
+	})
+
+	t.Run("unknown status counts as degraded", func(t *testing.T) {
+		result := AggregateHealth(map[string]HealthStatus{"legacy": {Status: "starting"}})
+		assert.Equal(t, HealthStatusDegraded, result.Status)
+	})
+
+	t.Run("no components is healthy", func(t *testing.T) {
+		assert.True(t, AggregateHealth(nil).IsHealthy())
+	})
+}
+
+func TestCheckHealth(t *testing.T) {
+	t.Run("checks services concurrently", func(t *testing.T) {
+		start := time.Now()
+		result := CheckServices(context.Background(), time.Second,
+			&fakeService{name: "db", delay: 50 * time.Millisecond},
+			&fakeService{name: "cache", delay: 50 * time.Millisecond},
+			&fakeService{name: "queue", err: New("broker unreachable")},
+		)
+
+		assert.Less(t, time.Since(start), 90*time.Millisecond)
+		assert.Equal(t, HealthStatusUnhealthy, result.Status)
+		assert.Equal(t, "healthy", result.Details["db"])
+		assert.Equal(t, "unhealthy: broker unreachable", result.Details["queue"])
+	})
+
+	t.Run("reports timed out services as unhealthy", func(t *testing.T) {
+		result := CheckServices(context.Background(), 20*time.Millisecond,
+			&fakeService{name: "db"},
+			&fakeService{name: "slow", delay: time.Second},
+		)
+
+		assert.Equal(t, HealthStatusUnhealthy, result.Status)
+		assert.Equal(t, "unhealthy components: slow", result.Message)
+		assert.Contains(t, result.Details["slow"], "deadline exceeded")
+	})
+
+	t.Run("aggregates health checkers including degraded", func(t *testing.T) {
+		result := CheckHealth(context.Background(), time.Second, map[string]HealthChecker{
+			"db":    ServiceHealth(&fakeService{name: "db"}),
+			"cache": staticChecker{Status: HealthStatusDegraded, Message: "evicting"},
+		})
+		assert.Equal(t, HealthStatusDegraded, result.Status)
+		assert.Equal(t, "degraded: evicting", result.Details["cache"])
+	})
+
+	t.Run("reports panicking checks as unhealthy", func(t *testing.T) {
+		result := CheckHealth(context.Background(), time.Second, map[string]HealthChecker{
+			"broken": panickingChecker{},
+		})
+		assert.Equal(t, HealthStatusUnhealthy, result.Status)
+		assert.Contains(t, result.Details["broken"], "panic recovered")
+	})
+}
+
+// panickingChecker is a HealthChecker that panics
+type panickingChecker struct{}
+
+func (panickingChecker) Health(ctx context.Context) HealthStatus {
+	panic("checker crashed")
+}
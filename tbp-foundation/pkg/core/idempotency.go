@@ -0,0 +1,128 @@
+// File: idempotency.go
+// Title: Idempotency Key Store for TBP
+// Description: Provides the IdempotencyStore interface used by mutating
+//              services to recognize retried requests by their idempotency
+//              key, and an in-memory implementation with expiry for local
+//              development and tests.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial IdempotencyStore and in-memory implementation
+
+package core
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// IdempotencyStore records the idempotency keys of processed requests so
+// that retries can be detected and answered with the original result.
+// Implementations must be safe for concurrent use.
+type IdempotencyStore interface {
+	// SeenBefore reports whether a result has been recorded for key and
+	// has not expired
+	SeenBefore(ctx context.Context, key string) (bool, error)
+
+	// Record stores the result of the request with the given key for ttl.
+	// A ttl of zero or less keeps the record until the store is discarded.
+	Record(ctx context.Context, key string, result []byte, ttl time.Duration) error
+}
+
+// idempotencyRecord is a recorded result and its expiry (zero = never)
+type idempotencyRecord struct {
+	result    []byte
+	expiresAt time.Time
+}
+
+// expired reports whether the record has expired at now
+func (r idempotencyRecord) expired(now time.Time) bool {
+	return !r.expiresAt.IsZero() && !now.Before(r.expiresAt)
+}
+
+// MemoryIdempotencyStore is an IdempotencyStore keeping records in memory.
+// Records are lost on restart and not shared between instances, so it is
+// intended for local development and tests. Expiry uses the clock of the
+// context (see ClockFromContext).
+type MemoryIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]idempotencyRecord
+}
+
+// NewMemoryIdempotencyStore creates an empty in-memory idempotency store
+func NewMemoryIdempotencyStore() *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{records: make(map[string]idempotencyRecord)}
+}
+
+// SeenBefore implements IdempotencyStore. Expired records are removed.
+func (s *MemoryIdempotencyStore) SeenBefore(ctx context.Context, key string) (bool, error) {
+	_, ok := s.lookup(ctx, key)
+	return ok, nil
+}
+
+// Result returns a copy of the result recorded for key, and false if no
+// unexpired record exists
+func (s *MemoryIdempotencyStore) Result(ctx context.Context, key string) ([]byte, bool) {
+	record, ok := s.lookup(ctx, key)
+	if !ok {
+		return nil, false
+	}
+	return append([]byte(nil), record.result...), true
+}
+
+// Record implements IdempotencyStore. The result is copied, and recording
+// a key again replaces the previous record. Fails with ErrCodeInvalidInput
+// if key is empty.
+func (s *MemoryIdempotencyStore) Record(ctx context.Context, key string, result []byte, ttl time.Duration) error {
+	if key == "" {
+		return NewWithCode(ErrCodeInvalidInput, "idempotency key cannot be empty")
+	}
+
+	now := ClockFromContext(ctx).Now()
+	record := idempotencyRecord{result: append([]byte(nil), result...)}
+	if ttl > 0 {
+		record.expiresAt = now.Add(ttl)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Drop expired records so that the store does not grow without bound
+	for k, existing := range s.records {
+		if existing.expired(now) {
+			delete(s.records, k)
+		}
+	}
+	s.records[key] = record
+	return nil
+}
+
+// Len returns the number of records, including expired records that have
+// not been removed yet
+func (s *MemoryIdempotencyStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.records)
+}
+
+// lookup returns the unexpired record of key, removing it if expired
+func (s *MemoryIdempotencyStore) lookup(ctx context.Context, key string) (idempotencyRecord, bool) {
+	now := ClockFromContext(ctx).Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[key]
+	if !ok {
+		return idempotencyRecord{}, false
+	}
+	if record.expired(now) {
+		delete(s.records, key)
+		return idempotencyRecord{}, false
+	}
+	return record, true
+}
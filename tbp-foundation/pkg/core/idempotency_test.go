@@ -0,0 +1,113 @@
+// File: idempotency_test.go
+// Title: Tests for the Idempotency Key Store
+// Description: Tests recording and detecting idempotency keys, expiry with
+//              a fake clock, result copies, and concurrent use of the
+//              in-memory store.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryIdempotencyStore(t *testing.T) {
+	var _ IdempotencyStore = NewMemoryIdempotencyStore()
+
+	clock := NewFakeClock(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC))
+	ctx := WithClock(context.Background(), clock)
+
+	t.Run("detects recorded keys", func(t *testing.T) {
+		store := NewMemoryIdempotencyStore()
+
+		seen, err := store.SeenBefore(ctx, "order-42")
+		require.NoError(t, err)
+		assert.False(t, seen)
+
+		require.NoError(t, store.Record(ctx, "order-42", []byte(`{"id":42}`), time.Hour))
+
+		seen, err = store.SeenBefore(ctx, "order-42")
+		require.NoError(t, err)
+		assert.True(t, seen)
+
+		result, ok := store.Result(ctx, "order-42")
+		require.True(t, ok)
+		assert.Equal(t, `{"id":42}`, string(result))
+	})
+
+	t.Run("expires records", func(t *testing.T) {
+		store := NewMemoryIdempotencyStore()
+		require.NoError(t, store.Record(ctx, "order-42", nil, time.Minute))
+		require.NoError(t, store.Record(ctx, "order-43", nil, 0))
+
+		clock.Advance(time.Minute)
+
+		seen, _ := store.SeenBefore(ctx, "order-42")
+		assert.False(t, seen)
+		seen, _ = store.SeenBefore(ctx, "order-43")
+		assert.True(t, seen, "records without ttl do not expire")
+	})
+
+	t.Run("removes expired records on record", func(t *testing.T) {
+		store := NewMemoryIdempotencyStore()
+		for i := 0; i < 10; i++ {
+			require.NoError(t, store.Record(ctx, fmt.Sprintf("key-%d", i), nil, time.Second))
+		}
+		clock.Advance(time.Second)
+
+		require.NoError(t, store.Record(ctx, "fresh", nil, time.Second))
+		assert.Equal(t, 1, store.Len())
+	})
+
+	t.Run("copies results", func(t *testing.T) {
+		store := NewMemoryIdempotencyStore()
+		result := []byte("created")
+		require.NoError(t, store.Record(ctx, "order-42", result, time.Hour))
+		result[0] = 'X'
+
+		stored, _ := store.Result(ctx, "order-42")
+		assert.Equal(t, "created", string(stored))
+		stored[0] = 'Y'
+
+		stored, _ = store.Result(ctx, "order-42")
+		assert.Equal(t, "created", string(stored))
+	})
+
+	t.Run("rejects empty key", func(t *testing.T) {
+		err := NewMemoryIdempotencyStore().Record(ctx, "", nil, time.Hour)
+		assert.True(t, IsInvalidInput(err))
+	})
+
+	t.Run("is safe for concurrent use", func(t *testing.T) {
+		store := NewMemoryIdempotencyStore()
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				key := fmt.Sprintf("key-%d", i%5)
+				assert.NoError(t, store.Record(ctx, key, []byte(key), time.Hour))
+				seen, err := store.SeenBefore(ctx, key)
+				assert.NoError(t, err)
+				assert.True(t, seen)
+			}(i)
+		}
+		wg.Wait()
+
+		assert.Equal(t, 5, store.Len())
+	})
+}
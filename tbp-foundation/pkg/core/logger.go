@@ -0,0 +1,122 @@
+// File: logger.go
+// Title: Context-Aware Logger Injection for TBP
+// Description: Defines a minimal, dependency-free logging interface that is
+//              carried in the request context, so that packages can log with
+//              the request and correlation identifiers of the current call
+//              without depending on a concrete logging library.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial Logger interface with context injection
+
+package core
+
+import (
+	"context"
+	"sort"
+)
+
+// keyLogger is the context key holding the request logger
+const keyLogger contextKey = "tbp:logger"
+
+// Logger is the minimal structured logging interface used throughout TBP.
+// kv contains alternating keys and values, e.g.
+// logger.Info("user created", "user_id", id, "tenant_id", tenantID).
+// Applications adapt their logging library of choice to this interface.
+type Logger interface {
+	Debug(msg string, kv ...interface{})
+	Info(msg string, kv ...interface{})
+	Warn(msg string, kv ...interface{})
+	Error(msg string, kv ...interface{})
+}
+
+// noopLogger discards all log entries
+type noopLogger struct{}
+
+func (noopLogger) Debug(string, ...interface{}) {}
+func (noopLogger) Info(string, ...interface{})  {}
+func (noopLogger) Warn(string, ...interface{})  {}
+func (noopLogger) Error(string, ...interface{}) {}
+
+// NopLogger returns a Logger that discards all log entries
+func NopLogger() Logger {
+	return noopLogger{}
+}
+
+// WithLogger adds a logger to the context.
+// A nil logger removes a previously set logger.
+func WithLogger(ctx context.Context, logger Logger) context.Context {
+	if logger == nil {
+		logger = noopLogger{}
+	}
+	return context.WithValue(ctx, keyLogger, logger)
+}
+
+// LoggerFromContext returns the logger from the context.
+// Returns a no-op logger if none is set, so callers never need nil checks.
+func LoggerFromContext(ctx context.Context) Logger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(keyLogger).(Logger); ok {
+			return logger
+		}
+	}
+	return noopLogger{}
+}
+
+// ContextLogger returns the logger from the context decorated with the
+// fields of ContextSummary, such as request_id, correlation_id, user_id
+// and tenant_id. The fields are evaluated on every log call and precede
+// the fields passed by the caller.
+func ContextLogger(ctx context.Context) Logger {
+	logger := LoggerFromContext(ctx)
+	if _, isNoop := logger.(noopLogger); isNoop {
+		return logger
+	}
+	return &contextLogger{ctx: ctx, logger: logger}
+}
+
+// contextLogger adds context summary fields to every log entry
+type contextLogger struct {
+	ctx    context.Context
+	logger Logger
+}
+
+// Debug implements Logger interface.
+func (l *contextLogger) Debug(msg string, kv ...interface{}) {
+	l.logger.Debug(msg, l.fields(kv)...)
+}
+
+// Info implements Logger interface.
+func (l *contextLogger) Info(msg string, kv ...interface{}) {
+	l.logger.Info(msg, l.fields(kv)...)
+}
+
+// Warn implements Logger interface.
+func (l *contextLogger) Warn(msg string, kv ...interface{}) {
+	l.logger.Warn(msg, l.fields(kv)...)
+}
+
+// Error implements Logger interface.
+func (l *contextLogger) Error(msg string, kv ...interface{}) {
+	l.logger.Error(msg, l.fields(kv)...)
+}
+
+// fields returns the context summary as sorted key-value pairs followed by kv
+func (l *contextLogger) fields(kv []interface{}) []interface{} {
+	summary := ContextSummary(l.ctx)
+
+	keys := make([]string, 0, len(summary))
+	for key := range summary {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fields := make([]interface{}, 0, 2*len(keys)+len(kv))
+	for _, key := range keys {
+		fields = append(fields, key, summary[key])
+	}
+	return append(fields, kv...)
+}
@@ -0,0 +1,95 @@
+// File: logger_test.go
+// Title: Tests for Context-Aware Logger Injection
+// Description: Test suite for storing loggers in the context and decorating
+//              log entries with context summary fields.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// logEntry is a log entry captured by recordingLogger
+type logEntry struct {
+	level string
+	msg   string
+	kv    []interface{}
+}
+
+// recordingLogger is a Logger that captures all entries
+type recordingLogger struct {
+	entries []logEntry
+}
+
+func (l *recordingLogger) Debug(msg string, kv ...interface{}) { l.record("debug", msg, kv) }
+func (l *recordingLogger) Info(msg string, kv ...interface{})  { l.record("info", msg, kv) }
+func (l *recordingLogger) Warn(msg string, kv ...interface{})  { l.record("warn", msg, kv) }
+func (l *recordingLogger) Error(msg string, kv ...interface{}) { l.record("error", msg, kv) }
+
+func (l *recordingLogger) record(level, msg string, kv []interface{}) {
+	l.entries = append(l.entries, logEntry{level: level, msg: msg, kv: kv})
+}
+
+func TestLoggerFromContext(t *testing.T) {
+	t.Run("defaults to no-op logger", func(t *testing.T) {
+		logger := LoggerFromContext(context.Background())
+		require.NotNil(t, logger)
+		assert.NotPanics(t, func() { logger.Info("ignored", "key", "value") })
+		assert.NotNil(t, ContextLogger(context.Background()))
+	})
+
+	t.Run("returns injected logger", func(t *testing.T) {
+		recorder := &recordingLogger{}
+		ctx := WithLogger(context.Background(), recorder)
+
+		LoggerFromContext(ctx).Warn("disk almost full", "free_mb", 12)
+
+		require.Len(t, recorder.entries, 1)
+		assert.Equal(t, logEntry{level: "warn", msg: "disk almost full", kv: []interface{}{"free_mb", 12}}, recorder.entries[0])
+	})
+
+	t.Run("nil logger resets to no-op", func(t *testing.T) {
+		ctx := WithLogger(WithLogger(context.Background(), &recordingLogger{}), nil)
+		assert.Equal(t, NopLogger(), LoggerFromContext(ctx))
+	})
+}
+
+func TestContextLogger(t *testing.T) {
+	recorder := &recordingLogger{}
+	ctx := WithLogger(context.Background(), recorder)
+	ctx = WithRequestID(ctx, "req-1")
+	ctx = WithCorrelationID(ctx, "corr-1")
+	ctx = WithTenantID(ctx, "tenant-1")
+
+	logger := ContextLogger(ctx)
+	logger.Error("payment failed", "order_id", "o-42")
+	logger.Debug("retrying")
+
+	require.Len(t, recorder.entries, 2)
+	entry := recorder.entries[0]
+	assert.Equal(t, "error", entry.level)
+	assert.Equal(t, "payment failed", entry.msg)
+
+	fields := make(map[interface{}]interface{})
+	for i := 0; i+1 < len(entry.kv); i += 2 {
+		fields[entry.kv[i]] = entry.kv[i+1]
+	}
+	assert.Equal(t, "req-1", fields["request_id"])
+	assert.Equal(t, "corr-1", fields["correlation_id"])
+	assert.Equal(t, "tenant-1", fields["tenant_id"])
+	assert.Equal(t, "o-42", fields["order_id"])
+	assert.Equal(t, []interface{}{"order_id", "o-42"}, entry.kv[len(entry.kv)-2:])
+
+	assert.Equal(t, "debug", recorder.entries[1].level)
+}
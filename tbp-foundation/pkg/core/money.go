@@ -0,0 +1,415 @@
+// File: money.go
+// Title: Money Type for TBP Business Amounts
+// Description: Provides an exact monetary amount type storing integer minor
+//              units with an ISO 4217 currency code. Supports arithmetic with
+//              currency checks, rounding-mode-aware division, allocation
+//              without losing minor units, and JSON as "EUR 12.34" strings.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial Money implementation
+
+package core
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Money represents an exact monetary amount in the minor units of a
+// currency (e.g. cents for EUR). Money values are immutable; all operations
+// return new values. The zero value has no currency and is only useful as a
+// placeholder.
+type Money struct {
+	amount   int64
+	currency string
+}
+
+// RoundingMode defines how division results are rounded to minor units.
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds to the nearest unit, ties away from zero
+	RoundHalfUp RoundingMode = iota
+
+	// RoundHalfEven rounds to the nearest unit, ties to the even unit (banker's rounding)
+	RoundHalfEven
+
+	// RoundDown rounds toward zero (truncation)
+	RoundDown
+
+	// RoundUp rounds away from zero
+	RoundUp
+
+	// RoundFloor rounds toward negative infinity
+	RoundFloor
+
+	// RoundCeiling rounds toward positive infinity
+	RoundCeiling
+)
+
+// currencyExponents lists ISO 4217 currencies whose minor unit exponent
+// differs from the default of 2.
+var currencyExponents = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0,
+	"KRW": 0, "PYG": 0, "RWF": 0, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0,
+	"XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+// CurrencyExponent returns the number of minor unit digits of a currency,
+// e.g. 2 for EUR and 0 for JPY.
+func CurrencyExponent(currency string) int {
+	if exponent, ok := currencyExponents[currency]; ok {
+		return exponent
+	}
+	return 2
+}
+
+// NewMoney creates a Money value from an amount in minor units and an
+// ISO 4217 currency code (three upper-case letters).
+func NewMoney(amount int64, currency string) (Money, error) {
+	if !isValidCurrency(currency) {
+		return Money{}, Newf("invalid currency code '%s'", currency).WithCode(ErrCodeInvalidInput)
+	}
+	return Money{amount: amount, currency: currency}, nil
+}
+
+// MustNewMoney creates a Money value, panicking on an invalid currency.
+// Should only be used with constant currency codes.
+func MustNewMoney(amount int64, currency string) Money {
+	money, err := NewMoney(amount, currency)
+	if err != nil {
+		panic(err)
+	}
+	return money
+}
+
+// ParseMoney parses a string like "EUR 12.34" or "JPY -500".
+// The number of decimals must not exceed the currency's minor unit digits.
+func ParseMoney(s string) (Money, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		return Money{}, Newf("invalid money format '%s', expected '<CURRENCY> <AMOUNT>'", s).WithCode(ErrCodeInvalidInput)
+	}
+
+	currency, amountStr := fields[0], fields[1]
+	if !isValidCurrency(currency) {
+		return Money{}, Newf("invalid currency code '%s'", currency).WithCode(ErrCodeInvalidInput)
+	}
+
+	amount, err := parseMinorUnits(amountStr, CurrencyExponent(currency))
+	if err != nil {
+		return Money{}, Wrapf(err, "invalid money amount '%s'", amountStr).WithCode(ErrCodeInvalidInput)
+	}
+
+	return Money{amount: amount, currency: currency}, nil
+}
+
+// Amount returns the amount in minor units.
+func (m Money) Amount() int64 {
+	return m.amount
+}
+
+// Currency returns the ISO 4217 currency code.
+func (m Money) Currency() string {
+	return m.currency
+}
+
+// IsZero checks if the amount is zero.
+func (m Money) IsZero() bool {
+	return m.amount == 0
+}
+
+// IsNegative checks if the amount is below zero.
+func (m Money) IsNegative() bool {
+	return m.amount < 0
+}
+
+// IsPositive checks if the amount is above zero.
+func (m Money) IsPositive() bool {
+	return m.amount > 0
+}
+
+// Negate returns the amount with inverted sign.
+func (m Money) Negate() Money {
+	return Money{amount: -m.amount, currency: m.currency}
+}
+
+// Add returns the sum of two amounts in the same currency.
+func (m Money) Add(other Money) (Money, error) {
+	if err := m.checkCurrency(other); err != nil {
+		return Money{}, err
+	}
+	if (other.amount > 0 && m.amount > math.MaxInt64-other.amount) ||
+		(other.amount < 0 && m.amount < math.MinInt64-other.amount) {
+		return Money{}, Newf("money addition overflows: %s + %s", m, other).WithCode(ErrCodeInvalidInput)
+	}
+	return Money{amount: m.amount + other.amount, currency: m.currency}, nil
+}
+
+// Sub returns the difference of two amounts in the same currency.
+func (m Money) Sub(other Money) (Money, error) {
+	if err := m.checkCurrency(other); err != nil {
+		return Money{}, err
+	}
+	if (other.amount < 0 && m.amount > math.MaxInt64+other.amount) ||
+		(other.amount > 0 && m.amount < math.MinInt64+other.amount) {
+		return Money{}, Newf("money subtraction overflows: %s - %s", m, other).WithCode(ErrCodeInvalidInput)
+	}
+	return Money{amount: m.amount - other.amount, currency: m.currency}, nil
+}
+
+// Mul returns the amount multiplied by an integer factor.
+func (m Money) Mul(factor int64) (Money, error) {
+	product := new(big.Int).Mul(big.NewInt(m.amount), big.NewInt(factor))
+	if !product.IsInt64() {
+		return Money{}, Newf("money multiplication overflows: %s * %d", m, factor).WithCode(ErrCodeInvalidInput)
+	}
+	return Money{amount: product.Int64(), currency: m.currency}, nil
+}
+
+// Div returns the amount divided by an integer divisor, rounded to minor
+// units using the given rounding mode.
+func (m Money) Div(divisor int64, mode RoundingMode) (Money, error) {
+	if divisor == 0 {
+		return Money{}, New("money division by zero").WithCode(ErrCodeInvalidInput)
+	}
+	if m.amount == math.MinInt64 && divisor == -1 {
+		return Money{}, Newf("money division overflows: %s / %d", m, divisor).WithCode(ErrCodeInvalidInput)
+	}
+
+	quotient := m.amount / divisor
+	remainder := m.amount % divisor
+	if remainder == 0 {
+		return Money{amount: quotient, currency: m.currency}, nil
+	}
+
+	negative := (m.amount < 0) != (divisor < 0)
+	step := int64(1)
+	if negative {
+		step = -1
+	}
+
+	absRemainder, absDivisor := absUint64(remainder), absUint64(divisor)
+	// Compare the remainder against half the divisor without overflowing
+	aboveHalf := absRemainder > absDivisor-absRemainder
+	atHalf := absRemainder == absDivisor-absRemainder
+
+	switch mode {
+	case RoundHalfUp:
+		if aboveHalf || atHalf {
+			quotient += step
+		}
+	case RoundHalfEven:
+		if aboveHalf || (atHalf && quotient%2 != 0) {
+			quotient += step
+		}
+	case RoundDown:
+		// Go integer division already truncates toward zero
+	case RoundUp:
+		quotient += step
+	case RoundFloor:
+		if negative {
+			quotient--
+		}
+	case RoundCeiling:
+		if !negative {
+			quotient++
+		}
+	default:
+		return Money{}, Newf("unsupported rounding mode %d", mode).WithCode(ErrCodeInvalidInput)
+	}
+
+	return Money{amount: quotient, currency: m.currency}, nil
+}
+
+// Allocate splits the amount by the given ratios without losing minor
+// units, e.g. EUR 0.05 split by []int{1, 1} yields EUR 0.03 and EUR 0.02.
+// Leftover minor units are distributed one by one starting with the first
+// share. Returns nil if ratios are empty, contain negative values, or sum
+// to zero.
+func (m Money) Allocate(ratios []int) []Money {
+	var total int64
+	for _, ratio := range ratios {
+		if ratio < 0 {
+			return nil
+		}
+		total += int64(ratio)
+	}
+	if len(ratios) == 0 || total == 0 {
+		return nil
+	}
+
+	amount := big.NewInt(m.amount)
+	bigTotal := big.NewInt(total)
+	shares := make([]Money, len(ratios))
+	remainder := m.amount
+
+	for i, ratio := range ratios {
+		share := new(big.Int).Mul(amount, big.NewInt(int64(ratio)))
+		share.Quo(share, bigTotal) // Truncates toward zero
+		shares[i] = Money{amount: share.Int64(), currency: m.currency}
+		remainder -= share.Int64()
+	}
+
+	step := int64(1)
+	if remainder < 0 {
+		step = -1
+	}
+	for i := 0; remainder != 0; i = (i + 1) % len(shares) {
+		if ratios[i] == 0 {
+			continue
+		}
+		shares[i].amount += step
+		remainder -= step
+	}
+
+	return shares
+}
+
+// Equals checks if two amounts have the same value and currency.
+func (m Money) Equals(other Money) bool {
+	return m.amount == other.amount && m.currency == other.currency
+}
+
+// Compare compares two amounts in the same currency and returns -1, 0, or 1.
+func (m Money) Compare(other Money) (int, error) {
+	if err := m.checkCurrency(other); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.amount < other.amount:
+		return -1, nil
+	case m.amount > other.amount:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// GreaterThan checks if the amount is greater than another in the same currency.
+func (m Money) GreaterThan(other Money) (bool, error) {
+	result, err := m.Compare(other)
+	return result > 0, err
+}
+
+// LessThan checks if the amount is less than another in the same currency.
+func (m Money) LessThan(other Money) (bool, error) {
+	result, err := m.Compare(other)
+	return result < 0, err
+}
+
+// String returns the amount formatted as "<CURRENCY> <AMOUNT>", e.g. "EUR 12.34".
+func (m Money) String() string {
+	return m.currency + " " + formatMinorUnits(m.amount, CurrencyExponent(m.currency))
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	parsed, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// checkCurrency returns an error if the currencies of two amounts differ
+func (m Money) checkCurrency(other Money) error {
+	if m.currency != other.currency {
+		return Newf("currency mismatch: %s and %s", m.currency, other.currency).
+			WithCode(ErrCodeInvalidInput).
+			WithContext("currency", m.currency).
+			WithContext("other_currency", other.currency)
+	}
+	return nil
+}
+
+// isValidCurrency checks if a currency code consists of three upper-case letters
+func isValidCurrency(currency string) bool {
+	if len(currency) != 3 {
+		return false
+	}
+	for i := 0; i < len(currency); i++ {
+		if currency[i] < 'A' || currency[i] > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// formatMinorUnits formats minor units as a decimal string
+func formatMinorUnits(amount int64, exponent int) string {
+	digits := strconv.FormatUint(absUint64(amount), 10)
+	if exponent > 0 {
+		if len(digits) <= exponent {
+			digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-exponent] + "." + digits[len(digits)-exponent:]
+	}
+	if amount < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// parseMinorUnits parses a decimal string into minor units
+func parseMinorUnits(s string, exponent int) (int64, error) {
+	negative := strings.HasPrefix(s, "-")
+	unsigned := s
+	if negative || strings.HasPrefix(s, "+") {
+		unsigned = s[1:]
+	}
+
+	whole, fraction, hasFraction := strings.Cut(unsigned, ".")
+	if whole == "" || (hasFraction && fraction == "") {
+		return 0, Newf("malformed decimal '%s'", s)
+	}
+	if len(fraction) > exponent {
+		return 0, Newf("too many decimal places, currency allows %d", exponent)
+	}
+	fraction += strings.Repeat("0", exponent-len(fraction))
+
+	for _, part := range []string{whole, fraction} {
+		for i := 0; i < len(part); i++ {
+			if part[i] < '0' || part[i] > '9' {
+				return 0, Newf("malformed decimal '%s'", s)
+			}
+		}
+	}
+
+	units, err := strconv.ParseUint(whole+fraction, 10, 64)
+	if err != nil || units > math.MaxInt64+1 || (!negative && units > math.MaxInt64) {
+		return 0, Newf("amount '%s' is out of range", s)
+	}
+
+	if negative {
+		return int64(-units), nil
+	}
+	return int64(units), nil
+}
+
+// absUint64 returns the absolute value of n as uint64, handling math.MinInt64
+func absUint64(n int64) uint64 {
+	if n < 0 {
+		return uint64(-(n + 1)) + 1
+	}
+	return uint64(n)
+}
@@ -0,0 +1,365 @@
+// File: money_test.go
+// Title: Tests for Money Type
+// Description: Test suite for the Money type including construction, parsing,
+//              formatting, arithmetic with currency checks, rounding modes,
+//              allocation, comparisons, and JSON round trips with a focus
+//              on negative amounts and zero.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+
+package core
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMoney(t *testing.T) {
+	t.Run("accepts valid currency codes", func(t *testing.T) {
+		money, err := NewMoney(1234, "EUR")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1234), money.Amount())
+		assert.Equal(t, "EUR", money.Currency())
+	})
+
+	t.Run("rejects invalid currency codes", func(t *testing.T) {
+		for _, currency := range []string{"", "eur", "EURO", "E1R"} {
+			_, err := NewMoney(100, currency)
+			require.Error(t, err, currency)
+			assert.True(t, IsInvalidInput(err), currency)
+		}
+	})
+
+	t.Run("MustNewMoney panics on invalid currency", func(t *testing.T) {
+		assert.Panics(t, func() { MustNewMoney(100, "eur") })
+	})
+
+	t.Run("reports sign", func(t *testing.T) {
+		zero := MustNewMoney(0, "EUR")
+		assert.True(t, zero.IsZero())
+		assert.False(t, zero.IsNegative())
+		assert.False(t, zero.IsPositive())
+
+		negative := MustNewMoney(-1, "EUR")
+		assert.True(t, negative.IsNegative())
+		assert.Equal(t, int64(1), negative.Negate().Amount())
+	})
+}
+
+func TestMoney_String(t *testing.T) {
+	tests := []struct {
+		amount   int64
+		currency string
+		expected string
+	}{
+		{1234, "EUR", "EUR 12.34"},
+		{-1234, "EUR", "EUR -12.34"},
+		{0, "EUR", "EUR 0.00"},
+		{5, "EUR", "EUR 0.05"},
+		{-5, "EUR", "EUR -0.05"},
+		{1234, "JPY", "JPY 1234"},
+		{-1234, "JPY", "JPY -1234"},
+		{1234, "KWD", "KWD 1.234"},
+		{math.MinInt64, "EUR", "EUR -92233720368547758.08"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			assert.Equal(t, tt.expected, MustNewMoney(tt.amount, tt.currency).String())
+		})
+	}
+}
+
+func TestParseMoney(t *testing.T) {
+	t.Run("parses valid amounts", func(t *testing.T) {
+		tests := []struct {
+			input    string
+			expected Money
+		}{
+			{"EUR 12.34", MustNewMoney(1234, "EUR")},
+			{"EUR -12.34", MustNewMoney(-1234, "EUR")},
+			{"EUR 12", MustNewMoney(1200, "EUR")},
+			{"EUR 12.3", MustNewMoney(1230, "EUR")},
+			{"EUR 0.00", MustNewMoney(0, "EUR")},
+			{"EUR -0.05", MustNewMoney(-5, "EUR")},
+			{"EUR +1.00", MustNewMoney(100, "EUR")},
+			{"JPY 500", MustNewMoney(500, "JPY")},
+			{"KWD 1.234", MustNewMoney(1234, "KWD")},
+			{"EUR -92233720368547758.08", MustNewMoney(math.MinInt64, "EUR")},
+		}
+
+		for _, tt := range tests {
+			money, err := ParseMoney(tt.input)
+			require.NoError(t, err, tt.input)
+			assert.True(t, tt.expected.Equals(money), tt.input)
+		}
+	})
+
+	t.Run("rejects invalid amounts", func(t *testing.T) {
+		inputs := []string{
+			"",
+			"12.34",
+			"EUR",
+			"eur 12.34",
+			"EUR 12.345",
+			"JPY 1.5",
+			"EUR 1,00",
+			"EUR .5",
+			"EUR 5.",
+			"EUR --5",
+			"EUR 92233720368547758.08",
+		}
+
+		for _, input := range inputs {
+			_, err := ParseMoney(input)
+			require.Error(t, err, input)
+			assert.True(t, IsInvalidInput(err), input)
+		}
+	})
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	eur := func(amount int64) Money { return MustNewMoney(amount, "EUR") }
+
+	t.Run("adds and subtracts", func(t *testing.T) {
+		sum, err := eur(1000).Add(eur(-250))
+		require.NoError(t, err)
+		assert.Equal(t, eur(750), sum)
+
+		diff, err := eur(250).Sub(eur(1000))
+		require.NoError(t, err)
+		assert.Equal(t, eur(-750), diff)
+
+		zero, err := eur(-5).Add(eur(5))
+		require.NoError(t, err)
+		assert.True(t, zero.IsZero())
+	})
+
+	t.Run("returns coded error on currency mismatch", func(t *testing.T) {
+		usd := MustNewMoney(100, "USD")
+
+		_, err := eur(100).Add(usd)
+		require.Error(t, err)
+		assert.True(t, IsInvalidInput(err))
+		assert.Contains(t, err.Error(), "currency mismatch")
+
+		_, err = eur(100).Sub(usd)
+		assert.True(t, IsInvalidInput(err))
+
+		_, err = eur(100).Compare(usd)
+		assert.True(t, IsInvalidInput(err))
+	})
+
+	t.Run("detects overflow", func(t *testing.T) {
+		_, err := eur(math.MaxInt64).Add(eur(1))
+		assert.True(t, IsInvalidInput(err))
+
+		_, err = eur(math.MinInt64).Sub(eur(1))
+		assert.True(t, IsInvalidInput(err))
+
+		_, err = eur(math.MaxInt64).Mul(2)
+		assert.True(t, IsInvalidInput(err))
+	})
+
+	t.Run("multiplies", func(t *testing.T) {
+		product, err := eur(-125).Mul(3)
+		require.NoError(t, err)
+		assert.Equal(t, eur(-375), product)
+
+		product, err = eur(125).Mul(0)
+		require.NoError(t, err)
+		assert.True(t, product.IsZero())
+	})
+}
+
+func TestMoney_Div(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   int64
+		divisor  int64
+		mode     RoundingMode
+		expected int64
+	}{
+		{"half up rounds tie away from zero", 5, 2, RoundHalfUp, 3},
+		{"half up rounds negative tie away from zero", -5, 2, RoundHalfUp, -3},
+		{"half up rounds below half down", 10, 3, RoundHalfUp, 3},
+		{"half even rounds tie to even", 5, 2, RoundHalfEven, 2},
+		{"half even rounds odd tie up", 7, 2, RoundHalfEven, 4},
+		{"half even rounds negative tie to even", -5, 2, RoundHalfEven, -2},
+		{"half even rounds above half", 5, 3, RoundHalfEven, 2},
+		{"down truncates", 19, 10, RoundDown, 1},
+		{"down truncates negative", -19, 10, RoundDown, -1},
+		{"up rounds away from zero", 11, 10, RoundUp, 2},
+		{"up rounds negative away from zero", -11, 10, RoundUp, -2},
+		{"floor rounds negative down", -11, 10, RoundFloor, -2},
+		{"floor rounds positive down", 19, 10, RoundFloor, 1},
+		{"ceiling rounds positive up", 11, 10, RoundCeiling, 2},
+		{"ceiling rounds negative up", -19, 10, RoundCeiling, -1},
+		{"negative divisor", 5, -2, RoundHalfUp, -3},
+		{"exact division", 100, 4, RoundUp, 25},
+		{"zero amount", 0, 7, RoundUp, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := MustNewMoney(tt.amount, "EUR").Div(tt.divisor, tt.mode)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result.Amount())
+			assert.Equal(t, "EUR", result.Currency())
+		})
+	}
+
+	t.Run("rejects division by zero", func(t *testing.T) {
+		_, err := MustNewMoney(100, "EUR").Div(0, RoundHalfUp)
+		require.Error(t, err)
+		assert.True(t, IsInvalidInput(err))
+	})
+
+	t.Run("rejects overflow", func(t *testing.T) {
+		_, err := MustNewMoney(math.MinInt64, "EUR").Div(-1, RoundHalfUp)
+		assert.True(t, IsInvalidInput(err))
+	})
+
+	t.Run("rejects unknown rounding mode", func(t *testing.T) {
+		_, err := MustNewMoney(5, "EUR").Div(2, RoundingMode(99))
+		assert.True(t, IsInvalidInput(err))
+	})
+}
+
+func TestMoney_Allocate(t *testing.T) {
+	amounts := func(shares []Money) []int64 {
+		result := make([]int64, len(shares))
+		for i, share := range shares {
+			result[i] = share.Amount()
+		}
+		return result
+	}
+
+	t.Run("distributes remainder to first shares", func(t *testing.T) {
+		shares := MustNewMoney(5, "EUR").Allocate([]int{1, 1})
+		assert.Equal(t, []int64{3, 2}, amounts(shares))
+
+		shares = MustNewMoney(100, "EUR").Allocate([]int{1, 1, 1})
+		assert.Equal(t, []int64{34, 33, 33}, amounts(shares))
+	})
+
+	t.Run("respects ratios", func(t *testing.T) {
+		shares := MustNewMoney(1000, "EUR").Allocate([]int{70, 20, 10})
+		assert.Equal(t, []int64{700, 200, 100}, amounts(shares))
+	})
+
+	t.Run("allocates negative amounts", func(t *testing.T) {
+		shares := MustNewMoney(-100, "EUR").Allocate([]int{1, 1, 1})
+		assert.Equal(t, []int64{-34, -33, -33}, amounts(shares))
+	})
+
+	t.Run("allocates zero", func(t *testing.T) {
+		shares := MustNewMoney(0, "EUR").Allocate([]int{1, 2})
+		assert.Equal(t, []int64{0, 0}, amounts(shares))
+	})
+
+	t.Run("skips zero ratios when distributing remainder", func(t *testing.T) {
+		shares := MustNewMoney(5, "EUR").Allocate([]int{0, 1, 1})
+		assert.Equal(t, []int64{0, 3, 2}, amounts(shares))
+	})
+
+	t.Run("preserves total and currency", func(t *testing.T) {
+		total := MustNewMoney(math.MaxInt64, "JPY")
+		shares := total.Allocate([]int{3, 7, 11})
+
+		sum := MustNewMoney(0, "JPY")
+		for _, share := range shares {
+			assert.Equal(t, "JPY", share.Currency())
+			var err error
+			sum, err = sum.Add(share)
+			require.NoError(t, err)
+		}
+		assert.True(t, total.Equals(sum))
+	})
+
+	t.Run("returns nil for invalid ratios", func(t *testing.T) {
+		money := MustNewMoney(100, "EUR")
+		assert.Nil(t, money.Allocate(nil))
+		assert.Nil(t, money.Allocate([]int{0, 0}))
+		assert.Nil(t, money.Allocate([]int{1, -1}))
+	})
+}
+
+func TestMoney_Comparison(t *testing.T) {
+	small := MustNewMoney(-100, "EUR")
+	large := MustNewMoney(100, "EUR")
+
+	result, err := small.Compare(large)
+	require.NoError(t, err)
+	assert.Equal(t, -1, result)
+
+	result, err = large.Compare(small)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result)
+
+	result, err = small.Compare(small)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result)
+
+	less, err := small.LessThan(large)
+	require.NoError(t, err)
+	assert.True(t, less)
+
+	greater, err := small.GreaterThan(large)
+	require.NoError(t, err)
+	assert.False(t, greater)
+
+	assert.True(t, small.Equals(MustNewMoney(-100, "EUR")))
+	assert.False(t, small.Equals(MustNewMoney(-100, "USD")))
+}
+
+func TestMoney_JSON(t *testing.T) {
+	type invoice struct {
+		Total Money `json:"total"`
+	}
+
+	t.Run("round trips through JSON", func(t *testing.T) {
+		for _, money := range []Money{
+			MustNewMoney(1234, "EUR"),
+			MustNewMoney(-1234, "EUR"),
+			MustNewMoney(0, "JPY"),
+		} {
+			data, err := json.Marshal(invoice{Total: money})
+			require.NoError(t, err)
+
+			var decoded invoice
+			require.NoError(t, json.Unmarshal(data, &decoded))
+			assert.True(t, money.Equals(decoded.Total))
+		}
+	})
+
+	t.Run("marshals as string", func(t *testing.T) {
+		data, err := json.Marshal(invoice{Total: MustNewMoney(-1234, "EUR")})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"total":"EUR -12.34"}`, string(data))
+	})
+
+	t.Run("rejects invalid JSON values", func(t *testing.T) {
+		var decoded invoice
+		assert.Error(t, json.Unmarshal([]byte(`{"total":1234}`), &decoded))
+		assert.Error(t, json.Unmarshal([]byte(`{"total":"EUR 1.234"}`), &decoded))
+	})
+}
+
+func BenchmarkMoney_Add(b *testing.B) {
+	a := MustNewMoney(1234, "EUR")
+	c := MustNewMoney(5678, "EUR")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = a.Add(c)
+	}
+}
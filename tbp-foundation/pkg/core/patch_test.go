@@ -0,0 +1,155 @@
+// File: patch_test.go
+// Title: Tests for Partial Entity Updates
+// Description: Tests applying sparse JSON patches, protection of immutable
+//              fields, version handling, and rejection of unknown fields
+//              and type mismatches.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+
+package core
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyPatch(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	previous := SetClock(NewFakeClock(now))
+	defer SetClock(previous)
+
+	created := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+	newOrder := func() *orderEntity {
+		return &orderEntity{
+			BaseEntity: BaseEntity{ID: ID("order-1"), Version: 3, CreatedAt: created, UpdatedAt: created},
+			Customer:   "acme",
+			Address:    &orderAddress{Street: "Main St 1", City: "Berlin"},
+			Lines:      []string{"widget"},
+			Labels:     map[string]string{"priority": "high"},
+			Secret:     "s3cr3t",
+			Untagged:   7,
+		}
+	}
+
+	t.Run("applies present fields only", func(t *testing.T) {
+		original := newOrder()
+
+		patched, err := ApplyPatch(original, json.RawMessage(`{"customer": "globex", "address": {"city": "Hamburg"}}`))
+		require.NoError(t, err)
+
+		assert.Equal(t, "globex", patched.Customer)
+		assert.Equal(t, &orderAddress{Street: "Main St 1", City: "Hamburg"}, patched.Address)
+		assert.Equal(t, []string{"widget"}, patched.Lines)
+		assert.Equal(t, "s3cr3t", patched.Secret)
+		assert.Equal(t, 7, patched.Untagged)
+		assert.Equal(t, ID("order-1"), patched.ID)
+		assert.Equal(t, int64(4), patched.Version)
+		assert.Equal(t, now, patched.UpdatedAt)
+
+		assert.Equal(t, newOrder(), original, "original is not modified")
+		assert.Equal(t, map[string]FieldChange{
+			"customer":   {Old: "acme", New: "globex"},
+			"address":    {Old: original.Address, New: patched.Address},
+			"version":    {Old: int64(3), New: int64(4)},
+			"updated_at": {Old: created, New: now},
+		}, DiffEntities(original, patched))
+	})
+
+	t.Run("clears fields set to null", func(t *testing.T) {
+		patched, err := ApplyPatch(newOrder(), json.RawMessage(`{"address": null, "labels": null}`))
+		require.NoError(t, err)
+
+		assert.Nil(t, patched.Address)
+		assert.Nil(t, patched.Labels)
+		assert.Equal(t, "acme", patched.Customer)
+	})
+
+	t.Run("accepts unchanged immutable fields", func(t *testing.T) {
+		patched, err := ApplyPatch(newOrder(), json.RawMessage(
+			`{"id": "order-1", "created_at": "2024-01-01T09:00:00+01:00", "version": 3, "customer": "globex"}`))
+		require.NoError(t, err)
+
+		assert.Equal(t, "globex", patched.Customer)
+		assert.Equal(t, int64(4), patched.Version)
+	})
+
+	t.Run("rejects changes of ID and CreatedAt", func(t *testing.T) {
+		_, err := ApplyPatch(newOrder(), json.RawMessage(`{"id": "order-2", "customer": "globex"}`))
+		require.Error(t, err)
+		assert.True(t, IsInvalidInput(err))
+
+		validationErr, ok := IsValidationError(err)
+		require.True(t, ok)
+		assert.Equal(t, []FieldError{{Field: "id", Message: "field 'id' cannot be changed", Rule: "immutable"}},
+			validationErr.Fields)
+
+		_, err = ApplyPatch(newOrder(), json.RawMessage(`{"id": "", "created_at": "2025-01-01T00:00:00Z"}`))
+		validationErr, ok = IsValidationError(err)
+		require.True(t, ok)
+		assert.Len(t, validationErr.Fields, 2)
+		assert.Len(t, validationErr.FieldErrors("created_at"), 1)
+	})
+
+	t.Run("rejects stale versions", func(t *testing.T) {
+		_, err := ApplyPatch(newOrder(), json.RawMessage(`{"version": 2, "customer": "globex"}`))
+		require.Error(t, err)
+		assert.True(t, IsConflict(err))
+		assert.True(t, IsVersionConflict(err))
+	})
+
+	t.Run("rejects type mismatches", func(t *testing.T) {
+		_, err := ApplyPatch(newOrder(), json.RawMessage(`{"customer": 42}`))
+		require.Error(t, err)
+		assert.True(t, IsInvalidInput(err))
+
+		validationErr, ok := IsValidationError(err)
+		require.True(t, ok)
+		require.Len(t, validationErr.Fields, 1)
+		assert.Equal(t, "customer", validationErr.Fields[0].Field)
+		assert.Equal(t, "type", validationErr.Fields[0].Rule)
+		assert.Contains(t, validationErr.Fields[0].Message, "must be of type string, got number")
+
+		_, err = ApplyPatch(newOrder(), json.RawMessage(`{"address": {"city": true}}`))
+		validationErr, ok = IsValidationError(err)
+		require.True(t, ok)
+		assert.Equal(t, "address.city", validationErr.Fields[0].Field)
+	})
+
+	t.Run("rejects unknown and hidden fields", func(t *testing.T) {
+		for _, patch := range []string{`{"discount": 10}`, `{"Secret": "changed"}`} {
+			_, err := ApplyPatch(newOrder(), json.RawMessage(patch))
+			require.Error(t, err, patch)
+
+			validationErr, ok := IsValidationError(err)
+			require.True(t, ok, patch)
+			assert.Equal(t, "unknown", validationErr.Fields[0].Rule, patch)
+		}
+	})
+
+	t.Run("rejects invalid patches", func(t *testing.T) {
+		for _, patch := range []string{``, `null`, `[]`, `"customer"`, `{"customer": `, `{} {}`} {
+			_, err := ApplyPatch(newOrder(), json.RawMessage(patch))
+			require.Error(t, err, patch)
+			assert.True(t, IsInvalidInput(err), patch)
+		}
+
+		_, err := ApplyPatch[*orderEntity](nil, json.RawMessage(`{}`))
+		assert.True(t, IsInvalidInput(err))
+	})
+
+	t.Run("bumps version for empty patch", func(t *testing.T) {
+		patched, err := ApplyPatch(&TestEntity{BaseEntity: BaseEntity{ID: ID("e1")}, Name: "name"}, json.RawMessage(`{}`))
+		require.NoError(t, err)
+		assert.Equal(t, "name", patched.Name)
+		assert.Equal(t, int64(1), patched.Version)
+	})
+}
@@ -0,0 +1,100 @@
+// File: recover.go
+// Title: Panic Recovery Helpers for TBP
+// Description: Converts recovered panic values into coded TBP errors with an
+//              optional stack trace and provides a goroutine launcher that
+//              reports panics and returned errors through a callback.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation with RecoverToError and SafeGo
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync/atomic"
+)
+
+// Context keys set on errors created by RecoverToError
+const (
+	// PanicValueKey holds the formatted panic value
+	PanicValueKey = "panic"
+
+	// PanicStackKey holds the stack trace captured at recovery
+	PanicStackKey = "stack"
+)
+
+// stackCapture controls whether RecoverToError attaches stack traces
+var stackCapture atomic.Bool
+
+func init() {
+	stackCapture.Store(true)
+}
+
+// SetStackCapture enables or disables stack trace capture for recovered
+// panics. Capture is enabled by default; disabling it avoids the cost of
+// collecting stacks in hot paths.
+func SetStackCapture(enabled bool) {
+	stackCapture.Store(enabled)
+}
+
+// StackCaptureEnabled reports whether stack traces are captured for
+// recovered panics.
+func StackCaptureEnabled() bool {
+	return stackCapture.Load()
+}
+
+// RecoverToError converts a value returned by recover() into an
+// ErrCodeInternal error. The panic value is stored in the error context
+// under PanicValueKey and, if stack capture is enabled, the stack trace
+// under PanicStackKey. Panics with an error value keep it as Cause.
+// Returns nil if recovered is nil.
+//
+// Must be called from the deferred function that recovered the panic for
+// the stack trace to include the panicking frames.
+func RecoverToError(recovered interface{}) *Error {
+	if recovered == nil {
+		return nil
+	}
+
+	err := &Error{
+		Message: fmt.Sprintf("panic recovered: %v", recovered),
+		Code:    ErrCodeInternal,
+		Context: map[string]interface{}{
+			PanicValueKey: fmt.Sprint(recovered),
+		},
+	}
+
+	if cause, ok := recovered.(error); ok {
+		err.Message = "panic recovered"
+		err.Cause = cause
+	}
+
+	if StackCaptureEnabled() {
+		err.Context[PanicStackKey] = string(debug.Stack())
+	}
+
+	return err
+}
+
+// SafeGo runs fn in a new goroutine. Panics are recovered and converted
+// with RecoverToError. A non-nil error returned by fn or a recovered panic
+// is passed to onErr, which may be nil to discard errors.
+func SafeGo(ctx context.Context, fn func(ctx context.Context) error, onErr func(error)) {
+	go func() {
+		defer func() {
+			if recovered := recover(); recovered != nil && onErr != nil {
+				onErr(RecoverToError(recovered))
+			}
+		}()
+
+		if err := fn(ctx); err != nil && onErr != nil {
+			onErr(err)
+		}
+	}()
+}
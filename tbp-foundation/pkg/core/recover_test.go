@@ -0,0 +1,135 @@
+// File: recover_test.go
+// Title: Tests for Panic Recovery Helpers
+// Description: Test suite for converting recovered panics into coded errors,
+//              stack capture toggling, and SafeGo error reporting.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func recoverFrom(fn func()) (err *Error) {
+	defer func() {
+		err = RecoverToError(recover())
+	}()
+	fn()
+	return nil
+}
+
+func TestRecoverToError(t *testing.T) {
+	t.Run("returns nil without panic", func(t *testing.T) {
+		assert.Nil(t, RecoverToError(nil))
+		assert.Nil(t, recoverFrom(func() {}))
+	})
+
+	t.Run("converts panic value into internal error", func(t *testing.T) {
+		err := recoverFrom(func() { panic("boom") })
+		require.NotNil(t, err)
+
+		assert.True(t, IsInternal(err))
+		assert.Equal(t, "panic recovered: boom", err.Error())
+
+		value, ok := err.GetContext(PanicValueKey)
+		require.True(t, ok)
+		assert.Equal(t, "boom", value)
+	})
+
+	t.Run("keeps error panics as cause", func(t *testing.T) {
+		cause := New("broken").WithCode(ErrCodeConflict)
+		err := recoverFrom(func() { panic(cause) })
+		require.NotNil(t, err)
+
+		assert.Equal(t, ErrCodeInternal, err.Code)
+		assert.True(t, errors.Is(err, cause))
+		assert.Equal(t, "panic recovered: broken", err.Error())
+	})
+
+	t.Run("attaches stack when capture is enabled", func(t *testing.T) {
+		require.True(t, StackCaptureEnabled())
+
+		err := recoverFrom(func() { panic("boom") })
+		stack, ok := err.GetContext(PanicStackKey)
+		require.True(t, ok)
+		assert.Contains(t, stack, "recoverFrom")
+	})
+
+	t.Run("omits stack when capture is disabled", func(t *testing.T) {
+		SetStackCapture(false)
+		defer SetStackCapture(true)
+
+		err := recoverFrom(func() { panic("boom") })
+		_, ok := err.GetContext(PanicStackKey)
+		assert.False(t, ok)
+	})
+}
+
+func TestSafeGo(t *testing.T) {
+	run := func(fn func(ctx context.Context) error) error {
+		errs := make(chan error, 1)
+		SafeGo(context.Background(), fn, func(err error) { errs <- err })
+
+		select {
+		case err := <-errs:
+			return err
+		case <-time.After(100 * time.Millisecond):
+			return nil
+		}
+	}
+
+	t.Run("reports recovered panics", func(t *testing.T) {
+		err := run(func(ctx context.Context) error { panic("boom") })
+		require.Error(t, err)
+		assert.True(t, IsInternal(err))
+	})
+
+	t.Run("reports returned errors", func(t *testing.T) {
+		expected := New("failed").WithCode(ErrCodeTimeout)
+		err := run(func(ctx context.Context) error { return expected })
+		assert.Equal(t, expected, err)
+	})
+
+	t.Run("does not report success", func(t *testing.T) {
+		assert.NoError(t, run(func(ctx context.Context) error { return nil }))
+	})
+
+	t.Run("passes context to function", func(t *testing.T) {
+		ctx := WithRequestID(context.Background(), "req-1")
+		done := make(chan string, 1)
+
+		SafeGo(ctx, func(ctx context.Context) error {
+			requestID, _ := GetRequestID(ctx)
+			done <- requestID
+			return nil
+		}, nil)
+
+		select {
+		case requestID := <-done:
+			assert.Equal(t, "req-1", requestID)
+		case <-time.After(time.Second):
+			t.Fatal("function was not run")
+		}
+	})
+
+	t.Run("tolerates nil error handler", func(t *testing.T) {
+		done := make(chan struct{})
+		SafeGo(context.Background(), func(ctx context.Context) error {
+			defer close(done)
+			panic("boom")
+		}, nil)
+		<-done
+	})
+}
@@ -0,0 +1,114 @@
+// File: retry.go
+// Title: Retry Helper for TBP
+// Description: Provides a central retry loop that retries retryable errors
+//              with exponential backoff, honors retry-after hints attached to
+//              errors, and stops when the context is cancelled.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.1
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial Retry implementation with exponential backoff
+// - 2026-10-16 v0.1.1: Map only deadline expiry to ErrCodeTimeout; cancellation uses ErrCodeCanceled
+
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// RetryPolicy configures the retry behavior of Retry.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of calls including the first (0 = unlimited)
+	MaxAttempts int `json:"max_attempts"`
+
+	// InitialBackoff is the wait time before the first retry
+	InitialBackoff time.Duration `json:"initial_backoff"`
+
+	// MaxBackoff caps the computed backoff (0 = no cap); retry-after hints are not capped
+	MaxBackoff time.Duration `json:"max_backoff"`
+
+	// Multiplier increases the backoff after each retry (values below 1 are treated as 1)
+	Multiplier float64 `json:"multiplier"`
+}
+
+// DefaultRetryPolicy returns a policy with 3 attempts and exponential
+// backoff starting at 100ms, capped at 5s.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+	}
+}
+
+// Backoff returns the policy-driven wait time before the given retry
+// (1 for the first retry).
+func (p RetryPolicy) Backoff(retry int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	backoff := float64(p.InitialBackoff)
+	for i := 1; i < retry; i++ {
+		backoff *= multiplier
+		if p.MaxBackoff > 0 && backoff >= float64(p.MaxBackoff) {
+			return p.MaxBackoff
+		}
+	}
+
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		return p.MaxBackoff
+	}
+	return time.Duration(backoff)
+}
+
+// Retry calls fn until it succeeds, returns an error that is not
+// retryable according to IsRetryable, or the policy's MaxAttempts is
+// reached. Between attempts it waits for the error's retry-after hint if
+// present and the policy's exponential backoff otherwise.
+//
+// Non-retryable errors are returned unchanged. When attempts are exhausted
+// the last error is wrapped preserving its code. If ctx ends while waiting,
+// the context error is returned wrapped with ErrCodeTimeout when its
+// deadline was exceeded and with the non-retryable ErrCodeCanceled when it
+// was canceled, so outer retry loops do not retry an abandoned operation.
+func Retry(ctx context.Context, policy RetryPolicy, fn func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		if !IsRetryable(err) {
+			return err
+		}
+		if policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts {
+			return WrapPreservingCode(err, fmt.Sprintf("giving up after %d attempts", attempt))
+		}
+
+		wait, hinted := GetRetryAfter(err)
+		if !hinted {
+			wait = policy.Backoff(attempt)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			code := ErrCodeCanceled
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				code = ErrCodeTimeout
+			}
+			return WrapWithCode(ctx.Err(), code,
+				fmt.Sprintf("retry aborted after %d attempts", attempt)).
+				WithContext("last_error", err.Error())
+		case <-timer.C:
+		}
+	}
+}
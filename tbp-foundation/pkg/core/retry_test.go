@@ -0,0 +1,178 @@
+// File: retry_test.go
+// Title: Tests for Retry Helper
+// Description: Test suite for Retry covering success, non-retryable errors,
+//              attempt limits, hint-driven and policy-driven backoff, and
+//              context cancellation.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.1
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+// - 2026-10-16 v0.1.1: Added cancellation classification test
+
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryAfter(t *testing.T) {
+	t.Run("attaches and finds hint through chain", func(t *testing.T) {
+		err := New("rate limited").WithCode(ErrCodeUnavailable).WithRetryAfter(2 * time.Second)
+		wrapped := Wrap(err, "call failed")
+
+		d, ok := GetRetryAfter(wrapped)
+		require.True(t, ok)
+		assert.Equal(t, 2*time.Second, d)
+		assert.True(t, IsUnavailable(wrapped))
+	})
+
+	t.Run("reports missing hint", func(t *testing.T) {
+		_, ok := GetRetryAfter(New("plain"))
+		assert.False(t, ok)
+
+		_, ok = GetRetryAfter(errors.New("standard"))
+		assert.False(t, ok)
+
+		_, ok = GetRetryAfter(nil)
+		assert.False(t, ok)
+	})
+
+	t.Run("hint makes error retryable", func(t *testing.T) {
+		err := New("quota exceeded").WithCode("RATE_LIMITED")
+		assert.False(t, IsRetryable(err))
+		assert.True(t, IsRetryable(err.WithRetryAfter(time.Second)))
+	})
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 2}
+
+	assert.Equal(t, 100*time.Millisecond, policy.Backoff(1))
+	assert.Equal(t, 200*time.Millisecond, policy.Backoff(2))
+	assert.Equal(t, 400*time.Millisecond, policy.Backoff(3))
+	assert.Equal(t, time.Second, policy.Backoff(5))
+	assert.Equal(t, time.Second, policy.Backoff(1000))
+
+	constant := RetryPolicy{InitialBackoff: 50 * time.Millisecond}
+	assert.Equal(t, 50*time.Millisecond, constant.Backoff(4))
+}
+
+func TestRetry(t *testing.T) {
+	fastPolicy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, Multiplier: 2}
+
+	t.Run("returns nil on success", func(t *testing.T) {
+		calls := 0
+		err := Retry(context.Background(), fastPolicy, func() error {
+			calls++
+			if calls < 2 {
+				return ErrUnavailable
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("does not retry non-retryable errors", func(t *testing.T) {
+		calls := 0
+		err := Retry(context.Background(), fastPolicy, func() error {
+			calls++
+			return ErrInvalidInput
+		})
+		assert.Equal(t, ErrInvalidInput, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("gives up after max attempts preserving code", func(t *testing.T) {
+		calls := 0
+		err := Retry(context.Background(), fastPolicy, func() error {
+			calls++
+			return ErrTimeout
+		})
+		require.Error(t, err)
+		assert.Equal(t, 3, calls)
+		assert.True(t, IsTimeout(err))
+		assert.Contains(t, err.Error(), "giving up after 3 attempts")
+	})
+
+	t.Run("policy-driven backoff grows exponentially", func(t *testing.T) {
+		policy := RetryPolicy{MaxAttempts: 4, InitialBackoff: 10 * time.Millisecond, Multiplier: 2}
+
+		var attempts []time.Time
+		_ = Retry(context.Background(), policy, func() error {
+			attempts = append(attempts, time.Now())
+			return ErrUnavailable
+		})
+
+		require.Len(t, attempts, 4)
+		assert.GreaterOrEqual(t, attempts[1].Sub(attempts[0]), 10*time.Millisecond)
+		assert.GreaterOrEqual(t, attempts[2].Sub(attempts[1]), 20*time.Millisecond)
+		assert.GreaterOrEqual(t, attempts[3].Sub(attempts[2]), 40*time.Millisecond)
+	})
+
+	t.Run("hint-driven backoff overrides policy", func(t *testing.T) {
+		policy := RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Hour}
+
+		start := time.Now()
+		calls := 0
+		err := Retry(context.Background(), policy, func() error {
+			calls++
+			if calls == 1 {
+				return ErrUnavailable.WithRetryAfter(20 * time.Millisecond)
+			}
+			return nil
+		})
+		require.NoError(t, err)
+
+		elapsed := time.Since(start)
+		assert.GreaterOrEqual(t, elapsed, 20*time.Millisecond)
+		assert.Less(t, elapsed, time.Second)
+	})
+
+	t.Run("stops on context cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		policy := RetryPolicy{InitialBackoff: time.Hour}
+		err := Retry(ctx, policy, func() error { return ErrUnavailable })
+
+		require.Error(t, err)
+		assert.True(t, IsTimeout(err))
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		lastErr, ok := err.(*Error).GetContext("last_error")
+		require.True(t, ok)
+		assert.Equal(t, "service unavailable", lastErr)
+	})
+
+	t.Run("does not classify cancellation as retryable", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(20*time.Millisecond, cancel)
+		defer cancel()
+
+		policy := RetryPolicy{InitialBackoff: time.Hour}
+		err := Retry(ctx, policy, func() error { return ErrUnavailable })
+
+		require.Error(t, err)
+		assert.True(t, IsCode(err, ErrCodeCanceled))
+		assert.False(t, IsTimeout(err))
+		assert.False(t, IsRetryable(err))
+		assert.True(t, errors.Is(err, context.Canceled))
+
+		calls := 0
+		outer := Retry(context.Background(), RetryPolicy{MaxAttempts: 3}, func() error {
+			calls++
+			return err
+		})
+		assert.Equal(t, 1, calls, "outer retry loop does not retry a canceled operation")
+		assert.True(t, IsCode(outer, ErrCodeCanceled))
+	})
+}
@@ -0,0 +1,162 @@
+// File: tenant.go
+// Title: Tenant Isolation for TBP Repositories
+// Description: Provides helpers that take the tenant from the request
+//              context and enforce it in data access: RequireTenant,
+//              TenantFilter for ListOptions, and a Repository decorator
+//              that scopes queries and rejects cross-tenant entities.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.1
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial tenant scoping helpers and repository decorator
+// - 2026-10-16 v0.1.1: GetByID reports hidden entities with ErrNotFound
+
+package core
+
+import "context"
+
+// TenantFilterField is the ListOptions filter key used to scope queries
+// to a tenant.
+const TenantFilterField = "tenant_id"
+
+// TenantOwned is implemented by entities that belong to a tenant.
+// The tenant-scoped repository uses it to verify that entities read or
+// written belong to the tenant of the context.
+type TenantOwned interface {
+	// GetTenantID returns the ID of the tenant owning the entity
+	GetTenantID() string
+}
+
+// RequireTenant returns the tenant ID from the context. Returns an
+// ErrCodeForbidden error matching ErrForbidden if no tenant is set.
+func RequireTenant(ctx context.Context) (string, error) {
+	tenantID, ok := GetTenantID(ctx)
+	if !ok || tenantID == "" {
+		return "", WrapWithCode(ErrForbidden, ErrCodeForbidden, "tenant required but not present in context")
+	}
+	return tenantID, nil
+}
+
+// TenantFilter returns a copy of opts whose Filters contain the tenant ID
+// from the context under TenantFilterField. The Filters map of opts is not
+// modified. Returns an ErrCodeForbidden error if the context has no tenant
+// or opts already filters on a different tenant.
+func TenantFilter(ctx context.Context, opts ListOptions) (ListOptions, error) {
+	tenantID, err := RequireTenant(ctx)
+	if err != nil {
+		return opts, err
+	}
+
+	if existing, ok := opts.Filters[TenantFilterField]; ok && existing != tenantID {
+		return opts, NewWithCodef(ErrCodeForbidden, "filter on tenant %v does not match tenant of context", existing)
+	}
+
+	filters := make(map[string]interface{}, len(opts.Filters)+1)
+	for field, value := range opts.Filters {
+		filters[field] = value
+	}
+	filters[TenantFilterField] = tenantID
+	opts.Filters = filters
+
+	return opts, nil
+}
+
+// tenantScopedRepository enforces the tenant of the context on an inner repository
+type tenantScopedRepository[T Entity] struct {
+	inner Repository[T]
+}
+
+// NewTenantScopedRepository wraps inner so that every call requires a
+// tenant in the context. List and Count are scoped with TenantFilter, so
+// inner must honor the TenantFilterField filter. For entities implementing
+// TenantOwned, GetByID reports entities of other tenants as not found
+// (without revealing that they exist), and Create, Update, and Delete
+// reject entities of other tenants with an ErrCodeForbidden error.
+func NewTenantScopedRepository[T Entity](inner Repository[T]) Repository[T] {
+	return &tenantScopedRepository[T]{inner: inner}
+}
+
+// Create persists a new entity after checking that it belongs to the tenant
+func (r *tenantScopedRepository[T]) Create(ctx context.Context, entity T) error {
+	tenantID, err := RequireTenant(ctx)
+	if err != nil {
+		return err
+	}
+	if err := checkEntityTenant(entity, tenantID); err != nil {
+		return err
+	}
+	return r.inner.Create(ctx, entity)
+}
+
+// GetByID retrieves an entity, treating entities of other tenants as not found
+func (r *tenantScopedRepository[T]) GetByID(ctx context.Context, id ID) (T, error) {
+	var zero T
+
+	tenantID, err := RequireTenant(ctx)
+	if err != nil {
+		return zero, err
+	}
+
+	entity, err := r.inner.GetByID(ctx, id)
+	if err != nil {
+		return zero, err
+	}
+	if owned, ok := any(entity).(TenantOwned); ok && owned.GetTenantID() != tenantID {
+		return zero, WrapWithCodef(ErrNotFound, ErrCodeNotFound, "entity %s not found", id)
+	}
+	return entity, nil
+}
+
+// Update modifies an entity that belongs to the tenant, both as stored and
+// as given, so an entity cannot be moved to or from another tenant
+func (r *tenantScopedRepository[T]) Update(ctx context.Context, entity T) error {
+	tenantID, err := RequireTenant(ctx)
+	if err != nil {
+		return err
+	}
+	if err := checkEntityTenant(entity, tenantID); err != nil {
+		return err
+	}
+	if _, err := r.GetByID(ctx, entity.GetID()); err != nil {
+		return err
+	}
+	return r.inner.Update(ctx, entity)
+}
+
+// Delete removes an entity that belongs to the tenant
+func (r *tenantScopedRepository[T]) Delete(ctx context.Context, id ID) error {
+	if _, err := r.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return r.inner.Delete(ctx, id)
+}
+
+// List retrieves the tenant's entities
+func (r *tenantScopedRepository[T]) List(ctx context.Context, opts ListOptions) ([]T, error) {
+	scoped, err := TenantFilter(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return r.inner.List(ctx, scoped)
+}
+
+// Count returns the number of the tenant's entities matching the criteria
+func (r *tenantScopedRepository[T]) Count(ctx context.Context, opts ListOptions) (int64, error) {
+	scoped, err := TenantFilter(ctx, opts)
+	if err != nil {
+		return 0, err
+	}
+	return r.inner.Count(ctx, scoped)
+}
+
+// checkEntityTenant rejects TenantOwned entities of another tenant
+func checkEntityTenant(entity interface{}, tenantID string) error {
+	owned, ok := entity.(TenantOwned)
+	if !ok || owned.GetTenantID() == tenantID {
+		return nil
+	}
+	return NewWithCodef(ErrCodeForbidden, "entity belongs to tenant %s, not to tenant %s of context",
+		owned.GetTenantID(), tenantID)
+}
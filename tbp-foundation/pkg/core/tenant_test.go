@@ -0,0 +1,189 @@
+// File: tenant_test.go
+// Title: Tests for Tenant Isolation Helpers
+// Description: Tests RequireTenant, TenantFilter, and the tenant-scoped
+//              repository decorator, including rejection of cross-tenant
+//              entities.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tenantEntity is a test entity owned by a tenant
+type tenantEntity struct {
+	BaseEntity
+	TenantID string `json:"tenant_id"`
+}
+
+func (e *tenantEntity) GetTenantID() string {
+	return e.TenantID
+}
+
+// filteringRepository records the options passed to List and Count
+type filteringRepository struct {
+	*mockRepository[*tenantEntity]
+	lastOpts ListOptions
+}
+
+func (r *filteringRepository) List(ctx context.Context, opts ListOptions) ([]*tenantEntity, error) {
+	r.lastOpts = opts
+	return r.mockRepository.List(ctx, opts)
+}
+
+func (r *filteringRepository) Count(ctx context.Context, opts ListOptions) (int64, error) {
+	r.lastOpts = opts
+	return r.mockRepository.Count(ctx, opts)
+}
+
+func TestRequireTenant(t *testing.T) {
+	t.Run("returns tenant from context", func(t *testing.T) {
+		tenantID, err := RequireTenant(WithTenantID(context.Background(), "acme"))
+		require.NoError(t, err)
+		assert.Equal(t, "acme", tenantID)
+	})
+
+	t.Run("rejects missing tenant", func(t *testing.T) {
+		for _, ctx := range []context.Context{context.Background(), WithTenantID(context.Background(), "")} {
+			_, err := RequireTenant(ctx)
+			require.Error(t, err)
+			assert.True(t, IsForbidden(err))
+			assert.True(t, errors.Is(err, ErrForbidden))
+		}
+	})
+}
+
+func TestTenantFilter(t *testing.T) {
+	ctx := WithTenantID(context.Background(), "acme")
+
+	t.Run("injects tenant without modifying input", func(t *testing.T) {
+		opts := NewListOptions().WithFilter("status", "active")
+
+		scoped, err := TenantFilter(ctx, opts)
+		require.NoError(t, err)
+		assert.Equal(t, "acme", scoped.Filters[TenantFilterField])
+		assert.Equal(t, "active", scoped.Filters["status"])
+		assert.NotContains(t, opts.Filters, TenantFilterField)
+	})
+
+	t.Run("handles nil filters", func(t *testing.T) {
+		scoped, err := TenantFilter(ctx, ListOptions{Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{TenantFilterField: "acme"}, scoped.Filters)
+	})
+
+	t.Run("rejects filter on other tenant", func(t *testing.T) {
+		_, err := TenantFilter(ctx, NewListOptions().WithFilter(TenantFilterField, "globex"))
+		require.Error(t, err)
+		assert.True(t, IsForbidden(err))
+
+		scoped, err := TenantFilter(ctx, NewListOptions().WithFilter(TenantFilterField, "acme"))
+		require.NoError(t, err)
+		assert.Equal(t, "acme", scoped.Filters[TenantFilterField])
+	})
+
+	t.Run("requires tenant", func(t *testing.T) {
+		_, err := TenantFilter(context.Background(), NewListOptions())
+		assert.True(t, IsForbidden(err))
+	})
+}
+
+func TestTenantScopedRepository(t *testing.T) {
+	acme := WithTenantID(context.Background(), "acme")
+
+	newRepo := func() (*filteringRepository, Repository[*tenantEntity]) {
+		inner := &filteringRepository{mockRepository: &mockRepository[*tenantEntity]{}}
+		inner.store(&tenantEntity{BaseEntity: BaseEntity{ID: "a1"}, TenantID: "acme"})
+		inner.store(&tenantEntity{BaseEntity: BaseEntity{ID: "g1"}, TenantID: "globex"})
+		return inner, NewTenantScopedRepository[*tenantEntity](inner)
+	}
+
+	t.Run("returns own entities", func(t *testing.T) {
+		_, repo := newRepo()
+		entity, err := repo.GetByID(acme, "a1")
+		require.NoError(t, err)
+		assert.Equal(t, "acme", entity.TenantID)
+	})
+
+	t.Run("hides entities of other tenants", func(t *testing.T) {
+		_, repo := newRepo()
+		entity, err := repo.GetByID(acme, "g1")
+		require.Error(t, err)
+		assert.True(t, IsNotFound(err))
+		assert.Nil(t, entity)
+	})
+
+	t.Run("scopes list and count", func(t *testing.T) {
+		inner, repo := newRepo()
+
+		_, err := repo.List(acme, NewListOptions())
+		require.NoError(t, err)
+		assert.Equal(t, "acme", inner.lastOpts.Filters[TenantFilterField])
+
+		_, err = repo.Count(acme, ListOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "acme", inner.lastOpts.Filters[TenantFilterField])
+	})
+
+	t.Run("rejects writes to other tenants", func(t *testing.T) {
+		inner, repo := newRepo()
+
+		err := repo.Create(acme, &tenantEntity{BaseEntity: BaseEntity{ID: "g2"}, TenantID: "globex"})
+		assert.True(t, IsForbidden(err))
+
+		err = repo.Update(acme, &tenantEntity{BaseEntity: BaseEntity{ID: "g1"}, TenantID: "acme"})
+		assert.True(t, IsNotFound(err), "stored entity of other tenant cannot be taken over")
+
+		err = repo.Update(acme, &tenantEntity{BaseEntity: BaseEntity{ID: "a1"}, TenantID: "globex"})
+		assert.True(t, IsForbidden(err), "entity cannot be moved to other tenant")
+
+		err = repo.Delete(acme, "g1")
+		assert.True(t, IsNotFound(err))
+
+		assert.Zero(t, inner.createCalled)
+		assert.Zero(t, inner.updateCalled)
+		assert.Zero(t, inner.deleteCalled)
+	})
+
+	t.Run("allows writes to own tenant", func(t *testing.T) {
+		inner, repo := newRepo()
+
+		require.NoError(t, repo.Create(acme, &tenantEntity{BaseEntity: BaseEntity{ID: "a2"}, TenantID: "acme"}))
+		require.NoError(t, repo.Update(acme, &tenantEntity{BaseEntity: BaseEntity{ID: "a1"}, TenantID: "acme"}))
+		require.NoError(t, repo.Delete(acme, "a1"))
+
+		assert.Equal(t, 1, inner.createCalled)
+		assert.Equal(t, 1, inner.updateCalled)
+		assert.Equal(t, 1, inner.deleteCalled)
+	})
+
+	t.Run("requires tenant for every call", func(t *testing.T) {
+		inner, repo := newRepo()
+		ctx := context.Background()
+
+		_, err := repo.GetByID(ctx, "a1")
+		assert.True(t, IsForbidden(err))
+		_, err = repo.List(ctx, NewListOptions())
+		assert.True(t, IsForbidden(err))
+		_, err = repo.Count(ctx, NewListOptions())
+		assert.True(t, IsForbidden(err))
+		assert.True(t, IsForbidden(repo.Create(ctx, &tenantEntity{TenantID: "acme"})))
+		assert.True(t, IsForbidden(repo.Update(ctx, &tenantEntity{TenantID: "acme"})))
+		assert.True(t, IsForbidden(repo.Delete(ctx, "a1")))
+
+		assert.Zero(t, inner.getByIDCalled)
+		assert.Zero(t, inner.listCalled)
+	})
+}
@@ -0,0 +1,181 @@
+// File: transaction.go
+// Title: Transaction Abstraction for TBP Repositories
+// Description: Provides a storage-agnostic transaction abstraction that lets
+//              use cases span multiple repository calls atomically. The active
+//              transaction is propagated through context.Context so
+//              repositories can join it without changing their signatures.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial transaction abstraction with in-memory manager
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// keyTransaction is the context key for the active transaction
+const keyTransaction contextKey = "tbp:transaction"
+
+// Transaction represents an active unit of work in a storage backend.
+type Transaction interface {
+	// Commit makes all changes of the transaction permanent
+	Commit(ctx context.Context) error
+
+	// Rollback discards all changes of the transaction
+	Rollback(ctx context.Context) error
+}
+
+// TxManager runs functions within a transaction.
+// Implementations commit when fn returns nil and roll back when fn returns
+// an error or panics. Repositories find the active transaction in txCtx
+// via TxFromContext.
+type TxManager interface {
+	WithTransaction(ctx context.Context, fn func(txCtx context.Context) error) error
+}
+
+// ContextWithTx adds a transaction to the context.
+// A nil transaction leaves the context unchanged.
+func ContextWithTx(ctx context.Context, tx Transaction) context.Context {
+	if tx == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, keyTransaction, tx)
+}
+
+// TxFromContext retrieves the active transaction from the context.
+// Returns the transaction and true if found, nil and false otherwise.
+func TxFromContext(ctx context.Context) (Transaction, bool) {
+	if tx, ok := ctx.Value(keyTransaction).(Transaction); ok && tx != nil {
+		return tx, true
+	}
+	return nil, false
+}
+
+// RunInTransaction implements the WithTransaction contract on top of a
+// begin function, so storage-specific TxManagers only need to start a
+// transaction. If ctx already carries a transaction, fn joins it and the
+// outermost call decides whether to commit or roll back.
+func RunInTransaction(ctx context.Context, begin func(ctx context.Context) (Transaction, error), fn func(txCtx context.Context) error) (err error) {
+	if _, active := TxFromContext(ctx); active {
+		return fn(ctx)
+	}
+
+	tx, err := begin(ctx)
+	if err != nil {
+		return Wrap(err, "failed to begin transaction")
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			_ = tx.Rollback(ctx)
+			panic(recovered)
+		}
+	}()
+
+	if err = fn(ContextWithTx(ctx, tx)); err != nil {
+		if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+			return WrapPreservingCode(err, fmt.Sprintf("transaction rollback failed: %v", rollbackErr))
+		}
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return Wrap(err, "failed to commit transaction")
+	}
+
+	return nil
+}
+
+// InMemoryTxManager is a no-op TxManager for tests and in-memory
+// repositories. It counts committed and rolled back transactions
+// but does not isolate or undo any changes.
+type InMemoryTxManager struct {
+	mu sync.Mutex
+
+	// nextID numbers transactions in the order they are begun
+	nextID int
+
+	// commits counts committed transactions
+	commits int
+
+	// rollbacks counts rolled back transactions
+	rollbacks int
+}
+
+// NewInMemoryTxManager creates a new in-memory transaction manager.
+func NewInMemoryTxManager() *InMemoryTxManager {
+	return &InMemoryTxManager{}
+}
+
+// WithTransaction implements TxManager interface.
+func (m *InMemoryTxManager) WithTransaction(ctx context.Context, fn func(txCtx context.Context) error) error {
+	return RunInTransaction(ctx, m.begin, fn)
+}
+
+// Commits returns the number of committed transactions.
+func (m *InMemoryTxManager) Commits() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.commits
+}
+
+// Rollbacks returns the number of rolled back transactions.
+func (m *InMemoryTxManager) Rollbacks() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.rollbacks
+}
+
+// begin starts a new in-memory transaction
+func (m *InMemoryTxManager) begin(ctx context.Context) (Transaction, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.nextID++
+	return &inMemoryTx{manager: m, id: fmt.Sprintf("tx_%d", m.nextID)}, nil
+}
+
+// inMemoryTx is the transaction handle used by InMemoryTxManager
+type inMemoryTx struct {
+	manager *InMemoryTxManager
+	id      string
+	done    bool
+}
+
+// Commit implements Transaction interface.
+func (tx *inMemoryTx) Commit(ctx context.Context) error {
+	tx.manager.mu.Lock()
+	defer tx.manager.mu.Unlock()
+
+	if tx.done {
+		return Newf("transaction %s already finished", tx.id)
+	}
+	tx.done = true
+	tx.manager.commits++
+	return nil
+}
+
+// Rollback implements Transaction interface.
+func (tx *inMemoryTx) Rollback(ctx context.Context) error {
+	tx.manager.mu.Lock()
+	defer tx.manager.mu.Unlock()
+
+	if tx.done {
+		return Newf("transaction %s already finished", tx.id)
+	}
+	tx.done = true
+	tx.manager.rollbacks++
+	return nil
+}
+
+// String returns the transaction identifier.
+func (tx *inMemoryTx) String() string {
+	return tx.id
+}
@@ -0,0 +1,149 @@
+// File: transaction_test.go
+// Title: Tests for Transaction Abstraction
+// Description: Test suite for the transaction abstraction including context
+//              propagation, commit and rollback semantics, panic handling,
+//              nested transactions, and the in-memory transaction manager.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingTx is a transaction whose commit and rollback fail
+type failingTx struct {
+	commitErr   error
+	rollbackErr error
+}
+
+func (tx *failingTx) Commit(ctx context.Context) error   { return tx.commitErr }
+func (tx *failingTx) Rollback(ctx context.Context) error { return tx.rollbackErr }
+
+func TestTxContext(t *testing.T) {
+	t.Run("adds and retrieves transaction", func(t *testing.T) {
+		tx := &failingTx{}
+		ctx := ContextWithTx(context.Background(), tx)
+
+		retrieved, exists := TxFromContext(ctx)
+		assert.True(t, exists)
+		assert.Same(t, tx, retrieved)
+	})
+
+	t.Run("handles missing transaction", func(t *testing.T) {
+		ctx := ContextWithTx(context.Background(), nil)
+
+		_, exists := TxFromContext(ctx)
+		assert.False(t, exists)
+	})
+}
+
+func TestInMemoryTxManager(t *testing.T) {
+	t.Run("commits on success", func(t *testing.T) {
+		manager := NewInMemoryTxManager()
+		var _ TxManager = manager
+
+		err := manager.WithTransaction(context.Background(), func(txCtx context.Context) error {
+			_, active := TxFromContext(txCtx)
+			assert.True(t, active)
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, manager.Commits())
+		assert.Equal(t, 0, manager.Rollbacks())
+	})
+
+	t.Run("rolls back on error", func(t *testing.T) {
+		manager := NewInMemoryTxManager()
+		expectedErr := New("version conflict").WithCode(ErrCodeConflict)
+
+		err := manager.WithTransaction(context.Background(), func(txCtx context.Context) error {
+			return expectedErr
+		})
+
+		assert.Equal(t, expectedErr, err)
+		assert.Equal(t, 0, manager.Commits())
+		assert.Equal(t, 1, manager.Rollbacks())
+	})
+
+	t.Run("rolls back and re-panics on panic", func(t *testing.T) {
+		manager := NewInMemoryTxManager()
+
+		assert.PanicsWithValue(t, "boom", func() {
+			_ = manager.WithTransaction(context.Background(), func(txCtx context.Context) error {
+				panic("boom")
+			})
+		})
+
+		assert.Equal(t, 0, manager.Commits())
+		assert.Equal(t, 1, manager.Rollbacks())
+	})
+
+	t.Run("nested calls join the outer transaction", func(t *testing.T) {
+		manager := NewInMemoryTxManager()
+
+		err := manager.WithTransaction(context.Background(), func(outerCtx context.Context) error {
+			outerTx, _ := TxFromContext(outerCtx)
+
+			return manager.WithTransaction(outerCtx, func(innerCtx context.Context) error {
+				innerTx, _ := TxFromContext(innerCtx)
+				assert.Same(t, outerTx, innerTx)
+				return nil
+			})
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, manager.Commits())
+	})
+}
+
+func TestRunInTransaction(t *testing.T) {
+	t.Run("returns begin errors", func(t *testing.T) {
+		called := false
+		err := RunInTransaction(context.Background(),
+			func(ctx context.Context) (Transaction, error) {
+				return nil, errors.New("connection refused")
+			},
+			func(txCtx context.Context) error {
+				called = true
+				return nil
+			})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to begin transaction")
+		assert.False(t, called)
+	})
+
+	t.Run("returns commit errors", func(t *testing.T) {
+		tx := &failingTx{commitErr: errors.New("serialization failure")}
+		err := RunInTransaction(context.Background(),
+			func(ctx context.Context) (Transaction, error) { return tx, nil },
+			func(txCtx context.Context) error { return nil })
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to commit transaction")
+	})
+
+	t.Run("reports rollback errors and keeps error code", func(t *testing.T) {
+		tx := &failingTx{rollbackErr: errors.New("connection lost")}
+		err := RunInTransaction(context.Background(),
+			func(ctx context.Context) (Transaction, error) { return tx, nil },
+			func(txCtx context.Context) error { return New("order not found").WithCode(ErrCodeNotFound) })
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "transaction rollback failed: connection lost")
+		assert.True(t, IsNotFound(err))
+	})
+}
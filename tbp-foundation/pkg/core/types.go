@@ -5,20 +5,43 @@
 //              foundation for domain modeling, service contracts, and
 //              data exchange between components.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.17
 // Created: 2025-05-26
-// Modified: 2025-05-26
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-05-26 v0.1.0: Initial implementation with basic types and interfaces
+// - 2026-10-16 v0.1.1: Added generic TypedID and TypedBaseEntity
+// - 2026-10-16 v0.1.2: Added UUID-based ID generation and validation helpers
+// - 2026-10-16 v0.1.3: Added optional ExistsRepository and UpsertRepository interfaces
+// - 2026-10-16 v0.1.4: Added StatusMachine for status transitions
+// - 2026-10-16 v0.1.5: Added TypedMetadata with typed getters
+// - 2026-10-16 v0.1.6: Added BaseEvent.EventData for event decoding
+// - 2026-10-16 v0.1.7: Added CreatedBy/UpdatedBy audit fields to BaseEntity
+// - 2026-10-16 v0.1.8: Added ListOptions query parameter parsing and encoding
+// - 2026-10-16 v0.1.9: Added ListResult.LinkHeader for pagination links
+// - 2026-10-16 v0.1.10: Added SoftDeletable and FilterSoftDeleted
+// - 2026-10-16 v0.1.11: Take the current time from the Clock abstraction
+// - 2026-10-16 v0.1.12: Added Priority ordering, ParsePriority and AllPriorities
+// - 2026-10-16 v0.1.13: Added CheckVersion for optimistic locking
+// - 2026-10-16 v0.1.14: Status validation delegates to the new StatusSet
+// - 2026-10-16 v0.1.15: Added IsNotFoundID and GetByIDOrDefault; documented the GetByID not found contract
+// - 2026-10-16 v0.1.16: Added MapListResult and FilterListResult
+// - 2026-10-16 v0.1.17: CheckVersion returns an ErrCodeVersionConflict error
 
 package core
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -51,6 +74,61 @@ func (id *ID) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// TypedID is an identifier bound to an entity type at compile time.
+// The type parameter is a marker type that is never instantiated, so
+// TypedID[userMarker] and TypedID[tenantMarker] cannot be mixed up:
+//
+//	type userMarker struct{}
+//	type UserID = TypedID[userMarker]
+//
+// TypedIDs serialize exactly like ID, as a plain JSON string.
+type TypedID[T any] string
+
+// NewTypedID converts an untyped ID to a TypedID.
+// Use this at the boundary to generic repository layers.
+func NewTypedID[T any](id ID) TypedID[T] {
+	return TypedID[T](id)
+}
+
+// ParseTypedID converts a string to a TypedID using the same rules as ParseID.
+func ParseTypedID[T any](s string, opts ...ParseIDOption) (TypedID[T], error) {
+	id, err := ParseID(s, opts...)
+	if err != nil {
+		return "", err
+	}
+	return TypedID[T](id), nil
+}
+
+// String returns the string representation of the ID.
+func (id TypedID[T]) String() string {
+	return string(id)
+}
+
+// IsEmpty checks if the ID is empty or unset.
+func (id TypedID[T]) IsEmpty() bool {
+	return string(id) == ""
+}
+
+// ID converts the TypedID to an untyped ID.
+func (id TypedID[T]) ID() ID {
+	return ID(id)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (id TypedID[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(id))
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (id *TypedID[T]) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	*id = TypedID[T](s)
+	return nil
+}
+
 // Entity represents the base interface for all domain entities.
 // All business objects should implement this interface.
 type Entity interface {
@@ -74,6 +152,8 @@ type BaseEntity struct {
 	Version   int64     `json:"version" db:"version"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	CreatedBy ID        `json:"created_by" db:"created_by"`
+	UpdatedBy ID        `json:"updated_by" db:"updated_by"`
 }
 
 // GetID implements Entity interface.
@@ -102,14 +182,84 @@ func (e *BaseEntity) SetID(id ID) {
 }
 
 // IncrementVersion increments the version for optimistic locking.
+// UpdatedAt is set from the package-level clock (see SetClock).
 func (e *BaseEntity) IncrementVersion() {
 	e.Version++
-	e.UpdatedAt = time.Now()
+	e.UpdatedAt = Now()
 }
 
 // Touch updates the UpdatedAt timestamp without changing version.
+// UpdatedAt is set from the package-level clock (see SetClock).
 func (e *BaseEntity) Touch() {
-	e.UpdatedAt = time.Now()
+	e.UpdatedAt = Now()
+}
+
+// IncrementVersionWithContext increments the version like IncrementVersion
+// and records the current user from the context as UpdatedBy (and as
+// CreatedBy if not yet set). An unauthenticated context leaves both fields
+// unchanged rather than writing an empty ID. UpdatedAt is set from the
+// clock of the context (see ClockFromContext).
+func (e *BaseEntity) IncrementVersionWithContext(ctx context.Context) {
+	e.Version++
+	e.UpdatedAt = ClockFromContext(ctx).Now()
+	e.stampUser(ctx)
+}
+
+// TouchWithContext updates the UpdatedAt timestamp like Touch and records
+// the current user from the context as UpdatedBy (and as CreatedBy if not
+// yet set). An unauthenticated context leaves both fields unchanged.
+// UpdatedAt is set from the clock of the context (see ClockFromContext).
+func (e *BaseEntity) TouchWithContext(ctx context.Context) {
+	e.UpdatedAt = ClockFromContext(ctx).Now()
+	e.stampUser(ctx)
+}
+
+// stampUser records the authenticated user from the context in the audit fields
+func (e *BaseEntity) stampUser(ctx context.Context) {
+	userID, ok := GetUserID(ctx)
+	if !ok || userID == "" {
+		return
+	}
+
+	if e.CreatedBy.IsEmpty() {
+		e.CreatedBy = ID(userID)
+	}
+	e.UpdatedBy = ID(userID)
+}
+
+// CheckVersion verifies optimistic locking for an update of stored with
+// incoming. Returns nil if both have the same version and otherwise an
+// error wrapping ErrVersionConflict whose own code is
+// ErrCodeVersionConflict, so that GetCode distinguishes it from other
+// conflicts while IsConflict and IsVersionConflict both report true. A zero
+// version is not a wildcard: incoming version 0 only matches a stored
+// entity of version 0.
+func CheckVersion(stored, incoming Entity) error {
+	if incoming.GetVersion() == stored.GetVersion() {
+		return nil
+	}
+	return WrapWithCodef(ErrVersionConflict, ErrCodeVersionConflict,
+		"version conflict for entity %s: stored version %d, got %d",
+		stored.GetID(), stored.GetVersion(), incoming.GetVersion()).
+		WithContext("stored_version", stored.GetVersion()).
+		WithContext("incoming_version", incoming.GetVersion())
+}
+
+// TypedBaseEntity is a BaseEntity whose ID is accessed as a TypedID.
+// Entities embed it to choose their ID type while keeping the standard
+// fields, the Entity implementation, and the wire format of BaseEntity.
+type TypedBaseEntity[T any] struct {
+	BaseEntity
+}
+
+// TypedID returns the entity ID as a TypedID.
+func (e *TypedBaseEntity[T]) TypedID() TypedID[T] {
+	return TypedID[T](e.ID)
+}
+
+// SetTypedID sets the entity ID from a TypedID.
+func (e *TypedBaseEntity[T]) SetTypedID(id TypedID[T]) {
+	e.ID = ID(id)
 }
 
 // Service represents the base interface for all business services.
@@ -124,11 +274,17 @@ type Service interface {
 
 // Repository represents the base interface for data access objects.
 // Repositories abstract the data persistence layer.
+//
+// GetByID reports a missing entity by returning the zero value of T and an
+// error matching ErrNotFound with code ErrCodeNotFound, never a zero value
+// without an error. Callers check for it with IsNotFoundID, or use
+// GetByIDOrDefault to fall back to a default.
 type Repository[T Entity] interface {
 	// Create persists a new entity
 	Create(ctx context.Context, entity T) error
 
-	// GetByID retrieves an entity by its ID
+	// GetByID retrieves an entity by its ID, returning an ErrNotFound
+	// error if no such entity exists
 	GetByID(ctx context.Context, id ID) (T, error)
 
 	// Update modifies an existing entity
@@ -144,6 +300,92 @@ type Repository[T Entity] interface {
 	Count(ctx context.Context, opts ListOptions) (int64, error)
 }
 
+// ExistsRepository is an optional extension of Repository for backends that
+// can check existence without loading the entity.
+type ExistsRepository[T Entity] interface {
+	Repository[T]
+
+	// Exists reports whether an entity with the given ID exists
+	Exists(ctx context.Context, id ID) (bool, error)
+}
+
+// UpsertRepository is an optional extension of Repository supporting
+// create-or-update in a single call.
+//
+// Upsert creates the entity when its ID is empty or not yet stored and
+// updates it otherwise. Updates follow the same optimistic locking rules as
+// Update: the stored version must match BaseEntity.Version and a mismatch
+// returns an ErrCodeVersionConflict error, typically created by CheckVersion. On create the supplied version is
+// ignored and the version is initialized by the repository.
+type UpsertRepository[T Entity] interface {
+	Repository[T]
+
+	// Upsert creates or updates an entity
+	Upsert(ctx context.Context, entity T) error
+}
+
+// EntityExists checks whether an entity exists, using Exists if the
+// repository implements ExistsRepository and falling back to GetByID,
+// treating not found errors as false.
+func EntityExists[T Entity](ctx context.Context, repo Repository[T], id ID) (bool, error) {
+	if existsRepo, ok := repo.(ExistsRepository[T]); ok {
+		return existsRepo.Exists(ctx, id)
+	}
+
+	if _, err := repo.GetByID(ctx, id); err != nil {
+		if IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// IsNotFoundID reports whether err is the not found error returned by
+// Repository.GetByID for a missing entity.
+func IsNotFoundID(err error) bool {
+	return IsNotFound(err)
+}
+
+// GetByIDOrDefault retrieves an entity by its ID, returning def if the
+// repository reports the entity as not found. Other errors are returned
+// together with the zero value.
+func GetByIDOrDefault[T Entity](repo Repository[T], ctx context.Context, id ID, def T) (T, error) {
+	entity, err := repo.GetByID(ctx, id)
+	if err != nil {
+		if IsNotFoundID(err) {
+			return def, nil
+		}
+		var zero T
+		return zero, err
+	}
+	return entity, nil
+}
+
+// SoftDeletable is implemented by entities that are marked as deleted
+// instead of being removed from storage.
+type SoftDeletable interface {
+	// IsDeleted reports whether the entity has been soft-deleted
+	IsDeleted() bool
+}
+
+// FilterSoftDeleted returns the items that are not soft-deleted, or all
+// items if includeDeleted is set, applying ListOptions.IncludeDeleted for
+// in-memory repositories. Items that do not implement SoftDeletable are
+// always kept. The input slice is not modified.
+func FilterSoftDeleted[T Entity](items []T, includeDeleted bool) []T {
+	filtered := make([]T, 0, len(items))
+	for _, item := range items {
+		if !includeDeleted {
+			if deletable, ok := any(item).(SoftDeletable); ok && deletable.IsDeleted() {
+				continue
+			}
+		}
+		filtered = append(filtered, item)
+	}
+	return filtered
+}
+
 // ListOptions defines parameters for list operations.
 // Provides standardized pagination, sorting, and filtering.
 type ListOptions struct {
@@ -165,7 +407,7 @@ type ListOptions struct {
 	// Search provides full-text search functionality
 	Search string `json:"search" form:"search"`
 
-	// IncludeDeleted includes soft-deleted records in results
+	// IncludeDeleted includes soft-deleted records in results (see FilterSoftDeleted)
 	IncludeDeleted bool `json:"include_deleted" form:"include_deleted"`
 }
 
@@ -247,23 +489,124 @@ func (opts ListOptions) GetPage() int64 {
 // and that sort order is valid if specified.
 func (opts ListOptions) Validate() error {
 	if opts.Limit < 0 {
-		return New("limit cannot be negative")
+		return New("limit cannot be negative").WithCode(ErrCodeInvalidInput)
 	}
 	if opts.Offset < 0 {
-		return New("offset cannot be negative")
+		return New("offset cannot be negative").WithCode(ErrCodeInvalidInput)
 	}
 	if opts.SortOrder != "" && !opts.SortOrder.IsValid() {
-		return Newf("invalid sort order: %s", opts.SortOrder)
+		return Newf("invalid sort order: %s", opts.SortOrder).WithCode(ErrCodeInvalidInput)
 	}
 
 	// Optional: Add reasonable upper limits to prevent abuse
 	if opts.Limit > 1000 {
-		return New("limit cannot exceed 1000")
+		return New("limit cannot exceed 1000").WithCode(ErrCodeInvalidInput)
 	}
 
 	return nil
 }
 
+// filterQueryPrefix marks query parameters that map to ListOptions.Filters
+const filterQueryPrefix = "filter."
+
+// ListOptionsFromQuery parses ListOptions from URL query parameters
+// (offset, limit, sort_by, sort_order, search, include_deleted, and
+// filter.<field>). Missing parameters keep the NewListOptions defaults.
+// Filters with a single value are stored as string, repeated filters as
+// []string. Malformed or invalid values return an ErrCodeInvalidInput error.
+func ListOptionsFromQuery(query url.Values) (ListOptions, error) {
+	opts := NewListOptions()
+
+	if value := query.Get("offset"); value != "" {
+		offset, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return ListOptions{}, Newf("invalid offset '%s'", value).WithCode(ErrCodeInvalidInput)
+		}
+		opts.Offset = offset
+	}
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return ListOptions{}, Newf("invalid limit '%s'", value).WithCode(ErrCodeInvalidInput)
+		}
+		opts.Limit = limit
+	}
+
+	if value := query.Get("include_deleted"); value != "" {
+		includeDeleted, err := strconv.ParseBool(value)
+		if err != nil {
+			return ListOptions{}, Newf("invalid include_deleted '%s'", value).WithCode(ErrCodeInvalidInput)
+		}
+		opts.IncludeDeleted = includeDeleted
+	}
+
+	opts.SortBy = query.Get("sort_by")
+	if value := query.Get("sort_order"); value != "" {
+		opts.SortOrder = SortOrder(strings.ToLower(value))
+	}
+	opts.Search = query.Get("search")
+
+	for key, values := range query {
+		if !strings.HasPrefix(key, filterQueryPrefix) || len(values) == 0 {
+			continue
+		}
+
+		field := strings.TrimPrefix(key, filterQueryPrefix)
+		if field == "" {
+			return ListOptions{}, Newf("invalid filter parameter '%s'", key).WithCode(ErrCodeInvalidInput)
+		}
+
+		if len(values) == 1 {
+			opts.Filters[field] = values[0]
+		} else {
+			opts.Filters[field] = append([]string(nil), values...)
+		}
+	}
+
+	if err := opts.Validate(); err != nil {
+		return ListOptions{}, err
+	}
+
+	return opts, nil
+}
+
+// ToQuery converts the ListOptions to URL query parameters understood by
+// ListOptionsFromQuery. Offset and limit are always included; other
+// parameters only if set. Filter values are formatted with fmt.Sprint,
+// []string values become repeated parameters.
+func (opts ListOptions) ToQuery() url.Values {
+	query := url.Values{}
+	query.Set("offset", strconv.FormatInt(opts.Offset, 10))
+	query.Set("limit", strconv.FormatInt(opts.Limit, 10))
+
+	if opts.SortBy != "" {
+		query.Set("sort_by", opts.SortBy)
+	}
+	if opts.SortOrder != "" {
+		query.Set("sort_order", opts.SortOrder.String())
+	}
+	if opts.Search != "" {
+		query.Set("search", opts.Search)
+	}
+	if opts.IncludeDeleted {
+		query.Set("include_deleted", "true")
+	}
+
+	for field, value := range opts.Filters {
+		key := filterQueryPrefix + field
+		if values, ok := value.([]string); ok {
+			for _, v := range values {
+				query.Add(key, v)
+			}
+			continue
+		}
+		query.Set(key, fmt.Sprint(value))
+	}
+
+	return query
+}
+
 // ListResult represents the result of a list operation with pagination metadata.
 type ListResult[T any] struct {
 	// Items contains the actual data
@@ -322,6 +665,102 @@ func (r *ListResult[T]) GetPageInfo() PageInfo {
 	}
 }
 
+// LinkHeader builds an RFC 5988 Link header value with first, prev, next,
+// and last page links based on the pagination state. Existing query
+// parameters of baseURL are preserved; offset and limit are overwritten.
+// prev is omitted on the first page and next/last when there are no more
+// items. Returns an empty string if baseURL cannot be parsed.
+func (r *ListResult[T]) LinkHeader(baseURL string) string {
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return ""
+	}
+
+	info := r.GetPageInfo()
+	links := []string{pageLink(base, 0, r.Limit, "first")}
+
+	if info.HasPrev && r.Limit > 0 {
+		prevOffset := r.Offset - r.Limit
+		if prevOffset < 0 {
+			prevOffset = 0
+		}
+		links = append(links, pageLink(base, prevOffset, r.Limit, "prev"))
+	}
+
+	if info.HasNext && r.Limit > 0 {
+		links = append(links, pageLink(base, r.Offset+r.Limit, r.Limit, "next"))
+		links = append(links, pageLink(base, (info.TotalPages-1)*r.Limit, r.Limit, "last"))
+	}
+
+	return strings.Join(links, ", ")
+}
+
+// pageLink formats a single Link header entry for the given page
+func pageLink(base *url.URL, offset, limit int64, rel string) string {
+	link := *base
+	query := link.Query()
+	query.Set("offset", strconv.FormatInt(offset, 10))
+	query.Set("limit", strconv.FormatInt(limit, 10))
+	link.RawQuery = query.Encode()
+
+	return fmt.Sprintf("<%s>; rel=\"%s\"", link.String(), rel)
+}
+
+// MapListResult converts the items of a list result with fn, e.g. from
+// entities to DTOs, and copies the pagination metadata (Total, Offset,
+// Limit, and HasMore) unchanged. Returns nil if in is nil.
+func MapListResult[T, U any](in *ListResult[T], fn func(T) U) *ListResult[U] {
+	if in == nil {
+		return nil
+	}
+
+	var items []U
+	if in.Items != nil {
+		items = make([]U, len(in.Items))
+		for i, item := range in.Items {
+			items[i] = fn(item)
+		}
+	}
+
+	return &ListResult[U]{
+		Items:   items,
+		Total:   in.Total,
+		Offset:  in.Offset,
+		Limit:   in.Limit,
+		HasMore: in.HasMore,
+	}
+}
+
+// FilterListResult returns a copy of a list result with only the items for
+// which keep returns true. The pagination metadata is copied unchanged:
+// Total still counts the items matching the original query, so it no
+// longer matches the filtered items and page sizes may vary. Prefer
+// filtering in the query where totals must be exact. Returns nil if in is
+// nil; in is not modified.
+func FilterListResult[T any](in *ListResult[T], keep func(T) bool) *ListResult[T] {
+	if in == nil {
+		return nil
+	}
+
+	var items []T
+	if in.Items != nil {
+		items = make([]T, 0, len(in.Items))
+		for _, item := range in.Items {
+			if keep(item) {
+				items = append(items, item)
+			}
+		}
+	}
+
+	return &ListResult[T]{
+		Items:   items,
+		Total:   in.Total,
+		Offset:  in.Offset,
+		Limit:   in.Limit,
+		HasMore: in.HasMore,
+	}
+}
+
 // PageInfo provides detailed pagination information.
 type PageInfo struct {
 	CurrentPage  int64 `json:"current_page"`
@@ -410,6 +849,11 @@ func (e *BaseEvent) Version() int64 {
 	return e.Ver
 }
 
+// EventData implements EventDataProvider interface.
+func (e *BaseEvent) EventData() []byte {
+	return e.Data
+}
+
 // Value represents a value object in domain-driven design.
 // Value objects are immutable and defined by their attributes.
 type Value interface {
@@ -443,14 +887,16 @@ const (
 	StatusDeleted Status = "deleted"
 )
 
+// StatusSet contains the predefined statuses. Use StatusSet.Parse to
+// read a status from user input and StatusSet.Values to list them.
+var StatusSet = NewEnumSet(
+	StatusActive, StatusInactive, StatusPending,
+	StatusCompleted, StatusCancelled, StatusDeleted,
+)
+
 // IsValid checks if the status is one of the predefined values.
 func (s Status) IsValid() bool {
-	switch s {
-	case StatusActive, StatusInactive, StatusPending, StatusCompleted, StatusCancelled, StatusDeleted:
-		return true
-	default:
-		return false
-	}
+	return StatusSet.IsValid(s)
 }
 
 // String returns the string representation of the status.
@@ -458,6 +904,82 @@ func (s Status) String() string {
 	return string(s)
 }
 
+// StatusMachine defines the allowed transitions between statuses.
+// Only declared transitions are legal; this includes transitions from a
+// status to itself. Declare all transitions before sharing a machine
+// between goroutines.
+type StatusMachine struct {
+	transitions map[Status]map[Status]bool
+}
+
+// NewStatusMachine creates an empty StatusMachine without any transitions.
+func NewStatusMachine() *StatusMachine {
+	return &StatusMachine{
+		transitions: make(map[Status]map[Status]bool),
+	}
+}
+
+// NewDefaultStatusMachine creates a StatusMachine for the built-in statuses:
+//
+//	pending   -> active, cancelled, deleted
+//	active    -> inactive, completed, cancelled, deleted
+//	inactive  -> active, deleted
+//	completed -> deleted
+//	cancelled -> deleted
+//
+// Deleted is terminal. Each call returns a new machine that callers may extend.
+func NewDefaultStatusMachine() *StatusMachine {
+	return NewStatusMachine().
+		Allow(StatusPending, StatusActive, StatusCancelled, StatusDeleted).
+		Allow(StatusActive, StatusInactive, StatusCompleted, StatusCancelled, StatusDeleted).
+		Allow(StatusInactive, StatusActive, StatusDeleted).
+		Allow(StatusCompleted, StatusDeleted).
+		Allow(StatusCancelled, StatusDeleted)
+}
+
+// Allow declares transitions from one status to each of the target statuses.
+// Returns the machine to allow chaining.
+func (m *StatusMachine) Allow(from Status, to ...Status) *StatusMachine {
+	targets, exists := m.transitions[from]
+	if !exists {
+		targets = make(map[Status]bool, len(to))
+		m.transitions[from] = targets
+	}
+	for _, target := range to {
+		targets[target] = true
+	}
+	return m
+}
+
+// CanTransition checks if the transition from one status to another is allowed.
+func (m *StatusMachine) CanTransition(from, to Status) bool {
+	return m.transitions[from][to]
+}
+
+// Transition validates a status change and returns the new status.
+// Illegal transitions return the current status and an ErrCodeConflict error.
+func (m *StatusMachine) Transition(from, to Status) (Status, error) {
+	if !m.CanTransition(from, to) {
+		return from, Newf("illegal status transition from '%s' to '%s'", from, to).
+			WithCode(ErrCodeConflict).
+			WithContext("from", from.String()).
+			WithContext("to", to.String())
+	}
+	return to, nil
+}
+
+// AllowedTransitions returns the statuses reachable from the given status
+// in sorted order.
+func (m *StatusMachine) AllowedTransitions(from Status) []Status {
+	targets := m.transitions[from]
+	result := make([]Status, 0, len(targets))
+	for target := range targets {
+		result = append(result, target)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
+
 // Priority represents a priority level enumeration.
 type Priority int
 
@@ -496,6 +1018,52 @@ func (p Priority) String() string {
 	}
 }
 
+// Compare compares the urgency of two priorities.
+// Returns -1 if p is lower than other, 0 if they are equal, and +1 if p is higher.
+func (p Priority) Compare(other Priority) int {
+	switch {
+	case p < other:
+		return -1
+	case p > other:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Higher reports whether p is more urgent than other.
+func (p Priority) Higher(other Priority) bool {
+	return p > other
+}
+
+// Lower reports whether p is less urgent than other.
+func (p Priority) Lower(other Priority) bool {
+	return p < other
+}
+
+// AllPriorities returns all valid priorities from lowest to highest.
+func AllPriorities() []Priority {
+	return []Priority{PriorityLow, PriorityMedium, PriorityHigh, PriorityCritical}
+}
+
+// ParsePriority parses a priority from its name ("low", "medium", "high",
+// "critical"), case-insensitively, or from its numeric value ("1" to "4").
+// Other input returns an ErrCodeInvalidInput error.
+func ParsePriority(s string) (Priority, error) {
+	normalized := strings.ToLower(strings.TrimSpace(s))
+	for _, priority := range AllPriorities() {
+		if normalized == priority.String() {
+			return priority, nil
+		}
+	}
+
+	if i, err := strconv.Atoi(normalized); err == nil && Priority(i).IsValid() {
+		return Priority(i), nil
+	}
+
+	return 0, Newf("invalid priority: %s", s).WithCode(ErrCodeInvalidInput)
+}
+
 // MarshalJSON implements json.Marshaler interface for Priority.
 func (p Priority) MarshalJSON() ([]byte, error) {
 	return json.Marshal(p.String())
@@ -613,18 +1181,278 @@ func (m Metadata) Clone() Metadata {
 	return clone
 }
 
+// TypedMetadata represents key-value metadata with typed values.
+// It complements the string-only Metadata for values such as numbers,
+// booleans, and timestamps that would otherwise be stringified.
+type TypedMetadata map[string]interface{}
+
+// metadataTimeFormats lists the string formats accepted by TypedMetadata.GetTime
+var metadataTimeFormats = []string{
+	time.RFC3339,
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05Z",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// Get retrieves a metadata value by key.
+func (m TypedMetadata) Get(key string) (interface{}, bool) {
+	if m == nil {
+		return nil, false
+	}
+	value, exists := m[key]
+	return value, exists
+}
+
+// Set sets a metadata value.
+func (m TypedMetadata) Set(key string, value interface{}) {
+	if m != nil {
+		m[key] = value
+	}
+}
+
+// Has checks if a metadata key exists.
+func (m TypedMetadata) Has(key string) bool {
+	if m == nil {
+		return false
+	}
+	_, exists := m[key]
+	return exists
+}
+
+// Clone creates a copy of the metadata.
+// Values are copied shallowly.
+func (m TypedMetadata) Clone() TypedMetadata {
+	if m == nil {
+		return nil
+	}
+
+	clone := make(TypedMetadata, len(m))
+	for k, v := range m {
+		clone[k] = v
+	}
+	return clone
+}
+
+// GetString retrieves a metadata value as string.
+// Non-string values are formatted with %v.
+func (m TypedMetadata) GetString(key string) (string, error) {
+	value, exists := m.Get(key)
+	if !exists {
+		return "", Newf("metadata key '%s' not found", key).WithCode(ErrCodeNotFound)
+	}
+
+	if str, ok := value.(string); ok {
+		return str, nil
+	}
+	return fmt.Sprintf("%v", value), nil
+}
+
+// GetInt retrieves a metadata value as int.
+// Accepts integer and float values, JSON numbers, and numeric strings.
+func (m TypedMetadata) GetInt(key string) (int, error) {
+	value, exists := m.Get(key)
+	if !exists {
+		return 0, Newf("metadata key '%s' not found", key).WithCode(ErrCodeNotFound)
+	}
+
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int32:
+		return int(v), nil
+	case int64:
+		return int(v), nil
+	case float64:
+		return int(v), nil
+	case float32:
+		return int(v), nil
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return int(i), nil
+		}
+		if f, err := v.Float64(); err == nil {
+			return int(f), nil
+		}
+	case string:
+		if i, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
+			return i, nil
+		}
+	}
+
+	return 0, Newf("metadata key '%s' with value '%v' cannot be converted to int", key, value).WithCode(ErrCodeInvalidInput)
+}
+
+// GetBool retrieves a metadata value as bool.
+// Accepts booleans, numbers (non-zero is true), and common boolean strings
+// such as "yes", "on", or "1".
+func (m TypedMetadata) GetBool(key string) (bool, error) {
+	value, exists := m.Get(key)
+	if !exists {
+		return false, Newf("metadata key '%s' not found", key).WithCode(ErrCodeNotFound)
+	}
+
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "true", "yes", "1", "on", "enable", "enabled", "y", "t":
+			return true, nil
+		case "false", "no", "0", "off", "disable", "disabled", "n", "f", "":
+			return false, nil
+		}
+	case int:
+		return v != 0, nil
+	case float64:
+		return v != 0, nil
+	case json.Number:
+		if f, err := v.Float64(); err == nil {
+			return f != 0, nil
+		}
+	}
+
+	return false, Newf("metadata key '%s' with value '%v' cannot be converted to bool", key, value).WithCode(ErrCodeInvalidInput)
+}
+
+// GetTime retrieves a metadata value as time.
+// Accepts time.Time values, Unix timestamps in seconds, and strings in
+// RFC3339, "2006-01-02 15:04:05", or "2006-01-02" format.
+func (m TypedMetadata) GetTime(key string) (time.Time, error) {
+	value, exists := m.Get(key)
+	if !exists {
+		return time.Time{}, Newf("metadata key '%s' not found", key).WithCode(ErrCodeNotFound)
+	}
+
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case int:
+		return time.Unix(int64(v), 0), nil
+	case int64:
+		return time.Unix(v, 0), nil
+	case float64:
+		return time.Unix(int64(v), 0), nil
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return time.Unix(i, 0), nil
+		}
+	case string:
+		for _, format := range metadataTimeFormats {
+			if t, err := time.Parse(format, strings.TrimSpace(v)); err == nil {
+				return t, nil
+			}
+		}
+	}
+
+	return time.Time{}, Newf("metadata key '%s' with value '%v' cannot be converted to time", key, value).WithCode(ErrCodeInvalidInput)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+// Numbers are decoded as json.Number to preserve integer precision.
+func (m *TypedMetadata) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var values map[string]interface{}
+	if err := decoder.Decode(&values); err != nil {
+		return err
+	}
+	*m = values
+	return nil
+}
+
+// ToMetadata converts the typed metadata to string metadata.
+// Times are formatted as RFC3339Nano, other values with %v.
+func (m TypedMetadata) ToMetadata() Metadata {
+	if m == nil {
+		return nil
+	}
+
+	result := make(Metadata, len(m))
+	for k, v := range m {
+		switch value := v.(type) {
+		case string:
+			result[k] = value
+		case time.Time:
+			result[k] = value.Format(time.RFC3339Nano)
+		default:
+			result[k] = fmt.Sprintf("%v", value)
+		}
+	}
+	return result
+}
+
+// ToTypedMetadata converts string metadata to typed metadata.
+// Values stay strings; the typed getters convert them on access.
+func (m Metadata) ToTypedMetadata() TypedMetadata {
+	if m == nil {
+		return nil
+	}
+
+	result := make(TypedMetadata, len(m))
+	for k, v := range m {
+		result[k] = v
+	}
+	return result
+}
+
+// parseIDOptions holds the format requirements applied by ParseID.
+type parseIDOptions struct {
+	requireUUID bool
+	prefix      string
+}
+
+// ParseIDOption configures format validation in ParseID.
+type ParseIDOption func(*parseIDOptions)
+
+// RequireUUID makes ParseID reject IDs that are not UUIDs.
+var RequireUUID ParseIDOption = func(opts *parseIDOptions) {
+	opts.requireUUID = true
+}
+
+// RequirePrefixedUUID makes ParseID accept only IDs in the format produced
+// by NewIDWithPrefix, e.g. user_<uuid>.
+func RequirePrefixedUUID(prefix string) ParseIDOption {
+	return func(opts *parseIDOptions) {
+		opts.requireUUID = true
+		opts.prefix = prefix
+	}
+}
+
 // ParseID converts a string to an ID, handling common parsing scenarios.
-func ParseID(s string) (ID, error) {
+// Options such as RequireUUID additionally validate the ID format.
+func ParseID(s string, opts ...ParseIDOption) (ID, error) {
 	if s == "" {
 		return "", Newf("ID cannot be empty")
 	}
+
+	var options parseIDOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.requireUUID {
+		value := s
+		if options.prefix != "" {
+			prefix := options.prefix + "_"
+			if !strings.HasPrefix(value, prefix) {
+				return "", Newf("ID '%s' does not have prefix '%s'", s, prefix)
+			}
+			value = strings.TrimPrefix(value, prefix)
+		}
+		if !IsValidUUID(ID(value)) {
+			return "", Newf("ID '%s' is not a valid UUID", s)
+		}
+	}
+
 	return ID(s), nil
 }
 
 // MustParseID converts a string to an ID, panicking on error.
 // Should only be used in contexts where the ID is guaranteed to be valid.
-func MustParseID(s string) ID {
-	id, err := ParseID(s)
+func MustParseID(s string, opts ...ParseIDOption) ID {
+	id, err := ParseID(s, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -640,3 +1468,70 @@ func ToIntID(id ID) (int64, error) {
 func FromIntID(i int64) ID {
 	return ID(strconv.FormatInt(i, 10))
 }
+
+// NewID creates a new random ID in UUID version 4 format.
+// Uses crypto/rand and is safe for concurrent use.
+func NewID() ID {
+	return ID(newUUID())
+}
+
+// NewIDWithPrefix creates a new random UUID-based ID with a type prefix,
+// e.g. NewIDWithPrefix("user") returns user_<uuid>.
+func NewIDWithPrefix(prefix string) ID {
+	if prefix == "" {
+		return NewID()
+	}
+	return ID(prefix + "_" + newUUID())
+}
+
+// IsValidUUID checks if the ID is a UUID in canonical 8-4-4-4-12 hex format.
+// Prefixed IDs from NewIDWithPrefix are not UUIDs; use ParseID with
+// RequirePrefixedUUID to validate them.
+func IsValidUUID(id ID) bool {
+	s := string(id)
+	if len(s) != 36 {
+		return false
+	}
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return false
+			}
+		default:
+			isHex := (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+			if !isHex {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// newUUID generates a random UUID version 4 string.
+// Panics if the system random source fails, as IDs must never collide.
+func newUUID() string {
+	var uuid [16]byte
+	if _, err := rand.Read(uuid[:]); err != nil {
+		panic("failed to generate UUID: " + err.Error())
+	}
+
+	uuid[6] = (uuid[6] & 0x0f) | 0x40 // Version 4
+	uuid[8] = (uuid[8] & 0x3f) | 0x80 // RFC 4122 variant
+
+	var buf [36]byte
+	hex.Encode(buf[0:8], uuid[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], uuid[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], uuid[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], uuid[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], uuid[10:])
+
+	return string(buf[:])
+}
@@ -5,19 +5,39 @@
 //              and interface compliance. Tests cover edge cases, performance,
 //              and type safety for the foundation layer.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.14
 // Created: 2025-05-26
-// Modified: 2025-05-26
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-05-26 v0.1.0: Initial test implementation with comprehensive coverage
+// - 2026-10-16 v0.1.1: Added TypedID tests
+// - 2026-10-16 v0.1.2: Added ID generation tests and benchmarks
+// - 2026-10-16 v0.1.3: Extended mock repository with Exists and Upsert
+// - 2026-10-16 v0.1.4: Added StatusMachine tests
+// - 2026-10-16 v0.1.5: Added TypedMetadata tests
+// - 2026-10-16 v0.1.6: Added BaseEntity audit field tests
+// - 2026-10-16 v0.1.7: Added ListOptions query parameter tests
+// - 2026-10-16 v0.1.8: Added LinkHeader tests
+// - 2026-10-16 v0.1.9: Store entities in mock repository and honor IncludeDeleted
+// - 2026-10-16 v0.1.10: Replaced sleeps with FakeClock
+// - 2026-10-16 v0.1.11: Added Priority ordering and parsing tests
+// - 2026-10-16 v0.1.12: Added CheckVersion tests
+// - 2026-10-16 v0.1.13: Added MapListResult and FilterListResult tests
+// - 2026-10-16 v0.1.14: Check the outermost code of version conflicts
 
 package core
 
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -82,6 +102,84 @@ func TestID(t *testing.T) {
 	})
 }
 
+type userMarker struct{}
+type tenantMarker struct{}
+
+type UserID = TypedID[userMarker]
+type TenantID = TypedID[tenantMarker]
+
+// TypedTestEntity is a mock entity using a typed ID
+type TypedTestEntity struct {
+	TypedBaseEntity[userMarker]
+	Name string `json:"name"`
+}
+
+func TestTypedID(t *testing.T) {
+	t.Run("basic operations", func(t *testing.T) {
+		id := UserID("user123")
+		assert.Equal(t, "user123", id.String())
+		assert.False(t, id.IsEmpty())
+		assert.Equal(t, ID("user123"), id.ID())
+
+		var empty TenantID
+		assert.True(t, empty.IsEmpty())
+	})
+
+	t.Run("converts from untyped ID", func(t *testing.T) {
+		id := NewTypedID[userMarker](ID("user123"))
+		assert.Equal(t, UserID("user123"), id)
+	})
+
+	t.Run("parses strings", func(t *testing.T) {
+		id, err := ParseTypedID[tenantMarker]("tenant456")
+		require.NoError(t, err)
+		assert.Equal(t, TenantID("tenant456"), id)
+
+		_, err = ParseTypedID[tenantMarker]("")
+		assert.Error(t, err)
+	})
+
+	t.Run("JSON wire format matches ID", func(t *testing.T) {
+		typed, err := json.Marshal(UserID("user123"))
+		require.NoError(t, err)
+		untyped, err := json.Marshal(ID("user123"))
+		require.NoError(t, err)
+		assert.Equal(t, string(untyped), string(typed))
+		assert.Equal(t, `"user123"`, string(typed))
+
+		var decoded UserID
+		require.NoError(t, json.Unmarshal([]byte(`"user456"`), &decoded))
+		assert.Equal(t, UserID("user456"), decoded)
+
+		assert.Error(t, json.Unmarshal([]byte(`123`), &decoded))
+	})
+
+	t.Run("entity uses typed ID without changing wire format", func(t *testing.T) {
+		entity := &TypedTestEntity{Name: "test"}
+		entity.SetTypedID(UserID("user123"))
+
+		assert.Equal(t, UserID("user123"), entity.TypedID())
+		assert.Equal(t, ID("user123"), entity.GetID())
+
+		var _ Entity = entity
+
+		data, err := json.Marshal(entity)
+		require.NoError(t, err)
+
+		plain := struct {
+			BaseEntity
+			Name string `json:"name"`
+		}{BaseEntity: BaseEntity{ID: "user123"}, Name: "test"}
+		plainData, err := json.Marshal(plain)
+		require.NoError(t, err)
+		assert.JSONEq(t, string(plainData), string(data))
+
+		var decoded TypedTestEntity
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, UserID("user123"), decoded.TypedID())
+	})
+}
+
 func TestBaseEntity(t *testing.T) {
 	t.Run("implements Entity interface", func(t *testing.T) {
 		entity := &BaseEntity{
@@ -106,26 +204,77 @@ func TestBaseEntity(t *testing.T) {
 	})
 
 	t.Run("increment version", func(t *testing.T) {
-		entity := &BaseEntity{Version: 1}
+		clock := NewFakeClock(time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC))
+		defer SetClock(SetClock(clock))
+
+		entity := &BaseEntity{Version: 1, UpdatedAt: clock.Now()}
 		originalTime := entity.UpdatedAt
 
-		time.Sleep(1 * time.Millisecond) // Ensure time difference
+		clock.Advance(time.Millisecond)
 		entity.IncrementVersion()
 
 		assert.Equal(t, int64(2), entity.Version)
 		assert.True(t, entity.UpdatedAt.After(originalTime))
+		assert.Equal(t, clock.Now(), entity.UpdatedAt)
 	})
 
 	t.Run("touch updates timestamp", func(t *testing.T) {
-		entity := &BaseEntity{Version: 1}
+		clock := NewFakeClock(time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC))
+		defer SetClock(SetClock(clock))
+
+		entity := &BaseEntity{Version: 1, UpdatedAt: clock.Now()}
 		originalTime := entity.UpdatedAt
 		originalVersion := entity.Version
 
-		time.Sleep(1 * time.Millisecond) // Ensure time difference
+		clock.Advance(time.Millisecond)
 		entity.Touch()
 
 		assert.Equal(t, originalVersion, entity.Version) // Version unchanged
 		assert.True(t, entity.UpdatedAt.After(originalTime))
+		assert.Equal(t, clock.Now(), entity.UpdatedAt)
+	})
+
+	t.Run("touch with context stamps user", func(t *testing.T) {
+		entity := &BaseEntity{Version: 1}
+		ctx := WithUserID(context.Background(), "alice")
+
+		entity.TouchWithContext(ctx)
+		assert.Equal(t, ID("alice"), entity.CreatedBy)
+		assert.Equal(t, ID("alice"), entity.UpdatedBy)
+		assert.Equal(t, int64(1), entity.Version)
+		assert.False(t, entity.UpdatedAt.IsZero())
+	})
+
+	t.Run("increment version with context keeps creator", func(t *testing.T) {
+		entity := &BaseEntity{Version: 1, CreatedBy: ID("alice")}
+		ctx := WithUserID(context.Background(), "bob")
+
+		entity.IncrementVersionWithContext(ctx)
+		assert.Equal(t, int64(2), entity.Version)
+		assert.Equal(t, ID("alice"), entity.CreatedBy)
+		assert.Equal(t, ID("bob"), entity.UpdatedBy)
+	})
+
+	t.Run("unauthenticated context leaves audit fields unchanged", func(t *testing.T) {
+		entity := &BaseEntity{Version: 1, CreatedBy: ID("alice"), UpdatedBy: ID("alice")}
+
+		entity.IncrementVersionWithContext(context.Background())
+		entity.TouchWithContext(context.Background())
+		assert.Equal(t, int64(2), entity.Version)
+		assert.Equal(t, ID("alice"), entity.CreatedBy)
+		assert.Equal(t, ID("alice"), entity.UpdatedBy)
+
+		fresh := &BaseEntity{}
+		fresh.TouchWithContext(context.Background())
+		assert.True(t, fresh.CreatedBy.IsEmpty())
+		assert.True(t, fresh.UpdatedBy.IsEmpty())
+	})
+
+	t.Run("audit fields serialize with snake case names", func(t *testing.T) {
+		data, err := json.Marshal(BaseEntity{CreatedBy: ID("alice"), UpdatedBy: ID("bob")})
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"created_by":"alice"`)
+		assert.Contains(t, string(data), `"updated_by":"bob"`)
 	})
 }
 
@@ -200,6 +349,78 @@ func TestListOptions(t *testing.T) {
 	})
 }
 
+func TestListOptionsFromQuery(t *testing.T) {
+	t.Run("missing parameters use defaults", func(t *testing.T) {
+		opts, err := ListOptionsFromQuery(url.Values{})
+		require.NoError(t, err)
+		assert.Equal(t, NewListOptions(), opts)
+	})
+
+	t.Run("partial parameters keep remaining defaults", func(t *testing.T) {
+		opts, err := ListOptionsFromQuery(url.Values{"offset": {"20"}, "sort_by": {"name"}})
+		require.NoError(t, err)
+		assert.Equal(t, int64(20), opts.Offset)
+		assert.Equal(t, int64(50), opts.Limit)
+		assert.Equal(t, "name", opts.SortBy)
+		assert.Equal(t, SortAsc, opts.SortOrder)
+	})
+
+	t.Run("all parameters", func(t *testing.T) {
+		query, err := url.ParseQuery("offset=10&limit=25&sort_by=created_at&sort_order=DESC&search=acme" +
+			"&include_deleted=true&filter.status=active&filter.tag=a&filter.tag=b")
+		require.NoError(t, err)
+
+		opts, err := ListOptionsFromQuery(query)
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), opts.Offset)
+		assert.Equal(t, int64(25), opts.Limit)
+		assert.Equal(t, "created_at", opts.SortBy)
+		assert.Equal(t, SortDesc, opts.SortOrder)
+		assert.Equal(t, "acme", opts.Search)
+		assert.True(t, opts.IncludeDeleted)
+		assert.Equal(t, "active", opts.Filters["status"])
+		assert.Equal(t, []string{"a", "b"}, opts.Filters["tag"])
+	})
+
+	t.Run("malformed parameters return invalid input", func(t *testing.T) {
+		queries := []url.Values{
+			{"offset": {"abc"}},
+			{"limit": {"1.5"}},
+			{"limit": {"-1"}},
+			{"offset": {"-10"}},
+			{"limit": {"5000"}},
+			{"sort_order": {"sideways"}},
+			{"include_deleted": {"maybe"}},
+			{"filter.": {"x"}},
+		}
+
+		for _, query := range queries {
+			_, err := ListOptionsFromQuery(query)
+			require.Error(t, err, query.Encode())
+			assert.True(t, IsInvalidInput(err), query.Encode())
+		}
+	})
+
+	t.Run("round trips through ToQuery", func(t *testing.T) {
+		original := NewListOptions().
+			WithOffset(40).
+			WithLimit(20).
+			WithSort("name", SortDesc).
+			WithSearch("acme").
+			WithFilter("status", "active").
+			WithFilter("tag", []string{"a", "b"})
+
+		parsed, err := ListOptionsFromQuery(original.ToQuery())
+		require.NoError(t, err)
+		assert.Equal(t, original, parsed)
+	})
+
+	t.Run("ToQuery omits unset parameters", func(t *testing.T) {
+		opts := ListOptions{Limit: 10}.WithFilter("count", 3)
+		assert.Equal(t, "filter.count=3&limit=10&offset=0", opts.ToQuery().Encode())
+	})
+}
+
 func TestSortOrder(t *testing.T) {
 	t.Run("valid sort orders", func(t *testing.T) {
 		assert.True(t, SortAsc.IsValid())
@@ -278,6 +499,131 @@ func TestListResult(t *testing.T) {
 	})
 }
 
+func TestListResult_LinkHeader(t *testing.T) {
+	items := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}
+
+	t.Run("first page", func(t *testing.T) {
+		result := NewListResult(items, 35, ListOptions{Offset: 0, Limit: 10})
+		assert.Equal(t,
+			`<https://api.example.com/users?limit=10&offset=0>; rel="first", `+
+				`<https://api.example.com/users?limit=10&offset=10>; rel="next", `+
+				`<https://api.example.com/users?limit=10&offset=30>; rel="last"`,
+			result.LinkHeader("https://api.example.com/users"))
+	})
+
+	t.Run("middle page", func(t *testing.T) {
+		result := NewListResult(items, 35, ListOptions{Offset: 10, Limit: 10})
+		assert.Equal(t,
+			`</users?limit=10&offset=0>; rel="first", `+
+				`</users?limit=10&offset=0>; rel="prev", `+
+				`</users?limit=10&offset=20>; rel="next", `+
+				`</users?limit=10&offset=30>; rel="last"`,
+			result.LinkHeader("/users"))
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		result := NewListResult(items[:5], 35, ListOptions{Offset: 30, Limit: 10})
+		assert.Equal(t,
+			`</users?limit=10&offset=0>; rel="first", `+
+				`</users?limit=10&offset=20>; rel="prev"`,
+			result.LinkHeader("/users"))
+	})
+
+	t.Run("single page", func(t *testing.T) {
+		result := NewListResult(items[:3], 3, ListOptions{Offset: 0, Limit: 10})
+		assert.Equal(t, `</users?limit=10&offset=0>; rel="first"`, result.LinkHeader("/users"))
+	})
+
+	t.Run("unaligned offset clamps prev to zero", func(t *testing.T) {
+		result := NewListResult(items[:3], 8, ListOptions{Offset: 5, Limit: 10})
+		assert.Contains(t, result.LinkHeader("/users"), `</users?limit=10&offset=0>; rel="prev"`)
+	})
+
+	t.Run("preserves existing query parameters", func(t *testing.T) {
+		result := NewListResult(items, 35, ListOptions{Offset: 10, Limit: 10})
+		header := result.LinkHeader("/users?status=active&offset=99")
+		assert.Contains(t, header, `</users?limit=10&offset=20&status=active>; rel="next"`)
+		assert.NotContains(t, header, "offset=99")
+	})
+
+	t.Run("invalid base URL", func(t *testing.T) {
+		result := NewListResult(items, 35, ListOptions{Offset: 0, Limit: 10})
+		assert.Empty(t, result.LinkHeader("://bad"))
+	})
+}
+
+func TestMapListResult(t *testing.T) {
+	type userDTO struct {
+		ID   string
+		Name string
+	}
+	toDTO := func(entity *TestEntity) userDTO {
+		return userDTO{ID: entity.ID.String(), Name: entity.Name}
+	}
+	entities := []*TestEntity{
+		{BaseEntity: BaseEntity{ID: ID("u1")}, Name: "alice"},
+		{BaseEntity: BaseEntity{ID: ID("u2")}, Name: "bob"},
+	}
+
+	t.Run("converts items and preserves metadata", func(t *testing.T) {
+		in := NewListResult(entities, 12, ListOptions{Offset: 10, Limit: 2})
+
+		out := MapListResult(in, toDTO)
+
+		assert.Equal(t, []userDTO{{ID: "u1", Name: "alice"}, {ID: "u2", Name: "bob"}}, out.Items)
+		assert.Equal(t, int64(12), out.Total)
+		assert.Equal(t, int64(10), out.Offset)
+		assert.Equal(t, int64(2), out.Limit)
+		assert.False(t, out.HasMore)
+		assert.Equal(t, in.GetPageInfo(), out.GetPageInfo())
+	})
+
+	t.Run("preserves HasMore and empty items", func(t *testing.T) {
+		in := &ListResult[int]{Items: []int{}, Total: 50, Offset: 0, Limit: 10, HasMore: true}
+
+		out := MapListResult(in, strconv.Itoa)
+
+		assert.NotNil(t, out.Items)
+		assert.Empty(t, out.Items)
+		assert.True(t, out.HasMore)
+		assert.Equal(t, int64(50), out.Total)
+	})
+
+	t.Run("handles nil", func(t *testing.T) {
+		assert.Nil(t, MapListResult[int, string](nil, strconv.Itoa))
+		assert.Nil(t, MapListResult(&ListResult[int]{}, strconv.Itoa).Items)
+	})
+}
+
+func TestFilterListResult(t *testing.T) {
+	t.Run("keeps matching items and metadata", func(t *testing.T) {
+		in := NewListResult([]int{1, 2, 3, 4, 5}, 20, ListOptions{Offset: 5, Limit: 5})
+
+		out := FilterListResult(in, func(n int) bool { return n%2 == 1 })
+
+		assert.Equal(t, []int{1, 3, 5}, out.Items)
+		assert.Equal(t, int64(20), out.Total, "Total is not adjusted")
+		assert.Equal(t, int64(5), out.Offset)
+		assert.Equal(t, int64(5), out.Limit)
+		assert.True(t, out.HasMore)
+		assert.Equal(t, []int{1, 2, 3, 4, 5}, in.Items, "input is not modified")
+	})
+
+	t.Run("may drop all items", func(t *testing.T) {
+		in := NewListResult([]int{1, 2}, 2, ListOptions{Limit: 10})
+
+		out := FilterListResult(in, func(int) bool { return false })
+
+		assert.True(t, out.IsEmpty())
+		assert.NotNil(t, out.Items)
+		assert.Equal(t, int64(2), out.Total)
+	})
+
+	t.Run("handles nil", func(t *testing.T) {
+		assert.Nil(t, FilterListResult[int](nil, func(int) bool { return true }))
+	})
+}
+
 func TestStatus(t *testing.T) {
 	t.Run("valid statuses", func(t *testing.T) {
 		validStatuses := []Status{
@@ -307,6 +653,69 @@ func TestStatus(t *testing.T) {
 	})
 }
 
+func TestStatusMachine(t *testing.T) {
+	t.Run("default machine rules", func(t *testing.T) {
+		machine := NewDefaultStatusMachine()
+
+		assert.True(t, machine.CanTransition(StatusPending, StatusActive))
+		assert.True(t, machine.CanTransition(StatusActive, StatusCompleted))
+		assert.True(t, machine.CanTransition(StatusInactive, StatusActive))
+		assert.True(t, machine.CanTransition(StatusCompleted, StatusDeleted))
+
+		assert.False(t, machine.CanTransition(StatusCompleted, StatusActive))
+		assert.False(t, machine.CanTransition(StatusCancelled, StatusPending))
+		assert.False(t, machine.CanTransition(StatusActive, StatusActive))
+		assert.Empty(t, machine.AllowedTransitions(StatusDeleted))
+	})
+
+	t.Run("transition returns new status", func(t *testing.T) {
+		machine := NewDefaultStatusMachine()
+
+		status, err := machine.Transition(StatusPending, StatusActive)
+		require.NoError(t, err)
+		assert.Equal(t, StatusActive, status)
+	})
+
+	t.Run("illegal transition returns coded error", func(t *testing.T) {
+		machine := NewDefaultStatusMachine()
+
+		status, err := machine.Transition(StatusCompleted, StatusActive)
+		require.Error(t, err)
+		assert.Equal(t, StatusCompleted, status)
+		assert.True(t, IsConflict(err))
+		assert.Contains(t, err.Error(), "illegal status transition from 'completed' to 'active'")
+
+		var tbpErr *Error
+		require.ErrorAs(t, err, &tbpErr)
+		from, _ := tbpErr.GetContext("from")
+		assert.Equal(t, "completed", from)
+	})
+
+	t.Run("custom machine", func(t *testing.T) {
+		const (
+			draft     Status = "draft"
+			review    Status = "review"
+			published Status = "published"
+		)
+
+		machine := NewStatusMachine().
+			Allow(draft, review).
+			Allow(review, draft, published)
+
+		assert.True(t, machine.CanTransition(draft, review))
+		assert.True(t, machine.CanTransition(review, draft))
+		assert.False(t, machine.CanTransition(draft, published))
+		assert.Equal(t, []Status{draft, published}, machine.AllowedTransitions(review))
+	})
+
+	t.Run("default machines are independent", func(t *testing.T) {
+		extended := NewDefaultStatusMachine().Allow(StatusCompleted, StatusActive)
+
+		assert.True(t, extended.CanTransition(StatusCompleted, StatusActive))
+		assert.False(t, NewDefaultStatusMachine().CanTransition(StatusCompleted, StatusActive))
+	})
+}
+
 func TestPriority(t *testing.T) {
 	t.Run("valid priorities", func(t *testing.T) {
 		validPriorities := []Priority{
@@ -368,6 +777,57 @@ func TestPriority(t *testing.T) {
 		err := json.Unmarshal([]byte(`true`), &priority)
 		assert.Error(t, err)
 	})
+
+	t.Run("ordering across all levels", func(t *testing.T) {
+		all := AllPriorities()
+		assert.Equal(t, []Priority{PriorityLow, PriorityMedium, PriorityHigh, PriorityCritical}, all)
+
+		for i, p := range all {
+			for j, other := range all {
+				assert.Equal(t, i > j, p.Higher(other), "%s higher than %s", p, other)
+				assert.Equal(t, i < j, p.Lower(other), "%s lower than %s", p, other)
+				switch {
+				case i < j:
+					assert.Equal(t, -1, p.Compare(other))
+				case i > j:
+					assert.Equal(t, 1, p.Compare(other))
+				default:
+					assert.Equal(t, 0, p.Compare(other))
+				}
+			}
+		}
+
+		sorted := []Priority{PriorityHigh, PriorityLow, PriorityCritical, PriorityMedium}
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i].Higher(sorted[j]) })
+		assert.Equal(t, []Priority{PriorityCritical, PriorityHigh, PriorityMedium, PriorityLow}, sorted)
+	})
+
+	t.Run("parses names and numbers", func(t *testing.T) {
+		for _, priority := range AllPriorities() {
+			parsed, err := ParsePriority(priority.String())
+			require.NoError(t, err)
+			assert.Equal(t, priority, parsed)
+
+			parsed, err = ParsePriority(strconv.Itoa(int(priority)))
+			require.NoError(t, err)
+			assert.Equal(t, priority, parsed)
+		}
+
+		parsed, err := ParsePriority(" Critical ")
+		require.NoError(t, err)
+		assert.Equal(t, PriorityCritical, parsed)
+	})
+
+	t.Run("rejects invalid zero and overflow values", func(t *testing.T) {
+		for _, input := range []string{"", "0", "5", "99", "-1", "urgent", "unknown"} {
+			_, err := ParsePriority(input)
+			require.Error(t, err, input)
+			assert.True(t, IsInvalidInput(err), input)
+		}
+
+		assert.True(t, Priority(0).Lower(PriorityLow))
+		assert.True(t, Priority(5).Higher(PriorityCritical))
+	})
 }
 
 func TestBaseEvent(t *testing.T) {
@@ -482,6 +942,169 @@ func TestMetadata(t *testing.T) {
 	})
 }
 
+func TestTypedMetadata(t *testing.T) {
+	created := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	t.Run("get, set, and has", func(t *testing.T) {
+		metadata := make(TypedMetadata)
+		metadata.Set("retries", 3)
+
+		value, exists := metadata.Get("retries")
+		assert.True(t, exists)
+		assert.Equal(t, 3, value)
+		assert.True(t, metadata.Has("retries"))
+		assert.False(t, metadata.Has("missing"))
+	})
+
+	t.Run("nil metadata", func(t *testing.T) {
+		var metadata TypedMetadata
+
+		_, exists := metadata.Get("key")
+		assert.False(t, exists)
+		assert.False(t, metadata.Has("key"))
+
+		// Set on nil metadata should not panic but also not work
+		metadata.Set("key", 1)
+		assert.False(t, metadata.Has("key"))
+		assert.Nil(t, metadata.Clone())
+		assert.Nil(t, metadata.ToMetadata())
+	})
+
+	t.Run("clone", func(t *testing.T) {
+		original := TypedMetadata{"count": 1}
+		cloned := original.Clone()
+		cloned.Set("count", 2)
+
+		assert.Equal(t, 1, original["count"])
+		assert.Equal(t, 2, cloned["count"])
+	})
+
+	t.Run("typed getters convert values", func(t *testing.T) {
+		metadata := TypedMetadata{
+			"count":     42,
+			"ratio":     2.9,
+			"count_str": " 7 ",
+			"enabled":   true,
+			"flag_str":  "yes",
+			"flag_num":  0,
+			"created":   created,
+			"date_str":  "2024-01-15",
+			"unix":      int64(1705314600),
+			"name":      "test",
+		}
+
+		count, err := metadata.GetInt("count")
+		require.NoError(t, err)
+		assert.Equal(t, 42, count)
+
+		ratio, err := metadata.GetInt("ratio")
+		require.NoError(t, err)
+		assert.Equal(t, 2, ratio)
+
+		countStr, err := metadata.GetInt("count_str")
+		require.NoError(t, err)
+		assert.Equal(t, 7, countStr)
+
+		enabled, err := metadata.GetBool("enabled")
+		require.NoError(t, err)
+		assert.True(t, enabled)
+
+		flagStr, err := metadata.GetBool("flag_str")
+		require.NoError(t, err)
+		assert.True(t, flagStr)
+
+		flagNum, err := metadata.GetBool("flag_num")
+		require.NoError(t, err)
+		assert.False(t, flagNum)
+
+		createdAt, err := metadata.GetTime("created")
+		require.NoError(t, err)
+		assert.Equal(t, created, createdAt)
+
+		date, err := metadata.GetTime("date_str")
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), date)
+
+		unix, err := metadata.GetTime("unix")
+		require.NoError(t, err)
+		assert.True(t, created.Equal(unix))
+
+		name, err := metadata.GetString("name")
+		require.NoError(t, err)
+		assert.Equal(t, "test", name)
+
+		countAsString, err := metadata.GetString("count")
+		require.NoError(t, err)
+		assert.Equal(t, "42", countAsString)
+	})
+
+	t.Run("typed getters return coded errors", func(t *testing.T) {
+		metadata := TypedMetadata{"name": "test"}
+
+		_, err := metadata.GetInt("missing")
+		assert.True(t, IsNotFound(err))
+
+		_, err = metadata.GetInt("name")
+		assert.True(t, IsInvalidInput(err))
+		assert.Contains(t, err.Error(), "cannot be converted to int")
+
+		_, err = metadata.GetBool("name")
+		assert.True(t, IsInvalidInput(err))
+
+		_, err = metadata.GetTime("name")
+		assert.True(t, IsInvalidInput(err))
+	})
+
+	t.Run("JSON round-trip", func(t *testing.T) {
+		metadata := TypedMetadata{
+			"count":   9007199254740993, // Beyond float64 integer precision
+			"enabled": true,
+			"created": created,
+		}
+
+		data, err := json.Marshal(metadata)
+		require.NoError(t, err)
+
+		var decoded TypedMetadata
+		require.NoError(t, json.Unmarshal(data, &decoded))
+
+		count, err := decoded.GetInt("count")
+		require.NoError(t, err)
+		assert.Equal(t, 9007199254740993, count)
+
+		enabled, err := decoded.GetBool("enabled")
+		require.NoError(t, err)
+		assert.True(t, enabled)
+
+		createdAt, err := decoded.GetTime("created")
+		require.NoError(t, err)
+		assert.True(t, created.Equal(createdAt))
+	})
+
+	t.Run("converts to and from string metadata", func(t *testing.T) {
+		typed := TypedMetadata{"count": 42, "enabled": true, "created": created}
+
+		metadata := typed.ToMetadata()
+		assert.Equal(t, Metadata{
+			"count":   "42",
+			"enabled": "true",
+			"created": "2024-01-15T10:30:00Z",
+		}, metadata)
+
+		roundTrip := metadata.ToTypedMetadata()
+		count, err := roundTrip.GetInt("count")
+		require.NoError(t, err)
+		assert.Equal(t, 42, count)
+
+		createdAt, err := roundTrip.GetTime("created")
+		require.NoError(t, err)
+		assert.Equal(t, created, createdAt)
+
+		var nilMetadata Metadata
+		assert.Nil(t, nilMetadata.ToTypedMetadata())
+	})
+}
+
 func TestParseID(t *testing.T) {
 	t.Run("valid ID", func(t *testing.T) {
 		id, err := ParseID("test123")
@@ -494,6 +1117,97 @@ func TestParseID(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "cannot be empty")
 	})
+
+	t.Run("requires UUID format", func(t *testing.T) {
+		id, err := ParseID("3f2b8c1e-9d4a-4e6b-8f1a-2c3d4e5f6a7b", RequireUUID)
+		require.NoError(t, err)
+		assert.Equal(t, ID("3f2b8c1e-9d4a-4e6b-8f1a-2c3d4e5f6a7b"), id)
+
+		_, err = ParseID("test123", RequireUUID)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not a valid UUID")
+	})
+
+	t.Run("requires prefixed UUID format", func(t *testing.T) {
+		generated := NewIDWithPrefix("user")
+
+		id, err := ParseID(generated.String(), RequirePrefixedUUID("user"))
+		require.NoError(t, err)
+		assert.Equal(t, generated, id)
+
+		_, err = ParseID(generated.String(), RequirePrefixedUUID("tenant"))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "does not have prefix")
+
+		_, err = ParseID("user_123", RequirePrefixedUUID("user"))
+		assert.Error(t, err)
+	})
+}
+
+func TestNewID(t *testing.T) {
+	t.Run("generates valid UUIDv4", func(t *testing.T) {
+		id := NewID()
+		assert.True(t, IsValidUUID(id))
+		assert.Equal(t, byte('4'), id.String()[14])         // Version 4
+		assert.Contains(t, "89ab", string(id.String()[19])) // RFC 4122 variant
+	})
+
+	t.Run("generates prefixed IDs", func(t *testing.T) {
+		id := NewIDWithPrefix("user")
+		assert.True(t, strings.HasPrefix(id.String(), "user_"))
+		assert.True(t, IsValidUUID(ID(strings.TrimPrefix(id.String(), "user_"))))
+
+		assert.True(t, IsValidUUID(NewIDWithPrefix("")))
+	})
+
+	t.Run("generates unique IDs across goroutines", func(t *testing.T) {
+		const numGoroutines = 50
+		const idsPerGoroutine = 200
+
+		var wg sync.WaitGroup
+		results := make(chan ID, numGoroutines*idsPerGoroutine)
+
+		for i := 0; i < numGoroutines; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < idsPerGoroutine; j++ {
+					results <- NewID()
+				}
+			}()
+		}
+
+		wg.Wait()
+		close(results)
+
+		seen := make(map[ID]bool)
+		for id := range results {
+			assert.False(t, seen[id], "Duplicate ID found: %s", id)
+			seen[id] = true
+		}
+		assert.Len(t, seen, numGoroutines*idsPerGoroutine)
+	})
+}
+
+func TestIsValidUUID(t *testing.T) {
+	testCases := []struct {
+		id    ID
+		valid bool
+	}{
+		{"3f2b8c1e-9d4a-4e6b-8f1a-2c3d4e5f6a7b", true},
+		{"3F2B8C1E-9D4A-4E6B-8F1A-2C3D4E5F6A7B", true},
+		{"3f2b8c1e9d4a4e6b8f1a2c3d4e5f6a7b", false},
+		{"3f2b8c1e-9d4a-4e6b-8f1a-2c3d4e5f6a7", false},
+		{"3f2b8c1e-9d4a-4e6b-8f1a-2c3d4e5f6a7g", false},
+		{"user_3f2b8c1e-9d4a-4e6b-8f1a-2c3d4e5f6a7b", false},
+		{"", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.id), func(t *testing.T) {
+			assert.Equal(t, tc.valid, IsValidUUID(tc.id))
+		})
+	}
 }
 
 func TestMustParseID(t *testing.T) {
@@ -604,9 +1318,71 @@ func TestRepository_Generics(t *testing.T) {
 		assert.Equal(t, int64(1), count)
 		assert.Equal(t, 1, repo.countCalled)
 	})
+
+	t.Run("optional exists and upsert", func(t *testing.T) {
+		repo := &mockRepository[*TestEntity]{}
+		var _ ExistsRepository[*TestEntity] = repo
+		var _ UpsertRepository[*TestEntity] = repo
+
+		ctx := context.Background()
+		entity := &TestEntity{BaseEntity: BaseEntity{ID: ID("test123")}}
+
+		exists, err := repo.Exists(ctx, entity.ID)
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		// Upsert of an absent entity creates it
+		require.NoError(t, repo.Upsert(ctx, entity))
+		assert.Equal(t, 1, repo.createCalled)
+		assert.Equal(t, int64(1), entity.Version)
+
+		exists, err = EntityExists[*TestEntity](ctx, repo, entity.ID)
+		require.NoError(t, err)
+		assert.True(t, exists)
+		assert.Equal(t, 2, repo.existsCalled)
+		assert.Equal(t, 0, repo.getByIDCalled)
+
+		// Upsert of a stored entity updates it
+		require.NoError(t, repo.Upsert(ctx, entity))
+		assert.Equal(t, 1, repo.updateCalled)
+		assert.Equal(t, int64(2), entity.Version)
+
+		// Stale versions conflict like Update
+		stale := &TestEntity{BaseEntity: BaseEntity{ID: ID("test123"), Version: 1}}
+		err = repo.Upsert(ctx, stale)
+		assert.True(t, IsConflict(err))
+	})
+
+	t.Run("exists falls back to GetByID", func(t *testing.T) {
+		mock := &mockRepository[*TestEntity]{}
+		repo := minimalRepository[*TestEntity]{mock}
+
+		_, isExistsRepo := Repository[*TestEntity](repo).(ExistsRepository[*TestEntity])
+		assert.False(t, isExistsRepo)
+
+		exists, err := EntityExists[*TestEntity](context.Background(), repo, ID("missing"))
+		require.NoError(t, err)
+		assert.False(t, exists)
+		assert.Equal(t, 1, mock.getByIDCalled)
+
+		require.NoError(t, mock.Create(context.Background(), &TestEntity{BaseEntity: BaseEntity{ID: ID("test123")}}))
+		exists, err = EntityExists[*TestEntity](context.Background(), repo, ID("test123"))
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
 }
 
-// Mock repository for testing generics
+// minimalRepository exposes only the Repository methods of a repository
+type minimalRepository[T Entity] struct {
+	Repository[T]
+}
+
+// Mock repository for testing generics.
+// It stores entities in memory and serves as a reference implementation of
+// the Repository semantics: GetByID returns ErrNotFound for missing
+// entities, List and Count honor ListOptions.IncludeDeleted, and Delete
+// marks soft-deletable entities as deleted. Entities without
+// soft delete support are kept, as the mock only records hard deletes.
 type mockRepository[T Entity] struct {
 	createCalled  int
 	getByIDCalled int
@@ -614,39 +1390,269 @@ type mockRepository[T Entity] struct {
 	deleteCalled  int
 	listCalled    int
 	countCalled   int
-	entity        T
+	existsCalled  int
+	upsertCalled  int
+	entities      []T
+	versions      map[ID]int64
+}
+
+// find returns the index of the stored entity with the given ID or -1
+func (r *mockRepository[T]) find(id ID) int {
+	for i, entity := range r.entities {
+		if entity.GetID() == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// store adds or replaces an entity and records its stored version
+func (r *mockRepository[T]) store(entity T) {
+	if r.versions == nil {
+		r.versions = make(map[ID]int64)
+	}
+	r.versions[entity.GetID()] = entity.GetVersion()
+
+	if i := r.find(entity.GetID()); i >= 0 {
+		r.entities[i] = entity
+		return
+	}
+	r.entities = append(r.entities, entity)
 }
 
 func (r *mockRepository[T]) Create(ctx context.Context, entity T) error {
 	r.createCalled++
-	r.entity = entity
+	r.store(entity)
 	return nil
 }
 
 func (r *mockRepository[T]) GetByID(ctx context.Context, id ID) (T, error) {
 	r.getByIDCalled++
-	return r.entity, nil
+	i := r.find(id)
+	if i < 0 {
+		var zero T
+		return zero, WrapWithCodef(ErrNotFound, ErrCodeNotFound, "entity %s not found", id)
+	}
+	return r.entities[i], nil
 }
 
 func (r *mockRepository[T]) Update(ctx context.Context, entity T) error {
 	r.updateCalled++
-	r.entity = entity
+	r.store(entity)
 	return nil
 }
 
 func (r *mockRepository[T]) Delete(ctx context.Context, id ID) error {
 	r.deleteCalled++
+	if i := r.find(id); i >= 0 {
+		if deletable, ok := any(r.entities[i]).(interface{ MarkDeleted() }); ok {
+			deletable.MarkDeleted()
+		}
+	}
 	return nil
 }
 
+func (r *mockRepository[T]) Exists(ctx context.Context, id ID) (bool, error) {
+	r.existsCalled++
+	return !id.IsEmpty() && r.find(id) >= 0, nil
+}
+
+// Upsert creates absent entities and updates stored ones, applying
+// optimistic locking through the entity version
+func (r *mockRepository[T]) Upsert(ctx context.Context, entity T) error {
+	r.upsertCalled++
+
+	versioned, hasVersion := any(entity).(interface{ IncrementVersion() })
+	if entity.GetID().IsEmpty() || r.find(entity.GetID()) < 0 {
+		if hasVersion && entity.GetVersion() == 0 {
+			versioned.IncrementVersion()
+		}
+		return r.Create(ctx, entity)
+	}
+
+	storedVersion := r.versions[entity.GetID()]
+	if entity.GetVersion() != storedVersion {
+		return Newf("version conflict: stored %d, got %d", storedVersion, entity.GetVersion()).WithCode(ErrCodeConflict)
+	}
+	if hasVersion {
+		versioned.IncrementVersion()
+	}
+	return r.Update(ctx, entity)
+}
+
 func (r *mockRepository[T]) List(ctx context.Context, opts ListOptions) ([]T, error) {
 	r.listCalled++
-	return []T{r.entity}, nil
+	return FilterSoftDeleted(r.entities, opts.IncludeDeleted), nil
 }
 
 func (r *mockRepository[T]) Count(ctx context.Context, opts ListOptions) (int64, error) {
 	r.countCalled++
-	return 1, nil
+	return int64(len(FilterSoftDeleted(r.entities, opts.IncludeDeleted))), nil
+}
+
+// archivableEntity is a test entity supporting soft deletes
+type archivableEntity struct {
+	BaseEntity
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+func (e *archivableEntity) IsDeleted() bool {
+	return e.DeletedAt != nil
+}
+
+func (e *archivableEntity) MarkDeleted() {
+	now := time.Now()
+	e.DeletedAt = &now
+}
+
+func TestCheckVersion(t *testing.T) {
+	t.Run("accepts matching versions", func(t *testing.T) {
+		stored := &BaseEntity{ID: "order-1", Version: 3}
+		incoming := &BaseEntity{ID: "order-1", Version: 3}
+		assert.NoError(t, CheckVersion(stored, incoming))
+	})
+
+	t.Run("rejects mismatching versions", func(t *testing.T) {
+		stored := &BaseEntity{ID: "order-1", Version: 4}
+		incoming := &BaseEntity{ID: "order-1", Version: 3}
+
+		err := CheckVersion(stored, incoming)
+		require.Error(t, err)
+		assert.True(t, IsVersionConflict(err))
+		assert.True(t, IsConflict(err))
+		assert.True(t, errors.Is(err, ErrVersionConflict))
+		code, ok := GetCode(err)
+		require.True(t, ok)
+		assert.Equal(t, ErrCodeVersionConflict, code, "outermost code identifies the version conflict")
+		assert.False(t, IsRetryable(err), "conflicts require a refetch before retrying")
+		assert.Contains(t, err.Error(), "order-1")
+
+		var tbpErr *Error
+		require.True(t, errors.As(err, &tbpErr))
+		assert.Equal(t, int64(4), tbpErr.Context["stored_version"])
+		assert.Equal(t, int64(3), tbpErr.Context["incoming_version"])
+	})
+
+	t.Run("treats zero version as a new entity", func(t *testing.T) {
+		assert.NoError(t, CheckVersion(&BaseEntity{ID: "new"}, &BaseEntity{ID: "new"}))
+
+		err := CheckVersion(&BaseEntity{ID: "order-1", Version: 2}, &BaseEntity{ID: "order-1"})
+		assert.True(t, IsVersionConflict(err), "zero version does not bypass the check")
+	})
+}
+
+func TestGetByIDContract(t *testing.T) {
+	ctx := context.Background()
+	stored := &TestEntity{BaseEntity: BaseEntity{ID: ID("order-1")}, Name: "stored"}
+	def := &TestEntity{Name: "default"}
+
+	newRepo := func() *mockRepository[*TestEntity] {
+		repo := &mockRepository[*TestEntity]{}
+		repo.store(stored)
+		return repo
+	}
+
+	t.Run("returns ErrNotFound for missing entities", func(t *testing.T) {
+		entity, err := newRepo().GetByID(ctx, ID("missing"))
+		require.Error(t, err)
+		assert.Nil(t, entity)
+		assert.True(t, IsNotFoundID(err))
+		assert.True(t, errors.Is(err, ErrNotFound))
+		code, _ := GetCode(err)
+		assert.Equal(t, ErrCodeNotFound, code)
+		assert.Contains(t, err.Error(), "missing")
+	})
+
+	t.Run("IsNotFoundID rejects other errors", func(t *testing.T) {
+		assert.False(t, IsNotFoundID(nil))
+		assert.False(t, IsNotFoundID(ErrConflict))
+		assert.False(t, IsNotFoundID(errors.New("connection refused")))
+		assert.True(t, IsNotFoundID(Wrap(ErrNotFound, "loading order")))
+	})
+
+	t.Run("GetByIDOrDefault returns stored entity", func(t *testing.T) {
+		entity, err := GetByIDOrDefault[*TestEntity](newRepo(), ctx, ID("order-1"), def)
+		require.NoError(t, err)
+		assert.Same(t, stored, entity)
+	})
+
+	t.Run("GetByIDOrDefault swallows not found", func(t *testing.T) {
+		entity, err := GetByIDOrDefault[*TestEntity](newRepo(), ctx, ID("missing"), def)
+		require.NoError(t, err)
+		assert.Same(t, def, entity)
+	})
+
+	t.Run("GetByIDOrDefault returns other errors", func(t *testing.T) {
+		repo := NewTenantScopedRepository[*tenantEntity](&mockRepository[*tenantEntity]{})
+
+		entity, err := GetByIDOrDefault(repo, ctx, ID("a1"), &tenantEntity{})
+		require.Error(t, err)
+		assert.True(t, IsForbidden(err))
+		assert.Nil(t, entity)
+	})
+
+	t.Run("tenant-scoped repository follows the contract", func(t *testing.T) {
+		inner := &mockRepository[*tenantEntity]{}
+		inner.store(&tenantEntity{BaseEntity: BaseEntity{ID: "g1"}, TenantID: "globex"})
+		repo := NewTenantScopedRepository[*tenantEntity](inner)
+
+		_, err := repo.GetByID(WithTenantID(ctx, "acme"), ID("g1"))
+		assert.True(t, errors.Is(err, ErrNotFound))
+		assert.True(t, IsNotFoundID(err))
+	})
+}
+
+func TestFilterSoftDeleted(t *testing.T) {
+	deletedAt := time.Now()
+	active := &archivableEntity{BaseEntity: BaseEntity{ID: ID("active")}}
+	deleted := &archivableEntity{BaseEntity: BaseEntity{ID: ID("deleted")}, DeletedAt: &deletedAt}
+
+	t.Run("drops deleted entities by default", func(t *testing.T) {
+		items := []*archivableEntity{active, deleted}
+		assert.Equal(t, []*archivableEntity{active}, FilterSoftDeleted(items, false))
+		assert.Len(t, items, 2, "input must not be modified")
+	})
+
+	t.Run("keeps deleted entities when included", func(t *testing.T) {
+		assert.Equal(t, []*archivableEntity{active, deleted}, FilterSoftDeleted([]*archivableEntity{active, deleted}, true))
+	})
+
+	t.Run("keeps entities without soft delete support", func(t *testing.T) {
+		items := []*TestEntity{{BaseEntity: BaseEntity{ID: ID("plain")}}}
+		assert.Equal(t, items, FilterSoftDeleted(items, false))
+	})
+
+	t.Run("empty input", func(t *testing.T) {
+		assert.Empty(t, FilterSoftDeleted[*archivableEntity](nil, false))
+	})
+
+	t.Run("mock repository honors IncludeDeleted", func(t *testing.T) {
+		ctx := context.Background()
+		repo := &mockRepository[*archivableEntity]{}
+		require.NoError(t, repo.Create(ctx, &archivableEntity{BaseEntity: BaseEntity{ID: ID("a")}}))
+		require.NoError(t, repo.Create(ctx, &archivableEntity{BaseEntity: BaseEntity{ID: ID("b")}}))
+		require.NoError(t, repo.Delete(ctx, ID("b")))
+
+		visible, err := repo.List(ctx, NewListOptions())
+		require.NoError(t, err)
+		require.Len(t, visible, 1)
+		assert.Equal(t, ID("a"), visible[0].ID)
+
+		count, err := repo.Count(ctx, NewListOptions())
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+
+		opts := NewListOptions()
+		opts.IncludeDeleted = true
+		all, err := repo.List(ctx, opts)
+		require.NoError(t, err)
+		assert.Len(t, all, 2)
+
+		// Soft-deleted entities remain retrievable by ID
+		entity, err := repo.GetByID(ctx, ID("b"))
+		require.NoError(t, err)
+		assert.True(t, entity.IsDeleted())
+	})
 }
 
 // Benchmark tests for performance validation
@@ -912,6 +1918,39 @@ func BenchmarkParseID(b *testing.B) {
 	}
 }
 
+func BenchmarkNewID(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = NewID()
+	}
+}
+
+func BenchmarkNewID_Parallel(b *testing.B) {
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = NewID()
+		}
+	})
+}
+
+func BenchmarkNewIDWithPrefix(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = NewIDWithPrefix("user")
+	}
+}
+
+func BenchmarkIsValidUUID(b *testing.B) {
+	id := NewID()
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = IsValidUUID(id)
+	}
+}
+
 func BenchmarkToIntID(b *testing.B) {
 	id := ID("123456")
 
@@ -955,11 +1994,11 @@ func BenchmarkRepository_Create(b *testing.B) {
 
 func BenchmarkRepository_GetByID(b *testing.B) {
 	repo := &mockRepository[*TestEntity]{}
-	repo.entity = &TestEntity{
+	ctx := context.Background()
+	_ = repo.Create(ctx, &TestEntity{
 		BaseEntity: BaseEntity{ID: ID("test123")},
 		Name:       "Test Entity",
-	}
-	ctx := context.Background()
+	})
 
 	b.ResetTimer()
 	b.ReportAllocs()
@@ -970,11 +2009,11 @@ func BenchmarkRepository_GetByID(b *testing.B) {
 
 func BenchmarkRepository_List(b *testing.B) {
 	repo := &mockRepository[*TestEntity]{}
-	repo.entity = &TestEntity{
+	ctx := context.Background()
+	_ = repo.Create(ctx, &TestEntity{
 		BaseEntity: BaseEntity{ID: ID("test123")},
 		Name:       "Test Entity",
-	}
-	ctx := context.Background()
+	})
 	opts := NewListOptions().WithLimit(10)
 
 	b.ResetTimer()
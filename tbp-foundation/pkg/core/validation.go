@@ -0,0 +1,229 @@
+// File: validation.go
+// Title: Domain Object Validation for TBP
+// Description: Provides the Validatable interface for domain objects and a
+//              small reflection-based validator driven by `validate` struct
+//              tags, so simple entities can be validated without hand-written
+//              Validate methods. Field errors are aggregated into a MultiError.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial Validatable interface and tag validator
+
+package core
+
+import (
+	"fmt"
+	"net/mail"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Validatable is implemented by domain objects that can check their own
+// invariants. Implementations may return a *MultiError to report several
+// field errors at once.
+type Validatable interface {
+	Validate() error
+}
+
+// ValidateEntity validates an entity using its `validate` struct tags and,
+// if it implements Validatable, its Validate method. All failures are
+// aggregated into a *MultiError wrapped in an ErrCodeInvalidInput error.
+// Entities without tags or Validate method always pass.
+func ValidateEntity[T Entity](e T) error {
+	return validateObject(e, "entity validation failed")
+}
+
+// ValidateStruct validates any struct (or pointer to struct) like
+// ValidateEntity, e.g. commands or request payloads.
+func ValidateStruct(v interface{}) error {
+	return validateObject(v, "validation failed")
+}
+
+// validateObject runs tag and interface validation and aggregates the results
+func validateObject(v interface{}, message string) error {
+	errs := &MultiError{}
+
+	if err := validateTags(reflect.ValueOf(v), errs); err != nil {
+		return err
+	}
+
+	if validatable, ok := v.(Validatable); ok {
+		errs.Append(validatable.Validate())
+	}
+
+	if errs.Len() == 0 {
+		return nil
+	}
+	return WrapWithCode(errs, ErrCodeInvalidInput, message)
+}
+
+// Supported validation tag rules.
+//
+//	required   the value must not be the zero value
+//	omitempty  skip the remaining rules if the value is the zero value
+//	min=N      numbers must be >= N; strings, slices, and maps need length >= N
+//	max=N      numbers must be <= N; strings, slices, and maps need length <= N
+//	email      strings must be a plain e-mail address
+//	oneof=a b  the value formatted with fmt.Sprint must be one of the listed words
+//
+// Rules other than required are skipped for nil pointers.
+const validateTagName = "validate"
+
+// validateTags checks the `validate` tags of a struct and its embedded
+// structs. Returns an error only for malformed tags.
+func validateTags(rv reflect.Value, errs *MultiError) error {
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		value := rv.Field(i)
+		if field.Anonymous {
+			if err := validateTags(value, errs); err != nil {
+				return err
+			}
+		}
+
+		tag := field.Tag.Get(validateTagName)
+		if tag == "" || tag == "-" {
+			continue
+		}
+
+		if err := validateField(fieldName(field), value, tag, errs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fieldName returns the JSON name of a field, falling back to the Go name
+func fieldName(field reflect.StructField) string {
+	if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name != "" && name != "-" {
+		return name
+	}
+	return field.Name
+}
+
+// validateField applies all rules of a tag to a field value
+func validateField(name string, value reflect.Value, tag string, errs *MultiError) error {
+	for value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+	isZero := value.IsZero()
+	isNil := value.Kind() == reflect.Ptr
+
+	for _, rule := range strings.Split(tag, ",") {
+		ruleName, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+
+		switch {
+		case ruleName == "required":
+			if isZero {
+				errs.Append(fieldError(name, ruleName, "field '%s' is required", name))
+				return nil // Further rules are meaningless for a missing value
+			}
+			continue
+		case ruleName == "omitempty":
+			if isZero {
+				return nil
+			}
+			continue
+		case isNil:
+			continue
+		}
+
+		var err error
+		switch ruleName {
+		case "min", "max":
+			err = validateBound(name, ruleName, param, value, errs)
+		case "email":
+			address, ok := value.Interface().(string)
+			if !ok {
+				return Newf("validation rule 'email' on field '%s' requires a string", name).WithCode(ErrCodeInternal)
+			}
+			if parsed, parseErr := mail.ParseAddress(address); parseErr != nil || parsed.Address != address {
+				errs.Append(fieldError(name, ruleName, "field '%s' must be a valid email address", name))
+			}
+		case "oneof":
+			options := strings.Fields(param)
+			actual := fmt.Sprint(value.Interface())
+			if !containsString(options, actual) {
+				errs.Append(fieldError(name, ruleName, "field '%s' must be one of [%s]", name, strings.Join(options, " ")))
+			}
+		default:
+			return Newf("unknown validation rule '%s' on field '%s'", ruleName, name).WithCode(ErrCodeInternal)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateBound checks min and max rules against numbers and lengths
+func validateBound(name, rule, param string, value reflect.Value, errs *MultiError) error {
+	bound, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return Newf("invalid parameter '%s' for validation rule '%s' on field '%s'", param, rule, name).WithCode(ErrCodeInternal)
+	}
+
+	var actual float64
+	subject := "value"
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		actual = float64(value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		actual = float64(value.Uint())
+	case reflect.Float32, reflect.Float64:
+		actual = value.Float()
+	case reflect.String:
+		actual = float64(len([]rune(value.String())))
+		subject = "length"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		actual = float64(value.Len())
+		subject = "length"
+	default:
+		return Newf("validation rule '%s' is not supported for field '%s' of kind %s", rule, name, value.Kind()).WithCode(ErrCodeInternal)
+	}
+
+	if rule == "min" && actual < bound {
+		errs.Append(fieldError(name, rule, "field '%s' %s must be at least %s", name, subject, param))
+	}
+	if rule == "max" && actual > bound {
+		errs.Append(fieldError(name, rule, "field '%s' %s must be at most %s", name, subject, param))
+	}
+	return nil
+}
+
+// fieldError creates an invalid input error for a failed field rule
+func fieldError(field, rule, format string, args ...interface{}) *Error {
+	return Newf(format, args...).
+		WithCode(ErrCodeInvalidInput).
+		WithContext("field", field).
+		WithContext("rule", rule)
+}
+
+// containsString checks if a slice contains a string
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
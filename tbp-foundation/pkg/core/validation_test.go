@@ -0,0 +1,185 @@
+// File: validation_test.go
+// Title: Tests for Domain Object Validation
+// Description: Test suite for the Validatable interface, tag-driven field
+//              validation, MultiError aggregation, and malformed tags.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type validatedCustomer struct {
+	BaseEntity
+	Name     string   `json:"name" validate:"required,min=2,max=20"`
+	Email    string   `json:"email" validate:"required,email"`
+	Age      int      `json:"age" validate:"omitempty,min=18,max=130"`
+	Tier     string   `json:"tier" validate:"omitempty,oneof=free pro enterprise"`
+	Tags     []string `json:"tags" validate:"max=2"`
+	Nickname *string  `json:"nickname" validate:"min=3"`
+}
+
+type selfValidatingOrder struct {
+	BaseEntity
+	Quantity int `json:"quantity" validate:"min=1"`
+	Discount int `json:"discount"`
+}
+
+func (o *selfValidatingOrder) Validate() error {
+	if o.Discount > o.Quantity {
+		return New("discount cannot exceed quantity").WithCode(ErrCodeInvalidInput)
+	}
+	return nil
+}
+
+type untaggedEntity struct {
+	BaseEntity
+	Name string
+}
+
+func fieldErrors(t *testing.T, err error) map[string]string {
+	t.Helper()
+
+	var multi *MultiError
+	require.True(t, errors.As(err, &multi), "expected MultiError in %v", err)
+
+	rules := make(map[string]string)
+	for _, fieldErr := range multi.Errors {
+		var tbpErr *Error
+		require.True(t, errors.As(fieldErr, &tbpErr))
+		field, _ := tbpErr.GetContext("field")
+		rule, _ := tbpErr.GetContext("rule")
+		rules[field.(string)] = rule.(string)
+	}
+	return rules
+}
+
+func TestValidateEntity(t *testing.T) {
+	t.Run("passes valid entity", func(t *testing.T) {
+		customer := &validatedCustomer{Name: "Ada", Email: "ada@example.com", Age: 36, Tier: "pro"}
+		assert.NoError(t, ValidateEntity(customer))
+	})
+
+	t.Run("aggregates field errors", func(t *testing.T) {
+		nickname := "x"
+		customer := &validatedCustomer{
+			Email:    "not-an-email",
+			Age:      12,
+			Tier:     "gold",
+			Tags:     []string{"a", "b", "c"},
+			Nickname: &nickname,
+		}
+
+		err := ValidateEntity(customer)
+		require.Error(t, err)
+		assert.True(t, IsInvalidInput(err))
+
+		assert.Equal(t, map[string]string{
+			"name":     "required",
+			"email":    "email",
+			"age":      "min",
+			"tier":     "oneof",
+			"tags":     "max",
+			"nickname": "min",
+		}, fieldErrors(t, err))
+	})
+
+	t.Run("checks string length and upper bounds", func(t *testing.T) {
+		customer := &validatedCustomer{Name: "A", Email: "ada@example.com", Age: 200}
+		assert.Equal(t, map[string]string{"name": "min", "age": "max"}, fieldErrors(t, ValidateEntity(customer)))
+	})
+
+	t.Run("skips rules for omitempty zero values and nil pointers", func(t *testing.T) {
+		customer := &validatedCustomer{Name: "Ada", Email: "ada@example.com"}
+		assert.NoError(t, ValidateEntity(customer))
+	})
+
+	t.Run("combines tags with Validate method", func(t *testing.T) {
+		order := &selfValidatingOrder{Quantity: 0, Discount: 5}
+
+		err := ValidateEntity(order)
+		require.Error(t, err)
+
+		var multi *MultiError
+		require.True(t, errors.As(err, &multi))
+		assert.Equal(t, 2, multi.Len())
+		assert.Contains(t, err.Error(), "discount cannot exceed quantity")
+		assert.Contains(t, err.Error(), "field 'quantity' value must be at least 1")
+	})
+
+	t.Run("passes entities without tags or interface", func(t *testing.T) {
+		assert.NoError(t, ValidateEntity(&untaggedEntity{}))
+	})
+
+	t.Run("reports malformed tags as internal errors", func(t *testing.T) {
+		type badRule struct {
+			Name string `validate:"uppercase"`
+		}
+		type badParam struct {
+			Count int `validate:"min=abc"`
+		}
+
+		err := ValidateStruct(badRule{Name: "x"})
+		assert.True(t, IsInternal(err))
+		assert.Contains(t, err.Error(), "unknown validation rule 'uppercase'")
+
+		err = ValidateStruct(&badParam{Count: 1})
+		assert.True(t, IsInternal(err))
+	})
+
+	t.Run("validates plain structs", func(t *testing.T) {
+		type request struct {
+			Priority int `json:"priority" validate:"oneof=1 2 3"`
+		}
+		assert.NoError(t, ValidateStruct(request{Priority: 2}))
+		assert.Error(t, ValidateStruct(request{Priority: 7}))
+		assert.NoError(t, ValidateStruct(nil))
+	})
+}
+
+func TestMultiError(t *testing.T) {
+	t.Run("appends and flattens errors", func(t *testing.T) {
+		inner := &MultiError{}
+		inner.Append(New("b"), New("c"))
+
+		multi := &MultiError{}
+		multi.Append(New("a"), nil, inner)
+
+		assert.Equal(t, 3, multi.Len())
+		assert.Equal(t, "3 errors occurred: a; b; c", multi.Error())
+	})
+
+	t.Run("single error keeps its message", func(t *testing.T) {
+		multi := &MultiError{}
+		multi.Append(New("only"))
+		assert.Equal(t, "only", multi.Error())
+	})
+
+	t.Run("ErrorOrNil", func(t *testing.T) {
+		var nilMulti *MultiError
+		assert.NoError(t, nilMulti.ErrorOrNil())
+		assert.NoError(t, (&MultiError{}).ErrorOrNil())
+
+		multi := &MultiError{}
+		multi.Append(ErrNotFound)
+		assert.Error(t, multi.ErrorOrNil())
+	})
+
+	t.Run("supports errors.Is on contained errors", func(t *testing.T) {
+		multi := &MultiError{}
+		multi.Append(New("x"), ErrConflict)
+		assert.True(t, errors.Is(multi, ErrConflict))
+		assert.False(t, errors.Is(multi, ErrNotFound))
+	})
+}
@@ -0,0 +1,165 @@
+// File: validationerror.go
+// Title: Structured Validation Errors for TBP
+// Description: Provides ValidationError, an ErrCodeInvalidInput error that
+//              carries field-level details (field, rule, message), so APIs
+//              can report which field failed and why. Renders a clean
+//              per-field JSON structure.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial ValidationError with field errors and JSON rendering
+
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// FieldError describes why a single field failed validation
+type FieldError struct {
+	// Field is the name of the field, e.g. "email" or "server.port"
+	Field string `json:"field"`
+
+	// Message is the human-readable reason, e.g. "field 'email' is required"
+	Message string `json:"message"`
+
+	// Rule is the validation rule that failed, e.g. "required" or "max"
+	Rule string `json:"rule,omitempty"`
+}
+
+// codedError is an alias of Error so that ValidationError can embed it
+// without the embedded field hiding the Error method
+type codedError = Error
+
+// ValidationError is an ErrCodeInvalidInput error with field-level
+// details. It embeds the coded Error, so GetCode, IsInvalidInput, and
+// errors.Is(err, ErrInvalidInput) work as for any other TBP error.
+type ValidationError struct {
+	*codedError
+
+	// Fields lists the failed fields in the order they were added
+	Fields []FieldError
+}
+
+// NewValidationError creates a validation error without field errors.
+// Add them with AddField.
+func NewValidationError(message string) *ValidationError {
+	return &ValidationError{codedError: NewWithCode(ErrCodeInvalidInput, message)}
+}
+
+// AddField adds a field error and returns the validation error for chaining.
+func (v *ValidationError) AddField(field, rule, message string) *ValidationError {
+	v.Fields = append(v.Fields, FieldError{Field: field, Message: message, Rule: rule})
+	return v
+}
+
+// WithContext adds context information to the error.
+// Returns a new validation error with the same field errors.
+func (v *ValidationError) WithContext(key string, value interface{}) *ValidationError {
+	return &ValidationError{
+		codedError: v.codedError.WithContext(key, value),
+		Fields:     append([]FieldError(nil), v.Fields...),
+	}
+}
+
+// HasFields reports whether any field errors were added.
+func (v *ValidationError) HasFields() bool {
+	return len(v.Fields) > 0
+}
+
+// FieldErrors returns the field errors of field, or nil if it is valid.
+func (v *ValidationError) FieldErrors(field string) []FieldError {
+	var result []FieldError
+	for _, fieldErr := range v.Fields {
+		if fieldErr.Field == field {
+			result = append(result, fieldErr)
+		}
+	}
+	return result
+}
+
+// Error implements the error interface.
+// Returns the message followed by one line per field error.
+func (v *ValidationError) Error() string {
+	if len(v.Fields) == 0 {
+		return v.codedError.Error()
+	}
+
+	messages := make([]string, len(v.Fields))
+	for i, fieldErr := range v.Fields {
+		messages[i] = fieldErr.Message
+	}
+	return v.codedError.Error() + ":\n  - " + strings.Join(messages, "\n  - ")
+}
+
+// Unwrap returns the embedded coded error, so that the error chain
+// functions find its code and cause.
+func (v *ValidationError) Unwrap() error {
+	return v.codedError
+}
+
+// validationErrorJSON is the JSON representation of a ValidationError
+type validationErrorJSON struct {
+	Message string                     `json:"message"`
+	Code    string                     `json:"code"`
+	Context map[string]json.RawMessage `json:"context,omitempty"`
+	Fields  []FieldError               `json:"fields"`
+}
+
+// MarshalJSON implements json.Marshaler. The error is rendered as its
+// message, code, context, and a "fields" array of field errors; the cause
+// is omitted, as the output is meant for API clients. Values of registered
+// sensitive context keys are redacted.
+func (v *ValidationError) MarshalJSON() ([]byte, error) {
+	if v == nil {
+		return []byte("null"), nil
+	}
+
+	out := validationErrorJSON{
+		Message: v.Message,
+		Code:    v.Code,
+		Fields:  v.Fields,
+	}
+	if out.Fields == nil {
+		out.Fields = []FieldError{}
+	}
+	for key, value := range v.Context {
+		if out.Context == nil {
+			out.Context = make(map[string]json.RawMessage, len(v.Context))
+		}
+		out.Context[key] = marshalContextValue(key, value)
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON implements json.Unmarshaler for the output of MarshalJSON.
+// Context values are restored as their decoded JSON values.
+func (v *ValidationError) UnmarshalJSON(data []byte) error {
+	var in struct {
+		Message string                 `json:"message"`
+		Code    string                 `json:"code"`
+		Context map[string]interface{} `json:"context"`
+		Fields  []FieldError           `json:"fields"`
+	}
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	v.codedError = &Error{Message: in.Message, Code: in.Code, Context: in.Context}
+	v.Fields = in.Fields
+	return nil
+}
+
+// IsValidationError returns the first ValidationError in the error chain.
+func IsValidationError(err error) (*ValidationError, bool) {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr, true
+	}
+	return nil, false
+}
@@ -0,0 +1,113 @@
+// File: validationerror_test.go
+// Title: Tests for Structured Validation Errors
+// Description: Tests building multi-field validation errors, their
+//              integration with the error chain functions, and the JSON
+//              round trip.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial test implementation
+
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidationError(t *testing.T) {
+	newOrderError := func() *ValidationError {
+		return NewValidationError("order validation failed").
+			AddField("email", "required", "field 'email' is required").
+			AddField("quantity", "min", "field 'quantity' value must be at least 1").
+			AddField("email", "email", "field 'email' must be a valid email address")
+	}
+
+	t.Run("collects field errors", func(t *testing.T) {
+		err := newOrderError()
+
+		assert.True(t, err.HasFields())
+		assert.Len(t, err.Fields, 3)
+		assert.Equal(t, []FieldError{
+			{Field: "email", Message: "field 'email' is required", Rule: "required"},
+			{Field: "email", Message: "field 'email' must be a valid email address", Rule: "email"},
+		}, err.FieldErrors("email"))
+		assert.Nil(t, err.FieldErrors("name"))
+
+		assert.Equal(t, "order validation failed:\n"+
+			"  - field 'email' is required\n"+
+			"  - field 'quantity' value must be at least 1\n"+
+			"  - field 'email' must be a valid email address", err.Error())
+		assert.Equal(t, "empty", NewValidationError("empty").Error())
+	})
+
+	t.Run("is a coded invalid input error", func(t *testing.T) {
+		var err error = newOrderError()
+
+		code, ok := GetCode(err)
+		assert.True(t, ok)
+		assert.Equal(t, ErrCodeInvalidInput, code)
+		assert.True(t, IsInvalidInput(err))
+		assert.True(t, errors.Is(err, ErrInvalidInput))
+	})
+
+	t.Run("is found in the error chain", func(t *testing.T) {
+		wrapped := fmt.Errorf("create order: %w", newOrderError())
+
+		validationErr, ok := IsValidationError(wrapped)
+		require.True(t, ok)
+		assert.Len(t, validationErr.Fields, 3)
+		assert.True(t, IsInvalidInput(wrapped))
+
+		_, ok = IsValidationError(New("other"))
+		assert.False(t, ok)
+		_, ok = IsValidationError(nil)
+		assert.False(t, ok)
+	})
+
+	t.Run("keeps fields when adding context", func(t *testing.T) {
+		original := newOrderError()
+		withContext := original.WithContext("order_id", "o-1")
+
+		assert.Len(t, withContext.Fields, 3)
+		assert.Equal(t, "o-1", withContext.Context["order_id"])
+		assert.Nil(t, original.Context)
+	})
+
+	t.Run("round-trips through JSON", func(t *testing.T) {
+		data, err := json.Marshal(newOrderError().WithContext("order_id", "o-1"))
+		require.NoError(t, err)
+
+		assert.JSONEq(t, `{
+			"message": "order validation failed",
+			"code": "INVALID_INPUT",
+			"context": {"order_id": "o-1"},
+			"fields": [
+				{"field": "email", "message": "field 'email' is required", "rule": "required"},
+				{"field": "quantity", "message": "field 'quantity' value must be at least 1", "rule": "min"},
+				{"field": "email", "message": "field 'email' must be a valid email address", "rule": "email"}
+			]
+		}`, string(data))
+
+		var decoded ValidationError
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, newOrderError().Fields, decoded.Fields)
+		assert.Equal(t, "order validation failed", decoded.Message)
+		assert.True(t, IsInvalidInput(&decoded))
+		assert.Equal(t, "o-1", decoded.Context["order_id"])
+	})
+
+	t.Run("renders empty fields as array", func(t *testing.T) {
+		data, err := json.Marshal(NewValidationError("failed"))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"message": "failed", "code": "INVALID_INPUT", "fields": []}`, string(data))
+	})
+}
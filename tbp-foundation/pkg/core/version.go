@@ -5,12 +5,19 @@
 //              injection of version data and runtime version comparison
 //              functionality for compatibility checks.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.7
 // Created: 2025-05-26
-// Modified: 2025-05-26
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-05-26 v0.1.0: Initial implementation with semantic versioning support
+// - 2026-10-16 v0.1.1: Added version constraint parsing and matching
+// - 2026-10-16 v0.1.2: Implemented spec-compliant pre-release precedence
+// - 2026-10-16 v0.1.3: Added bump helpers, sorting, and LatestStable
+// - 2026-10-16 v0.1.4: Added ComponentRegistry for component version requirements
+// - 2026-10-16 v0.1.5: Added ValidateBuildVersion and MustGetCurrentSemVer
+// - 2026-10-16 v0.1.6: Added VersionInfo.Labels and BuildInfoLabels for build_info metrics
+// - 2026-10-16 v0.1.7: Added CompatibilityPolicy, IsCompatibleWith, and CheckMinimumVersionPolicy
 
 package core
 
@@ -19,8 +26,10 @@ import (
 	"fmt"
 	"os"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -140,6 +149,36 @@ func (vi *VersionInfo) String() string {
 	return strings.Join(parts, " ")
 }
 
+// Labels returns the build information as metric labels, e.g. for a
+// Prometheus build_info gauge: version, short_version, commit, build_date,
+// go_version, platform, and component. Labels whose value is empty or
+// "unknown" are omitted, so the label set only contains known values.
+func (vi *VersionInfo) Labels() map[string]string {
+	labels := make(map[string]string, 7)
+	addLabel := func(name, value string) {
+		if value != "" && value != "unknown" {
+			labels[name] = value
+		}
+	}
+
+	addLabel("version", vi.Version)
+	addLabel("short_version", strings.TrimPrefix(vi.Version, "v"))
+	addLabel("commit", vi.GitCommit)
+	addLabel("build_date", vi.BuildDate)
+	addLabel("go_version", vi.GoVersion)
+	addLabel("platform", vi.Platform)
+	addLabel("component", vi.ComponentName)
+
+	return labels
+}
+
+// BuildInfoLabels returns the labels of the current build, see
+// VersionInfo.Labels. Use GetVersionInfoForComponent(name).Labels() to
+// include the component label.
+func BuildInfoLabels() map[string]string {
+	return GetVersionInfo().Labels()
+}
+
 // GetVersion returns the current version string.
 func GetVersion() string {
 	return Version
@@ -265,36 +304,218 @@ func (sv SemVer) Compare(other SemVer) int {
 		return -1
 	}
 	
-	// Both have pre-release, compare lexically
-	if sv.PreRelease < other.PreRelease {
-		return -1
+	// Both have pre-release, compare identifiers per the SemVer spec.
+	// Build metadata is ignored for precedence.
+	return comparePreRelease(sv.PreRelease, other.PreRelease)
+}
+
+// comparePreRelease compares two pre-release strings according to the
+// SemVer 2.0.0 precedence rules. Dot-separated identifiers are compared
+// from left to right: numeric identifiers compare numerically and rank
+// lower than alphanumeric identifiers, which compare lexically in ASCII
+// order. A larger set of identifiers has higher precedence if all
+// preceding identifiers are equal.
+func comparePreRelease(a, b string) int {
+	if a == b {
+		return 0
 	}
-	if sv.PreRelease > other.PreRelease {
+	
+	for a != "" && b != "" {
+		var idA, idB string
+		idA, a = nextPreReleaseIdentifier(a)
+		idB, b = nextPreReleaseIdentifier(b)
+		
+		if cmp := comparePreReleaseIdentifier(idA, idB); cmp != 0 {
+			return cmp
+		}
+	}
+	
+	switch {
+	case a == "" && b == "":
+		return 0
+	case a == "":
+		return -1
+	default:
 		return 1
 	}
+}
+
+// nextPreReleaseIdentifier splits off the first dot-separated identifier
+func nextPreReleaseIdentifier(s string) (string, string) {
+	if idx := strings.IndexByte(s, '.'); idx >= 0 {
+		return s[:idx], s[idx+1:]
+	}
+	return s, ""
+}
+
+// comparePreReleaseIdentifier compares two single pre-release identifiers
+func comparePreReleaseIdentifier(a, b string) int {
+	aNumeric := isNumericIdentifier(a)
+	bNumeric := isNumericIdentifier(b)
 	
-	return 0
+	switch {
+	case aNumeric && bNumeric:
+		// Compare by length first to avoid overflow on large numbers
+		a = strings.TrimLeft(a, "0")
+		b = strings.TrimLeft(b, "0")
+		if len(a) != len(b) {
+			if len(a) < len(b) {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a, b)
+	case aNumeric:
+		return -1
+	case bNumeric:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
 }
 
-// IsCompatible checks if this version is compatible with another version.
-// Uses semantic versioning compatibility rules.
-func (sv SemVer) IsCompatible(other SemVer) bool {
-	// Major version must match for compatibility
-	if sv.Major != other.Major {
+// isNumericIdentifier checks if an identifier consists only of digits
+func isNumericIdentifier(s string) bool {
+	if s == "" {
 		return false
 	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// BumpMajor returns the next major version with minor and patch reset
+// and pre-release and build metadata cleared.
+func (sv SemVer) BumpMajor() SemVer {
+	return SemVer{Major: sv.Major + 1}
+}
+
+// BumpMinor returns the next minor version with patch reset
+// and pre-release and build metadata cleared.
+func (sv SemVer) BumpMinor() SemVer {
+	return SemVer{Major: sv.Major, Minor: sv.Minor + 1}
+}
+
+// BumpPatch returns the next patch version with pre-release
+// and build metadata cleared.
+func (sv SemVer) BumpPatch() SemVer {
+	return SemVer{Major: sv.Major, Minor: sv.Minor, Patch: sv.Patch + 1}
+}
+
+// WithPreRelease returns a copy of the version with the given pre-release.
+func (sv SemVer) WithPreRelease(preRelease string) SemVer {
+	sv.PreRelease = preRelease
+	return sv
+}
+
+// WithBuild returns a copy of the version with the given build metadata.
+func (sv SemVer) WithBuild(build string) SemVer {
+	sv.Build = build
+	return sv
+}
+
+// IsStable checks if the version is not a pre-release.
+func (sv SemVer) IsStable() bool {
+	return sv.PreRelease == ""
+}
+
+// SortSemVers sorts versions in ascending order of precedence in place.
+func SortSemVers(versions []SemVer) {
+	slices.SortStableFunc(versions, func(a, b SemVer) int {
+		return a.Compare(b)
+	})
+}
+
+// LatestStable returns the highest version that is not a pre-release.
+// Returns false if the list contains no stable version.
+func LatestStable(versions []SemVer) (SemVer, bool) {
+	var latest SemVer
+	found := false
 	
-	// Minor version of this should be >= other
-	if sv.Minor < other.Minor {
-		return false
+	for _, v := range versions {
+		if !v.IsStable() {
+			continue
+		}
+		if !found || v.Compare(latest) > 0 {
+			latest = v
+			found = true
+		}
 	}
 	
-	// If minor versions match, patch version of this should be >= other
-	if sv.Minor == other.Minor && sv.Patch < other.Patch {
+	return latest, found
+}
+
+// CompatibilityPolicy determines which versions satisfy a required
+// version in IsCompatibleWith. Pre-release and build identifiers are only
+// considered by Exact.
+type CompatibilityPolicy string
+
+const (
+	// Exact requires the same version, including the pre-release
+	Exact CompatibilityPolicy = "exact"
+
+	// PatchCompatible allows patch upgrades: same major and minor version,
+	// patch at least the required one
+	PatchCompatible CompatibilityPolicy = "patch"
+
+	// MinorCompatible allows minor and patch upgrades: same major version,
+	// at least the required one. This is the rule of IsCompatible.
+	MinorCompatible CompatibilityPolicy = "minor"
+
+	// MajorCompatible allows any version at least the required one,
+	// including major upgrades
+	MajorCompatible CompatibilityPolicy = "major"
+)
+
+// CompatibilityPolicySet contains the defined compatibility policies
+var CompatibilityPolicySet = NewEnumSet(Exact, PatchCompatible, MinorCompatible, MajorCompatible)
+
+// IsValid checks if the compatibility policy is defined
+func (p CompatibilityPolicy) IsValid() bool {
+	return CompatibilityPolicySet.IsValid(p)
+}
+
+// String returns the string representation of the compatibility policy
+func (p CompatibilityPolicy) String() string {
+	return string(p)
+}
+
+// IsCompatible checks if this version is compatible with another version.
+// Uses semantic versioning compatibility rules, see MinorCompatible.
+func (sv SemVer) IsCompatible(other SemVer) bool {
+	return sv.IsCompatibleWith(other, MinorCompatible)
+}
+
+// IsCompatibleWith checks if this version satisfies the required version
+// under the given policy. Returns false for undefined policies.
+func (sv SemVer) IsCompatibleWith(required SemVer, policy CompatibilityPolicy) bool {
+	switch policy {
+	case Exact:
+		return sv.Compare(required) == 0
+	case PatchCompatible:
+		return sv.Major == required.Major && sv.Minor == required.Minor && sv.Patch >= required.Patch
+	case MinorCompatible:
+		return sv.Major == required.Major && !sv.coreLess(required)
+	case MajorCompatible:
+		return !sv.coreLess(required)
+	default:
 		return false
 	}
-	
-	return true
+}
+
+// coreLess reports whether the major.minor.patch part of this version is
+// lower than that of other
+func (sv SemVer) coreLess(other SemVer) bool {
+	if sv.Major != other.Major {
+		return sv.Major < other.Major
+	}
+	if sv.Minor != other.Minor {
+		return sv.Minor < other.Minor
+	}
+	return sv.Patch < other.Patch
 }
 
 // ParseSemVer parses a semantic version string.
@@ -351,6 +572,91 @@ func GetCurrentSemVer() (*SemVer, error) {
 	return ParseSemVer(Version)
 }
 
+// MustGetCurrentSemVer returns the current version as a SemVer struct and
+// panics if it is not a valid semantic version (see ValidateBuildVersion).
+// Intended for startup code that should fail fast.
+func MustGetCurrentSemVer() *SemVer {
+	if err := ValidateBuildVersion(); err != nil {
+		panic(err.Error())
+	}
+	version, _ := GetCurrentSemVer()
+	return version
+}
+
+// ValidateBuildVersion checks that the Version injected at build time is a
+// well-formed semantic version such as "v1.2.3", "1.2.3-rc.1", or
+// "v1.2.3+build.5". Call it at startup to detect a broken release
+// pipeline before the first compatibility check. Returns an
+// ErrCodeInvalidInput error describing the problem otherwise.
+func ValidateBuildVersion() error {
+	if err := validateSemVerString(Version); err != nil {
+		return WrapWithCodef(err, ErrCodeInvalidInput,
+			"build version %q is not a valid semantic version (expected MAJOR.MINOR.PATCH with optional v prefix, "+
+				"-prerelease and +build; check the -X %s.Version ldflag)", Version, versionPackagePath)
+	}
+	return nil
+}
+
+// versionPackagePath is the import path used in ldflags to inject Version
+const versionPackagePath = "github.com/msto63/tbp/tbp-foundation/pkg/core"
+
+// validateSemVerString checks a version strictly against the SemVer 2.0.0
+// grammar, which ParseSemVer applies only loosely
+func validateSemVerString(version string) error {
+	if version == "" || strings.TrimSpace(version) != version {
+		return fmt.Errorf("version is empty or has surrounding whitespace")
+	}
+
+	rest, build, hasBuild := strings.Cut(strings.TrimPrefix(version, "v"), "+")
+	rest, preRelease, hasPreRelease := strings.Cut(rest, "-")
+
+	parts := strings.Split(rest, ".")
+	if len(parts) != 3 {
+		return fmt.Errorf("version %q must have exactly three components", rest)
+	}
+	for _, part := range parts {
+		if !isNumericIdentifier(part) || (len(part) > 1 && part[0] == '0') {
+			return fmt.Errorf("version component %q must be a number without sign or leading zeros", part)
+		}
+	}
+
+	if hasPreRelease {
+		if err := validateSemVerIdentifiers(preRelease, true); err != nil {
+			return fmt.Errorf("invalid pre-release %q: %w", preRelease, err)
+		}
+	}
+	if hasBuild {
+		if err := validateSemVerIdentifiers(build, false); err != nil {
+			return fmt.Errorf("invalid build metadata %q: %w", build, err)
+		}
+	}
+	return nil
+}
+
+// validateSemVerIdentifiers checks dot-separated pre-release or build
+// identifiers. Numeric pre-release identifiers must not have leading zeros.
+func validateSemVerIdentifiers(identifiers string, preRelease bool) error {
+	for _, identifier := range strings.Split(identifiers, ".") {
+		if identifier == "" {
+			return fmt.Errorf("empty identifier")
+		}
+		numeric := true
+		for _, c := range identifier {
+			switch {
+			case c >= '0' && c <= '9':
+			case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '-':
+				numeric = false
+			default:
+				return fmt.Errorf("identifier %q contains invalid character %q", identifier, c)
+			}
+		}
+		if preRelease && numeric && len(identifier) > 1 && identifier[0] == '0' {
+			return fmt.Errorf("numeric identifier %q has leading zeros", identifier)
+		}
+	}
+	return nil
+}
+
 // IsVersionCompatible checks if the current version is compatible with a required version.
 func IsVersionCompatible(requiredVersion string) (bool, error) {
 	current, err := GetCurrentSemVer()
@@ -379,6 +685,339 @@ func MustBeCompatible(requiredVersion string) {
 	}
 }
 
+// Constraint represents a set of version requirements that must all be met.
+// Constraints use npm-style syntax: caret ranges (^1.2.0), tilde ranges
+// (~1.2.3), comparison operators (>=, >, <=, <, =, !=), and space-separated
+// clauses that are combined with AND (">=1.2.0 <2.0.0").
+type Constraint struct {
+	raw     string
+	clauses []constraintClause
+}
+
+// constraintClause is a single comparison against a version
+type constraintClause struct {
+	op      string
+	version SemVer
+}
+
+// ParseConstraint parses a version constraint string.
+// An empty constraint or "*" matches every release version.
+func ParseConstraint(constraint string) (Constraint, error) {
+	c := Constraint{raw: strings.TrimSpace(constraint)}
+	
+	tokens := strings.Fields(c.raw)
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		
+		// Allow whitespace between operator and version (">= 1.2.0")
+		if isConstraintOperator(token) {
+			if i+1 >= len(tokens) {
+				return Constraint{}, fmt.Errorf("invalid version constraint %q: operator %s without version", constraint, token)
+			}
+			i++
+			token += tokens[i]
+		}
+		
+		clauses, err := parseConstraintToken(token)
+		if err != nil {
+			return Constraint{}, fmt.Errorf("invalid version constraint %q: %w", constraint, err)
+		}
+		c.clauses = append(c.clauses, clauses...)
+	}
+	
+	return c, nil
+}
+
+// isConstraintOperator checks if a token consists only of an operator
+func isConstraintOperator(token string) bool {
+	switch token {
+	case "^", "~", ">=", "<=", ">", "<", "=", "!=":
+		return true
+	}
+	return false
+}
+
+// parseConstraintToken expands a single constraint token into comparison clauses
+func parseConstraintToken(token string) ([]constraintClause, error) {
+	if token == "*" {
+		return nil, nil
+	}
+	
+	var op string
+	for _, candidate := range []string{">=", "<=", "!=", ">", "<", "=", "^", "~"} {
+		if strings.HasPrefix(token, candidate) {
+			op = candidate
+			token = token[len(candidate):]
+			break
+		}
+	}
+	
+	version, components, err := parsePartialSemVer(token)
+	if err != nil {
+		return nil, err
+	}
+	
+	switch op {
+	case "^":
+		return []constraintClause{
+			{op: ">=", version: version},
+			{op: "<", version: caretUpperBound(version, components)},
+		}, nil
+	case "~":
+		upper := SemVer{Major: version.Major, Minor: version.Minor + 1}
+		if components == 1 {
+			upper = SemVer{Major: version.Major + 1}
+		}
+		return []constraintClause{
+			{op: ">=", version: version},
+			{op: "<", version: upper},
+		}, nil
+	case "", "=":
+		// Partial versions act as ranges ("1.2" matches any 1.2.x)
+		if components < 3 {
+			upper := SemVer{Major: version.Major, Minor: version.Minor + 1}
+			if components == 1 {
+				upper = SemVer{Major: version.Major + 1}
+			}
+			return []constraintClause{
+				{op: ">=", version: version},
+				{op: "<", version: upper},
+			}, nil
+		}
+		return []constraintClause{{op: "=", version: version}}, nil
+	default:
+		return []constraintClause{{op: op, version: version}}, nil
+	}
+}
+
+// caretUpperBound returns the exclusive upper bound of a caret range,
+// which allows changes that do not modify the left-most non-zero component
+func caretUpperBound(version SemVer, components int) SemVer {
+	switch {
+	case version.Major > 0 || components == 1:
+		return SemVer{Major: version.Major + 1}
+	case version.Minor > 0 || components == 2:
+		return SemVer{Minor: version.Minor + 1}
+	default:
+		return SemVer{Patch: version.Patch + 1}
+	}
+}
+
+// parsePartialSemVer parses a version that may omit minor and patch components.
+// Returns the version and the number of numeric components specified.
+func parsePartialSemVer(version string) (SemVer, int, error) {
+	trimmed := strings.TrimPrefix(version, "v")
+	if trimmed == "" {
+		return SemVer{}, 0, fmt.Errorf("missing version")
+	}
+	
+	numeric := trimmed
+	if idx := strings.IndexAny(numeric, "-+"); idx >= 0 {
+		numeric = numeric[:idx]
+	}
+	
+	components := strings.Count(numeric, ".") + 1
+	switch components {
+	case 1:
+		trimmed = numeric + ".0.0" + trimmed[len(numeric):]
+	case 2:
+		trimmed = numeric + ".0" + trimmed[len(numeric):]
+	}
+	
+	parsed, err := ParseSemVer(trimmed)
+	if err != nil {
+		return SemVer{}, 0, err
+	}
+	if components < 3 && (parsed.PreRelease != "" || parsed.Build != "") {
+		return SemVer{}, 0, fmt.Errorf("partial version %s cannot have pre-release or build metadata", version)
+	}
+	
+	return *parsed, components, nil
+}
+
+// Matches checks if a version satisfies all clauses of the constraint.
+// Pre-release versions only match if a clause references a pre-release
+// of the same major.minor.patch version.
+func (c Constraint) Matches(version SemVer) bool {
+	if version.PreRelease != "" && !c.allowsPreRelease(version) {
+		return false
+	}
+	
+	for _, clause := range c.clauses {
+		if !clause.matches(version) {
+			return false
+		}
+	}
+	
+	return true
+}
+
+// allowsPreRelease checks if the constraint explicitly references a
+// pre-release with the same major.minor.patch as the given version
+func (c Constraint) allowsPreRelease(version SemVer) bool {
+	for _, clause := range c.clauses {
+		cv := clause.version
+		if cv.PreRelease != "" && cv.Major == version.Major &&
+			cv.Minor == version.Minor && cv.Patch == version.Patch {
+			return true
+		}
+	}
+	return false
+}
+
+// matches checks if a version satisfies a single clause
+func (cc constraintClause) matches(version SemVer) bool {
+	cmp := version.Compare(cc.version)
+	switch cc.op {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	}
+	return false
+}
+
+// String returns the original constraint string.
+func (c Constraint) String() string {
+	return c.raw
+}
+
+// Satisfies checks if this version satisfies the given constraint string.
+func (sv SemVer) Satisfies(constraint string) (bool, error) {
+	c, err := ParseConstraint(constraint)
+	if err != nil {
+		return false, err
+	}
+	return c.Matches(sv), nil
+}
+
+// ComponentRegistry tracks the running versions of components in a
+// deployment and checks version requirements between them.
+// It is safe for concurrent use.
+type ComponentRegistry struct {
+	mu         sync.RWMutex
+	components map[string]*VersionInfo
+}
+
+// NewComponentRegistry creates an empty component registry.
+func NewComponentRegistry() *ComponentRegistry {
+	return &ComponentRegistry{
+		components: make(map[string]*VersionInfo),
+	}
+}
+
+// Register records the running version of a component.
+// The version information is based on GetVersionInfoForComponent with the
+// version replaced by the given one; use RegisterVersionInfo to provide
+// complete build information for remote components.
+func (r *ComponentRegistry) Register(name, version string) error {
+	info := GetVersionInfoForComponent(name)
+	info.Version = version
+	return r.RegisterVersionInfo(info)
+}
+
+// RegisterVersionInfo records complete version information for a component.
+// The component name is taken from info.ComponentName.
+func (r *ComponentRegistry) RegisterVersionInfo(info *VersionInfo) error {
+	if info == nil {
+		return fmt.Errorf("version info cannot be nil")
+	}
+	if info.ComponentName == "" {
+		return fmt.Errorf("component name cannot be empty")
+	}
+	
+	semver, err := ParseSemVer(info.Version)
+	if err != nil {
+		return fmt.Errorf("invalid version for component %s: %w", info.ComponentName, err)
+	}
+	
+	registered := *info
+	registered.IsRelease = semver.IsStable()
+	registered.IsDevelopment = !registered.IsRelease
+	registered.Dependencies = make(map[string]string, len(info.Dependencies))
+	for k, v := range info.Dependencies {
+		registered.Dependencies[k] = v
+	}
+	
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	
+	r.components[info.ComponentName] = &registered
+	return nil
+}
+
+// Unregister removes a component from the registry.
+func (r *ComponentRegistry) Unregister(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	
+	delete(r.components, name)
+}
+
+// Lookup returns the version information of a registered component.
+func (r *ComponentRegistry) Lookup(name string) (VersionInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	
+	info, exists := r.components[name]
+	if !exists {
+		return VersionInfo{}, false
+	}
+	return *info, true
+}
+
+// RequireComponent checks that a component is registered and that its
+// version satisfies the given constraint (e.g. ">=1.4.0").
+func (r *ComponentRegistry) RequireComponent(name, constraint string) error {
+	c, err := ParseConstraint(constraint)
+	if err != nil {
+		return err
+	}
+	
+	info, exists := r.Lookup(name)
+	if !exists {
+		return fmt.Errorf("required component %s is not registered", name)
+	}
+	
+	version, err := ParseSemVer(info.Version)
+	if err != nil {
+		return fmt.Errorf("invalid version for component %s: %w", name, err)
+	}
+	
+	if !c.Matches(*version) {
+		return fmt.Errorf("component %s version %s does not satisfy constraint %s",
+			name, info.Version, c.String())
+	}
+	
+	return nil
+}
+
+// RegistrySnapshot returns a copy of all registered component versions,
+// suitable for exposing on a version debug endpoint.
+func (r *ComponentRegistry) RegistrySnapshot() map[string]VersionInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	
+	snapshot := make(map[string]VersionInfo, len(r.components))
+	for name, info := range r.components {
+		copied := *info
+		copied.Dependencies = make(map[string]string, len(info.Dependencies))
+		for k, v := range info.Dependencies {
+			copied.Dependencies[k] = v
+		}
+		snapshot[name] = copied
+	}
+	return snapshot
+}
+
 // VersionHeader returns version information as HTTP header value.
 func VersionHeader() string {
 	return fmt.Sprintf("TBP/%s (%s; %s)", GetShortVersion(), Platform, GoVersion)
@@ -461,6 +1100,32 @@ func CheckMinimumVersion(minimumVersion string) error {
 	return nil
 }
 
+// CheckMinimumVersionPolicy checks if the current version satisfies the
+// minimum version under the given policy, e.g. MinorCompatible to reject
+// both older versions and newer major versions.
+func CheckMinimumVersionPolicy(minimumVersion string, policy CompatibilityPolicy) error {
+	if !policy.IsValid() {
+		return fmt.Errorf("unknown compatibility policy %q", policy)
+	}
+
+	current, err := GetCurrentSemVer()
+	if err != nil {
+		return fmt.Errorf("failed to parse current version: %w", err)
+	}
+
+	minimum, err := ParseSemVer(minimumVersion)
+	if err != nil {
+		return fmt.Errorf("failed to parse minimum version: %w", err)
+	}
+
+	if !current.IsCompatibleWith(*minimum, policy) {
+		return fmt.Errorf("version %s does not meet minimum requirement %s under %s policy",
+			current.String(), minimum.String(), policy)
+	}
+
+	return nil
+}
+
 // PrintVersion prints version information to stdout in a formatted way.
 func PrintVersion(componentName string) {
 	info := GetVersionInfoForComponent(componentName)
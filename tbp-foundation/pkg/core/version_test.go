@@ -5,17 +5,25 @@
 //              and version comparison logic. Tests edge cases, parsing,
 //              and enterprise version control scenarios.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.6
 // Created: 2025-05-26
-// Modified: 2025-05-26
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-05-26 v0.1.0: Initial test implementation with comprehensive coverage
+// - 2026-10-16 v0.1.1: Added table-driven constraint tests
+// - 2026-10-16 v0.1.2: Added semver.org precedence tests
+// - 2026-10-16 v0.1.3: Added bump, sorting, and LatestStable tests
+// - 2026-10-16 v0.1.4: Added component registry tests
+// - 2026-10-16 v0.1.5: Added build info label tests
+// - 2026-10-16 v0.1.6: Added compatibility policy tests
 
 package core
 
 import (
 	"encoding/json"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -303,6 +311,36 @@ func TestSemVer_Compare(t *testing.T) {
 			v2:       SemVer{1, 2, 3, "alpha", ""},
 			expected: 1,
 		},
+		{
+			name:     "numeric identifiers compare numerically",
+			v1:       SemVer{1, 0, 0, "rc.11", ""},
+			v2:       SemVer{1, 0, 0, "rc.2", ""},
+			expected: 1,
+		},
+		{
+			name:     "numeric identifier < alphanumeric identifier",
+			v1:       SemVer{1, 0, 0, "alpha.1", ""},
+			v2:       SemVer{1, 0, 0, "alpha.beta", ""},
+			expected: -1,
+		},
+		{
+			name:     "more identifiers > fewer identifiers",
+			v1:       SemVer{1, 0, 0, "alpha.1", ""},
+			v2:       SemVer{1, 0, 0, "alpha", ""},
+			expected: 1,
+		},
+		{
+			name:     "build metadata is ignored",
+			v1:       SemVer{1, 0, 0, "alpha", "build.1"},
+			v2:       SemVer{1, 0, 0, "alpha", "build.2"},
+			expected: 0,
+		},
+		{
+			name:     "build metadata is ignored for releases",
+			v1:       SemVer{1, 0, 0, "", "20240115"},
+			v2:       SemVer{1, 0, 0, "", ""},
+			expected: 0,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -313,6 +351,126 @@ func TestSemVer_Compare(t *testing.T) {
 	}
 }
 
+func TestSemVer_Compare_SpecPrecedence(t *testing.T) {
+	// Precedence example from semver.org (section 11):
+	// 1.0.0-alpha < 1.0.0-alpha.1 < 1.0.0-alpha.beta < 1.0.0-beta <
+	// 1.0.0-beta.2 < 1.0.0-beta.11 < 1.0.0-rc.1 < 1.0.0
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"2.0.0",
+		"2.1.0",
+		"2.1.1",
+	}
+
+	for i := 0; i < len(ordered); i++ {
+		for j := 0; j < len(ordered); j++ {
+			vi, err := ParseSemVer(ordered[i])
+			require.NoError(t, err)
+			vj, err := ParseSemVer(ordered[j])
+			require.NoError(t, err)
+
+			expected := 0
+			if i < j {
+				expected = -1
+			} else if i > j {
+				expected = 1
+			}
+			assert.Equal(t, expected, vi.Compare(*vj), "%s vs %s", ordered[i], ordered[j])
+		}
+	}
+
+	t.Run("compares large numeric identifiers", func(t *testing.T) {
+		v1 := SemVer{1, 0, 0, "alpha.99999999999999999999", ""}
+		v2 := SemVer{1, 0, 0, "alpha.100000000000000000000", ""}
+		assert.Equal(t, -1, v1.Compare(v2))
+	})
+}
+
+func TestSemVer_Bump(t *testing.T) {
+	version := SemVer{Major: 1, Minor: 2, Patch: 3, PreRelease: "rc.1", Build: "build.5"}
+
+	t.Run("bumps major", func(t *testing.T) {
+		assert.Equal(t, SemVer{Major: 2}, version.BumpMajor())
+	})
+
+	t.Run("bumps minor", func(t *testing.T) {
+		assert.Equal(t, SemVer{Major: 1, Minor: 3}, version.BumpMinor())
+	})
+
+	t.Run("bumps patch", func(t *testing.T) {
+		assert.Equal(t, SemVer{Major: 1, Minor: 2, Patch: 4}, version.BumpPatch())
+	})
+
+	t.Run("does not modify original", func(t *testing.T) {
+		_ = version.BumpMajor()
+		_ = version.WithPreRelease("beta")
+		assert.Equal(t, "1.2.3-rc.1+build.5", version.String())
+	})
+
+	t.Run("sets pre-release and build", func(t *testing.T) {
+		next := version.BumpMinor().WithPreRelease("alpha.1").WithBuild("sha.abc")
+		assert.Equal(t, "1.3.0-alpha.1+sha.abc", next.String())
+		assert.False(t, next.IsStable())
+		assert.True(t, next.WithPreRelease("").IsStable())
+	})
+}
+
+func TestSortSemVers(t *testing.T) {
+	t.Run("sorts ascending by precedence", func(t *testing.T) {
+		versions := []SemVer{
+			{1, 0, 0, "", ""},
+			{1, 0, 0, "rc.11", ""},
+			{0, 9, 0, "", ""},
+			{1, 0, 0, "rc.2", ""},
+			{2, 0, 0, "", ""},
+			{1, 0, 0, "alpha", ""},
+		}
+
+		SortSemVers(versions)
+
+		expected := []string{"0.9.0", "1.0.0-alpha", "1.0.0-rc.2", "1.0.0-rc.11", "1.0.0", "2.0.0"}
+		for i, v := range versions {
+			assert.Equal(t, expected[i], v.String())
+		}
+	})
+
+	t.Run("handles empty slice", func(t *testing.T) {
+		var versions []SemVer
+		SortSemVers(versions)
+		assert.Empty(t, versions)
+	})
+}
+
+func TestLatestStable(t *testing.T) {
+	t.Run("returns highest stable version", func(t *testing.T) {
+		versions := []SemVer{
+			{1, 2, 0, "", ""},
+			{2, 0, 0, "beta", ""},
+			{1, 10, 0, "", ""},
+			{1, 9, 0, "", ""},
+		}
+
+		latest, ok := LatestStable(versions)
+		assert.True(t, ok)
+		assert.Equal(t, "1.10.0", latest.String())
+	})
+
+	t.Run("returns false without stable versions", func(t *testing.T) {
+		_, ok := LatestStable([]SemVer{{1, 0, 0, "rc.1", ""}})
+		assert.False(t, ok)
+
+		_, ok = LatestStable(nil)
+		assert.False(t, ok)
+	})
+}
+
 func TestSemVer_IsCompatible(t *testing.T) {
 	testCases := []struct {
 		name       string
@@ -372,6 +530,97 @@ func TestSemVer_IsCompatible(t *testing.T) {
 	}
 }
 
+func TestSemVer_IsCompatibleWith(t *testing.T) {
+	testCases := []struct {
+		current  string
+		required string
+		exact    bool
+		patch    bool
+		minor    bool
+		major    bool
+	}{
+		{"1.2.3", "1.2.3", true, true, true, true},
+		{"1.2.3+build.5", "1.2.3", true, true, true, true},
+		{"1.2.4", "1.2.3", false, true, true, true},
+		{"1.3.0", "1.2.3", false, false, true, true},
+		{"2.0.0", "1.2.3", false, false, false, true},
+		{"1.2.2", "1.2.3", false, false, false, false},
+		{"1.1.9", "1.2.0", false, false, false, false},
+		{"0.9.0", "1.0.0", false, false, false, false},
+		{"1.2.3-rc.1", "1.2.3", false, true, true, true},
+		{"1.2.3", "1.2.3-rc.1", false, true, true, true},
+	}
+
+	for _, tc := range testCases {
+		current, err := ParseSemVer(tc.current)
+		require.NoError(t, err)
+		required, err := ParseSemVer(tc.required)
+		require.NoError(t, err)
+
+		for policy, expected := range map[CompatibilityPolicy]bool{
+			Exact:           tc.exact,
+			PatchCompatible: tc.patch,
+			MinorCompatible: tc.minor,
+			MajorCompatible: tc.major,
+		} {
+			t.Run(fmt.Sprintf("%s with %s under %s", tc.current, tc.required, policy), func(t *testing.T) {
+				assert.Equal(t, expected, current.IsCompatibleWith(*required, policy))
+				if policy == MinorCompatible {
+					assert.Equal(t, expected, current.IsCompatible(*required), "IsCompatible is MinorCompatible")
+				}
+			})
+		}
+	}
+
+	t.Run("undefined policy", func(t *testing.T) {
+		v := SemVer{Major: 1}
+		assert.False(t, v.IsCompatibleWith(v, CompatibilityPolicy("caret")))
+		assert.False(t, CompatibilityPolicy("caret").IsValid())
+		assert.True(t, MinorCompatible.IsValid())
+	})
+}
+
+func TestCheckMinimumVersionPolicy(t *testing.T) {
+	originalVersion := Version
+	defer func() {
+		Version = originalVersion
+	}()
+	Version = "v1.4.2"
+
+	testCases := []struct {
+		minimum string
+		policy  CompatibilityPolicy
+		ok      bool
+	}{
+		{"v1.4.2", Exact, true},
+		{"v1.4.0", Exact, false},
+		{"v1.4.0", PatchCompatible, true},
+		{"v1.3.0", PatchCompatible, false},
+		{"v1.3.0", MinorCompatible, true},
+		{"v0.9.0", MinorCompatible, false},
+		{"v0.9.0", MajorCompatible, true},
+		{"v1.5.0", MajorCompatible, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s under %s", tc.minimum, tc.policy), func(t *testing.T) {
+			err := CheckMinimumVersionPolicy(tc.minimum, tc.policy)
+			if tc.ok {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "does not meet minimum requirement")
+			assert.Contains(t, err.Error(), string(tc.policy))
+		})
+	}
+
+	t.Run("rejects invalid input", func(t *testing.T) {
+		assert.ErrorContains(t, CheckMinimumVersionPolicy("v1.0.0", "caret"), "unknown compatibility policy")
+		assert.ErrorContains(t, CheckMinimumVersionPolicy("invalid", MinorCompatible), "failed to parse minimum version")
+	})
+}
+
 func TestParseSemVer(t *testing.T) {
 	t.Run("basic version", func(t *testing.T) {
 		v, err := ParseSemVer("1.2.3")
@@ -550,6 +799,243 @@ func TestMustBeCompatible(t *testing.T) {
 	})
 }
 
+func TestParseConstraint(t *testing.T) {
+	t.Run("parses valid constraints", func(t *testing.T) {
+		validConstraints := []string{
+			"", "*", "1.2.3", "=1.2.3", "v1.2.3", "^1.2.0", "~1.2.3",
+			">=1.2.0 <2.0.0", ">= 1.2.0", "!=1.5.0", "^1", "~1.2", "^1.2.3-beta.1",
+		}
+		for _, constraint := range validConstraints {
+			_, err := ParseConstraint(constraint)
+			assert.NoError(t, err, constraint)
+		}
+	})
+
+	t.Run("rejects invalid constraints", func(t *testing.T) {
+		invalidConstraints := []string{
+			"abc", "^", ">=", "1.2.3.4", "^1.x", ">=1.2.0 <", "1.2-beta", "~",
+		}
+		for _, constraint := range invalidConstraints {
+			_, err := ParseConstraint(constraint)
+			assert.Error(t, err, constraint)
+		}
+	})
+
+	t.Run("returns original string", func(t *testing.T) {
+		c, err := ParseConstraint(" >=1.2.0 <2.0.0 ")
+		require.NoError(t, err)
+		assert.Equal(t, ">=1.2.0 <2.0.0", c.String())
+	})
+}
+
+func TestConstraint_Matches(t *testing.T) {
+	testCases := []struct {
+		constraint string
+		version    string
+		expected   bool
+	}{
+		// Exact versions
+		{"1.2.3", "1.2.3", true},
+		{"1.2.3", "1.2.4", false},
+		{"=1.2.3", "1.2.3", true},
+		{"v1.2.3", "1.2.3+build.5", true},
+
+		// Wildcards and partial versions
+		{"", "5.0.0", true},
+		{"*", "0.0.1", true},
+		{"1.2", "1.2.9", true},
+		{"1.2", "1.3.0", false},
+		{"1", "1.9.9", true},
+		{"1", "2.0.0", false},
+
+		// Caret ranges
+		{"^1.2.0", "1.2.0", true},
+		{"^1.2.0", "1.9.9", true},
+		{"^1.2.0", "2.0.0", false},
+		{"^1.2.0", "1.1.9", false},
+		{"^0.2.3", "0.2.9", true},
+		{"^0.2.3", "0.3.0", false},
+		{"^0.0.3", "0.0.3", true},
+		{"^0.0.3", "0.0.4", false},
+		{"^0", "0.9.0", true},
+		{"^0", "1.0.0", false},
+		{"^1", "1.5.0", true},
+
+		// Tilde ranges
+		{"~1.2.3", "1.2.3", true},
+		{"~1.2.3", "1.2.9", true},
+		{"~1.2.3", "1.3.0", false},
+		{"~1.2.3", "1.2.2", false},
+		{"~1.2", "1.2.0", true},
+		{"~1", "1.9.0", true},
+		{"~1", "2.0.0", false},
+
+		// Comparison operators
+		{">1.2.0", "1.2.1", true},
+		{">1.2.0", "1.2.0", false},
+		{">=1.2.0", "1.2.0", true},
+		{"<1.2.0", "1.1.9", true},
+		{"<1.2.0", "1.2.0", false},
+		{"<=1.2.0", "1.2.0", true},
+		{"!=1.5.0", "1.5.0", false},
+		{"!=1.5.0", "1.5.1", true},
+		{">= 1.2.0", "1.3.0", true},
+
+		// AND clauses
+		{">=1.2.0 <2.0.0", "1.5.0", true},
+		{">=1.2.0 <2.0.0", "2.0.0", false},
+		{">=1.2.0 <2.0.0", "1.1.0", false},
+		{">=1.2.0 <2.0.0 !=1.5.0", "1.5.0", false},
+
+		// Pre-release handling
+		{"^1.2.0", "1.3.0-beta", false},
+		{">=1.0.0", "2.0.0-alpha", false},
+		{"<2.0.0", "2.0.0-alpha", false},
+		{"^1.2.3-beta.1", "1.2.3-beta.2", true},
+		{"^1.2.3-beta.1", "1.2.3", true},
+		{"^1.2.3-beta.1", "1.2.4-beta.1", false},
+		{">=1.2.3-alpha <1.3.0", "1.2.3-beta", true},
+		{"1.2.3-rc.1", "1.2.3-rc.1", true},
+		{"*", "1.0.0-alpha", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.constraint+" matches "+tc.version, func(t *testing.T) {
+			c, err := ParseConstraint(tc.constraint)
+			require.NoError(t, err)
+
+			version, err := ParseSemVer(tc.version)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, c.Matches(*version))
+		})
+	}
+}
+
+func TestSemVer_Satisfies(t *testing.T) {
+	t.Run("checks constraint", func(t *testing.T) {
+		version := SemVer{Major: 1, Minor: 4, Patch: 2}
+
+		ok, err := version.Satisfies("^1.2.0")
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		ok, err = version.Satisfies("~1.2.0")
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("returns error for invalid constraint", func(t *testing.T) {
+		version := SemVer{Major: 1}
+
+		ok, err := version.Satisfies(">=abc")
+		assert.Error(t, err)
+		assert.False(t, ok)
+		assert.Contains(t, err.Error(), "invalid version constraint")
+	})
+}
+
+func TestComponentRegistry(t *testing.T) {
+	t.Run("registers and requires components", func(t *testing.T) {
+		registry := NewComponentRegistry()
+		require.NoError(t, registry.Register("auth-service", "v1.5.2"))
+
+		assert.NoError(t, registry.RequireComponent("auth-service", ">=1.4.0"))
+		assert.NoError(t, registry.RequireComponent("auth-service", "^1.5.0"))
+
+		err := registry.RequireComponent("auth-service", ">=2.0.0")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "does not satisfy constraint >=2.0.0")
+	})
+
+	t.Run("fails for unregistered component", func(t *testing.T) {
+		registry := NewComponentRegistry()
+
+		err := registry.RequireComponent("gateway", ">=1.0.0")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not registered")
+	})
+
+	t.Run("fails for invalid constraint", func(t *testing.T) {
+		registry := NewComponentRegistry()
+		require.NoError(t, registry.Register("gateway", "1.0.0"))
+
+		err := registry.RequireComponent("gateway", ">=abc")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid version constraint")
+	})
+
+	t.Run("rejects invalid registrations", func(t *testing.T) {
+		registry := NewComponentRegistry()
+
+		assert.Error(t, registry.Register("gateway", "not-a-version"))
+		assert.Error(t, registry.Register("", "1.0.0"))
+		assert.Error(t, registry.RegisterVersionInfo(nil))
+	})
+
+	t.Run("derives release state from version", func(t *testing.T) {
+		registry := NewComponentRegistry()
+		require.NoError(t, registry.Register("stable", "1.0.0"))
+		require.NoError(t, registry.Register("preview", "1.1.0-rc.1"))
+
+		stable, ok := registry.Lookup("stable")
+		require.True(t, ok)
+		assert.True(t, stable.IsRelease)
+		assert.Equal(t, "stable", stable.ComponentName)
+
+		preview, ok := registry.Lookup("preview")
+		require.True(t, ok)
+		assert.False(t, preview.IsRelease)
+		assert.True(t, preview.IsDevelopment)
+
+		// Pre-releases only satisfy constraints referencing a pre-release
+		assert.Error(t, registry.RequireComponent("preview", ">=1.0.0"))
+		assert.NoError(t, registry.RequireComponent("preview", ">=1.1.0-rc.1"))
+	})
+
+	t.Run("returns independent snapshot", func(t *testing.T) {
+		registry := NewComponentRegistry()
+		require.NoError(t, registry.RegisterVersionInfo(&VersionInfo{
+			ComponentName: "billing",
+			Version:       "2.3.4",
+			Dependencies:  map[string]string{"db": "15.2"},
+		}))
+		require.NoError(t, registry.Register("gateway", "1.0.0"))
+
+		snapshot := registry.RegistrySnapshot()
+		assert.Len(t, snapshot, 2)
+		assert.Equal(t, "2.3.4", snapshot["billing"].Version)
+
+		snapshot["billing"].Dependencies["db"] = "changed"
+		billing, _ := registry.Lookup("billing")
+		assert.Equal(t, "15.2", billing.Dependencies["db"])
+
+		registry.Unregister("gateway")
+		assert.Len(t, registry.RegistrySnapshot(), 1)
+	})
+
+	t.Run("handles concurrent access", func(t *testing.T) {
+		registry := NewComponentRegistry()
+		var wg sync.WaitGroup
+
+		for i := 0; i < 50; i++ {
+			wg.Add(2)
+			go func(id int) {
+				defer wg.Done()
+				_ = registry.Register(fmt.Sprintf("service-%d", id), fmt.Sprintf("1.%d.0", id))
+			}(i)
+			go func(id int) {
+				defer wg.Done()
+				_ = registry.RequireComponent(fmt.Sprintf("service-%d", id), ">=1.0.0")
+				_ = registry.RegistrySnapshot()
+			}(i)
+		}
+
+		wg.Wait()
+		assert.Len(t, registry.RegistrySnapshot(), 50)
+	})
+}
+
 func TestVersionHeader(t *testing.T) {
 	// Save original value
 	originalVersion := Version
@@ -892,6 +1378,34 @@ func BenchmarkSemVer_IsCompatible(b *testing.B) {
 	}
 }
 
+func BenchmarkSortSemVers(b *testing.B) {
+	source := []SemVer{
+		{1, 0, 0, "", ""}, {1, 0, 0, "rc.11", ""}, {0, 9, 0, "", ""},
+		{1, 0, 0, "rc.2", ""}, {2, 0, 0, "", ""}, {1, 0, 0, "alpha", ""},
+	}
+	versions := make([]SemVer, len(source))
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		copy(versions, source)
+		SortSemVers(versions)
+	}
+}
+
+func BenchmarkLatestStable(b *testing.B) {
+	versions := []SemVer{
+		{1, 0, 0, "", ""}, {1, 0, 0, "rc.11", ""}, {0, 9, 0, "", ""},
+		{1, 0, 0, "rc.2", ""}, {2, 0, 0, "", ""}, {1, 0, 0, "alpha", ""},
+	}
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = LatestStable(versions)
+	}
+}
+
 func BenchmarkParseSemVer(b *testing.B) {
 	version := "v1.2.3-alpha.1+build.123"
 	
@@ -1027,4 +1541,97 @@ func BenchmarkVersionInfo_JSON_Unmarshal(b *testing.B) {
 		var info VersionInfo
 		_ = json.Unmarshal(data, &info)
 	}
-}
\ No newline at end of file
+}
+func TestValidateBuildVersion(t *testing.T) {
+	originalVersion := Version
+	defer func() { Version = originalVersion }()
+
+	t.Run("accepts valid versions", func(t *testing.T) {
+		for _, version := range []string{
+			"1.2.3",
+			"v1.2.3",
+			"v0.1.0-dev",
+			"1.0.0-rc.1",
+			"1.0.0-alpha-1.0.x",
+			"v2.3.4+build.5",
+			"1.2.3-beta.2+sha.0a1b2c",
+			"1.2.3+001",
+		} {
+			Version = version
+			assert.NoError(t, ValidateBuildVersion(), version)
+
+			semver := MustGetCurrentSemVer()
+			require.NotNil(t, semver, version)
+		}
+	})
+
+	t.Run("rejects malformed versions", func(t *testing.T) {
+		for _, version := range []string{
+			"",
+			"unknown",
+			"1.2",
+			"1.2.3.4",
+			"v01.2.3",
+			"1.2.x",
+			"1.2.3-",
+			"1.2.3-rc..1",
+			"1.2.3-rc.01",
+			"1.2.3+",
+			"1.2.3+build_1",
+			" 1.2.3",
+			"V1.2.3",
+		} {
+			Version = version
+			err := ValidateBuildVersion()
+			require.Error(t, err, version)
+			assert.True(t, IsInvalidInput(err), version)
+			assert.Contains(t, err.Error(), "ldflag", version)
+			assert.Panics(t, func() { MustGetCurrentSemVer() }, version)
+		}
+	})
+
+	t.Run("reports the malformed value", func(t *testing.T) {
+		Version = "release-2026"
+		err := ValidateBuildVersion()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `"release-2026"`)
+	})
+}
+
+func TestBuildInfoLabels(t *testing.T) {
+	originalVersion, originalGitCommit, originalBuildDate := Version, GitCommit, BuildDate
+	t.Cleanup(func() {
+		Version, GitCommit, BuildDate = originalVersion, originalGitCommit, originalBuildDate
+	})
+
+	t.Run("contains injected build information", func(t *testing.T) {
+		Version = "v1.4.2"
+		GitCommit = "0a1b2c3d4e5f"
+		BuildDate = "2026-10-16T08:00:00Z"
+
+		assert.Equal(t, map[string]string{
+			"version":       "v1.4.2",
+			"short_version": "1.4.2",
+			"commit":        "0a1b2c3d4e5f",
+			"build_date":    "2026-10-16T08:00:00Z",
+			"go_version":    GoVersion,
+			"platform":      Platform,
+		}, BuildInfoLabels())
+
+		labels := GetVersionInfoForComponent("order-service").Labels()
+		assert.Equal(t, "order-service", labels["component"])
+		assert.Len(t, labels, 7)
+	})
+
+	t.Run("omits unknown values", func(t *testing.T) {
+		Version = "v1.4.2"
+		GitCommit = "unknown"
+		BuildDate = ""
+
+		labels := BuildInfoLabels()
+		assert.NotContains(t, labels, "commit")
+		assert.NotContains(t, labels, "build_date")
+		assert.NotContains(t, labels, "component")
+		assert.Equal(t, "v1.4.2", labels["version"])
+	})
+}